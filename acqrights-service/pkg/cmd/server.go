@@ -21,6 +21,7 @@ import (
 	"optisam-backend/acqrights-service/pkg/worker"
 	"optisam-backend/common/optisam/buildinfo"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	gconn "optisam-backend/common/optisam/grpc"
 	"optisam-backend/common/optisam/healthcheck"
 	"optisam-backend/common/optisam/iam"
@@ -213,6 +214,9 @@ func RunServer() error {
 	if err != nil {
 		logger.Log.Error("Failed to register server stats view")
 	}
+	if err := view.Register(workerqueue.DefaultViews...); err != nil {
+		logger.Log.Error("Failed to register worker queue stats view")
+	}
 
 	// Run Instumentation Server
 	instrumentationServer := &http.Server{
@@ -260,5 +264,6 @@ func RunServer() error {
 	go func() {
 		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort)
 	}()
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
+	adminAPI := reconcile.NewAdminServer("acqrights", worker.NewReconciler(rep, dg, q))
+	return grpc.RunServer(ctx, v1API, adminAPI, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
 }
@@ -13,10 +13,12 @@ import (
 	"net"
 	v1 "optisam-backend/acqrights-service/pkg/api/v1"
 	"optisam-backend/acqrights-service/pkg/errors"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	mw "optisam-backend/common/optisam/middleware/grpc"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/open-policy-agent/opa/rego"
@@ -25,7 +27,7 @@ import (
 )
 
 // RunServer runs gRPC service to publish Auth service
-func RunServer(ctx context.Context, v1API v1.AcqRightsServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
+func RunServer(ctx context.Context, v1API v1.AcqRightsServiceServer, adminAPI rcv1.ReconcileServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
 	runtime.HTTPError = errors.CustomHTTPError
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -40,10 +42,11 @@ func RunServer(ctx context.Context, v1API v1.AcqRightsServiceServer, port string
 	// register service
 	server := grpc.NewServer(opts...)
 	v1.RegisterAcqRightsServiceServer(server, v1API)
+	rcv1.RegisterReconcileServiceServer(server, adminAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			// sig is a ^C, handle it
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	v1 "optisam-backend/acqrights-service/pkg/api/v1"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	rest_middleware "optisam-backend/common/optisam/middleware/rest"
 	"os"
@@ -69,5 +70,8 @@ func newGateway(ctx context.Context, grpcPort string) (http.Handler, error) {
 	if err = v1.RegisterAcqRightsServiceHandler(ctx, muxGateway, conn); err != nil {
 		return nil, err
 	}
+	if err = rcv1.RegisterReconcileServiceHandler(ctx, muxGateway, conn); err != nil {
+		return nil, err
+	}
 	return muxGateway, err
 }
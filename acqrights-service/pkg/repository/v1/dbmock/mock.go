@@ -144,6 +144,21 @@ func (mr *MockAcqRightsMockRecorder) ListAcqRightsMetrics(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcqRightsMetrics", reflect.TypeOf((*MockAcqRights)(nil).ListAcqRightsMetrics), arg0, arg1)
 }
 
+// ListAcqRightsOfScope mocks base method
+func (m *MockAcqRights) ListAcqRightsOfScope(arg0 context.Context, arg1 string) ([]db.Acqright, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAcqRightsOfScope", arg0, arg1)
+	ret0, _ := ret[0].([]db.Acqright)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAcqRightsOfScope indicates an expected call of ListAcqRightsOfScope
+func (mr *MockAcqRightsMockRecorder) ListAcqRightsOfScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcqRightsOfScope", reflect.TypeOf((*MockAcqRights)(nil).ListAcqRightsOfScope), arg0, arg1)
+}
+
 // ListAcqRightsProducts mocks base method
 func (m *MockAcqRights) ListAcqRightsProducts(arg0 context.Context, arg1 db.ListAcqRightsProductsParams) ([]db.ListAcqRightsProductsRow, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +189,21 @@ func (mr *MockAcqRightsMockRecorder) ListAggregation(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAggregation", reflect.TypeOf((*MockAcqRights)(nil).ListAggregation), arg0, arg1)
 }
 
+// ListAggregationsOfScope mocks base method
+func (m *MockAcqRights) ListAggregationsOfScope(arg0 context.Context, arg1 string) ([]db.Aggregation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAggregationsOfScope", arg0, arg1)
+	ret0, _ := ret[0].([]db.Aggregation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAggregationsOfScope indicates an expected call of ListAggregationsOfScope
+func (mr *MockAcqRightsMockRecorder) ListAggregationsOfScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAggregationsOfScope", reflect.TypeOf((*MockAcqRights)(nil).ListAggregationsOfScope), arg0, arg1)
+}
+
 // UpdateAggregation mocks base method
 func (m *MockAcqRights) UpdateAggregation(arg0 context.Context, arg1 db.UpdateAggregationParams) (db.Aggregation, error) {
 	m.ctrl.T.Helper()
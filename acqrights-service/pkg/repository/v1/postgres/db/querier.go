@@ -20,8 +20,10 @@ type Querier interface {
 	ListAcqRightsEditors(ctx context.Context, scope string) ([]string, error)
 	ListAcqRightsIndividual(ctx context.Context, arg ListAcqRightsIndividualParams) ([]ListAcqRightsIndividualRow, error)
 	ListAcqRightsMetrics(ctx context.Context, scope string) ([]string, error)
+	ListAcqRightsOfScope(ctx context.Context, scope string) ([]Acqright, error)
 	ListAcqRightsProducts(ctx context.Context, arg ListAcqRightsProductsParams) ([]ListAcqRightsProductsRow, error)
 	ListAggregation(ctx context.Context, scope []string) ([]ListAggregationRow, error)
+	ListAggregationsOfScope(ctx context.Context, aggregationScope string) ([]Aggregation, error)
 	UpdateAggregation(ctx context.Context, arg UpdateAggregationParams) (Aggregation, error)
 	UpsertAcqRights(ctx context.Context, arg UpsertAcqRightsParams) error
 }
@@ -512,6 +512,53 @@ func (q *Queries) ListAcqRightsMetrics(ctx context.Context, scope string) ([]str
 	return items, nil
 }
 
+const listAcqRightsOfScope = `-- name: ListAcqRightsOfScope :many
+SELECT sku, swidtag, product_name, product_editor, entity, scope, metric, num_licenses_acquired, num_licences_maintainance, avg_unit_price, avg_maintenance_unit_price, total_purchase_cost, total_maintenance_cost, total_cost, created_on, created_by, updated_on, updated_by FROM acqrights
+WHERE scope = $1
+`
+
+func (q *Queries) ListAcqRightsOfScope(ctx context.Context, scope string) ([]Acqright, error) {
+	rows, err := q.db.QueryContext(ctx, listAcqRightsOfScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Acqright
+	for rows.Next() {
+		var i Acqright
+		if err := rows.Scan(
+			&i.Sku,
+			&i.Swidtag,
+			&i.ProductName,
+			&i.ProductEditor,
+			&i.Entity,
+			&i.Scope,
+			&i.Metric,
+			&i.NumLicensesAcquired,
+			&i.NumLicencesMaintainance,
+			&i.AvgUnitPrice,
+			&i.AvgMaintenanceUnitPrice,
+			&i.TotalPurchaseCost,
+			&i.TotalMaintenanceCost,
+			&i.TotalCost,
+			&i.CreatedOn,
+			&i.CreatedBy,
+			&i.UpdatedOn,
+			&i.UpdatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAcqRightsProducts = `-- name: ListAcqRightsProducts :many
 SELECT swidtag,product_name
 FROM acqrights acq
@@ -605,6 +652,44 @@ func (q *Queries) ListAggregation(ctx context.Context, scope []string) ([]ListAg
 	return items, nil
 }
 
+const listAggregationsOfScope = `-- name: ListAggregationsOfScope :many
+SELECT aggregation_id, aggregation_name, aggregation_metric, aggregation_scope, products, created_on, created_by, updated_on, updated_by FROM aggregations
+WHERE aggregation_scope = $1
+`
+
+func (q *Queries) ListAggregationsOfScope(ctx context.Context, aggregationScope string) ([]Aggregation, error) {
+	rows, err := q.db.QueryContext(ctx, listAggregationsOfScope, aggregationScope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Aggregation
+	for rows.Next() {
+		var i Aggregation
+		if err := rows.Scan(
+			&i.AggregationID,
+			&i.AggregationName,
+			&i.AggregationMetric,
+			&i.AggregationScope,
+			pq.Array(&i.Products),
+			&i.CreatedOn,
+			&i.CreatedBy,
+			&i.UpdatedOn,
+			&i.UpdatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateAggregation = `-- name: UpdateAggregation :one
 UPDATE aggregations
 SET aggregation_name = $1,products = $2
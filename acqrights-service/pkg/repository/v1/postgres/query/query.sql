@@ -128,4 +128,12 @@ WHERE acq.scope = $1;
 -- name: ListAcqRightsMetrics :many
 SELECT DISTINCT acq.metric
 FROM acqrights acq
-WHERE acq.scope = $1;
\ No newline at end of file
+WHERE acq.scope = $1;
+
+-- name: ListAcqRightsOfScope :many
+SELECT * FROM acqrights
+WHERE scope = $1;
+
+-- name: ListAggregationsOfScope :many
+SELECT * FROM aggregations
+WHERE aggregation_scope = $1;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- retries of workerqueue are counted on jobs
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS retry_count INTEGER DEFAULT 0;
+
+-- jobs are leased to the replica claiming them, see common/optisam/workerqueue
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS lease_owner VARCHAR;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS jobs_claimable_idx ON jobs (type,job_id) WHERE status IN ('PENDING','RETRY','RUNNING');
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS jobs_claimable_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS lease_owner;
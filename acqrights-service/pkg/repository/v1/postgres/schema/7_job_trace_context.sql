@@ -0,0 +1,9 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- span context of the pusher of a job, work on job is traced as a part of it
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS trace_context BYTEA;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE jobs DROP COLUMN IF EXISTS trace_context;
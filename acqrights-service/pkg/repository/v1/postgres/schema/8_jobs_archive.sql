@@ -0,0 +1,23 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- ended jobs past their retention are moved here when they are archived rather than deleted
+CREATE TABLE IF NOT EXISTS jobs_archive (
+  job_id INTEGER PRIMARY KEY,
+  type VARCHAR NOT NULL,
+  status job_status NOT NULL,
+  data JSONB NOT NULL,
+  comments VARCHAR,
+  start_time TIMESTAMP,
+  end_time TIMESTAMP,
+  created_at TIMESTAMP NOT NULL,
+  retry_count INTEGER,
+  archived_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_ended_idx ON jobs (status, COALESCE(end_time, created_at)) WHERE status IN ('COMPLETED','FAILED','DEAD','CANCELLED');
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS jobs_ended_idx;
+DROP TABLE IF EXISTS jobs_archive;
@@ -79,6 +79,8 @@ func (lr *acqRightsServiceServer) UpsertAcqRights(ctx context.Context, req *v1.U
 		Type:   sql.NullString{String: "aw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "aw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -293,6 +295,8 @@ func (lr *acqRightsServiceServer) CreateProductAggregation(ctx context.Context,
 		Type:   sql.NullString{String: "aw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "aw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -373,6 +377,8 @@ func (lr *acqRightsServiceServer) UpdateProductAggregation(ctx context.Context,
 		Type:   sql.NullString{String: "aw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "aw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -412,6 +418,8 @@ func (lr *acqRightsServiceServer) DeleteProductAggregation(ctx context.Context,
 		Type:   sql.NullString{String: "aw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "aw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -435,6 +443,8 @@ func (lr *acqRightsServiceServer) rpcCalls(ctx context.Context, service string,
 		Type:   sql.NullString{String: "rpc"},
 		Status: job.JobStatusPENDING,
 		Data:   dataToPush,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, dataToPush),
 	}, "rpc")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
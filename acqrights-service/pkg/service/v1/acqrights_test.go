@@ -13,12 +13,12 @@ import (
 	v1 "optisam-backend/acqrights-service/pkg/api/v1"
 	dbmock "optisam-backend/acqrights-service/pkg/repository/v1/dbmock"
 	"optisam-backend/acqrights-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/acqrights-service/pkg/repository/v1/queuemock"
 	"optisam-backend/acqrights-service/pkg/rpc"
 	"optisam-backend/acqrights-service/pkg/worker"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/token/claims"
+	"optisam-backend/common/optisam/workerqueue"
 	"optisam-backend/common/optisam/workerqueue/job"
 	"testing"
 
@@ -51,7 +51,7 @@ func getJob(input interface{}, jtype worker.MessageType) (json.RawMessage, error
 func TestUpsertAcqRights(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.UpsertAcqRightsRequest
@@ -59,6 +59,8 @@ func TestUpsertAcqRights(t *testing.T) {
 		mock   func(*v1.UpsertAcqRightsRequest)
 		outErr bool
 		ctx    context.Context
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "UpsertAcqRightsWithCompleteData",
@@ -82,7 +84,7 @@ func TestUpsertAcqRights(t *testing.T) {
 			outErr: false,
 			ctx:    ctx,
 			mock: func(input *v1.UpsertAcqRightsRequest) {
-				fcall := dbObj.EXPECT().UpsertAcqRights(ctx, db.UpsertAcqRightsParams{
+				dbObj.EXPECT().UpsertAcqRights(ctx, db.UpsertAcqRightsParams{
 					Sku:                     input.Sku,
 					Swidtag:                 input.Swidtag,
 					ProductName:             input.ProductName,
@@ -103,18 +105,22 @@ func TestUpsertAcqRights(t *testing.T) {
 				if err != nil {
 					t.Errorf("Test cases has beed modiefied or test data has been modified")
 				}
-				qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "aw"},
-					Status: job.JobStatusPENDING,
-					Data:   eData,
-				}, "aw").Return(int32(1), nil).After(fcall)
+				jobData = eData
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewAcqRightsServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewAcqRightsServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.UpsertAcqRights(test.ctx, test.input)
 			if (err != nil) != test.outErr {
 				t.Errorf("Failed case [%s]  because expected err is mismatched with actual err ", test.name)
@@ -122,6 +128,9 @@ func TestUpsertAcqRights(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
@@ -132,7 +141,7 @@ func TestUpsertAcqRights(t *testing.T) {
 func TestListAcqRights(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsRequest
@@ -258,7 +267,7 @@ func TestListAcqRights(t *testing.T) {
 func TestListAcqRightsProducts(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsProductsRequest
@@ -348,7 +357,7 @@ func TestListAcqRightsProducts(t *testing.T) {
 func TestListAcqRightsEditors(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsEditorsRequest
@@ -420,7 +429,7 @@ func TestListAcqRightsEditors(t *testing.T) {
 func TestListAcqRightsMetrics(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsMetricsRequest
@@ -492,7 +501,7 @@ func TestListAcqRightsMetrics(t *testing.T) {
 func TestCreateProductAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var rpcData, jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.ProductAggregationMessage
@@ -500,6 +509,8 @@ func TestCreateProductAggregation(t *testing.T) {
 		mock   func(*v1.ProductAggregationMessage)
 		outErr bool
 		ctx    context.Context
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "CreateProductAggregationWithCorrectData",
@@ -520,7 +531,7 @@ func TestCreateProductAggregation(t *testing.T) {
 			outErr: false,
 			ctx:    ctx,
 			mock: func(input *v1.ProductAggregationMessage) {
-				fcall := dbObj.EXPECT().InsertAggregation(ctx, db.InsertAggregationParams{
+				dbObj.EXPECT().InsertAggregation(ctx, db.InsertAggregationParams{
 					AggregationName:   input.Name,
 					AggregationScope:  input.Scope,
 					AggregationMetric: input.Metric,
@@ -541,21 +552,24 @@ func TestCreateProductAggregation(t *testing.T) {
 				if err != nil {
 					t.Error("Failed to do json marshalling , something has been changed in test cases")
 				}
-				sec := qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "rpc"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "rpc").Return(int32(1), nil).After(fcall)
+				rpcData = edata
 
 				edata, err = getJob(input, worker.UpsertAggregation)
 				if err != nil {
 					t.Errorf("Something has been changed in testcases")
 				}
-				qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "aw"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "aw").Return(int32(2), nil).After(sec)
+				jobData = edata
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "rpc"},
+					Status:         job.JobStatusPENDING,
+					Data:           rpcData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, rpcData)}, {
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 		},
 		{
@@ -570,6 +584,7 @@ func TestCreateProductAggregation(t *testing.T) {
 			outErr: true,
 			ctx:    ctx,
 			mock:   func(input *v1.ProductAggregationMessage) {},
+			jobs:   func() []job.Job { return nil },
 		},
 		{
 			name: "CreateAggregationWithNoScope",
@@ -582,6 +597,7 @@ func TestCreateProductAggregation(t *testing.T) {
 			outErr: true,
 			ctx:    ctx,
 			mock:   func(input *v1.ProductAggregationMessage) {},
+			jobs:   func() []job.Job { return nil },
 		},
 		{
 			name: "CreateAggregationWithoutContext",
@@ -595,12 +611,14 @@ func TestCreateProductAggregation(t *testing.T) {
 			outErr: true,
 			ctx:    context.Background(),
 			mock:   func(input *v1.ProductAggregationMessage) {},
+			jobs:   func() []job.Job { return nil },
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewAcqRightsServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewAcqRightsServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.CreateProductAggregation(test.ctx, test.input)
 			if (err != nil) != test.outErr {
 				t.Errorf("Failed case [%s]  because expected err is mismatched with actual err ", test.name)
@@ -608,6 +626,9 @@ func TestCreateProductAggregation(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
@@ -618,7 +639,7 @@ func TestCreateProductAggregation(t *testing.T) {
 func TestListProductAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListProductAggregationRequest
@@ -724,7 +745,7 @@ func TestListProductAggregation(t *testing.T) {
 func TestUpdateProductAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var rpcData, jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.ProductAggregationMessage
@@ -732,6 +753,8 @@ func TestUpdateProductAggregation(t *testing.T) {
 		mock   func(*v1.ProductAggregationMessage)
 		outErr bool
 		ctx    context.Context
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "UpdateAggregationWithCorrectData",
@@ -756,7 +779,7 @@ func TestUpdateProductAggregation(t *testing.T) {
 				if !ok {
 					t.Errorf("Testcase has been failed ")
 				}
-				fcall := dbObj.EXPECT().UpdateAggregation(ctx, db.UpdateAggregationParams{
+				dbObj.EXPECT().UpdateAggregation(ctx, db.UpdateAggregationParams{
 					Scope:           userClaims.Socpes,
 					AggregationID:   input.ID,
 					AggregationName: input.Name,
@@ -776,21 +799,24 @@ func TestUpdateProductAggregation(t *testing.T) {
 				if err != nil {
 					t.Error("Failed to do json marshalling , something has been changed in test cases")
 				}
-				sec := qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "rpc"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "rpc").Return(int32(1), nil).After(fcall)
+				rpcData = edata
 
 				edata, err = getJob(input, worker.UpsertAggregation)
 				if err != nil {
 					t.Errorf("Something has been changed in testcases")
 				}
-				qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "aw"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "aw").Return(int32(2), nil).After(sec)
+				jobData = edata
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "rpc"},
+					Status:         job.JobStatusPENDING,
+					Data:           rpcData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, rpcData)}, {
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 		},
 		{
@@ -805,12 +831,14 @@ func TestUpdateProductAggregation(t *testing.T) {
 			outErr: true,
 			ctx:    context.Background(),
 			mock:   func(input *v1.ProductAggregationMessage) {},
+			jobs:   func() []job.Job { return nil },
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewAcqRightsServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewAcqRightsServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.UpdateProductAggregation(test.ctx, test.input)
 			if (err != nil) != test.outErr {
 				t.Errorf("Failed case [%s]  because expected err is mismatched with actual err ", test.name)
@@ -818,6 +846,9 @@ func TestUpdateProductAggregation(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
@@ -828,7 +859,7 @@ func TestUpdateProductAggregation(t *testing.T) {
 func TestDeleteProductAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var rpcData, jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.DeleteProductAggregationRequest
@@ -836,6 +867,8 @@ func TestDeleteProductAggregation(t *testing.T) {
 		mock   func(*v1.DeleteProductAggregationRequest)
 		outErr bool
 		ctx    context.Context
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "DeleteProductAggregationWithCorrectData",
@@ -851,7 +884,7 @@ func TestDeleteProductAggregation(t *testing.T) {
 				if !ok {
 					t.Errorf("Failed in testcases")
 				}
-				fcall := dbObj.EXPECT().DeleteAggregation(ctx, db.DeleteAggregationParams{
+				dbObj.EXPECT().DeleteAggregation(ctx, db.DeleteAggregationParams{
 					AggregationID: input.ID,
 					Scope:         userClaims.Socpes,
 				}).Return(nil).Times(1)
@@ -864,21 +897,24 @@ func TestDeleteProductAggregation(t *testing.T) {
 				if err != nil {
 					t.Error("Failed to do json marshalling , something has been changed in test cases")
 				}
-				sec := qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "rpc"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "rpc").Return(int32(1), nil).After(fcall)
+				rpcData = edata
 
 				edata, err = getJob(input, worker.DeleteAggregation)
 				if err != nil {
 					t.Errorf("Something has been changed in testcases")
 				}
-				qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "aw"},
-					Status: job.JobStatusPENDING,
-					Data:   edata,
-				}, "aw").Return(int32(2), nil).After(sec)
+				jobData = edata
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "rpc"},
+					Status:         job.JobStatusPENDING,
+					Data:           rpcData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, rpcData)}, {
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 		},
 		{
@@ -887,12 +923,14 @@ func TestDeleteProductAggregation(t *testing.T) {
 			outErr: true,
 			ctx:    context.Background(),
 			mock:   func(input *v1.DeleteProductAggregationRequest) {},
+			jobs:   func() []job.Job { return nil },
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewAcqRightsServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewAcqRightsServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.DeleteProductAggregation(test.ctx, test.input)
 
 			if (err != nil) != test.outErr {
@@ -901,9 +939,25 @@ func TestDeleteProductAggregation(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
 		})
 	}
 }
+
+//pushedJobs gives the jobs pushed to b in the order they were pushed
+func pushedJobs(t *testing.T, b workerqueue.Backend) []job.Job {
+	jobs, err := b.List(context.Background(), workerqueue.ListFilter{})
+	if err != nil {
+		t.Fatalf("cannot list jobs: %v", err)
+	}
+	var pushed []job.Job
+	for i := len(jobs) - 1; i >= 0; i-- {
+		pushed = append(pushed, job.Job{Type: jobs[i].Type, Status: jobs[i].Status, Data: jobs[i].Data, IdempotencyKey: jobs[i].IdempotencyKey})
+	}
+	return pushed
+}
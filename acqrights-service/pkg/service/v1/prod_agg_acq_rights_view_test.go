@@ -11,9 +11,9 @@ import (
 	v1 "optisam-backend/acqrights-service/pkg/api/v1"
 	dbmock "optisam-backend/acqrights-service/pkg/repository/v1/dbmock"
 	"optisam-backend/acqrights-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/acqrights-service/pkg/repository/v1/queuemock"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -24,7 +24,7 @@ import (
 func TestListAcqRightsAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsAggregationRequest
@@ -152,7 +152,7 @@ func TestListAcqRightsAggregation(t *testing.T) {
 func TestListAcqRightsAggregationRecords(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockAcqRights(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListAcqRightsAggregationRecordsRequest
@@ -12,12 +12,13 @@ import (
 	"errors"
 	"fmt"
 	v1 "optisam-backend/acqrights-service/pkg/api/v1"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/workerqueue/job"
 	"strconv"
 
 	dgo "github.com/dgraph-io/dgo/v2"
-	"github.com/dgraph-io/dgo/v2/protos/api"
 	"go.uber.org/zap"
 )
 
@@ -32,6 +33,7 @@ const (
 	UpsertAcqRightsRequest MessageType = "UpsertAcqRights"
 	UpsertAggregation      MessageType = "UpsertAggregation"
 	DeleteAggregation      MessageType = "DeleteAggregation"
+	DeleteNode             MessageType = "DeleteNode"
 )
 
 type Envelope struct {
@@ -56,137 +58,81 @@ func (w *Worker) DoWork(ctx context.Context, j *job.Job) error {
 	case UpsertAcqRightsRequest:
 		var uar v1.UpsertAcqRightsRequest
 		_ = json.Unmarshal(e.JSON, &uar)
-		query := `query {
-			var(func: eq(acqRights.SKU,"` + uar.GetSku() + `")) @filter(eq(type_name,"acqRights")){
-				acRights as uid
-				}
-			var(func: eq(product.swidtag,"` + uar.GetSwidtag() + `")) @filter(eq(type_name,"product")){
-				product as uid
-				}
-			}
-			`
-		upsertAcqRights := `
-			uid(acRights) <acqRights.SKU> "` + uar.GetSku() + `" .
-			uid(acRights) <type_name> "acqRights" .
-			uid(acRights) <dgraph.type> "AcquiredRights" .
-			uid(acRights) <scopes> "` + uar.GetScope() + `" .
-			uid(acRights) <acqRights.swidtag> "` + uar.GetSwidtag() + `" .
-			uid(acRights) <acqRights.productName> "` + uar.GetProductName() + `" .
-			uid(acRights) <acqRights.editor> "` + uar.GetProductEditor() + `" .
-			uid(acRights) <acqRights.entity> "` + uar.GetEntity() + `" .
-			uid(acRights) <acqRights.metric> "` + uar.GetMetricType() + `" .
-			uid(acRights) <acqRights.numOfAcqLicences> "` + strconv.Itoa(int(uar.GetNumLicensesAcquired())) + `" .
-			uid(acRights) <acqRights.numOfLicencesUnderMaintenance> "` + strconv.Itoa(int(uar.GetNumLicencesMaintainance())) + `" .
-			uid(acRights) <acqRights.averageUnitPrice> "` + strconv.Itoa(int(uar.GetAvgUnitPrice())) + `" .
-			uid(acRights) <acqRights.averageMaintenantUnitPrice> "` + strconv.Itoa(int(uar.GetAvgMaintenanceUnitPrice())) + `" .
-			uid(acRights) <acqRights.totalPurchaseCost> "` + strconv.Itoa(int(uar.GetTotalPurchaseCost())) + `" .
-			uid(acRights) <acqRights.totalMaintenanceCost> "` + strconv.Itoa(int(uar.GetTotalMaintenanceCost())) + `" .
-			uid(acRights) <acqRights.totalCost> "` + strconv.Itoa(int(uar.GetTotalCost())) + `" .
-			uid(product) <product.swidtag> "` + uar.GetSwidtag() + `" .
-			uid(product) <product.acqRights> uid(acRights) .
-			uid(product) <type_name> "product" .
-			uid(product) <dgraph.type> "Product" .
-			uid(product) <scopes> "` + uar.GetScope() + `" .
-		`
-
-		req := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{{SetNquads: []byte(upsertAcqRights)}},
-			CommitNow: true,
-		}
-		if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+		upsert := dgraph.NewUpsert()
+		acRights := upsert.Find("acRights", "acqRights.SKU", uar.GetSku(), "acqRights")
+		product := upsert.Find("product", "product.swidtag", uar.GetSwidtag(), "product")
+		upsert.Mutation().Set.
+			Add(acRights, "acqRights.SKU", dgraph.String(uar.GetSku())).
+			Add(acRights, "type_name", dgraph.String("acqRights")).
+			Add(acRights, "dgraph.type", dgraph.String("AcquiredRights")).
+			Add(acRights, "scopes", dgraph.String(uar.GetScope())).
+			Add(acRights, "acqRights.swidtag", dgraph.String(uar.GetSwidtag())).
+			Add(acRights, "acqRights.productName", dgraph.String(uar.GetProductName())).
+			Add(acRights, "acqRights.editor", dgraph.String(uar.GetProductEditor())).
+			Add(acRights, "acqRights.entity", dgraph.String(uar.GetEntity())).
+			Add(acRights, "acqRights.metric", dgraph.String(uar.GetMetricType())).
+			Add(acRights, "acqRights.numOfAcqLicences", dgraph.Int(int64(uar.GetNumLicensesAcquired()))).
+			Add(acRights, "acqRights.numOfLicencesUnderMaintenance", dgraph.Int(int64(uar.GetNumLicencesMaintainance()))).
+			Add(acRights, "acqRights.averageUnitPrice", dgraph.Float(float64(uar.GetAvgUnitPrice()))).
+			Add(acRights, "acqRights.averageMaintenantUnitPrice", dgraph.Float(float64(uar.GetAvgMaintenanceUnitPrice()))).
+			Add(acRights, "acqRights.totalPurchaseCost", dgraph.Float(float64(uar.GetTotalPurchaseCost()))).
+			Add(acRights, "acqRights.totalMaintenanceCost", dgraph.Float(float64(uar.GetTotalMaintenanceCost()))).
+			Add(acRights, "acqRights.totalCost", dgraph.Float(float64(uar.GetTotalCost()))).
+			Add(product, "product.swidtag", dgraph.String(uar.GetSwidtag())).
+			Add(product, "product.acqRights", acRights).
+			Add(product, "type_name", dgraph.String("product")).
+			Add(product, "dgraph.type", dgraph.String("Product")).
+			Add(product, "scopes", dgraph.String(uar.GetScope()))
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
 	case UpsertAggregation:
 		var uar v1.ProductAggregationMessage
 		_ = json.Unmarshal(e.JSON, &uar)
-		query := `query {
-			var(func: eq(product_aggregation.name,"` + uar.GetName() + `")) @filter(eq(type_name,"product_aggregation")){
-				aggregation as uid
-				}
-			var(func: eq(metric.name,"` + uar.GetMetric() + `")) @filter(eq(type_name,"metric")){
-				metric as uid
-			}
-			`
-		deleteQuery := `query {
-			var(func: eq(product_aggregation.name,"` + uar.GetName() + `")) @filter(eq(type_name,"product_aggregation")){
-				aggregation as uid
-				}
-			}
-			`
-		delete := `
-				uid(aggregation) <product_aggregation.products> * .
-		`
-		set := `
-		uid(aggregation) <product_aggregation.id> "` + strconv.Itoa(int(uar.GetID())) + `" .
-		uid(aggregation) <product_aggregation.name> "` + uar.GetName() + `" .
-		uid(aggregation) <type_name> "product_aggregation" .
-		uid(aggregation) <dgraph.type> "ProductAggregation" .
-		uid(aggregation) <scopes> "` + uar.GetScope() + `" .
-		uid(aggregation) <product_aggregation.editor> "` + uar.GetEditor() + `" .
-		uid(aggregation) <product_aggregation.metric> uid(metric) .
-		`
-		for i, product := range uar.GetProducts() {
-			query += `
-			var(func: eq(product.swidtag,"` + product + `")) @filter(eq(type_name,"product")){
-				product` + strconv.Itoa(i) + ` as uid
-			}
-			`
-			set += `
-			uid(aggregation) <product_aggregation.products> uid(product` + strconv.Itoa(i) + `) .
-			`
+		//products of aggregation are removed before its products of message are added
+		deletion := dgraph.NewUpsert()
+		aggregation := deletion.Find("aggregation", "product_aggregation.name", uar.GetName(), "product_aggregation")
+		deletion.Mutation().Delete.Add(aggregation, "product_aggregation.products", dgraph.Star)
+		if err := w.do(ctx, deletion); err != nil {
+			return err
 		}
-		query += `
-		}`
-		muDelete := &api.Mutation{DelNquads: []byte(delete)}
-		logger.Log.Info(query)
-		delreq := &api.Request{
-			Query:     deleteQuery,
-			Mutations: []*api.Mutation{muDelete},
-			CommitNow: true,
+		upsert := dgraph.NewUpsert()
+		aggregation = upsert.Find("aggregation", "product_aggregation.name", uar.GetName(), "product_aggregation")
+		metric := upsert.Find("metric", "metric.name", uar.GetMetric(), "metric")
+		set := &upsert.Mutation().Set
+		set.Add(aggregation, "product_aggregation.id", dgraph.Int(int64(uar.GetID()))).
+			Add(aggregation, "product_aggregation.name", dgraph.String(uar.GetName())).
+			Add(aggregation, "type_name", dgraph.String("product_aggregation")).
+			Add(aggregation, "dgraph.type", dgraph.String("ProductAggregation")).
+			Add(aggregation, "scopes", dgraph.String(uar.GetScope())).
+			Add(aggregation, "product_aggregation.metric", metric)
+		//editor is not kept in postgres, aggregations repaired from postgres keep their editor
+		if uar.GetEditor() != "" {
+			set.Add(aggregation, "product_aggregation.editor", dgraph.String(uar.GetEditor()))
 		}
-		if _, err := w.dg.NewTxn().Do(ctx, delreq); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+		for i, swidtag := range uar.GetProducts() {
+			product := upsert.Find("product"+strconv.Itoa(i), "product.swidtag", swidtag, "product")
+			set.Add(aggregation, "product_aggregation.products", product)
 		}
-		muUpsert := &api.Mutation{SetNquads: []byte(set)}
-		logger.Log.Info(query)
-		upsertreq := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{muUpsert},
-			CommitNow: true,
-		}
-		if _, err := w.dg.NewTxn().Do(ctx, upsertreq); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
 	case DeleteAggregation:
 		var dar v1.DeleteProductAggregationRequest
 		_ = json.Unmarshal(e.JSON, &dar)
-		query := `query {
-			var(func: eq(product_aggregation.id,"` + strconv.Itoa(int(dar.GetID())) + `")) @filter(eq(type_name,"product_aggregation")){
-				aggregation as uid
-				}
-			`
-		delete := `
-				uid(aggregation) * * .
-		`
-		set := `
-				uid(aggregation) <Recycle> "true" .
-		`
-		query += `
-		}`
-		muDelete := &api.Mutation{DelNquads: []byte(delete), SetNquads: []byte(set)}
-		logger.Log.Info(query)
-		req := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{muDelete},
-			CommitNow: true,
+		upsert := dgraph.NewUpsert()
+		aggregation := upsert.Find("aggregation", "product_aggregation.id", strconv.Itoa(int(dar.GetID())), "product_aggregation")
+		mu := upsert.Mutation()
+		mu.Delete.Add(aggregation, "*", dgraph.Star)
+		mu.Set.Add(aggregation, "Recycle", dgraph.String("true"))
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
-		if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+	case DeleteNode:
+		var orphan reconcile.Orphan
+		_ = json.Unmarshal(e.JSON, &orphan)
+		if err := w.do(ctx, orphan.Upsert()); err != nil {
+			return err
 		}
 	default:
 		fmt.Println(e.JSON)
@@ -195,3 +141,19 @@ func (w *Worker) DoWork(ctx context.Context, j *job.Job) error {
 	//Everything's fine, we're done here
 	return nil
 }
+
+//do runs an upsert in Dgraph, a failed upsert is retried
+func (w *Worker) do(ctx context.Context, upsert *dgraph.Upsert) error {
+	req, err := upsert.Request()
+	if err != nil {
+		//an invalid upsert fails again when retried
+		logger.Log.Error("Invalid upsert to Dgraph", zap.Error(err))
+		return job.Permanent(err)
+	}
+	logger.Log.Info(req.Query)
+	if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
+		logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
+		return errors.New("RETRY")
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	v1 "optisam-backend/acqrights-service/pkg/api/v1"
+	repo "optisam-backend/acqrights-service/pkg/repository/v1"
+	"optisam-backend/acqrights-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
+	"optisam-backend/common/optisam/workerqueue"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"strconv"
+
+	dgo "github.com/dgraph-io/dgo/v2"
+)
+
+const (
+	kindAcqRights   = "acqRights"
+	kindAggregation = "aggregation"
+)
+
+//Reconciler reconciles the acquired rights and aggregations of dgraph with postgres
+type Reconciler struct {
+	repo  repo.AcqRights
+	dg    *dgo.Dgraph
+	queue workerqueue.Workerqueue
+}
+
+//NewReconciler gives the reconciler of acquired rights, its repairs are pushed to the aw worker of queue
+func NewReconciler(r repo.AcqRights, dg *dgo.Dgraph, queue workerqueue.Workerqueue) *Reconciler {
+	return &Reconciler{repo: r, dg: dg, queue: queue}
+}
+
+//Postgres gives the acquired rights and aggregations of scope
+func (r *Reconciler) Postgres(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	acqRights, aggregations, err := r.ofScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]reconcile.Record, 0, len(acqRights)+len(aggregations))
+	for _, a := range acqRights {
+		records = append(records, reconcile.Record{Kind: kindAcqRights, Key: a.Sku, Fields: map[string]string{
+			"acqRights.swidtag":                       a.Swidtag,
+			"acqRights.productName":                   a.ProductName,
+			"acqRights.editor":                        a.ProductEditor,
+			"acqRights.entity":                        a.Entity,
+			"acqRights.metric":                        a.Metric,
+			"acqRights.numOfAcqLicences":              strconv.Itoa(int(a.NumLicensesAcquired)),
+			"acqRights.numOfLicencesUnderMaintenance": strconv.Itoa(int(a.NumLicencesMaintainance)),
+			"acqRights.averageUnitPrice":              float(float64(a.AvgUnitPrice)),
+			"acqRights.averageMaintenantUnitPrice":    float(float64(a.AvgMaintenanceUnitPrice)),
+			"acqRights.totalPurchaseCost":             float(float64(a.TotalPurchaseCost)),
+			"acqRights.totalMaintenanceCost":          float(float64(a.TotalMaintenanceCost)),
+			"acqRights.totalCost":                     float(float64(a.TotalCost)),
+			"product.acqRights":                       reconcile.Links([]string{a.Swidtag}),
+		}, Data: a})
+	}
+	for _, a := range aggregations {
+		records = append(records, reconcile.Record{Kind: kindAggregation, Key: a.AggregationName, Fields: map[string]string{
+			"product_aggregation.id":       strconv.Itoa(int(a.AggregationID)),
+			"product_aggregation.metric":   a.AggregationMetric,
+			"product_aggregation.products": reconcile.Links(a.Products),
+		}, Data: a})
+	}
+	return records, nil
+}
+
+type dgraphProduct struct {
+	Swidtag string `json:"product.swidtag"`
+}
+
+//Dgraph gives the acquired rights and aggregations of scope in dgraph
+func (r *Reconciler) Dgraph(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	q := `query Reconcile($scope: string) {
+		AcqRights(func: eq(type_name, "acqRights")) @filter(eq(scopes, $scope)) {
+			acqRights.SKU
+			acqRights.swidtag
+			acqRights.productName
+			acqRights.editor
+			acqRights.entity
+			acqRights.metric
+			acqRights.numOfAcqLicences
+			acqRights.numOfLicencesUnderMaintenance
+			acqRights.averageUnitPrice
+			acqRights.averageMaintenantUnitPrice
+			acqRights.totalPurchaseCost
+			acqRights.totalMaintenanceCost
+			acqRights.totalCost
+			~product.acqRights {
+				product.swidtag
+			}
+		}
+		Aggregations(func: eq(type_name, "product_aggregation")) @filter(eq(scopes, $scope)) {
+			product_aggregation.id
+			product_aggregation.name
+			product_aggregation.metric {
+				metric.name
+			}
+			product_aggregation.products {
+				product.swidtag
+			}
+		}
+	}`
+	resp, err := r.dg.NewReadOnlyTxn().QueryWithVars(ctx, q, map[string]string{"$scope": scope})
+	if err != nil {
+		return nil, fmt.Errorf("cannot query acquired rights of scope %s: %v", scope, err)
+	}
+	var data struct {
+		AcqRights []struct {
+			SKU                      string          `json:"acqRights.SKU"`
+			Swidtag                  string          `json:"acqRights.swidtag"`
+			ProductName              string          `json:"acqRights.productName"`
+			Editor                   string          `json:"acqRights.editor"`
+			Entity                   string          `json:"acqRights.entity"`
+			Metric                   string          `json:"acqRights.metric"`
+			AcqLicences              int64           `json:"acqRights.numOfAcqLicences"`
+			LicencesUnderMaintenance int64           `json:"acqRights.numOfLicencesUnderMaintenance"`
+			AverageUnitPrice         float64         `json:"acqRights.averageUnitPrice"`
+			AverageMaintenancePrice  float64         `json:"acqRights.averageMaintenantUnitPrice"`
+			TotalPurchaseCost        float64         `json:"acqRights.totalPurchaseCost"`
+			TotalMaintenanceCost     float64         `json:"acqRights.totalMaintenanceCost"`
+			TotalCost                float64         `json:"acqRights.totalCost"`
+			Products                 []dgraphProduct `json:"~product.acqRights"`
+		}
+		Aggregations []struct {
+			ID     int64  `json:"product_aggregation.id"`
+			Name   string `json:"product_aggregation.name"`
+			Metric struct {
+				Name string `json:"metric.name"`
+			} `json:"product_aggregation.metric"`
+			Products []dgraphProduct `json:"product_aggregation.products"`
+		}
+	}
+	if err := json.Unmarshal(resp.GetJson(), &data); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal acquired rights of scope %s: %v", scope, err)
+	}
+	records := make([]reconcile.Record, 0, len(data.AcqRights)+len(data.Aggregations))
+	for _, a := range data.AcqRights {
+		records = append(records, reconcile.Record{Kind: kindAcqRights, Key: a.SKU, Fields: map[string]string{
+			"acqRights.swidtag":                       a.Swidtag,
+			"acqRights.productName":                   a.ProductName,
+			"acqRights.editor":                        a.Editor,
+			"acqRights.entity":                        a.Entity,
+			"acqRights.metric":                        a.Metric,
+			"acqRights.numOfAcqLicences":              strconv.FormatInt(a.AcqLicences, 10),
+			"acqRights.numOfLicencesUnderMaintenance": strconv.FormatInt(a.LicencesUnderMaintenance, 10),
+			"acqRights.averageUnitPrice":              float(a.AverageUnitPrice),
+			"acqRights.averageMaintenantUnitPrice":    float(a.AverageMaintenancePrice),
+			"acqRights.totalPurchaseCost":             float(a.TotalPurchaseCost),
+			"acqRights.totalMaintenanceCost":          float(a.TotalMaintenanceCost),
+			"acqRights.totalCost":                     float(a.TotalCost),
+			"product.acqRights":                       reconcile.Links(swidtags(a.Products)),
+		}})
+	}
+	for _, a := range data.Aggregations {
+		records = append(records, reconcile.Record{Kind: kindAggregation, Key: a.Name, Fields: map[string]string{
+			"product_aggregation.id":       strconv.FormatInt(a.ID, 10),
+			"product_aggregation.metric":   a.Metric.Name,
+			"product_aggregation.products": reconcile.Links(swidtags(a.Products)),
+		}})
+	}
+	return records, nil
+}
+
+//Repair pushes the upserts of drifted records from their rows, and the deletions of orphan nodes
+func (r *Reconciler) Repair(ctx context.Context, scope string, drifts []reconcile.Drift) (int32, error) {
+	jobs := make([]job.Job, 0, len(drifts))
+	for _, d := range drifts {
+		var data []byte
+		var err error
+		switch {
+		case d.Status == reconcile.StatusOrphan && d.Kind == kindAcqRights:
+			data, err = envelope(DeleteNode, reconcile.Orphan{Predicate: "acqRights.SKU", Key: d.Key, TypeName: "acqRights"})
+		case d.Status == reconcile.StatusOrphan:
+			data, err = envelope(DeleteNode, reconcile.Orphan{Predicate: "product_aggregation.name", Key: d.Key, TypeName: "product_aggregation"})
+		case d.Kind == kindAcqRights:
+			a, ok := d.Data.(db.Acqright)
+			if !ok {
+				return 0, fmt.Errorf("acquired rights %s of scope %s have no row to repair from", d.Key, scope)
+			}
+			data, err = envelope(UpsertAcqRightsRequest, &v1.UpsertAcqRightsRequest{
+				Sku:                     a.Sku,
+				Swidtag:                 a.Swidtag,
+				ProductName:             a.ProductName,
+				ProductEditor:           a.ProductEditor,
+				MetricType:              a.Metric,
+				NumLicensesAcquired:     a.NumLicensesAcquired,
+				NumLicencesMaintainance: a.NumLicencesMaintainance,
+				AvgUnitPrice:            a.AvgUnitPrice,
+				AvgMaintenanceUnitPrice: a.AvgMaintenanceUnitPrice,
+				TotalPurchaseCost:       a.TotalPurchaseCost,
+				TotalMaintenanceCost:    a.TotalMaintenanceCost,
+				TotalCost:               a.TotalCost,
+				Entity:                  a.Entity,
+				Scope:                   a.Scope,
+			})
+		default:
+			a, ok := d.Data.(db.Aggregation)
+			if !ok {
+				return 0, fmt.Errorf("aggregation %s of scope %s has no row to repair from", d.Key, scope)
+			}
+			data, err = envelope(UpsertAggregation, &v1.ProductAggregationMessage{
+				ID:       a.AggregationID,
+				Name:     a.AggregationName,
+				Metric:   a.AggregationMetric,
+				Scope:    a.AggregationScope,
+				Products: a.Products,
+			})
+		}
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, job.Job{
+			Type:   sql.NullString{String: "aw"},
+			Status: job.JobStatusPENDING,
+			Data:   data,
+		})
+	}
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	ids, err := r.queue.PushJobs(ctx, jobs, "aw")
+	return int32(len(ids)), err
+}
+
+//Drop deletes the acquired rights and aggregations of scope, and unlinks them from products
+func (r *Reconciler) Drop(ctx context.Context, scope string) error {
+	upsert := dgraph.NewUpsert()
+	acqRights := upsert.Find("acqRights", "scopes", scope, "acqRights")
+	aggregations := upsert.Find("aggregations", "scopes", scope, "product_aggregation")
+	products := upsert.Find("products", "scopes", scope, "product")
+	upsert.Mutation().Delete.
+		Add(acqRights, "*", dgraph.Star).
+		Add(aggregations, "*", dgraph.Star).
+		Add(products, "product.acqRights", dgraph.Star)
+	req, err := upsert.Request()
+	if err != nil {
+		return err
+	}
+	if _, err := r.dg.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("cannot drop acquired rights of scope %s: %v", scope, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) ofScope(ctx context.Context, scope string) ([]db.Acqright, []db.Aggregation, error) {
+	acqRights, err := r.repo.ListAcqRightsOfScope(ctx, scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list acquired rights of scope %s: %v", scope, err)
+	}
+	aggregations, err := r.repo.ListAggregationsOfScope(ctx, scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list aggregations of scope %s: %v", scope, err)
+	}
+	return acqRights, aggregations, nil
+}
+
+//envelope gives the data of a job of worker
+func envelope(t MessageType, message interface{}) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{Type: t, JSON: data})
+}
+
+//float gives the value of a real column, dgraph keeps it as a float64
+func float(f float64) string {
+	return strconv.FormatFloat(float64(float32(f)), 'g', -1, 32)
+}
+
+func swidtags(products []dgraphProduct) []string {
+	tags := make([]string, len(products))
+	for i, p := range products {
+		tags[i] = p.Swidtag
+	}
+	return tags
+}
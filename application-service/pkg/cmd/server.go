@@ -20,6 +20,7 @@ import (
 	"optisam-backend/application-service/pkg/worker"
 	"optisam-backend/common/optisam/buildinfo"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	"optisam-backend/common/optisam/healthcheck"
 	"optisam-backend/common/optisam/iam"
 	"optisam-backend/common/optisam/jaeger"
@@ -210,6 +211,9 @@ func RunServer() error {
 	if err != nil {
 		logger.Log.Error("Failed to register server stats view")
 	}
+	if err := view.Register(workerqueue.DefaultViews...); err != nil {
+		logger.Log.Error("Failed to register worker queue stats view")
+	}
 
 	// Run Instumentation Server
 	instrumentationServer := &http.Server{
@@ -225,6 +229,7 @@ func RunServer() error {
 	if err != nil {
 		return fmt.Errorf("failed to create worker queue: %v", err)
 	}
+	defer q.Close(ctx)
 	lWorker := worker.NewWorker("lw", dg)
 	q.RegisterWorker(ctx, lWorker)
 
@@ -248,5 +253,6 @@ func RunServer() error {
 	go func() {
 		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, verifyKey)
 	}()
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
+	adminAPI := reconcile.NewAdminServer("applications", worker.NewReconciler(rep, dg, q))
+	return grpc.RunServer(ctx, v1API, adminAPI, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
 }
@@ -12,10 +12,12 @@ import (
 	"log"
 	"net"
 	v1 "optisam-backend/application-service/pkg/api/v1"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	mw "optisam-backend/common/optisam/middleware/grpc"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/open-policy-agent/opa/rego"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -23,7 +25,7 @@ import (
 )
 
 // RunServer runs gRPC service to publish Auth service
-func RunServer(ctx context.Context, v1API v1.ApplicationServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
+func RunServer(ctx context.Context, v1API v1.ApplicationServiceServer, adminAPI rcv1.ReconcileServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
@@ -37,10 +39,11 @@ func RunServer(ctx context.Context, v1API v1.ApplicationServiceServer, port stri
 	// register service
 	server := grpc.NewServer(opts...)
 	v1.RegisterApplicationServiceServer(server, v1API)
+	rcv1.RegisterReconcileServiceServer(server, adminAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			// sig is a ^C, handle it
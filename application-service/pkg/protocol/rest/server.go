@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	v1 "optisam-backend/application-service/pkg/api/v1"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	rest_middleware "optisam-backend/common/optisam/middleware/rest"
 	"os"
@@ -77,5 +78,8 @@ func newGateway(ctx context.Context, grpcPort string) (http.Handler, error) {
 	if err := v1.RegisterApplicationServiceHandler(ctx, mux_gateway, conn); err != nil {
 		return nil, err
 	}
+	if err := rcv1.RegisterReconcileServiceHandler(ctx, mux_gateway, conn); err != nil {
+		return nil, err
+	}
 	return mux_gateway, err
 }
@@ -86,6 +86,36 @@ func (mr *MockApplicationMockRecorder) GetInstancesView(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstancesView", reflect.TypeOf((*MockApplication)(nil).GetInstancesView), arg0, arg1)
 }
 
+// ListApplicationsOfScope mocks base method
+func (m *MockApplication) ListApplicationsOfScope(arg0 context.Context, arg1 string) ([]db.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationsOfScope", arg0, arg1)
+	ret0, _ := ret[0].([]db.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationsOfScope indicates an expected call of ListApplicationsOfScope
+func (mr *MockApplicationMockRecorder) ListApplicationsOfScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationsOfScope", reflect.TypeOf((*MockApplication)(nil).ListApplicationsOfScope), arg0, arg1)
+}
+
+// ListInstancesOfScope mocks base method
+func (m *MockApplication) ListInstancesOfScope(arg0 context.Context, arg1 string) ([]db.ApplicationsInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstancesOfScope", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApplicationsInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstancesOfScope indicates an expected call of ListInstancesOfScope
+func (mr *MockApplicationMockRecorder) ListInstancesOfScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstancesOfScope", reflect.TypeOf((*MockApplication)(nil).ListInstancesOfScope), arg0, arg1)
+}
+
 // UpsertApplication mocks base method
 func (m *MockApplication) UpsertApplication(arg0 context.Context, arg1 db.UpsertApplicationParams) error {
 	m.ctrl.T.Helper()
@@ -16,6 +16,8 @@ type Querier interface {
 	GetApplicationInstance(ctx context.Context, instanceID string) (ApplicationsInstance, error)
 	GetApplicationsView(ctx context.Context, arg GetApplicationsViewParams) ([]GetApplicationsViewRow, error)
 	GetInstancesView(ctx context.Context, arg GetInstancesViewParams) ([]GetInstancesViewRow, error)
+	ListApplicationsOfScope(ctx context.Context, scope string) ([]Application, error)
+	ListInstancesOfScope(ctx context.Context, scope string) ([]ApplicationsInstance, error)
 	UpsertApplication(ctx context.Context, arg UpsertApplicationParams) error
 	UpsertApplicationInstance(ctx context.Context, arg UpsertApplicationInstanceParams) error
 }
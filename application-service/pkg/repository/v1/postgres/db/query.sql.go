@@ -252,6 +252,76 @@ func (q *Queries) GetInstancesView(ctx context.Context, arg GetInstancesViewPara
 	return items, nil
 }
 
+const listApplicationsOfScope = `-- name: ListApplicationsOfScope :many
+SELECT application_id, application_name, application_version, application_owner, scope, created_on FROM applications
+WHERE scope = $1
+`
+
+func (q *Queries) ListApplicationsOfScope(ctx context.Context, scope string) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, listApplicationsOfScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.ApplicationName,
+			&i.ApplicationVersion,
+			&i.ApplicationOwner,
+			&i.Scope,
+			&i.CreatedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInstancesOfScope = `-- name: ListInstancesOfScope :many
+SELECT application_id, instance_id, instance_environment, products, equipments, scope FROM applications_instances
+WHERE scope = $1
+`
+
+func (q *Queries) ListInstancesOfScope(ctx context.Context, scope string) ([]ApplicationsInstance, error) {
+	rows, err := q.db.QueryContext(ctx, listInstancesOfScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationsInstance
+	for rows.Next() {
+		var i ApplicationsInstance
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.InstanceID,
+			&i.InstanceEnvironment,
+			pq.Array(&i.Products),
+			pq.Array(&i.Equipments),
+			&i.Scope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertApplication = `-- name: UpsertApplication :exec
 INSERT INTO applications (application_id, application_name, application_version, application_owner, scope, created_on)
 VALUES ($1,$2,$3,$4,$5,$6)
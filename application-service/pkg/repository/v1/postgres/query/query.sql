@@ -64,3 +64,12 @@ VALUES ($1,$2,$3,$4,$5,$6)
 ON CONFLICT (instance_id)
 DO
  UPDATE SET instance_environment = $3, products = $4,equipments = $5;
+
+
+-- name: ListApplicationsOfScope :many
+SELECT * FROM applications
+WHERE scope = $1;
+
+-- name: ListInstancesOfScope :many
+SELECT * FROM applications_instances
+WHERE scope = $1;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- a job pushed again with the key of a job still to run is not created twice
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR;
+
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_idempotency_key_idx ON jobs (type,idempotency_key) WHERE idempotency_key IS NOT NULL AND status IN ('PENDING','RETRY','RUNNING');
+CREATE INDEX IF NOT EXISTS jobs_idempotency_key_completed_idx ON jobs (type,idempotency_key,end_time) WHERE idempotency_key IS NOT NULL AND status = 'COMPLETED';
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS jobs_idempotency_key_completed_idx;
+DROP INDEX IF EXISTS jobs_idempotency_key_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS idempotency_key;
@@ -15,11 +15,11 @@ import (
 	v1 "optisam-backend/application-service/pkg/api/v1"
 	dbmock "optisam-backend/application-service/pkg/repository/v1/dbmock"
 	"optisam-backend/application-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/application-service/pkg/repository/v1/queuemock"
 	"optisam-backend/application-service/pkg/worker"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/token/claims"
+	"optisam-backend/common/optisam/workerqueue"
 	"optisam-backend/common/optisam/workerqueue/job"
 	"os"
 	"testing"
@@ -45,13 +45,15 @@ func TestMain(m *testing.M) {
 func TestUpsertApplication(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockApplication(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.UpsertApplicationRequest
 		output *v1.UpsertApplicationResponse
 		mock   func(*v1.UpsertApplicationRequest)
 		outErr bool
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "UpsertApplicationWithCorrectData",
@@ -64,7 +66,7 @@ func TestUpsertApplication(t *testing.T) {
 			},
 			output: &v1.UpsertApplicationResponse{Success: true},
 			mock: func(input *v1.UpsertApplicationRequest) {
-				firstCall := dbObj.EXPECT().UpsertApplication(ctx, db.UpsertApplicationParams{
+				dbObj.EXPECT().UpsertApplication(ctx, db.UpsertApplicationParams{
 					ApplicationID:      "a1",
 					ApplicationName:    "a1name",
 					ApplicationOwner:   "a1owner",
@@ -81,12 +83,14 @@ func TestUpsertApplication(t *testing.T) {
 				if err != nil {
 					t.Errorf("Failed to do json marshalling in test  %v", err)
 				}
-				job := job.Job{
-					Type:   sql.NullString{String: "lw"},
-					Status: job.JobStatusPENDING,
-					Data:   envolveData,
-				}
-				qObj.EXPECT().PushJob(ctx, job, "lw").Return(int32(1000), nil).After(firstCall)
+				jobData = envolveData
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "lw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 			outErr: false,
 		},
@@ -98,6 +102,7 @@ func TestUpsertApplication(t *testing.T) {
 				dbObj.EXPECT().UpsertApplication(ctx, db.UpsertApplicationParams{}).Return(errors.New("rpc error: code = Internal desc = DBError")).Times(1)
 			},
 			outErr: true,
+			jobs:   func() []job.Job { return nil },
 		},
 		{
 			name: "UpsertApplicationWithMissingapplicationID",
@@ -117,13 +122,15 @@ func TestUpsertApplication(t *testing.T) {
 				}).Return(errors.New("rpc error: code = Internal desc = DBError")).Times(1)
 			},
 			outErr: true,
+			jobs:   func() []job.Job { return nil },
 		},
 	}
 
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewApplicationServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewApplicationServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.UpsertApplication(ctx, test.input)
 			log.Println(" log to be removed RESP[", got, "][", err, "]")
 			if (err != nil) != test.outErr {
@@ -132,6 +139,9 @@ func TestUpsertApplication(t *testing.T) {
 			} else if got.Success != test.output.Success {
 				t.Errorf("Failed case [%s] because  exepcted output [%v] is not same as actual output [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
@@ -142,7 +152,7 @@ func TestUpsertApplication(t *testing.T) {
 func TestListApplications(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockApplication(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
 	if !ok {
 		t.Errorf("Failed to get claims, test cases has been changed")
@@ -273,7 +283,7 @@ func TestListApplications(t *testing.T) {
 func TestListInstances(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockApplication(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
 	if !ok {
 		t.Errorf("Failed to get claims, test cases has been changed")
@@ -401,7 +411,7 @@ func TestListInstances(t *testing.T) {
 func TestUpsertInstance(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockApplication(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.UpsertInstanceRequest
@@ -409,6 +419,8 @@ func TestUpsertInstance(t *testing.T) {
 		mock   func(*v1.UpsertInstanceRequest)
 		outErr bool
 		ctx    context.Context
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "UpsertInstanceWithCorrectData",
@@ -429,7 +441,7 @@ func TestUpsertInstance(t *testing.T) {
 			outErr: false,
 			ctx:    ctx,
 			mock: func(input *v1.UpsertInstanceRequest) {
-				firstCall := dbObj.EXPECT().UpsertInstanceTX(ctx, input).Return(nil).Times(1)
+				dbObj.EXPECT().UpsertInstanceTX(ctx, input).Return(nil).Times(1)
 				jsonData, err := json.Marshal(input)
 				if err != nil {
 					t.Errorf("Failed to do json marshalling in test %v", err)
@@ -440,12 +452,14 @@ func TestUpsertInstance(t *testing.T) {
 				if err != nil {
 					t.Errorf("Failed to do json marshalling in test  %v", err)
 				}
-				job := job.Job{
-					Type:   sql.NullString{String: "lw"},
-					Status: job.JobStatusPENDING,
-					Data:   envolveData,
-				}
-				qObj.EXPECT().PushJob(ctx, job, "lw").Return(int32(1000), nil).After(firstCall)
+				jobData = envolveData
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "lw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
 			},
 		},
 		{
@@ -468,12 +482,14 @@ func TestUpsertInstance(t *testing.T) {
 			mock: func(input *v1.UpsertInstanceRequest) {
 				dbObj.EXPECT().UpsertInstanceTX(ctx, input).Return(errors.New("DB Error")).Times(1)
 			},
+			jobs: func() []job.Job { return nil },
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewApplicationServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewApplicationServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.UpsertInstance(test.ctx, test.input)
 			log.Println(" log to be removed RESP[", got, "][", err, "]")
 			if (err != nil) != test.outErr {
@@ -482,9 +498,24 @@ func TestUpsertInstance(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 				return
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+				return
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
 		})
 	}
 }
+
+func pushedJobs(t *testing.T, b workerqueue.Backend) []job.Job {
+	jobs, err := b.List(context.Background(), workerqueue.ListFilter{})
+	if err != nil {
+		t.Fatalf("cannot list jobs: %v", err)
+	}
+	var pushed []job.Job
+	for _, j := range jobs {
+		pushed = append(pushed, job.Job{Type: j.Type, Status: j.Status, Data: j.Data, IdempotencyKey: j.IdempotencyKey})
+	}
+	return pushed
+}
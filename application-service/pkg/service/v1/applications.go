@@ -68,6 +68,8 @@ func (s *applicationServiceServer) UpsertApplication(ctx context.Context, req *v
 		Type:   sql.NullString{String: "lw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "lw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -103,6 +105,8 @@ func (s *applicationServiceServer) UpsertInstance(ctx context.Context, req *v1.U
 		Type:   sql.NullString{String: "lw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried call does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "lw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -12,12 +12,13 @@ import (
 	"errors"
 	"fmt"
 	v1 "optisam-backend/application-service/pkg/api/v1"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/workerqueue/job"
 	"strconv"
 
 	dgo "github.com/dgraph-io/dgo/v2"
-	"github.com/dgraph-io/dgo/v2/protos/api"
 	"go.uber.org/zap"
 )
 
@@ -31,6 +32,7 @@ type MessageType string
 const (
 	UpsertApplicationRequest MessageType = "UpsertApplication"
 	UpsertInstanceRequest    MessageType = "UpsertInstance"
+	DeleteNode               MessageType = "DeleteNode"
 )
 
 type Envelope struct {
@@ -55,103 +57,70 @@ func (w *Worker) DoWork(ctx context.Context, j *job.Job) error {
 	case UpsertApplicationRequest:
 		var uar v1.UpsertApplicationRequest
 		_ = json.Unmarshal(e.JSON, &uar)
-		query := `query {application as var(func: eq(application.id,` + uar.GetApplicationId() + `)) @filter(eq(type_name,"application"))}`
-		mu := &api.Mutation{SetNquads: []byte(`
-		uid(application) <application.id> "` + uar.GetApplicationId() + `" .
-		uid(application) <application.name>"` + uar.GetName() + `" .
-		uid(application) <application.version>"` + uar.GetVersion() + `" .
-		uid(application) <application.owner>"` + uar.GetOwner() + `" .
-		uid(application) <scopes> "` + uar.GetScope() + `" .
-		uid(application) <type_name> "application" .
-		uid(application) <dgraph.type> "Application" .
-		`)}
-		req := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{mu},
-			CommitNow: true,
-		}
-		if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+		upsert := dgraph.NewUpsert()
+		application := upsert.Find("application", "application.id", uar.GetApplicationId(), "application")
+		upsert.Mutation().Set.
+			Add(application, "application.id", dgraph.String(uar.GetApplicationId())).
+			Add(application, "application.name", dgraph.String(uar.GetName())).
+			Add(application, "application.version", dgraph.String(uar.GetVersion())).
+			Add(application, "application.owner", dgraph.String(uar.GetOwner())).
+			Add(application, "scopes", dgraph.String(uar.GetScope())).
+			Add(application, "type_name", dgraph.String("application")).
+			Add(application, "dgraph.type", dgraph.String("Application"))
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
 	case UpsertInstanceRequest:
 		var uir v1.UpsertInstanceRequest
 		_ = json.Unmarshal(e.JSON, &uir)
 		fmt.Println(uir)
-		query := `query {
-			  var(func: eq(instance.id,"` + uir.GetInstanceId() + `")) @filter(eq(type_name,"instance")){
-				  instance as uid
-			  }
-			`
-		addProductEquipment := `
-		uid(instance) <instance.id> "` + uir.GetInstanceId() + `" .
-		uid(instance) <scopes> "` + uir.GetScope() + `" .
-		uid(instance) <type_name> "instance" .
-		uid(instance) <dgraph.type> "Instance" .
-		`
+		upsert := dgraph.NewUpsert()
+		instance := upsert.Find("instance", "instance.id", uir.GetInstanceId(), "instance")
+		addProductEquipment := &upsert.Mutation().Set
+		addProductEquipment.Add(instance, "instance.id", dgraph.String(uir.GetInstanceId())).
+			Add(instance, "scopes", dgraph.String(uir.GetScope())).
+			Add(instance, "type_name", dgraph.String("instance")).
+			Add(instance, "dgraph.type", dgraph.String("Instance"))
 
 		if uir.Products.GetOperation() == "add" {
-			for i, product := range uir.GetProducts().GetProductId() {
-				query += `
-				var(func: eq(product.swidtag,"` + product + `")) @filter(eq(type_name,"product")){
-					product` + strconv.Itoa(i) + ` as uid
-				}
-				`
-				addProductEquipment += `
-				uid(product` + strconv.Itoa(i) + `) <product.swidtag> "` + product + `" .
-				uid(product` + strconv.Itoa(i) + `) <type_name> "product" .
-				uid(product` + strconv.Itoa(i) + `) <dgraph.type> "Product" .
-				uid(product` + strconv.Itoa(i) + `) <scopes> "` + uir.GetScope() + `" .
-				uid(instance) <instance.product> uid(product` + strconv.Itoa(i) + `) .
-				`
+			for i, swidtag := range uir.GetProducts().GetProductId() {
+				product := upsert.Find("product"+strconv.Itoa(i), "product.swidtag", swidtag, "product")
+				addProductEquipment.Add(product, "product.swidtag", dgraph.String(swidtag)).
+					Add(product, "type_name", dgraph.String("product")).
+					Add(product, "dgraph.type", dgraph.String("Product")).
+					Add(product, "scopes", dgraph.String(uir.GetScope())).
+					Add(instance, "instance.product", product)
 			}
 		}
 
 		if uir.Equipments.GetOperation() == "add" {
-			for i, equipment := range uir.GetEquipments().GetEquipmentId() {
-				query += `
-				var(func: eq(equipment.id,"` + equipment + `")) @filter(eq(type_name,"equipment")){
-					equipment` + strconv.Itoa(i) + ` as uid
-				}
-				`
-				addProductEquipment += `
-				uid(equipment` + strconv.Itoa(i) + `) <equipment.id> "` + equipment + `" .
-				uid(equipment` + strconv.Itoa(i) + `) <type_name> "equipment" .
-				uid(equipment` + strconv.Itoa(i) + `) <dgraph.type> "Equipment" .
-				uid(equipment` + strconv.Itoa(i) + `) <scopes> "` + uir.GetScope() + `" .
-				uid(instance) <instance.equipment> uid(equipment` + strconv.Itoa(i) + `) .
-				`
+			for i, equipmentID := range uir.GetEquipments().GetEquipmentId() {
+				equipment := upsert.Find("equipment"+strconv.Itoa(i), "equipment.id", equipmentID, "equipment")
+				addProductEquipment.Add(equipment, "equipment.id", dgraph.String(equipmentID)).
+					Add(equipment, "type_name", dgraph.String("equipment")).
+					Add(equipment, "dgraph.type", dgraph.String("Equipment")).
+					Add(equipment, "scopes", dgraph.String(uir.GetScope())).
+					Add(instance, "instance.equipment", equipment)
 			}
 		}
 
 		if uir.GetApplicationId() != "" {
-			query += `
-			var(func: eq(application.id,"` + uir.GetApplicationId() + `")) @filter(eq(type_name,"application")){
-				application as uid
-			}`
-			addProductEquipment += `
-			uid(instance) <instance.environment>"` + uir.GetInstanceName() + `" .
-			uid(application) <application.instance> uid(instance) .
-			uid(application) <application.id> "` + uir.GetApplicationId() + `" .
-			uid(application) <type_name> "application" .
-			uid(application) <dgraph.type> "Application" .
-			uid(application) <scopes> "` + uir.GetScope() + `" .
-			`
+			application := upsert.Find("application", "application.id", uir.GetApplicationId(), "application")
+			addProductEquipment.Add(instance, "instance.environment", dgraph.String(uir.GetInstanceName())).
+				Add(application, "application.instance", instance).
+				Add(application, "application.id", dgraph.String(uir.GetApplicationId())).
+				Add(application, "type_name", dgraph.String("application")).
+				Add(application, "dgraph.type", dgraph.String("Application")).
+				Add(application, "scopes", dgraph.String(uir.GetScope()))
 		}
-		//end query block
-		query += `
-		}`
-		logger.Log.Info(query)
-
-		muAdd := &api.Mutation{SetNquads: []byte(addProductEquipment)}
-		req := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{muAdd},
-			CommitNow: true,
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
-		if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+	case DeleteNode:
+		var orphan reconcile.Orphan
+		_ = json.Unmarshal(e.JSON, &orphan)
+		if err := w.do(ctx, orphan.Upsert()); err != nil {
+			return err
 		}
 	default:
 		fmt.Println(e.JSON)
@@ -160,3 +129,19 @@ func (w *Worker) DoWork(ctx context.Context, j *job.Job) error {
 	//Everything's fine, we're done here
 	return nil
 }
+
+//do runs an upsert in Dgraph, a failed upsert is retried
+func (w *Worker) do(ctx context.Context, upsert *dgraph.Upsert) error {
+	req, err := upsert.Request()
+	if err != nil {
+		//an invalid upsert fails again when retried
+		logger.Log.Error("Invalid upsert to Dgraph", zap.Error(err))
+		return job.Permanent(err)
+	}
+	logger.Log.Info(req.Query)
+	if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
+		logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
+		return errors.New("RETRY")
+	}
+	return nil
+}
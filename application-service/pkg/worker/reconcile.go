@@ -0,0 +1,249 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	v1 "optisam-backend/application-service/pkg/api/v1"
+	repo "optisam-backend/application-service/pkg/repository/v1"
+	"optisam-backend/application-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
+	"optisam-backend/common/optisam/workerqueue"
+	"optisam-backend/common/optisam/workerqueue/job"
+
+	dgo "github.com/dgraph-io/dgo/v2"
+)
+
+const (
+	kindApplication = "application"
+	kindInstance    = "instance"
+)
+
+//predicates of application nodes written by application service, products are linked to applications by product service
+var applicationPredicates = []string{
+	"application.name",
+	"application.version",
+	"application.owner",
+	"application.instance",
+}
+
+//Reconciler reconciles the applications and instances of dgraph with postgres.
+//Products and equipments of an instance which are linked in dgraph only are reported, they are not unlinked by repairs.
+type Reconciler struct {
+	repo  repo.Application
+	dg    *dgo.Dgraph
+	queue workerqueue.Workerqueue
+}
+
+//NewReconciler gives the reconciler of applications, its repairs are pushed to the lw worker of queue
+func NewReconciler(r repo.Application, dg *dgo.Dgraph, queue workerqueue.Workerqueue) *Reconciler {
+	return &Reconciler{repo: r, dg: dg, queue: queue}
+}
+
+//Postgres gives the applications and instances of scope
+func (r *Reconciler) Postgres(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	applications, instances, err := r.ofScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]reconcile.Record, 0, len(applications)+len(instances))
+	for _, a := range applications {
+		records = append(records, reconcile.Record{Kind: kindApplication, Key: a.ApplicationID, Fields: map[string]string{
+			"application.name":    a.ApplicationName,
+			"application.version": a.ApplicationVersion,
+			"application.owner":   a.ApplicationOwner,
+		}, Data: a})
+	}
+	for _, i := range instances {
+		records = append(records, reconcile.Record{Kind: kindInstance, Key: i.InstanceID, Fields: map[string]string{
+			"instance.environment": i.InstanceEnvironment,
+			"application.instance": reconcile.Links([]string{i.ApplicationID}),
+			"instance.product":     reconcile.Links(i.Products),
+			"instance.equipment":   reconcile.Links(i.Equipments),
+		}, Data: i})
+	}
+	return records, nil
+}
+
+//Dgraph gives the applications and instances of scope in dgraph,
+//applications are the application nodes having predicates of application service
+func (r *Reconciler) Dgraph(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	q := `query Reconcile($scope: string) {
+		Applications(func: eq(type_name, "application")) @filter(eq(scopes, $scope) AND has(application.name)) {
+			application.id
+			application.name
+			application.version
+			application.owner
+		}
+		Instances(func: eq(type_name, "instance")) @filter(eq(scopes, $scope)) {
+			instance.id
+			instance.environment
+			~application.instance {
+				application.id
+			}
+			instance.product {
+				product.swidtag
+			}
+			instance.equipment {
+				equipment.id
+			}
+		}
+	}`
+	resp, err := r.dg.NewReadOnlyTxn().QueryWithVars(ctx, q, map[string]string{"$scope": scope})
+	if err != nil {
+		return nil, fmt.Errorf("cannot query applications of scope %s: %v", scope, err)
+	}
+	var data struct {
+		Applications []struct {
+			ID      string `json:"application.id"`
+			Name    string `json:"application.name"`
+			Version string `json:"application.version"`
+			Owner   string `json:"application.owner"`
+		}
+		Instances []struct {
+			ID           string `json:"instance.id"`
+			Environment  string `json:"instance.environment"`
+			Applications []struct {
+				ID string `json:"application.id"`
+			} `json:"~application.instance"`
+			Products []struct {
+				Swidtag string `json:"product.swidtag"`
+			} `json:"instance.product"`
+			Equipments []struct {
+				ID string `json:"equipment.id"`
+			} `json:"instance.equipment"`
+		}
+	}
+	if err := json.Unmarshal(resp.GetJson(), &data); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal applications of scope %s: %v", scope, err)
+	}
+	records := make([]reconcile.Record, 0, len(data.Applications)+len(data.Instances))
+	for _, a := range data.Applications {
+		records = append(records, reconcile.Record{Kind: kindApplication, Key: a.ID, Fields: map[string]string{
+			"application.name":    a.Name,
+			"application.version": a.Version,
+			"application.owner":   a.Owner,
+		}})
+	}
+	for _, i := range data.Instances {
+		var applications, products, equipments []string
+		for _, a := range i.Applications {
+			applications = append(applications, a.ID)
+		}
+		for _, p := range i.Products {
+			products = append(products, p.Swidtag)
+		}
+		for _, e := range i.Equipments {
+			equipments = append(equipments, e.ID)
+		}
+		records = append(records, reconcile.Record{Kind: kindInstance, Key: i.ID, Fields: map[string]string{
+			"instance.environment": i.Environment,
+			"application.instance": reconcile.Links(applications),
+			"instance.product":     reconcile.Links(products),
+			"instance.equipment":   reconcile.Links(equipments),
+		}})
+	}
+	return records, nil
+}
+
+//Repair pushes the upserts of drifted applications and instances from their rows, and the deletions of orphan nodes
+func (r *Reconciler) Repair(ctx context.Context, scope string, drifts []reconcile.Drift) (int32, error) {
+	jobs := make([]job.Job, 0, len(drifts))
+	for _, d := range drifts {
+		var data []byte
+		var err error
+		switch {
+		case d.Status == reconcile.StatusOrphan && d.Kind == kindApplication:
+			data, err = envelope(DeleteNode, reconcile.Orphan{Predicate: "application.id", Key: d.Key, TypeName: "application", Predicates: applicationPredicates})
+		case d.Status == reconcile.StatusOrphan:
+			data, err = envelope(DeleteNode, reconcile.Orphan{Predicate: "instance.id", Key: d.Key, TypeName: "instance"})
+		case d.Kind == kindApplication:
+			a, ok := d.Data.(db.Application)
+			if !ok {
+				return 0, fmt.Errorf("application %s of scope %s has no row to repair from", d.Key, scope)
+			}
+			data, err = envelope(UpsertApplicationRequest, &v1.UpsertApplicationRequest{
+				ApplicationId: a.ApplicationID,
+				Name:          a.ApplicationName,
+				Version:       a.ApplicationVersion,
+				Owner:         a.ApplicationOwner,
+				Scope:         a.Scope,
+			})
+		default:
+			i, ok := d.Data.(db.ApplicationsInstance)
+			if !ok {
+				return 0, fmt.Errorf("instance %s of scope %s has no row to repair from", d.Key, scope)
+			}
+			data, err = envelope(UpsertInstanceRequest, &v1.UpsertInstanceRequest{
+				ApplicationId: i.ApplicationID,
+				InstanceId:    i.InstanceID,
+				InstanceName:  i.InstanceEnvironment,
+				Products:      &v1.UpsertInstanceRequestProduct{Operation: "add", ProductId: i.Products},
+				Equipments:    &v1.UpsertInstanceRequestEquipment{Operation: "add", EquipmentId: i.Equipments},
+				Scope:         i.Scope,
+			})
+		}
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, job.Job{
+			Type:   sql.NullString{String: "lw"},
+			Status: job.JobStatusPENDING,
+			Data:   data,
+		})
+	}
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	ids, err := r.queue.PushJobs(ctx, jobs, "lw")
+	return int32(len(ids)), err
+}
+
+//Drop deletes the instances of scope and the predicates of application service of its applications
+func (r *Reconciler) Drop(ctx context.Context, scope string) error {
+	upsert := dgraph.NewUpsert()
+	applications := upsert.Find("applications", "scopes", scope, "application")
+	instances := upsert.Find("instances", "scopes", scope, "instance")
+	deletion := &upsert.Mutation().Delete
+	for _, p := range applicationPredicates {
+		deletion.Add(applications, p, dgraph.Star)
+	}
+	deletion.Add(instances, "*", dgraph.Star)
+	req, err := upsert.Request()
+	if err != nil {
+		return err
+	}
+	if _, err := r.dg.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("cannot drop applications of scope %s: %v", scope, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) ofScope(ctx context.Context, scope string) ([]db.Application, []db.ApplicationsInstance, error) {
+	applications, err := r.repo.ListApplicationsOfScope(ctx, scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list applications of scope %s: %v", scope, err)
+	}
+	instances, err := r.repo.ListInstancesOfScope(ctx, scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list instances of scope %s: %v", scope, err)
+	}
+	return applications, instances, nil
+}
+
+//envelope gives the data of a job of worker
+func envelope(t MessageType, message interface{}) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{Type: t, JSON: data})
+}
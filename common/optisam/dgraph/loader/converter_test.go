@@ -0,0 +1,140 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package loader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v2/protos/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverters(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       converter
+		val     string
+		want    *api.Value
+		wantErr bool
+	}{
+		{name: "int with thousands separator",
+			c:    intConverter{},
+			val:  "1,024",
+			want: &api.Value{Val: &api.Value_IntVal{IntVal: 1024}},
+		},
+		{name: "int of a float",
+			c:       intConverter{},
+			val:     "10.5",
+			wantErr: true,
+		},
+		{name: "float",
+			c:    floatConverter{},
+			val:  "2,500.25",
+			want: &api.Value{Val: &api.Value_DoubleVal{DoubleVal: 2500.25}},
+		},
+		{name: "float of a string",
+			c:       floatConverter{},
+			val:     "ten",
+			wantErr: true,
+		},
+		{name: "string",
+			c:    stringConverter{},
+			val:  "1,024",
+			want: &api.Value{Val: &api.Value_StrVal{StrVal: "1,024"}},
+		},
+		{name: "default",
+			c:    defaultConverter{},
+			val:  "Oracle",
+			want: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Oracle"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.c.convert(test.val)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("convert() error = %v, wantErr %v", err, test.wantErr)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestIsRowDirty(t *testing.T) {
+	created := "2020-01-02T03:04:05Z"
+	updated := "2020-02-03T04:05:06Z"
+	tests := []struct {
+		name       string
+		row        []string
+		updatedIdx int
+		createdIdx int
+		want       string
+		wantErr    bool
+	}{
+		{name: "no time columns",
+			row:        []string{"p1"},
+			updatedIdx: -1,
+			createdIdx: -1,
+		},
+		{name: "updated is preferred",
+			row:        []string{"p1", updated, created},
+			updatedIdx: 1,
+			createdIdx: 2,
+			want:       updated,
+		},
+		{name: "empty updated falls back to created",
+			row:        []string{"p1", "", created},
+			updatedIdx: 1,
+			createdIdx: 2,
+			want:       created,
+		},
+		{name: "created only",
+			row:        []string{"p1", created},
+			updatedIdx: -1,
+			createdIdx: 1,
+			want:       created,
+		},
+		{name: "time values are missing",
+			row:        []string{"p1"},
+			updatedIdx: 1,
+			createdIdx: 2,
+			wantErr:    true,
+		},
+		{name: "invalid time",
+			row:        []string{"p1", "yesterday", created},
+			updatedIdx: 1,
+			createdIdx: 2,
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := isRowDirty(test.row, test.updatedIdx, test.createdIdx)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("isRowDirty() error = %v, wantErr %v", err, test.wantErr)
+			}
+			var want time.Time
+			if test.want != "" {
+				want, _ = time.Parse(time.RFC3339, test.want)
+			}
+			if !test.wantErr {
+				assert.True(t, want.Equal(got), "isRowDirty() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEquipmentType_PrimaryKeyAttribute(t *testing.T) {
+	id := &Attribute{Name: "server_id", IsIdentifier: true}
+	eqType := &EquipmentType{Attributes: []*Attribute{{Name: "server_name"}, id}}
+	got, err := eqType.PrimaryKeyAttribute()
+	assert.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	_, err = (&EquipmentType{Attributes: []*Attribute{{Name: "server_name"}}}).PrimaryKeyAttribute()
+	assert.Error(t, err)
+}
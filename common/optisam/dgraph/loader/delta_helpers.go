@@ -25,14 +25,14 @@ func isRowDirty(row []string, updatedIdx, createdIdx int) (time.Time, error) {
 	case len(row) <= updatedIdx && len(row) <= createdIdx:
 		// both created and updated records are not present we must treat this record as dirty
 		return time.Time{}, errors.New("updated and created  values are missing from row")
-	case (len(row) <= updatedIdx) && (createdIdx > -1 && len(row) > createdIdx):
+	case (updatedIdx < 0 || len(row) <= updatedIdx) && (createdIdx > -1 && len(row) > createdIdx):
 		t, err := time.Parse(time.RFC3339, row[createdIdx])
 		if err != nil {
 			return time.Time{}, fmt.Errorf("cannot parse created time : %s, err: %v", row[createdIdx], err)
 			// we cannot parse the time we must log an error and proceed row as dirty
 		}
 		return t, nil
-	case (updatedIdx > -1 && len(row) > updatedIdx) && len(row) <= createdIdx:
+	case (updatedIdx > -1 && len(row) > updatedIdx) && (createdIdx < 0 || len(row) <= createdIdx):
 		t, err := time.Parse(time.RFC3339, row[updatedIdx])
 		if err != nil {
 			return time.Time{}, fmt.Errorf("cannot parse updated time : %s, err: %v", row[updatedIdx], err)
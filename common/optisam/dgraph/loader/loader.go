@@ -25,7 +25,6 @@ import (
 	"time"
 
 	"optisam-backend/common/optisam/logger"
-	v1 "optisam-backend/license-service/pkg/repository/v1"
 
 	"go.uber.org/zap"
 
@@ -78,7 +77,7 @@ type Config struct {
 	SchemaFiles           []string
 	TypeFiles             []string
 	UsersFiles            []string
-	Repository            v1.License
+	Repository            Repository
 	BatchSize             int
 	IgnoreNew             bool
 	GenerateRDF           bool
@@ -299,7 +298,7 @@ func (al *AggregateLoader) Load() (retErr error) {
 	// load equipments using equiments types
 	// Preconditions: equipment types must have been created
 	if config.LoadEquipments {
-		eqTypes, err := config.Repository.EquipmentTypes(context.Background(), []string{})
+		eqTypes, err := config.Repository.EquipmentTypes(context.Background())
 		if err != nil {
 			return err
 		}
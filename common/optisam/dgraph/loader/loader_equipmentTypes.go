@@ -12,7 +12,6 @@ import (
 	"io"
 	"log"
 	"optisam-backend/common/optisam/logger"
-	v1 "optisam-backend/license-service/pkg/repository/v1"
 	"path/filepath"
 	"time"
 
@@ -31,13 +30,13 @@ const (
 
 var (
 	// EqTypeServer is equipment type server with all attributes
-	EqTypeServer = &v1.EquipmentType{
+	EqTypeServer = &EquipmentType{
 		Type:       Server,
 		SourceName: "equipment_server.csv",
-		Attributes: []*v1.Attribute{
+		Attributes: []*Attribute{
 			{
 				Name:               "HostName",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsIdentifier:       true,
 				IsDisplayed:        true,
 				IsSearchable:       true,
@@ -46,7 +45,7 @@ var (
 			},
 			{
 				Name:               "ServerCode",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
@@ -54,7 +53,7 @@ var (
 			},
 			{
 				Name:               "ServerManufacturer",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
@@ -62,7 +61,7 @@ var (
 			},
 			{
 				Name:               "ServerModel",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
@@ -70,100 +69,100 @@ var (
 			},
 			{
 				Name:               "ServerSerialNumber",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_serialNumber",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerDateInstallation",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_DateInstallation",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerProprietaryEntity",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_proprietaryEntity",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerHostingEntity",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_hostingEntity",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerUserEntity",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_userEntity",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerSite",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       false,
 				IsParentIdentifier: false,
 				MappedTo:           "server_Site",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerCPU",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_cpu",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerProcessorsNumber",
-				Type:               v1.DataTypeInt,
+				Type:               DataTypeInt,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_processorsNumber",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ServerCoresNumber",
-				Type:               v1.DataTypeInt,
+				Type:               DataTypeInt,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "server_coresNumber",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "Parent",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsParentIdentifier: true,
 				MappedTo:           "parent_id",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:         "OracleCoreFactor",
-				Type:         v1.DataTypeFloat,
+				Type:         DataTypeFloat,
 				IsDisplayed:  true,
 				IsSearchable: true,
 				MappedTo:     "corefactor_oracle",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:         "SAG",
-				Type:         v1.DataTypeFloat,
+				Type:         DataTypeFloat,
 				IsDisplayed:  true,
 				IsSearchable: true,
 				MappedTo:     "sag",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:         "PVU",
-				Type:         v1.DataTypeInt,
+				Type:         DataTypeInt,
 				IsDisplayed:  true,
 				IsSearchable: true,
 				MappedTo:     "pvu",
@@ -171,22 +170,22 @@ var (
 		},
 	}
 	// EqTypeCluster ...
-	EqTypeCluster = &v1.EquipmentType{
+	EqTypeCluster = &EquipmentType{
 		Type:       Cluster,
 		SourceName: "equipment_cluster.csv",
-		Attributes: []*v1.Attribute{
-			&v1.Attribute{
+		Attributes: []*Attribute{
+			&Attribute{
 				Name:               "ClusterName",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsIdentifier:       true,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "cluster_name",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "Parent",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsParentIdentifier: true,
 				MappedTo:           "parent_id",
@@ -194,22 +193,22 @@ var (
 		},
 	}
 	// EqTypeVcenter ...
-	EqTypeVcenter = &v1.EquipmentType{
+	EqTypeVcenter = &EquipmentType{
 		Type:       Vcenter,
 		SourceName: "equipment_vcenter.csv",
-		Attributes: []*v1.Attribute{
-			&v1.Attribute{
+		Attributes: []*Attribute{
+			&Attribute{
 				Name:               "VcenterName",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsIdentifier:       true,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "vcenter_name",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "Parent",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsParentIdentifier: true,
 				MappedTo:           "parent_id",
@@ -217,86 +216,86 @@ var (
 		},
 	}
 	// EqTypePartition ...
-	EqTypePartition = &v1.EquipmentType{
+	EqTypePartition = &EquipmentType{
 		Type:       Partition,
 		SourceName: "equipment_partition.csv",
-		Attributes: []*v1.Attribute{
-			&v1.Attribute{
+		Attributes: []*Attribute{
+			&Attribute{
 				Name:               "HostName",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsIdentifier:       true,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_hostname",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "PartitionCode",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_code",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "PartitionRole",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_role",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "Environment",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_environment",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "PartitionShortOs",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_shortOS",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "PartitionNormalizedOs",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       false,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_normalizedOS",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "CPU",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_cpu",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "ProcessorNumber",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_processorsNumber",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "CoresNumber",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsSearchable:       true,
 				IsParentIdentifier: false,
 				MappedTo:           "partition_coresNumber",
 			},
-			&v1.Attribute{
+			&Attribute{
 				Name:               "Parent",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsDisplayed:        true,
 				IsParentIdentifier: true,
 				MappedTo:           "parent_id",
@@ -304,13 +303,13 @@ var (
 		},
 	}
 	// EqTypeDataCenter ...
-	EqTypeDataCenter = &v1.EquipmentType{
+	EqTypeDataCenter = &EquipmentType{
 		Type:       "Datacenter",
 		SourceName: "equipment_datacenter.csv",
-		Attributes: []*v1.Attribute{
-			&v1.Attribute{
+		Attributes: []*Attribute{
+			&Attribute{
 				Name:               "Name",
-				Type:               v1.DataTypeString,
+				Type:               DataTypeString,
 				IsIdentifier:       true,
 				IsDisplayed:        true,
 				IsSearchable:       true,
@@ -322,15 +321,15 @@ var (
 )
 
 // LoadDefaultEquipmentTypes ...
-func LoadDefaultEquipmentTypes(repo v1.License) error {
-	eqTypes := []*v1.EquipmentType{
+func LoadDefaultEquipmentTypes(repo Repository) error {
+	eqTypes := []*EquipmentType{
 		EqTypeDataCenter,
 		EqTypeVcenter,
 		EqTypeCluster,
 		EqTypeServer,
 		EqTypePartition,
 	}
-	metas, err := repo.MetadataAllWithType(context.Background(), v1.MetadataTypeEquipment, []string{})
+	metas, err := repo.EquipmentMetadata(context.Background())
 	if err != nil {
 		return err
 	}
@@ -361,11 +360,8 @@ func LoadDefaultEquipmentTypes(repo v1.License) error {
 }
 
 // LoadEquipmentsType ...
-func LoadEquipmentsType(eqType *v1.EquipmentType, repo v1.License) error {
-	if _, err := repo.CreateEquipmentType(context.Background(), eqType, []string{}); err != nil {
-		return err
-	}
-	return nil
+func LoadEquipmentsType(eqType *EquipmentType, repo Repository) error {
+	return repo.CreateEquipmentType(context.Background(), eqType)
 }
 
 func loadEquipmentMetadata(ch chan<- *api.Request, doneChan <-chan struct{}, filename string) {
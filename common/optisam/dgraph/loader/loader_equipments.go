@@ -12,7 +12,6 @@ import (
 	"io"
 	"log"
 	"optisam-backend/common/optisam/logger"
-	v1 "optisam-backend/license-service/pkg/repository/v1"
 	"path/filepath"
 	"strings"
 	"time"
@@ -22,11 +21,11 @@ import (
 )
 
 type equipLoader struct {
-	eqTypes []*v1.EquipmentType
+	eqTypes []*EquipmentType
 }
 
 // LoadEquipments ...
-func loadEquipments(ml *MasterLoader, ch chan<- *api.Request, masterDir string, scopes []string, files []string, eqTypes []*v1.EquipmentType, doneChan <-chan struct{}) {
+func loadEquipments(ml *MasterLoader, ch chan<- *api.Request, masterDir string, scopes []string, files []string, eqTypes []*EquipmentType, doneChan <-chan struct{}) {
 	log.Println(files, len(eqTypes))
 	for _, scope := range scopes {
 		scopeLoader := ml.GetLoader(filepath.Base(scope))
@@ -34,7 +33,7 @@ func loadEquipments(ml *MasterLoader, ch chan<- *api.Request, masterDir string,
 			for _, et := range eqTypes {
 				if et.SourceName == filepath.Base(file) {
 					fileLoader := scopeLoader.GetLoader(masterDir, filepath.Base(file))
-					func(fl *FileLoader, f, s string, eqType *v1.EquipmentType) {
+					func(fl *FileLoader, f, s string, eqType *EquipmentType) {
 						load := func(version string) (time.Time, error) {
 							return loadEquipmentFile(fl, ch, masterDir, s, version, f, eqType, doneChan)
 						}
@@ -47,7 +46,7 @@ func loadEquipments(ml *MasterLoader, ch chan<- *api.Request, masterDir string,
 	}
 }
 
-func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, version string, filename string, eqType *v1.EquipmentType, doneChan <-chan struct{}) (retUpdatedOn time.Time, retErr error) {
+func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, version string, filename string, eqType *EquipmentType, doneChan <-chan struct{}) (retUpdatedOn time.Time, retErr error) {
 	log.Printf("start equip loading %s: %s \n", eqType.Type, filename)
 	defer log.Printf("end equip loading %s: %s \n", eqType.Type, filename)
 	updatedOn := l.UpdatedOn()
@@ -84,7 +83,7 @@ func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, versi
 		return updatedOn, err
 	}
 
-	attrMap := make(map[int]*v1.Attribute)
+	attrMap := make(map[int]*Attribute)
 	for _, attr := range eqType.Attributes {
 		index := findColoumnIdx(attr.MappedTo, columns)
 		if index < 0 {
@@ -219,7 +218,7 @@ func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, versi
 				continue
 			}
 			switch attr.Type {
-			case v1.DataTypeString:
+			case DataTypeString:
 				mu.Set = append(mu.Set,
 					&api.NQuad{
 						Subject:     uid,
@@ -227,7 +226,7 @@ func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, versi
 						ObjectValue: stringObjectValue(row[idx]),
 					},
 				)
-			case v1.DataTypeFloat:
+			case DataTypeFloat:
 				val, err := fc.convert(row[idx])
 				if err != nil {
 					logger.Log.Error("error converting data ", zap.String("filename:", filename), zap.String("header", columns[idx]), zap.String("col_val", row[idx]), zap.String("reason", err.Error()))
@@ -246,7 +245,7 @@ func loadEquipmentFile(l Loader, ch chan<- *api.Request, masterDir, scope, versi
 						ObjectValue: val,
 					},
 				)
-			case v1.DataTypeInt:
+			case DataTypeInt:
 				val, err := ic.convert(row[idx])
 				if err != nil {
 					logger.Log.Error("error converting data ", zap.String("filename:", filename), zap.String("header", columns[idx]), zap.String("col_val", row[idx]), zap.String("reason", err.Error()))
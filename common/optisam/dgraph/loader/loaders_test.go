@@ -0,0 +1,118 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package loader
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasterLoader_stateFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "state.json")
+	updated := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ml := &MasterLoader{}
+	fl := ml.GetLoader("scope1").GetLoader("testdata", "prod.csv")
+	fl.Version = "2"
+	fl.Succeeded(updated)
+	fl.SetError(errors.New("not saved"))
+	if err := saveMaterLoaderTofile(file, ml); err != nil {
+		t.Fatalf("saveMaterLoaderTofile() error = %v", err)
+	}
+	got, err := newMasterLoaderFromFile(file)
+	if err != nil {
+		t.Fatalf("newMasterLoaderFromFile() error = %v", err)
+	}
+	if !assert.Contains(t, got.Loaders, "scope1") {
+		return
+	}
+	if !assert.Contains(t, got.Loaders["scope1"].Loaders, "prod.csv") {
+		return
+	}
+	saved := got.Loaders["scope1"].Loaders["prod.csv"]
+	assert.Equal(t, "2", saved.Version)
+	assert.Equal(t, LoaderStateFailed, saved.State)
+	assert.True(t, updated.Equal(saved.Updated))
+	assert.NoError(t, saved.Error)
+
+	_, err = newMasterLoaderFromFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestFileLoader_Load(t *testing.T) {
+	versionDirs := []string{"1", "2", "3"}
+	tests := []struct {
+		name     string
+		fl       *FileLoader
+		failOn   string
+		loaded   []string
+		version  string
+		state    State
+		hasError bool
+	}{
+		{name: "new file",
+			fl:      &FileLoader{},
+			loaded:  []string{"1", "2", "3"},
+			version: "3",
+			state:   LoaderStateUpdated,
+		},
+		{name: "updated file resumes after its version",
+			fl:      &FileLoader{Version: "1", State: LoaderStateUpdated},
+			loaded:  []string{"2", "3"},
+			version: "3",
+			state:   LoaderStateUpdated,
+		},
+		{name: "failed file retries its version",
+			fl:      &FileLoader{Version: "2", State: LoaderStateFailed},
+			loaded:  []string{"2", "3"},
+			version: "3",
+			state:   LoaderStateUpdated,
+		},
+		{name: "unknown version loads all",
+			fl:      &FileLoader{Version: "0", State: LoaderStateUpdated},
+			loaded:  []string{"1", "2", "3"},
+			version: "3",
+			state:   LoaderStateUpdated,
+		},
+		{name: "load fails",
+			fl:       &FileLoader{},
+			failOn:   "2",
+			loaded:   []string{"1", "2"},
+			version:  "2",
+			state:    LoaderStateFailed,
+			hasError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var loaded []string
+			test.fl.SetLoaderFunc(func(version string) (time.Time, error) {
+				loaded = append(loaded, version)
+				if version == test.failOn {
+					return time.Time{}, errors.New("cannot load")
+				}
+				return time.Now(), nil
+			})
+			test.fl.Load(versionDirs)
+			assert.Equal(t, test.loaded, loaded)
+			assert.Equal(t, test.version, test.fl.Version)
+			assert.Equal(t, test.state, test.fl.CurrentState())
+			assert.Equal(t, test.hasError, test.fl.Error != nil)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package loader
+
+import (
+	"context"
+	"errors"
+)
+
+// DataType of the values of an attribute
+type DataType uint8
+
+func (d DataType) String() string {
+	switch d {
+	case DataTypeInt:
+		return "int"
+	case DataTypeFloat:
+		return "float"
+	case DataTypeString:
+		return "string"
+	default:
+		return "unsupported"
+	}
+}
+
+const (
+	// DataTypeString for string
+	DataTypeString DataType = 1
+	// DataTypeInt for int
+	DataTypeInt DataType = 2
+	// DataTypeFloat for float
+	DataTypeFloat DataType = 3
+)
+
+// EquipmentType is the type of the equipments of a csv file
+type EquipmentType struct {
+	ID         string
+	Type       string
+	SourceID   string
+	SourceName string
+	ParentID   string
+	ParentType string
+	Attributes []*Attribute
+}
+
+// PrimaryKeyAttribute returns primary key attribute of equipment type
+func (e *EquipmentType) PrimaryKeyAttribute() (*Attribute, error) {
+	for _, attr := range e.Attributes {
+		if attr.IsIdentifier {
+			return attr, nil
+		}
+	}
+	return nil, errors.New("Primary key attribute is not found")
+}
+
+// Attribute of an equipment type, mapped to a column of its csv file
+type Attribute struct {
+	ID                 string
+	Name               string
+	Type               DataType
+	IsIdentifier       bool
+	IsDisplayed        bool
+	IsSearchable       bool
+	IsParentIdentifier bool
+	MappedTo           string
+}
+
+// Metadata of a csv file of equipments
+type Metadata struct {
+	ID     string
+	Source string
+}
+
+// Repository gives and creates the equipment types in dgraph,
+// services adapt their repositories to it
+type Repository interface {
+	// EquipmentTypes gives all the equipment types
+	EquipmentTypes(ctx context.Context) ([]*EquipmentType, error)
+	// EquipmentMetadata gives the metadata of all the csv files of equipments
+	EquipmentMetadata(ctx context.Context) ([]*Metadata, error)
+	// CreateEquipmentType creates eqType and sets the ids of it and of its attributes
+	CreateEquipmentType(ctx context.Context, eqType *EquipmentType) error
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"optisam-backend/common/optisam/logger"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	dgo "github.com/dgraph-io/dgo/v2"
+	"github.com/dgraph-io/dgo/v2/protos/api"
+	"go.uber.org/zap"
+)
+
+//Extension is the extension of migration files, they are named <version>_<name>.dgraph, eg: 2_product_editor_index.dgraph
+const Extension = ".dgraph"
+
+//directive starts a section of a migration file, like +migrate Up does for postgres migrations
+const (
+	directive     = "# +migrate "
+	sectionSchema = "Schema"
+	sectionQuery  = "Query"
+	sectionSet    = "Set"
+	sectionDelete = "Delete"
+)
+
+//version of the schema is kept by a SchemaVersion node
+const (
+	versionSchema = "schema_version.version: int @index(int) @upsert .\ntype SchemaVersion {\n\tschema_version.version\n}"
+	versionQuery  = "migrated as var(func: has(schema_version.version))"
+	versionNquads = "uid(migrated) <schema_version.version> \"%d\" .\nuid(migrated) <dgraph.type> \"SchemaVersion\" ."
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.dgraph$`)
+
+// Migration is an up migration of the schema of dgraph and of the data it holds.
+// A migration file has sections, each one started by a directive:
+//
+//	# +migrate Schema
+//	product.editor: string @index(trigram,exact) .
+//	# +migrate Query
+//	products as var(func: has(product.editor))
+//	# +migrate Set
+//	uid(products) <updated> "migrated" .
+//
+// Query gives the variables of the Set and Delete nquads, it is made of query blocks without enclosing braces.
+type Migration struct {
+	Version int
+	Name    string
+	//Schema is altered before data are transformed, dgraph reindexes the predicates whose indexes change
+	Schema string
+	Query  string
+	Set    string
+	Delete string
+}
+
+//Box gives migration files by name, *packr.Box is a Box
+type Box interface {
+	List() []string
+	Find(name string) ([]byte, error)
+}
+
+//Dir is the box of the files of a directory
+type Dir string
+
+//List gives the names of the files of directory
+func (d Dir) List() []string {
+	files, err := ioutil.ReadDir(string(d))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if !f.IsDir() {
+			names = append(names, f.Name())
+		}
+	}
+	return names
+}
+
+//Find gives the content of file name of directory
+func (d Dir) Find(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(string(d), name))
+}
+
+//Load gives the migrations of box ordered by version, files without migration extension are ignored
+func Load(b Box) ([]*Migration, error) {
+	var migrations []*Migration
+	versions := make(map[int]string)
+	for _, name := range b.List() {
+		if filepath.Ext(name) != Extension {
+			continue
+		}
+		data, err := b.Find(name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read migration %s: %v", name, err)
+		}
+		m, err := Parse(name, data)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := versions[m.Version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, name)
+		}
+		versions[m.Version] = name
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+//Parse gives the migration of file name
+func Parse(name string, data []byte) (*Migration, error) {
+	match := fileName.FindStringSubmatch(filepath.Base(name))
+	if match == nil {
+		return nil, fmt.Errorf("migration %s is not named <version>_<name>%s", name, Extension)
+	}
+	version, err := strconv.Atoi(match[1])
+	if err != nil || version == 0 {
+		return nil, fmt.Errorf("migration %s has invalid version %s", name, match[1])
+	}
+	sections := make(map[string]*strings.Builder)
+	var current *strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.HasPrefix(text, directive) {
+			section := strings.TrimSpace(strings.TrimPrefix(text, directive))
+			switch section {
+			case sectionSchema, sectionQuery, sectionSet, sectionDelete:
+			default:
+				return nil, fmt.Errorf("migration %s line %d: unknown directive %s", name, line, section)
+			}
+			if _, ok := sections[section]; ok {
+				return nil, fmt.Errorf("migration %s line %d: section %s is repeated", name, line, section)
+			}
+			current = &strings.Builder{}
+			sections[section] = current
+			continue
+		}
+		if current == nil {
+			if trimmed := strings.TrimSpace(text); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				return nil, fmt.Errorf("migration %s line %d: statement is outside of a section", name, line)
+			}
+			continue
+		}
+		current.WriteString(text)
+		current.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read migration %s: %v", name, err)
+	}
+	content := func(section string) string {
+		if b, ok := sections[section]; ok {
+			return strings.TrimSpace(b.String())
+		}
+		return ""
+	}
+	m := &Migration{
+		Version: version,
+		Name:    match[2],
+		Schema:  content(sectionSchema),
+		Query:   content(sectionQuery),
+		Set:     content(sectionSet),
+		Delete:  content(sectionDelete),
+	}
+	if m.Query != "" && m.Set == "" && m.Delete == "" {
+		return nil, fmt.Errorf("migration %s has a query without set or delete", name)
+	}
+	return m, nil
+}
+
+//Version gives the version of the schema of dgraph, it is 0 when no migration has been applied
+func Version(ctx context.Context, dg *dgo.Dgraph) (int, error) {
+	resp, err := dg.NewReadOnlyTxn().Query(ctx, `{
+		Versions(func: has(schema_version.version)) {
+			schema_version.version
+		}
+	}`)
+	if err != nil {
+		return 0, fmt.Errorf("cannot query schema version: %v", err)
+	}
+	var data struct {
+		Versions []struct {
+			Version int `json:"schema_version.version"`
+		}
+	}
+	if err := json.Unmarshal(resp.GetJson(), &data); err != nil {
+		return 0, fmt.Errorf("cannot unmarshal schema version: %v", err)
+	}
+	version := 0
+	for _, v := range data.Versions {
+		if v.Version > version {
+			version = v.Version
+		}
+	}
+	return version, nil
+}
+
+//Up applies the migrations newer than the version of dgraph, in order, and gives the number applied.
+//The data of a migration and its version are committed together, so a failed migration can be applied again.
+//Schema of a migration is altered before, outside of this upsert, so migrations must be applied by one process at a time:
+//a concurrent run could alter the schema of a migration before the data of previous one are transformed.
+//A run finding that another one changed the version stops with an error.
+func Up(ctx context.Context, dg *dgo.Dgraph, migrations []*Migration) (int, error) {
+	if err := dg.Alter(ctx, &api.Operation{Schema: versionSchema}); err != nil {
+		return 0, fmt.Errorf("cannot alter schema of version: %v", err)
+	}
+	version, err := Version(ctx, dg)
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	for _, m := range pending(migrations, version) {
+		if m.Schema != "" {
+			if err := dg.Alter(ctx, &api.Operation{Schema: m.Schema}); err != nil {
+				return applied, fmt.Errorf("cannot alter schema of migration %d_%s: %v", m.Version, m.Name, err)
+			}
+		}
+		if _, err := dg.NewTxn().Do(ctx, request(m, version)); err != nil {
+			return applied, fmt.Errorf("cannot transform data of migration %d_%s: %v", m.Version, m.Name, err)
+		}
+		//upsert does nothing when dgraph is no more at version
+		current, err := Version(ctx, dg)
+		if err != nil {
+			return applied, err
+		}
+		if current != m.Version {
+			return applied, fmt.Errorf("migration %d_%s is not applied, schema version was changed to %d by another run", m.Version, m.Name, current)
+		}
+		logger.Log.Info("Dgraph migration applied", zap.Int("version", m.Version), zap.String("name", m.Name))
+		version = m.Version
+		applied++
+	}
+	return applied, nil
+}
+
+//pending gives the migrations newer than version
+func pending(migrations []*Migration, version int) []*Migration {
+	i := sort.Search(len(migrations), func(i int) bool {
+		return migrations[i].Version > version
+	})
+	return migrations[i:]
+}
+
+//request gives the upsert transforming the data of m and setting its version, when dgraph is still at version
+func request(m *Migration, version int) *api.Request {
+	query := versionQuery + "\n"
+	cond := "@if(eq(len(migrated), 0))"
+	if version > 0 {
+		query += fmt.Sprintf("current as var(func: eq(schema_version.version, %d))\n", version)
+		cond = "@if(eq(len(current), 1))"
+	}
+	query += m.Query
+	req := &api.Request{
+		Query: "{\n" + strings.TrimSpace(query) + "\n}",
+		Mutations: []*api.Mutation{
+			{
+				SetNquads: []byte(fmt.Sprintf(versionNquads, m.Version)),
+				Cond:      cond,
+			},
+		},
+		CommitNow: true,
+	}
+	if m.Set != "" || m.Delete != "" {
+		req.Mutations = append(req.Mutations, &api.Mutation{
+			SetNquads: []byte(m.Set),
+			DelNquads: []byte(m.Delete),
+			Cond:      cond,
+		})
+	}
+	return req
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package migrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v2/protos/api"
+	"github.com/stretchr/testify/assert"
+)
+
+//mapBox gives the files of a map
+type mapBox map[string]string
+
+func (m mapBox) List() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (m mapBox) Find(name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return []byte(data), nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		want    *Migration
+		wantErr bool
+	}{
+		{name: "all sections",
+			file: "12_product_editor.dgraph",
+			data: "# reindexes editors\n# +migrate Schema\nproduct.editor: string @index(exact) .\n\n# +migrate Query\np as var(func: has(product.editor))\n# +migrate Set\nuid(p) <updated> \"now\" .\n# +migrate Delete\nuid(p) <created> * .\n",
+			want: &Migration{
+				Version: 12,
+				Name:    "product_editor",
+				Schema:  "product.editor: string @index(exact) .",
+				Query:   "p as var(func: has(product.editor))",
+				Set:     "uid(p) <updated> \"now\" .",
+				Delete:  "uid(p) <created> * .",
+			},
+		},
+		{name: "schema only",
+			file: "migrations/1_init.dgraph",
+			data: "# +migrate Schema\nscopes: [string] @index(exact) .",
+			want: &Migration{Version: 1, Name: "init", Schema: "scopes: [string] @index(exact) ."},
+		},
+		{name: "file without version",
+			file:    "init.dgraph",
+			data:    "# +migrate Schema\nscopes: [string] .",
+			wantErr: true,
+		},
+		{name: "version 0",
+			file:    "0_init.dgraph",
+			wantErr: true,
+		},
+		{name: "unknown directive",
+			file:    "1_init.dgraph",
+			data:    "# +migrate Down\nscopes: [string] .",
+			wantErr: true,
+		},
+		{name: "repeated section",
+			file:    "1_init.dgraph",
+			data:    "# +migrate Schema\nscopes: [string] .\n# +migrate Schema\nupdated: string .",
+			wantErr: true,
+		},
+		{name: "statement outside of a section",
+			file:    "1_init.dgraph",
+			data:    "scopes: [string] .\n# +migrate Schema\nupdated: string .",
+			wantErr: true,
+		},
+		{name: "query without mutation",
+			file:    "1_init.dgraph",
+			data:    "# +migrate Query\np as var(func: has(product.editor))",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.file, []byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		box      Box
+		versions []int
+		wantErr  bool
+	}{
+		{name: "directory",
+			box:      Dir("testdata"),
+			versions: []int{1, 2, 3},
+		},
+		{name: "missing directory",
+			box: Dir("testdata/missing"),
+		},
+		{name: "same version",
+			box:     mapBox{"1_a.dgraph": "# +migrate Schema\na: int .", "01_b.dgraph": "# +migrate Schema\nb: int ."},
+			wantErr: true,
+		},
+		{name: "invalid migration",
+			box:     mapBox{"1_a.dgraph": "a: int ."},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Load(test.box)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, test.wantErr)
+			}
+			var versions []int
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	migrations := []*Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+	tests := []struct {
+		name    string
+		version int
+		want    []*Migration
+	}{
+		{name: "no version", version: 0, want: migrations},
+		{name: "between versions", version: 3, want: migrations[2:]},
+		{name: "last version", version: 5, want: []*Migration{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, pending(migrations, test.version))
+		})
+	}
+}
+
+func TestRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *Migration
+		version int
+		want    *api.Request
+	}{
+		{name: "first migration without data",
+			m:       &Migration{Version: 1, Schema: "a: int ."},
+			version: 0,
+			want: &api.Request{
+				Query: "{\nmigrated as var(func: has(schema_version.version))\n}",
+				Mutations: []*api.Mutation{
+					{SetNquads: []byte("uid(migrated) <schema_version.version> \"1\" .\nuid(migrated) <dgraph.type> \"SchemaVersion\" ."), Cond: "@if(eq(len(migrated), 0))"},
+				},
+				CommitNow: true,
+			},
+		},
+		{name: "migration of data",
+			m:       &Migration{Version: 4, Query: "p as var(func: has(a))", Delete: "uid(p) <a> * ."},
+			version: 2,
+			want: &api.Request{
+				Query: "{\nmigrated as var(func: has(schema_version.version))\ncurrent as var(func: eq(schema_version.version, 2))\np as var(func: has(a))\n}",
+				Mutations: []*api.Mutation{
+					{SetNquads: []byte("uid(migrated) <schema_version.version> \"4\" .\nuid(migrated) <dgraph.type> \"SchemaVersion\" ."), Cond: "@if(eq(len(current), 1))"},
+					{SetNquads: []byte(""), DelNquads: []byte("uid(p) <a> * ."), Cond: "@if(eq(len(current), 1))"},
+				},
+				CommitNow: true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, request(test.m, test.version))
+		})
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package dgraph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/v2/protos/api"
+)
+
+var (
+	varName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	uidRef  = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+)
+
+//Value is the object of an N-Quad: a literal or a node
+type Value interface {
+	nquad() (string, error)
+}
+
+type literal string
+
+func (l literal) nquad() (string, error) {
+	return string(l), nil
+}
+
+//String gives a string literal. Quotes, backslashes and control characters are escaped,
+//invalid UTF-8 is replaced, so any text is stored as it is and cannot end the N-Quad.
+func String(s string) Value {
+	return literal(quote(s))
+}
+
+//Int gives an int literal
+func Int(i int64) Value {
+	return literal(`"` + strconv.FormatInt(i, 10) + `"^^<xs:int>`)
+}
+
+//Float gives a float literal
+func Float(f float64) Value {
+	return literal(`"` + strconv.FormatFloat(f, 'g', -1, 64) + `"^^<xs:float>`)
+}
+
+//Bool gives a boolean literal
+func Bool(b bool) Value {
+	return literal(`"` + strconv.FormatBool(b) + `"^^<xs:boolean>`)
+}
+
+//DateTime gives a datetime literal
+func DateTime(t time.Time) Value {
+	return literal(`"` + t.Format(time.RFC3339Nano) + `"^^<xs:dateTime>`)
+}
+
+//Star matches every value of a predicate, it is only valid in deletions
+var Star Value = literal("*")
+
+//Node is a node of dgraph, subject of an N-Quad or object of an edge
+type Node struct {
+	ref string
+	err error
+}
+
+func (n Node) nquad() (string, error) {
+	return n.ref, n.err
+}
+
+//Var gives the nodes of a variable of the query block of an upsert, eg: uid(product)
+func Var(name string) Node {
+	if !varName.MatchString(name) {
+		return Node{err: fmt.Errorf("dgraph: invalid variable name %q", name)}
+	}
+	return Node{ref: "uid(" + name + ")"}
+}
+
+//UID gives an existing node by its uid, eg: 0x1a
+func UID(uid string) Node {
+	if !uidRef.MatchString(uid) {
+		return Node{err: fmt.Errorf("dgraph: invalid uid %q", uid)}
+	}
+	return Node{ref: "<" + uid + ">"}
+}
+
+//Blank gives a new node, nodes having the same blank name in a mutation are one node
+func Blank(name string) Node {
+	if !varName.MatchString(name) {
+		return Node{err: fmt.Errorf("dgraph: invalid blank node name %q", name)}
+	}
+	return Node{ref: "_:" + name}
+}
+
+//NQuads are the N-Quads of a mutation, they are written one per line.
+//The first invalid N-Quad is kept as the error of Bytes, next ones are ignored.
+type NQuads struct {
+	b   strings.Builder
+	err error
+}
+
+//Add adds the N-Quad: subject <predicate> object .
+//Predicate * removes all predicates of subject in a deletion.
+func (n *NQuads) Add(subject Node, predicate string, object Value) *NQuads {
+	if n.err != nil {
+		return n
+	}
+	s, err := subject.nquad()
+	if err != nil {
+		n.err = err
+		return n
+	}
+	o, err := object.nquad()
+	if err != nil {
+		n.err = err
+		return n
+	}
+	p := "*"
+	if predicate != "*" {
+		if err := validPredicate(predicate); err != nil {
+			n.err = err
+			return n
+		}
+		p = "<" + predicate + ">"
+	}
+	n.b.WriteString(s + " " + p + " " + o + " .\n")
+	return n
+}
+
+//Len gives the length of N-Quads, 0 when there is none
+func (n *NQuads) Len() int {
+	return n.b.Len()
+}
+
+//Bytes gives the N-Quads, or the error of the first invalid one
+func (n *NQuads) Bytes() ([]byte, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	return []byte(n.b.String()), nil
+}
+
+//Mutation of an upsert, its deletions are applied before its additions
+type Mutation struct {
+	Set    NQuads
+	Delete NQuads
+}
+
+//Upsert builds an upsert request: a query block finding nodes in variables, and mutations of these nodes.
+//Nodes not found are created by mutations.
+type Upsert struct {
+	vars      strings.Builder
+	names     map[string]bool
+	mutations []*Mutation
+	err       error
+}
+
+//NewUpsert gives an empty upsert
+func NewUpsert() *Upsert {
+	return &Upsert{names: make(map[string]bool)}
+}
+
+// Find adds a variable having the nodes of type typeName whose predicate is value, eg:
+//
+//	var(func: eq(product.swidtag, "p1")) @filter(eq(type_name, "product")) { product as uid }
+func (u *Upsert) Find(name, predicate, value, typeName string) Node {
+	node := Var(name)
+	if !u.declare(node, name, predicate) {
+		return node
+	}
+	fmt.Fprintf(&u.vars, "\tvar(func: eq(%s, %s)) @filter(eq(type_name, %s)) {\n\t\t%s as uid\n\t}\n", predicate, quote(value), quote(typeName), name)
+	return node
+}
+
+// Linked adds a variable having the nodes linked by predicate to the nodes of variable from,
+// a reverse predicate gives the nodes linking to them, eg:
+//
+//	var(func: uid(products)) { editors as ~editor.product }
+func (u *Upsert) Linked(name string, from Node, predicate string) Node {
+	node := Var(name)
+	ref := u.varRef(from)
+	if !u.declare(node, name, predicate) {
+		return node
+	}
+	fmt.Fprintf(&u.vars, "\tvar(func: %s) {\n\t\t%s as %s\n\t}\n", ref, name, predicate)
+	return node
+}
+
+// Single adds a variable having the nodes of variable from with one value only of predicate,
+// their number of values is counted in the variable name_count, eg:
+//
+//	var(func: uid(products)) { owned_count as count(scopes) }
+//	owned as var(func: uid(products)) @filter(eq(val(owned_count), 1))
+func (u *Upsert) Single(name string, from Node, predicate string) Node {
+	node := Var(name)
+	ref := u.varRef(from)
+	count := name + "_count"
+	if u.err == nil && u.names[count] {
+		u.err = fmt.Errorf("dgraph: variable %q is found twice", count)
+	}
+	if !u.declare(node, name, predicate) {
+		return node
+	}
+	u.names[count] = true
+	fmt.Fprintf(&u.vars, "\tvar(func: %s) {\n\t\t%s as count(%s)\n\t}\n", ref, count, predicate)
+	fmt.Fprintf(&u.vars, "\t%s as var(func: %s) @filter(eq(val(%s), 1))\n", name, ref, count)
+	return node
+}
+
+//varRef gives the reference of the nodes of variable from, the nodes of a query block are only found from a variable
+func (u *Upsert) varRef(from Node) string {
+	ref, err := from.nquad()
+	if err == nil && !strings.HasPrefix(ref, "uid(") {
+		err = fmt.Errorf("dgraph: nodes linked to %s are not found from a variable", ref)
+	}
+	if err != nil && u.err == nil {
+		u.err = err
+	}
+	return ref
+}
+
+//declare checks that a variable can be added to the query block, and reserves its name
+func (u *Upsert) declare(node Node, name, predicate string) bool {
+	if u.err != nil {
+		return false
+	}
+	if _, err := node.nquad(); err != nil {
+		u.err = err
+		return false
+	}
+	if u.names[name] {
+		u.err = fmt.Errorf("dgraph: variable %q is found twice", name)
+		return false
+	}
+	if err := validPredicate(predicate); err != nil {
+		u.err = err
+		return false
+	}
+	u.names[name] = true
+	return true
+}
+
+//Mutation adds a mutation to upsert, mutations are applied in the order they are added
+func (u *Upsert) Mutation() *Mutation {
+	mu := &Mutation{}
+	u.mutations = append(u.mutations, mu)
+	return mu
+}
+
+//Request gives the request of upsert, committed at once. Mutations without N-Quads are left out.
+func (u *Upsert) Request() (*api.Request, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	req := &api.Request{CommitNow: true}
+	if u.vars.Len() > 0 {
+		req.Query = "query {\n" + u.vars.String() + "}"
+	}
+	for _, mu := range u.mutations {
+		set, err := mu.Set.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		del, err := mu.Delete.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		if len(set) == 0 && len(del) == 0 {
+			continue
+		}
+		req.Mutations = append(req.Mutations, &api.Mutation{SetNquads: set, DelNquads: del})
+	}
+	return req, nil
+}
+
+//validPredicate checks that a predicate can be written as an IRI and in a query function
+func validPredicate(predicate string) error {
+	if predicate == "" {
+		return fmt.Errorf("dgraph: empty predicate")
+	}
+	for _, r := range predicate {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("._-~", r) {
+			return fmt.Errorf("dgraph: invalid predicate %q", predicate)
+		}
+	}
+	return nil
+}
+
+//quote gives a double quoted literal with the escapes common to N-Quads and queries,
+//it is unquoted by strconv.Unquote as dgraph does.
+func quote(s string) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == '\u2028' || r == '\u2029' {
+				fmt.Fprintf(&b, `\u%04x`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
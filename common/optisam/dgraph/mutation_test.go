@@ -0,0 +1,259 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package dgraph
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/v2/protos/api"
+	"github.com/stretchr/testify/assert"
+)
+
+//nquadLine is an N-Quad whose object is a string literal, as written by NQuads
+var nquadLine = regexp.MustCompile(`^(uid\([A-Za-z_][A-Za-z0-9_]*\)|<0x[0-9a-fA-F]+>|_:[A-Za-z_][A-Za-z0-9_]*) <[^<>"{}|^` + "`" + `\\\s]+> ("(?:[^"\\\n\r]|\\.)*") \.$`)
+
+//findLine is a variable of the query block of an upsert
+var findLine = regexp.MustCompile(`^\tvar\(func: eq\([^\s(),"]+, ("(?:[^"\\\n\r]|\\.)*")\)\) @filter\(eq\(type_name, ("(?:[^"\\\n\r]|\\.)*")\)\) \{$`)
+
+func TestNQuads_Add(t *testing.T) {
+	tests := []struct {
+		name   string
+		add    func(n *NQuads)
+		want   string
+		outErr bool
+	}{
+		{name: "string literal",
+			add:  func(n *NQuads) { n.Add(Var("product"), "product.name", String(`Office "Pro"`)) },
+			want: "uid(product) <product.name> \"Office \\\"Pro\\\"\" .\n",
+		},
+		{name: "typed literals",
+			add: func(n *NQuads) {
+				n.Add(UID("0x1a"), "acqRights.numOfAcqLicences", Int(-5)).
+					Add(UID("0x1a"), "acqRights.averageUnitPrice", Float(2.5)).
+					Add(UID("0x1a"), "Recycle", Bool(true)).
+					Add(UID("0x1a"), "acqRights.date", DateTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)))
+			},
+			want: "<0x1a> <acqRights.numOfAcqLicences> \"-5\"^^<xs:int> .\n" +
+				"<0x1a> <acqRights.averageUnitPrice> \"2.5\"^^<xs:float> .\n" +
+				"<0x1a> <Recycle> \"true\"^^<xs:boolean> .\n" +
+				"<0x1a> <acqRights.date> \"2020-01-02T03:04:05Z\"^^<xs:dateTime> .\n",
+		},
+		{name: "edge and deletion of all predicates",
+			add: func(n *NQuads) {
+				n.Add(Blank("editor"), "editor.product", Var("product")).
+					Add(Var("aggregation"), "product_aggregation.products", Star).
+					Add(Var("aggregation"), "*", Star)
+			},
+			want: "_:editor <editor.product> uid(product) .\n" +
+				"uid(aggregation) <product_aggregation.products> * .\n" +
+				"uid(aggregation) * * .\n",
+		},
+		{name: "invalid predicate",
+			add:    func(n *NQuads) { n.Add(Var("product"), "product.name> \"x\" .\n<0x1> <scopes", String("s")) },
+			outErr: true,
+		},
+		{name: "invalid variable",
+			add:    func(n *NQuads) { n.Add(Var("product) * * .\nuid(x"), "product.name", String("n")) },
+			outErr: true,
+		},
+		{name: "invalid uid object",
+			add:    func(n *NQuads) { n.Add(Var("product"), "product.child", UID("0x1> * .")) },
+			outErr: true,
+		},
+		{name: "N-Quads after an invalid one are ignored",
+			add: func(n *NQuads) {
+				n.Add(Blank(""), "product.name", String("n")).Add(Var("product"), "product.name", String("n"))
+			},
+			outErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var n NQuads
+			test.add(&n)
+			got, err := n.Bytes()
+			if (err != nil) != test.outErr {
+				t.Fatalf("NQuads.Bytes() error = %v, outErr %v", err, test.outErr)
+			}
+			if !test.outErr {
+				assert.Equal(t, test.want, string(got))
+			}
+		})
+	}
+}
+
+func TestUpsert_Request(t *testing.T) {
+	tests := []struct {
+		name   string
+		upsert func(u *Upsert)
+		want   *api.Request
+		outErr bool
+	}{
+		{name: "query block and mutations in order",
+			upsert: func(u *Upsert) {
+				agg := u.Find("aggregation", "product_aggregation.name", `a"b`, "product_aggregation")
+				del := u.Mutation()
+				del.Delete.Add(agg, "product_aggregation.products", Star)
+				u.Mutation().Set.Add(agg, "product_aggregation.name", String(`a"b`))
+			},
+			want: &api.Request{
+				Query: "query {\n" +
+					"\tvar(func: eq(product_aggregation.name, \"a\\\"b\")) @filter(eq(type_name, \"product_aggregation\")) {\n\t\taggregation as uid\n\t}\n" +
+					"}",
+				Mutations: []*api.Mutation{
+					{SetNquads: []byte{}, DelNquads: []byte("uid(aggregation) <product_aggregation.products> * .\n")},
+					{SetNquads: []byte("uid(aggregation) <product_aggregation.name> \"a\\\"b\" .\n"), DelNquads: []byte{}},
+				},
+				CommitNow: true,
+			},
+		},
+		{name: "empty mutations are left out",
+			upsert: func(u *Upsert) {
+				u.Mutation()
+				u.Mutation().Set.Add(Blank("p"), "product.swidtag", String("p1"))
+			},
+			want: &api.Request{
+				Mutations: []*api.Mutation{{SetNquads: []byte("_:p <product.swidtag> \"p1\" .\n"), DelNquads: []byte{}}},
+				CommitNow: true,
+			},
+		},
+		{name: "nodes linking to found nodes",
+			upsert: func(u *Upsert) {
+				products := u.Find("products", "scopes", "s1", "product")
+				editors := u.Linked("editors", products, "~editor.product")
+				u.Mutation().Delete.Add(editors, "editor.product", products)
+			},
+			want: &api.Request{
+				Query: "query {\n" +
+					"\tvar(func: eq(scopes, \"s1\")) @filter(eq(type_name, \"product\")) {\n\t\tproducts as uid\n\t}\n" +
+					"\tvar(func: uid(products)) {\n\t\teditors as ~editor.product\n\t}\n" +
+					"}",
+				Mutations: []*api.Mutation{{SetNquads: []byte{}, DelNquads: []byte("uid(editors) <editor.product> uid(products) .\n")}},
+				CommitNow: true,
+			},
+		},
+		{name: "nodes having one value of predicate",
+			upsert: func(u *Upsert) {
+				products := u.Find("products", "scopes", "s1", "product")
+				owned := u.Single("owned", products, "scopes")
+				u.Mutation().Delete.Add(owned, "*", Star)
+			},
+			want: &api.Request{
+				Query: "query {\n" +
+					"\tvar(func: eq(scopes, \"s1\")) @filter(eq(type_name, \"product\")) {\n\t\tproducts as uid\n\t}\n" +
+					"\tvar(func: uid(products)) {\n\t\towned_count as count(scopes)\n\t}\n" +
+					"\towned as var(func: uid(products)) @filter(eq(val(owned_count), 1))\n" +
+					"}",
+				Mutations: []*api.Mutation{{SetNquads: []byte{}, DelNquads: []byte("uid(owned) * * .\n")}},
+				CommitNow: true,
+			},
+		},
+		{name: "count variable found twice",
+			upsert: func(u *Upsert) {
+				products := u.Find("owned_count", "scopes", "s1", "product")
+				u.Single("owned", products, "scopes")
+			},
+			outErr: true,
+		},
+		{name: "nodes linked to a node which is not a variable",
+			upsert: func(u *Upsert) { u.Linked("editors", UID("0x1"), "~editor.product") },
+			outErr: true,
+		},
+		{name: "variable found twice",
+			upsert: func(u *Upsert) {
+				u.Find("product", "product.swidtag", "p1", "product")
+				u.Find("product", "product.swidtag", "p2", "product")
+			},
+			outErr: true,
+		},
+		{name: "invalid predicate of query",
+			upsert: func(u *Upsert) { u.Find("product", `product.swidtag,"x") or eq(a`, "p1", "product") },
+			outErr: true,
+		},
+		{name: "invalid mutation",
+			upsert: func(u *Upsert) { u.Mutation().Set.Add(Var("1product"), "product.swidtag", String("p1")) },
+			outErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUpsert()
+			test.upsert(u)
+			got, err := u.Request()
+			if (err != nil) != test.outErr {
+				t.Fatalf("Upsert.Request() error = %v, outErr %v", err, test.outErr)
+			}
+			if !test.outErr {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func FuzzString(f *testing.F) {
+	for _, s := range []string{"", "Office", `Office "Pro"`, "line\nbreak\r\n", `back\slash\"`, "tab\tand\x00nul", "ünïcødé 日本語 🚀", "  ", "\xff\xfe", `" . uid(x) * * .`} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var n NQuads
+		n.Add(Var("product"), "product.name", String(s))
+		b, err := n.Bytes()
+		if err != nil {
+			t.Fatalf("NQuads.Bytes() error = %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+		if len(lines) != 1 {
+			t.Fatalf("string %q is written on %d lines: %q", s, len(lines), b)
+		}
+		m := nquadLine.FindStringSubmatch(lines[0])
+		if m == nil {
+			t.Fatalf("string %q does not give an N-Quad: %q", s, lines[0])
+		}
+		assertLiteral(t, s, m[2])
+	})
+}
+
+func FuzzUpsert_Find(f *testing.F) {
+	for _, s := range []string{"p1", `p"1`, "p\n1", `p\`, "produit été", `")) { product as uid } var(func: has(product.swidtag`} {
+		f.Add(s, "product")
+	}
+	f.Fuzz(func(t *testing.T, value, typeName string) {
+		u := NewUpsert()
+		u.Find("product", "product.swidtag", value, typeName)
+		req, err := u.Request()
+		if err != nil {
+			t.Fatalf("Upsert.Request() error = %v", err)
+		}
+		lines := strings.Split(req.Query, "\n")
+		if len(lines) != 5 {
+			t.Fatalf("value %q, type %q give a query of %d lines: %q", value, typeName, len(lines), req.Query)
+		}
+		m := findLine.FindStringSubmatch(lines[1])
+		if m == nil {
+			t.Fatalf("value %q, type %q do not give a variable: %q", value, typeName, lines[1])
+		}
+		assertLiteral(t, value, m[1])
+		assertLiteral(t, typeName, m[2])
+	})
+}
+
+//assertLiteral checks that literal is read back by dgraph as s, invalid UTF-8 being replaced
+func assertLiteral(t *testing.T, s, literal string) {
+	t.Helper()
+	got, err := strconv.Unquote(literal)
+	if err != nil {
+		t.Fatalf("literal %s of %q cannot be unquoted: %v", literal, s, err)
+	}
+	if want := strings.ToValidUTF8(s, string(utf8.RuneError)); got != want {
+		t.Fatalf("literal %s is read as %q, want %q", literal, got, want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package reconcile
+
+import (
+	"context"
+	"optisam-backend/common/optisam/ctxmanage"
+	v1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
+	"optisam-backend/common/optisam/helper"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/token/claims"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//defaultBatchSize is the number of jobs pushed at once by a rebuild
+const defaultBatchSize = 100
+
+//stages of a rebuild
+const (
+	stageDropped   = "DROPPED"
+	stageReplaying = "REPLAYING"
+	stageDone      = "DONE"
+)
+
+type adminServer struct {
+	service string
+	r       Reconciler
+}
+
+//NewAdminServer gives the reconciliation service of the graph of a service, eg: products.
+//Services mount it on their gRPC server and gateway.
+func NewAdminServer(service string, r Reconciler) v1.ReconcileServiceServer {
+	return &adminServer{service: service, r: r}
+}
+
+//ReconcileDgraph reports the drifts of dgraph from postgres for a scope, and repairs them when asked
+func (a *adminServer) ReconcileDgraph(ctx context.Context, req *v1.ReconcileRequest) (*v1.ReconcileResponse, error) {
+	if err := a.authorize(ctx, req.GetService(), req.GetScope()); err != nil {
+		return nil, err
+	}
+	records, err := a.r.Postgres(ctx, req.GetScope())
+	if err != nil {
+		logger.Log.Error("Failed to get records of postgres", zap.String("scope", req.GetScope()), zap.Error(err))
+		return nil, status.Error(codes.Internal, "DBError")
+	}
+	nodes, err := a.r.Dgraph(ctx, req.GetScope())
+	if err != nil {
+		logger.Log.Error("Failed to get records of dgraph", zap.String("scope", req.GetScope()), zap.Error(err))
+		return nil, status.Error(codes.Internal, "DGraphError")
+	}
+	drifts := Compare(records, nodes)
+	resp := &v1.ReconcileResponse{Drifts: make([]*v1.Drift, len(drifts))}
+	for i, d := range drifts {
+		resp.Drifts[i] = &v1.Drift{Kind: d.Kind, Key: d.Key, Status: string(d.Status), Fields: d.Fields}
+	}
+	logger.Log.Info("Dgraph reconciled", zap.String("service", a.service), zap.String("scope", req.GetScope()), zap.Int("drifts", len(drifts)))
+	if !req.GetRepair() || len(drifts) == 0 {
+		return resp, nil
+	}
+	resp.RepairJobs, err = a.r.Repair(ctx, req.GetScope(), drifts)
+	if err != nil {
+		logger.Log.Error("Failed to repair drifts", zap.String("scope", req.GetScope()), zap.Int32("pushed", resp.RepairJobs), zap.Error(err))
+		return nil, status.Error(codes.Internal, "RepairError")
+	}
+	return resp, nil
+}
+
+//RebuildDgraph drops the nodes of a scope and replays its records of postgres as missing nodes, by batches.
+//Records are read once, batches are repaired from their data.
+//Progress counts the jobs queued, nodes are written once workers have run them.
+//A rebuild which is stopped can be started again, or completed by reconciling scope.
+func (a *adminServer) RebuildDgraph(req *v1.RebuildRequest, stream v1.ReconcileService_RebuildDgraphServer) error {
+	ctx := stream.Context()
+	if err := a.authorize(ctx, req.GetService(), req.GetScope()); err != nil {
+		return err
+	}
+	records, err := a.r.Postgres(ctx, req.GetScope())
+	if err != nil {
+		logger.Log.Error("Failed to get records of postgres", zap.String("scope", req.GetScope()), zap.Error(err))
+		return status.Error(codes.Internal, "DBError")
+	}
+	nodes, err := a.r.Dgraph(ctx, req.GetScope())
+	if err != nil {
+		logger.Log.Error("Failed to get records of dgraph", zap.String("scope", req.GetScope()), zap.Error(err))
+		return status.Error(codes.Internal, "DGraphError")
+	}
+	if err := a.r.Drop(ctx, req.GetScope()); err != nil {
+		logger.Log.Error("Failed to drop nodes of scope", zap.String("scope", req.GetScope()), zap.Error(err))
+		return status.Error(codes.Internal, "DGraphError")
+	}
+	progress := &v1.RebuildProgress{Stage: stageDropped, Dropped: int32(len(nodes)), Records: int32(len(records))}
+	logger.Log.Info("Dgraph dropped", zap.String("service", a.service), zap.String("scope", req.GetScope()), zap.Int32("dropped", progress.Dropped))
+	if err := stream.Send(progress); err != nil {
+		return err
+	}
+	size := int(req.GetBatchSize())
+	if size == 0 {
+		size = defaultBatchSize
+	}
+	pause := time.Duration(req.GetBatchPauseMs()) * time.Millisecond
+	for start := 0; start < len(records); start += size {
+		if start > 0 && pause > 0 {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-time.After(pause):
+			}
+		}
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+		drifts := make([]Drift, 0, end-start)
+		for _, r := range records[start:end] {
+			drifts = append(drifts, Drift{Kind: r.Kind, Key: r.Key, Status: StatusMissing, Data: r.Data})
+		}
+		queued, err := a.r.Repair(ctx, req.GetScope(), drifts)
+		progress.Queued += queued
+		if err != nil {
+			logger.Log.Error("Failed to replay records", zap.String("scope", req.GetScope()), zap.Int32("queued", progress.Queued), zap.Error(err))
+			return status.Error(codes.Internal, "RepairError")
+		}
+		progress.Stage = stageReplaying
+		if err := stream.Send(progress); err != nil {
+			return err
+		}
+	}
+	progress.Stage = stageDone
+	logger.Log.Info("Dgraph rebuilt", zap.String("service", a.service), zap.String("scope", req.GetScope()), zap.Int32("queued", progress.Queued))
+	return stream.Send(progress)
+}
+
+//authorize checks that graph of service for scope can be administered by user
+func (a *adminServer) authorize(ctx context.Context, service, scope string) error {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return status.Error(codes.Internal, "cannot find claims in context")
+	}
+	if userClaims.Role != claims.RoleSuperAdmin {
+		return status.Error(codes.PermissionDenied, "RoleValidationError")
+	}
+	if service != a.service {
+		return status.Error(codes.NotFound, "service not found")
+	}
+	if !helper.Contains(userClaims.Socpes, scope) {
+		return status.Error(codes.PermissionDenied, "ScopeValidationError")
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"optisam-backend/common/optisam/ctxmanage"
+	v1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/token/claims"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(-1, "")
+	os.Exit(m.Run())
+}
+
+//fakeReconciler gives its records and keeps the drifts it repairs
+type fakeReconciler struct {
+	postgres, graph []Record
+	err             error
+	repaired        []Drift
+	dropped         bool
+	reads           int
+}
+
+func (f *fakeReconciler) Postgres(ctx context.Context, scope string) ([]Record, error) {
+	f.reads++
+	return f.postgres, nil
+}
+
+func (f *fakeReconciler) Dgraph(ctx context.Context, scope string) ([]Record, error) {
+	return f.graph, f.err
+}
+
+func (f *fakeReconciler) Repair(ctx context.Context, scope string, drifts []Drift) (int32, error) {
+	f.repaired = append(f.repaired, drifts...)
+	return int32(len(drifts)), nil
+}
+
+func (f *fakeReconciler) Drop(ctx context.Context, scope string) error {
+	f.dropped = true
+	return nil
+}
+
+//fakeStream keeps the progress sent by a rebuild
+type fakeStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	progress []v1.RebuildProgress
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) Send(p *v1.RebuildProgress) error {
+	f.progress = append(f.progress, *p)
+	return nil
+}
+
+func TestAdminServer_ReconcileDgraph(t *testing.T) {
+	superAdmin := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin, Socpes: []string{"s1"}})
+	postgres := []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "n1"}}}
+	graph := []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "old"}}, {Kind: "product", Key: "p2"}}
+	drifts := []*v1.Drift{
+		{Kind: "product", Key: "p1", Status: "STALE", Fields: []string{"product.name"}},
+		{Kind: "product", Key: "p2", Status: "ORPHAN"},
+	}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *v1.ReconcileRequest
+		r        *fakeReconciler
+		want     *v1.ReconcileResponse
+		repaired int
+		code     codes.Code
+	}{
+		{name: "drifts are reported",
+			ctx:  superAdmin,
+			req:  &v1.ReconcileRequest{Service: "products", Scope: "s1"},
+			r:    &fakeReconciler{postgres: postgres, graph: graph},
+			want: &v1.ReconcileResponse{Drifts: drifts},
+		},
+		{name: "drifts are repaired",
+			ctx:      superAdmin,
+			req:      &v1.ReconcileRequest{Service: "products", Scope: "s1", Repair: true},
+			r:        &fakeReconciler{postgres: postgres, graph: graph},
+			want:     &v1.ReconcileResponse{Drifts: drifts, RepairJobs: 2},
+			repaired: 2,
+		},
+		{name: "admin is not super admin",
+			ctx:  ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleAdmin, Socpes: []string{"s1"}}),
+			req:  &v1.ReconcileRequest{Service: "products", Scope: "s1"},
+			r:    &fakeReconciler{},
+			code: codes.PermissionDenied,
+		},
+		{name: "scope of another user",
+			ctx:  superAdmin,
+			req:  &v1.ReconcileRequest{Service: "products", Scope: "s2"},
+			r:    &fakeReconciler{},
+			code: codes.PermissionDenied,
+		},
+		{name: "graph of another service",
+			ctx:  superAdmin,
+			req:  &v1.ReconcileRequest{Service: "applications", Scope: "s1"},
+			r:    &fakeReconciler{},
+			code: codes.NotFound,
+		},
+		{name: "dgraph fails",
+			ctx:  superAdmin,
+			req:  &v1.ReconcileRequest{Service: "products", Scope: "s1", Repair: true},
+			r:    &fakeReconciler{err: errors.New("unavailable")},
+			code: codes.Internal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewAdminServer("products", test.r).ReconcileDgraph(test.ctx, test.req)
+			if status.Code(err) != test.code {
+				t.Fatalf("ReconcileDgraph() error = %v, want code %v", err, test.code)
+			}
+			assert.Equal(t, test.want, got)
+			assert.Len(t, test.r.repaired, test.repaired)
+		})
+	}
+}
+
+func TestAdminServer_RebuildDgraph(t *testing.T) {
+	superAdmin := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin, Socpes: []string{"s1"}})
+	postgres := []Record{{Kind: "product", Key: "p1", Data: 1}, {Kind: "product", Key: "p2", Data: 2}, {Kind: "product", Key: "p3", Data: 3}}
+	graph := []Record{{Kind: "product", Key: "p1"}, {Kind: "product", Key: "p4"}}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *v1.RebuildRequest
+		r        *fakeReconciler
+		want     []v1.RebuildProgress
+		repaired []Drift
+		code     codes.Code
+	}{
+		{name: "records are replayed by batches",
+			ctx: superAdmin,
+			req: &v1.RebuildRequest{Service: "products", Scope: "s1", BatchSize: 2, BatchPauseMs: 1},
+			r:   &fakeReconciler{postgres: postgres, graph: graph},
+			want: []v1.RebuildProgress{
+				{Stage: "DROPPED", Dropped: 2, Records: 3},
+				{Stage: "REPLAYING", Dropped: 2, Records: 3, Queued: 2},
+				{Stage: "REPLAYING", Dropped: 2, Records: 3, Queued: 3},
+				{Stage: "DONE", Dropped: 2, Records: 3, Queued: 3},
+			},
+			repaired: []Drift{
+				{Kind: "product", Key: "p1", Status: StatusMissing, Data: 1},
+				{Kind: "product", Key: "p2", Status: StatusMissing, Data: 2},
+				{Kind: "product", Key: "p3", Status: StatusMissing, Data: 3},
+			},
+		},
+		{name: "default batch",
+			ctx: superAdmin,
+			req: &v1.RebuildRequest{Service: "products", Scope: "s1"},
+			r:   &fakeReconciler{postgres: postgres[:1]},
+			want: []v1.RebuildProgress{
+				{Stage: "DROPPED", Records: 1},
+				{Stage: "REPLAYING", Records: 1, Queued: 1},
+				{Stage: "DONE", Records: 1, Queued: 1},
+			},
+			repaired: []Drift{{Kind: "product", Key: "p1", Status: StatusMissing, Data: 1}},
+		},
+		{name: "admin is not super admin",
+			ctx:  ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleAdmin, Socpes: []string{"s1"}}),
+			req:  &v1.RebuildRequest{Service: "products", Scope: "s1"},
+			r:    &fakeReconciler{postgres: postgres},
+			code: codes.PermissionDenied,
+		},
+		{name: "dgraph fails",
+			ctx:  superAdmin,
+			req:  &v1.RebuildRequest{Service: "products", Scope: "s1"},
+			r:    &fakeReconciler{postgres: postgres, err: errors.New("unavailable")},
+			code: codes.Internal,
+		},
+		{name: "rebuild is cancelled",
+			ctx: cancelled(superAdmin),
+			req: &v1.RebuildRequest{Service: "products", Scope: "s1", BatchSize: 1, BatchPauseMs: 60000},
+			r:   &fakeReconciler{postgres: postgres},
+			want: []v1.RebuildProgress{
+				{Stage: "DROPPED", Records: 3},
+				{Stage: "REPLAYING", Records: 3, Queued: 1},
+			},
+			repaired: []Drift{{Kind: "product", Key: "p1", Status: StatusMissing, Data: 1}},
+			code:     codes.Canceled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &fakeStream{ctx: test.ctx}
+			err := NewAdminServer("products", test.r).RebuildDgraph(test.req, stream)
+			if status.Code(err) != test.code {
+				t.Fatalf("RebuildDgraph() error = %v, want code %v", err, test.code)
+			}
+			assert.Equal(t, test.want, stream.progress)
+			assert.Equal(t, test.repaired, test.r.repaired)
+			assert.Equal(t, test.want != nil, test.r.dropped)
+			//records are read once whatever the number of batches
+			assert.LessOrEqual(t, test.r.reads, 1)
+		})
+	}
+}
+
+func cancelled(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	return ctx
+}
@@ -0,0 +1,544 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: reconcile.proto
+
+// graphs of every service are reconciled with same messages, package is kept
+// apart from the v1 package of services mounting it
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ReconcileRequest struct {
+	// service mounting the api, eg: products
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Scope   string `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	// drifts are only reported when false
+	Repair               bool     `protobuf:"varint,3,opt,name=repair,proto3" json:"repair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileRequest) Reset()         { *m = ReconcileRequest{} }
+func (m *ReconcileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileRequest) ProtoMessage()    {}
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c2ec52eaf3e16f2, []int{0}
+}
+
+func (m *ReconcileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileRequest.Unmarshal(m, b)
+}
+func (m *ReconcileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReconcileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileRequest.Merge(m, src)
+}
+func (m *ReconcileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconcileRequest.Size(m)
+}
+func (m *ReconcileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileRequest proto.InternalMessageInfo
+
+func (m *ReconcileRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ReconcileRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *ReconcileRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+type ReconcileResponse struct {
+	Drifts []*Drift `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	// jobs pushed to repair drifts
+	RepairJobs           int32    `protobuf:"varint,2,opt,name=repair_jobs,json=repairJobs,proto3" json:"repair_jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileResponse) Reset()         { *m = ReconcileResponse{} }
+func (m *ReconcileResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconcileResponse) ProtoMessage()    {}
+func (*ReconcileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c2ec52eaf3e16f2, []int{1}
+}
+
+func (m *ReconcileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileResponse.Unmarshal(m, b)
+}
+func (m *ReconcileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileResponse.Marshal(b, m, deterministic)
+}
+func (m *ReconcileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileResponse.Merge(m, src)
+}
+func (m *ReconcileResponse) XXX_Size() int {
+	return xxx_messageInfo_ReconcileResponse.Size(m)
+}
+func (m *ReconcileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileResponse proto.InternalMessageInfo
+
+func (m *ReconcileResponse) GetDrifts() []*Drift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
+
+func (m *ReconcileResponse) GetRepairJobs() int32 {
+	if m != nil {
+		return m.RepairJobs
+	}
+	return 0
+}
+
+type Drift struct {
+	// kind of record, eg: product
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// key of record in its kind, eg: swidtag of a product
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// MISSING: record has no node, STALE: node differs from record,
+	// ORPHAN: node has no record
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// predicates or links of a stale node differing from record
+	Fields               []string `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Drift) Reset()         { *m = Drift{} }
+func (m *Drift) String() string { return proto.CompactTextString(m) }
+func (*Drift) ProtoMessage()    {}
+func (*Drift) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c2ec52eaf3e16f2, []int{2}
+}
+
+func (m *Drift) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Drift.Unmarshal(m, b)
+}
+func (m *Drift) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Drift.Marshal(b, m, deterministic)
+}
+func (m *Drift) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Drift.Merge(m, src)
+}
+func (m *Drift) XXX_Size() int {
+	return xxx_messageInfo_Drift.Size(m)
+}
+func (m *Drift) XXX_DiscardUnknown() {
+	xxx_messageInfo_Drift.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Drift proto.InternalMessageInfo
+
+func (m *Drift) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Drift) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Drift) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Drift) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type RebuildRequest struct {
+	// service mounting the api, eg: products
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Scope   string `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	// jobs pushed at once, 100 when 0
+	BatchSize int32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// pause between batches in milliseconds, replay is throttled by it
+	BatchPauseMs         int32    `protobuf:"varint,4,opt,name=batch_pause_ms,json=batchPauseMs,proto3" json:"batch_pause_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildRequest) Reset()         { *m = RebuildRequest{} }
+func (m *RebuildRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildRequest) ProtoMessage()    {}
+func (*RebuildRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c2ec52eaf3e16f2, []int{3}
+}
+
+func (m *RebuildRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildRequest.Unmarshal(m, b)
+}
+func (m *RebuildRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildRequest.Marshal(b, m, deterministic)
+}
+func (m *RebuildRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildRequest.Merge(m, src)
+}
+func (m *RebuildRequest) XXX_Size() int {
+	return xxx_messageInfo_RebuildRequest.Size(m)
+}
+func (m *RebuildRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildRequest proto.InternalMessageInfo
+
+func (m *RebuildRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *RebuildRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *RebuildRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *RebuildRequest) GetBatchPauseMs() int32 {
+	if m != nil {
+		return m.BatchPauseMs
+	}
+	return 0
+}
+
+type RebuildProgress struct {
+	// DROPPED: nodes of scope are dropped, REPLAYING: jobs of a batch are
+	// queued, DONE: jobs of every record are queued
+	Stage string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// nodes of scope dropped from dgraph
+	Dropped int32 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// records of scope in postgres
+	Records int32 `protobuf:"varint,3,opt,name=records,proto3" json:"records,omitempty"`
+	// jobs queued so far, nodes are written once workers have run their jobs
+	Queued               int32    `protobuf:"varint,4,opt,name=queued,proto3" json:"queued,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildProgress) Reset()         { *m = RebuildProgress{} }
+func (m *RebuildProgress) String() string { return proto.CompactTextString(m) }
+func (*RebuildProgress) ProtoMessage()    {}
+func (*RebuildProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c2ec52eaf3e16f2, []int{4}
+}
+
+func (m *RebuildProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildProgress.Unmarshal(m, b)
+}
+func (m *RebuildProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildProgress.Marshal(b, m, deterministic)
+}
+func (m *RebuildProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildProgress.Merge(m, src)
+}
+func (m *RebuildProgress) XXX_Size() int {
+	return xxx_messageInfo_RebuildProgress.Size(m)
+}
+func (m *RebuildProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildProgress proto.InternalMessageInfo
+
+func (m *RebuildProgress) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *RebuildProgress) GetDropped() int32 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *RebuildProgress) GetRecords() int32 {
+	if m != nil {
+		return m.Records
+	}
+	return 0
+}
+
+func (m *RebuildProgress) GetQueued() int32 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ReconcileRequest)(nil), "reconcile.v1.ReconcileRequest")
+	proto.RegisterType((*ReconcileResponse)(nil), "reconcile.v1.ReconcileResponse")
+	proto.RegisterType((*Drift)(nil), "reconcile.v1.Drift")
+	proto.RegisterType((*RebuildRequest)(nil), "reconcile.v1.RebuildRequest")
+	proto.RegisterType((*RebuildProgress)(nil), "reconcile.v1.RebuildProgress")
+}
+
+func init() { proto.RegisterFile("reconcile.proto", fileDescriptor_3c2ec52eaf3e16f2) }
+
+var fileDescriptor_3c2ec52eaf3e16f2 = []byte{
+	// 556 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xc1, 0x52, 0xd4, 0x30,
+	0x18, 0xa6, 0xbb, 0xdb, 0x96, 0xfd, 0x41, 0xc0, 0xe8, 0x68, 0xa7, 0x03, 0xb2, 0x76, 0xc6, 0xb1,
+	0xc2, 0x48, 0x5d, 0x1c, 0x2f, 0xdc, 0xe8, 0xe0, 0x28, 0x8e, 0x20, 0x93, 0xf5, 0xe4, 0x65, 0x27,
+	0xbb, 0x0d, 0x4b, 0xa4, 0x36, 0x25, 0x69, 0x77, 0x46, 0xd0, 0x83, 0xbe, 0x02, 0x2f, 0xe2, 0xbb,
+	0xe8, 0x03, 0x78, 0xf0, 0xe0, 0x33, 0xec, 0xc9, 0x69, 0x92, 0xe2, 0xc2, 0xa8, 0x27, 0x6f, 0xf9,
+	0xff, 0xef, 0x4b, 0xbe, 0x2f, 0x5f, 0xfe, 0xc0, 0xa2, 0xa0, 0x43, 0x9e, 0x0d, 0x59, 0x4a, 0x37,
+	0x72, 0xc1, 0x0b, 0x8e, 0xe6, 0x7f, 0x37, 0xc6, 0x5d, 0x7f, 0x79, 0xc4, 0xf9, 0x28, 0xa5, 0x11,
+	0xc9, 0x59, 0x44, 0xb2, 0x8c, 0x17, 0xa4, 0x60, 0x3c, 0x93, 0x9a, 0xeb, 0xdf, 0x1e, 0x93, 0x94,
+	0x25, 0xa4, 0xa0, 0x51, 0xbd, 0xd0, 0x40, 0x90, 0xc2, 0x12, 0xae, 0x8f, 0xc1, 0xf4, 0xa4, 0xa4,
+	0xb2, 0x40, 0x77, 0xc1, 0x95, 0x54, 0x8c, 0xd9, 0x90, 0x7a, 0x56, 0xc7, 0x0a, 0xdb, 0xb1, 0x3b,
+	0x89, 0x5b, 0xa2, 0xb1, 0x64, 0xe1, 0xba, 0x8f, 0x56, 0xc0, 0x96, 0x43, 0x9e, 0x53, 0xaf, 0x71,
+	0x99, 0xa0, 0xbb, 0xe8, 0x16, 0x38, 0x82, 0xe6, 0x84, 0x09, 0xaf, 0xd9, 0xb1, 0xc2, 0x59, 0x6c,
+	0xaa, 0x80, 0xc0, 0xf5, 0x29, 0x35, 0x99, 0xf3, 0x4c, 0x52, 0xb4, 0x0e, 0x4e, 0x22, 0xd8, 0x61,
+	0x21, 0x3d, 0xab, 0xd3, 0x0c, 0xe7, 0x36, 0x6f, 0x6c, 0x4c, 0x5f, 0x6c, 0x63, 0xa7, 0xc2, 0xb0,
+	0xa1, 0xa0, 0x55, 0x98, 0xd3, 0x67, 0xf5, 0xdf, 0xf2, 0x81, 0x54, 0xf2, 0x36, 0x06, 0xdd, 0x7a,
+	0xc1, 0x07, 0x32, 0xf8, 0x00, 0xb6, 0xda, 0x81, 0x10, 0xb4, 0x8e, 0x59, 0x96, 0xe8, 0x2b, 0x60,
+	0xb5, 0x46, 0x4b, 0xd0, 0x3c, 0xa6, 0xef, 0xb5, 0x69, 0x5c, 0x2d, 0xd1, 0x13, 0x70, 0x64, 0x41,
+	0x8a, 0x52, 0x2a, 0xa7, 0xed, 0x78, 0x65, 0x12, 0xfb, 0xc2, 0xc3, 0xee, 0xde, 0x6e, 0xaf, 0xb7,
+	0xbb, 0xff, 0x0c, 0xdb, 0xbd, 0xd7, 0xdb, 0x2f, 0x9f, 0x62, 0xe7, 0x15, 0x3e, 0x78, 0xbe, 0xbd,
+	0x8f, 0x0d, 0xb9, 0xba, 0xe0, 0x21, 0xa3, 0x69, 0x22, 0xbd, 0x56, 0xa7, 0x19, 0xb6, 0xb1, 0xa9,
+	0x82, 0x2f, 0x16, 0x2c, 0x60, 0x3a, 0x28, 0x59, 0x9a, 0xfc, 0xbf, 0x34, 0x1f, 0x00, 0x0c, 0x48,
+	0x31, 0x3c, 0xea, 0x4b, 0x76, 0x4a, 0x95, 0x4f, 0x3b, 0x86, 0x49, 0xec, 0xfa, 0xb6, 0xf7, 0xd3,
+	0x0d, 0x67, 0x70, 0x5b, 0xa1, 0x3d, 0x76, 0x4a, 0x51, 0x17, 0x16, 0x34, 0x35, 0x27, 0xa5, 0xa4,
+	0xfd, 0x77, 0x95, 0xbf, 0x8a, 0x3e, 0x37, 0x89, 0x67, 0x7d, 0xc7, 0xfb, 0xfe, 0xad, 0x19, 0xce,
+	0xe0, 0x79, 0x45, 0x39, 0xa8, 0x18, 0x7b, 0x32, 0x90, 0xb0, 0x68, 0x1c, 0x1f, 0x08, 0x3e, 0x12,
+	0x54, 0x4a, 0x74, 0x13, 0x6c, 0x59, 0x90, 0x91, 0x31, 0x8c, 0x75, 0x81, 0x3c, 0x70, 0x13, 0xc1,
+	0xf3, 0x9c, 0x26, 0x26, 0xf6, 0xba, 0xac, 0x90, 0xea, 0xc9, 0x44, 0xa2, 0x53, 0xb4, 0x71, 0x5d,
+	0x56, 0x39, 0x9d, 0x94, 0xb4, 0xa4, 0x89, 0xf6, 0x81, 0x4d, 0xb5, 0x79, 0xde, 0x98, 0x9a, 0xbb,
+	0x9e, 0x89, 0xe1, 0x93, 0x05, 0x8b, 0x17, 0xcd, 0x9d, 0x91, 0x20, 0xf9, 0x11, 0xba, 0x73, 0x79,
+	0x18, 0xae, 0xce, 0xaa, 0xbf, 0xfa, 0x57, 0x5c, 0x4f, 0x57, 0xf0, 0xf0, 0xf3, 0xd7, 0x1f, 0xe7,
+	0x8d, 0xfb, 0x41, 0xa0, 0x7e, 0xc6, 0xb8, 0x1b, 0x9d, 0x99, 0xd4, 0x3f, 0x46, 0x89, 0x92, 0x88,
+	0x2e, 0x0e, 0xd8, 0xb2, 0xd6, 0xd0, 0x19, 0x5c, 0x33, 0x69, 0x18, 0x03, 0xcb, 0x57, 0x05, 0xa6,
+	0x1f, 0xd7, 0x5f, 0xf9, 0x23, 0x5a, 0x07, 0x19, 0xac, 0x2b, 0xf1, 0x7b, 0x41, 0xe7, 0x1f, 0xe2,
+	0x6a, 0xc7, 0x96, 0xb5, 0xf6, 0xc8, 0x8a, 0x5b, 0x6f, 0x1a, 0xe3, 0xee, 0xc0, 0x51, 0x3f, 0xf3,
+	0xf1, 0xaf, 0x01, 0x00, 0x99, 0x7e, 0x09, 0x02, 0xf1, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ReconcileServiceClient is the client API for ReconcileService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReconcileServiceClient interface {
+	// ReconcileDgraph compares the records of a scope in postgres with their
+	// nodes in dgraph, drifts are repaired by jobs when asked
+	ReconcileDgraph(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error)
+	// RebuildDgraph drops the nodes of a scope in dgraph and replays every
+	// record of the scope in postgres by jobs, progress is streamed
+	RebuildDgraph(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (ReconcileService_RebuildDgraphClient, error)
+}
+
+type reconcileServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReconcileServiceClient(cc grpc.ClientConnInterface) ReconcileServiceClient {
+	return &reconcileServiceClient{cc}
+}
+
+func (c *reconcileServiceClient) ReconcileDgraph(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error) {
+	out := new(ReconcileResponse)
+	err := c.cc.Invoke(ctx, "/reconcile.v1.ReconcileService/ReconcileDgraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconcileServiceClient) RebuildDgraph(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (ReconcileService_RebuildDgraphClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReconcileService_serviceDesc.Streams[0], "/reconcile.v1.ReconcileService/RebuildDgraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reconcileServiceRebuildDgraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReconcileService_RebuildDgraphClient interface {
+	Recv() (*RebuildProgress, error)
+	grpc.ClientStream
+}
+
+type reconcileServiceRebuildDgraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *reconcileServiceRebuildDgraphClient) Recv() (*RebuildProgress, error) {
+	m := new(RebuildProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReconcileServiceServer is the server API for ReconcileService service.
+type ReconcileServiceServer interface {
+	// ReconcileDgraph compares the records of a scope in postgres with their
+	// nodes in dgraph, drifts are repaired by jobs when asked
+	ReconcileDgraph(context.Context, *ReconcileRequest) (*ReconcileResponse, error)
+	// RebuildDgraph drops the nodes of a scope in dgraph and replays every
+	// record of the scope in postgres by jobs, progress is streamed
+	RebuildDgraph(*RebuildRequest, ReconcileService_RebuildDgraphServer) error
+}
+
+// UnimplementedReconcileServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedReconcileServiceServer struct {
+}
+
+func (*UnimplementedReconcileServiceServer) ReconcileDgraph(ctx context.Context, req *ReconcileRequest) (*ReconcileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileDgraph not implemented")
+}
+func (*UnimplementedReconcileServiceServer) RebuildDgraph(req *RebuildRequest, srv ReconcileService_RebuildDgraphServer) error {
+	return status.Errorf(codes.Unimplemented, "method RebuildDgraph not implemented")
+}
+
+func RegisterReconcileServiceServer(s *grpc.Server, srv ReconcileServiceServer) {
+	s.RegisterService(&_ReconcileService_serviceDesc, srv)
+}
+
+func _ReconcileService_ReconcileDgraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconcileServiceServer).ReconcileDgraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reconcile.v1.ReconcileService/ReconcileDgraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconcileServiceServer).ReconcileDgraph(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReconcileService_RebuildDgraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RebuildRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReconcileServiceServer).RebuildDgraph(m, &reconcileServiceRebuildDgraphServer{stream})
+}
+
+type ReconcileService_RebuildDgraphServer interface {
+	Send(*RebuildProgress) error
+	grpc.ServerStream
+}
+
+type reconcileServiceRebuildDgraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *reconcileServiceRebuildDgraphServer) Send(m *RebuildProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ReconcileService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "reconcile.v1.ReconcileService",
+	HandlerType: (*ReconcileServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReconcileDgraph",
+			Handler:    _ReconcileService_ReconcileDgraph_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RebuildDgraph",
+			Handler:       _ReconcileService_RebuildDgraph_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "reconcile.proto",
+}
@@ -0,0 +1,455 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: reconcile.proto
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _reconcile_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on ReconcileRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ReconcileRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetService()) < 1 {
+		return ReconcileRequestValidationError{
+			field:  "Service",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetScope()) < 1 {
+		return ReconcileRequestValidationError{
+			field:  "Scope",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	// no validation rules for Repair
+
+	return nil
+}
+
+// ReconcileRequestValidationError is the validation error returned by
+// ReconcileRequest.Validate if the designated constraints aren't met.
+type ReconcileRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReconcileRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReconcileRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReconcileRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReconcileRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReconcileRequestValidationError) ErrorName() string { return "ReconcileRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReconcileRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReconcileRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReconcileRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReconcileRequestValidationError{}
+
+// Validate checks the field values on ReconcileResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ReconcileResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetDrifts() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReconcileResponseValidationError{
+					field:  fmt.Sprintf("Drifts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for RepairJobs
+
+	return nil
+}
+
+// ReconcileResponseValidationError is the validation error returned by
+// ReconcileResponse.Validate if the designated constraints aren't met.
+type ReconcileResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReconcileResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReconcileResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReconcileResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReconcileResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReconcileResponseValidationError) ErrorName() string {
+	return "ReconcileResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReconcileResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReconcileResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReconcileResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReconcileResponseValidationError{}
+
+// Validate checks the field values on Drift with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Drift) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Kind
+
+	// no validation rules for Key
+
+	if _, ok := _Drift_Status_InLookup[m.GetStatus()]; !ok {
+		return DriftValidationError{
+			field:  "Status",
+			reason: "value must be in list [MISSING STALE ORPHAN]",
+		}
+	}
+
+	return nil
+}
+
+// DriftValidationError is the validation error returned by Drift.Validate if
+// the designated constraints aren't met.
+type DriftValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriftValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriftValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriftValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriftValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriftValidationError) ErrorName() string { return "DriftValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DriftValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDrift.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriftValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriftValidationError{}
+
+var _Drift_Status_InLookup = map[string]struct{}{
+	"MISSING": {},
+	"STALE":   {},
+	"ORPHAN":  {},
+}
+
+// Validate checks the field values on RebuildRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *RebuildRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetService()) < 1 {
+		return RebuildRequestValidationError{
+			field:  "Service",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetScope()) < 1 {
+		return RebuildRequestValidationError{
+			field:  "Scope",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if val := m.GetBatchSize(); val < 0 || val > 1000 {
+		return RebuildRequestValidationError{
+			field:  "BatchSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+	}
+
+	if val := m.GetBatchPauseMs(); val < 0 || val > 60000 {
+		return RebuildRequestValidationError{
+			field:  "BatchPauseMs",
+			reason: "value must be inside range [0, 60000]",
+		}
+	}
+
+	return nil
+}
+
+// RebuildRequestValidationError is the validation error returned by
+// RebuildRequest.Validate if the designated constraints aren't met.
+type RebuildRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RebuildRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RebuildRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RebuildRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RebuildRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RebuildRequestValidationError) ErrorName() string { return "RebuildRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RebuildRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRebuildRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RebuildRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RebuildRequestValidationError{}
+
+// Validate checks the field values on RebuildProgress with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *RebuildProgress) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Stage
+
+	// no validation rules for Dropped
+
+	// no validation rules for Records
+
+	// no validation rules for Queued
+
+	return nil
+}
+
+// RebuildProgressValidationError is the validation error returned by
+// RebuildProgress.Validate if the designated constraints aren't met.
+type RebuildProgressValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RebuildProgressValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RebuildProgressValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RebuildProgressValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RebuildProgressValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RebuildProgressValidationError) ErrorName() string { return "RebuildProgressValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RebuildProgressValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRebuildProgress.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RebuildProgressValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RebuildProgressValidationError{}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+//Package reconcile finds the drifts of dgraph from postgres: records of a service whose nodes, mirrored by its jobs,
+//are missing, stale or left without their record, eg: after a job has been dropped. Drifts are repaired by jobs of the service.
+package reconcile
+
+import (
+	"context"
+	"optisam-backend/common/optisam/dgraph"
+	"sort"
+	"strings"
+)
+
+//Status of a drift
+type Status string
+
+const (
+	//StatusMissing is a record without node
+	StatusMissing Status = "MISSING"
+	//StatusStale is a record whose node has other values
+	StatusStale Status = "STALE"
+	//StatusOrphan is a node without record
+	StatusOrphan Status = "ORPHAN"
+)
+
+//Record is a record of postgres, or its node in dgraph, with the values mirrored in dgraph.
+//Links to other nodes are values too, see Links.
+type Record struct {
+	//Kind of record, eg: product
+	Kind string
+	//Key of record in its kind, eg: swidtag of a product
+	Key string
+	//Fields are the values of record by predicate or link
+	Fields map[string]string
+	//Data of a record of postgres used by its repair, eg: its row. It is not compared.
+	Data interface{}
+}
+
+//Drift is a record whose node differs from it
+type Drift struct {
+	Kind   string
+	Key    string
+	Status Status
+	//Fields of a stale record whose values differ
+	Fields []string
+	//Data of the record of a missing or stale drift, see Record
+	Data interface{}
+}
+
+//Reconciler gives the records of a service, repairs their drifts and drops its nodes
+type Reconciler interface {
+	//Postgres gives the records of scope in postgres
+	Postgres(ctx context.Context, scope string) ([]Record, error)
+	//Dgraph gives the records of scope as they are in dgraph
+	Dgraph(ctx context.Context, scope string) ([]Record, error)
+	//Repair pushes the jobs repairing drifts of scope from their data, and gives their number
+	Repair(ctx context.Context, scope string, drifts []Drift) (int32, error)
+	//Drop deletes the nodes of scope owned by service from dgraph, nodes shared with other services only lose the predicates of service
+	//and nodes shared with other scopes are left to the replay
+	Drop(ctx context.Context, scope string) error
+}
+
+//Compare gives the drifts of dgraph from postgres by kind and key.
+//Values of fields are compared as they are, an absent field is empty.
+func Compare(postgres, graph []Record) []Drift {
+	nodes := make(map[[2]string]Record, len(graph))
+	for _, r := range graph {
+		nodes[[2]string{r.Kind, r.Key}] = r
+	}
+	var drifts []Drift
+	for _, r := range postgres {
+		id := [2]string{r.Kind, r.Key}
+		node, found := nodes[id]
+		if !found {
+			drifts = append(drifts, Drift{Kind: r.Kind, Key: r.Key, Status: StatusMissing, Data: r.Data})
+			continue
+		}
+		delete(nodes, id)
+		if fields := diff(r.Fields, node.Fields); len(fields) > 0 {
+			drifts = append(drifts, Drift{Kind: r.Kind, Key: r.Key, Status: StatusStale, Fields: fields, Data: r.Data})
+		}
+	}
+	for _, node := range nodes {
+		drifts = append(drifts, Drift{Kind: node.Kind, Key: node.Key, Status: StatusOrphan})
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Kind != drifts[j].Kind {
+			return drifts[i].Kind < drifts[j].Kind
+		}
+		return drifts[i].Key < drifts[j].Key
+	})
+	return drifts
+}
+
+//diff gives the fields having other values, sorted
+func diff(want, got map[string]string) []string {
+	var fields []string
+	for f, v := range want {
+		if got[f] != v {
+			fields = append(fields, f)
+		}
+	}
+	for f, v := range got {
+		if _, found := want[f]; !found && v != "" {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+//Links gives the value of a field having the keys of linked nodes, whatever their order and duplicates
+func Links(keys []string) string {
+	set := make(map[string]bool, len(keys))
+	links := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k == "" || set[k] {
+			continue
+		}
+		set[k] = true
+		links = append(links, k)
+	}
+	sort.Strings(links)
+	return strings.Join(links, ",")
+}
+
+//Orphan is a node without record, repairs delete it with a job of its service
+type Orphan struct {
+	//Predicate and Key find node, eg: product.swidtag and swidtag of product
+	Predicate string `json:"predicate"`
+	Key       string `json:"key"`
+	//TypeName of node
+	TypeName string `json:"type_name"`
+	//Predicates of node which are removed, all predicates of node are removed when it is empty.
+	//Nodes shared with other services only lose the predicates of service.
+	Predicates []string `json:"predicates,omitempty"`
+}
+
+//Upsert gives the upsert deleting orphan
+func (o Orphan) Upsert() *dgraph.Upsert {
+	upsert := dgraph.NewUpsert()
+	node := upsert.Find("orphan", o.Predicate, o.Key, o.TypeName)
+	deletion := &upsert.Mutation().Delete
+	if len(o.Predicates) == 0 {
+		deletion.Add(node, "*", dgraph.Star)
+	}
+	for _, p := range o.Predicates {
+		deletion.Add(node, p, dgraph.Star)
+	}
+	return upsert
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v2/protos/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		postgres []Record
+		graph    []Record
+		want     []Drift
+	}{
+		{name: "in sync",
+			postgres: []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "n1"}}},
+			graph:    []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "n1"}}},
+		},
+		{name: "missing, stale and orphan nodes by kind and key",
+			postgres: []Record{
+				{Kind: "product", Key: "p2", Fields: map[string]string{"product.name": "n2", "applications": "a1,a2"}, Data: "row2"},
+				{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "n1"}, Data: "row1"},
+				{Kind: "application", Key: "p1"},
+			},
+			graph: []Record{
+				{Kind: "product", Key: "p2", Fields: map[string]string{"product.name": "n2", "applications": "a1", "product.version": "v1"}},
+				{Kind: "product", Key: "p3", Fields: map[string]string{"product.name": "n3"}},
+				{Kind: "application", Key: "p1"},
+			},
+			want: []Drift{
+				{Kind: "product", Key: "p1", Status: StatusMissing, Data: "row1"},
+				{Kind: "product", Key: "p2", Status: StatusStale, Fields: []string{"applications", "product.version"}, Data: "row2"},
+				{Kind: "product", Key: "p3", Status: StatusOrphan},
+			},
+		},
+		{name: "absent fields are empty",
+			postgres: []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.name": "", "applications": ""}}},
+			graph:    []Record{{Kind: "product", Key: "p1", Fields: map[string]string{"product.editor": ""}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Compare(test.postgres, test.graph))
+		})
+	}
+}
+
+func TestLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{name: "no links", want: ""},
+		{name: "sorted without duplicates and empty keys", keys: []string{"e2", "", "e1", "e2"}, want: "e1,e2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Links(test.keys))
+		})
+	}
+}
+
+func TestOrphan_Upsert(t *testing.T) {
+	query := "query {\n\tvar(func: eq(product.swidtag, \"p\\\"1\")) @filter(eq(type_name, \"product\")) {\n\t\torphan as uid\n\t}\n}"
+	tests := []struct {
+		name   string
+		orphan Orphan
+		want   *api.Request
+		outErr bool
+	}{
+		{name: "all predicates",
+			orphan: Orphan{Predicate: "product.swidtag", Key: `p"1`, TypeName: "product"},
+			want: &api.Request{
+				Query:     query,
+				Mutations: []*api.Mutation{{SetNquads: []byte{}, DelNquads: []byte("uid(orphan) * * .\n")}},
+				CommitNow: true,
+			},
+		},
+		{name: "predicates of service",
+			orphan: Orphan{Predicate: "product.swidtag", Key: `p"1`, TypeName: "product", Predicates: []string{"product.name", "product.equipment"}},
+			want: &api.Request{
+				Query:     query,
+				Mutations: []*api.Mutation{{SetNquads: []byte{}, DelNquads: []byte("uid(orphan) <product.name> * .\nuid(orphan) <product.equipment> * .\n")}},
+				CommitNow: true,
+			},
+		},
+		{name: "invalid predicate",
+			orphan: Orphan{Predicate: "product.swidtag", Key: "p1", TypeName: "product", Predicates: []string{"product.name> * .\nuid(x) *"}},
+			outErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.orphan.Upsert().Request()
+			if (err != nil) != test.outErr {
+				t.Fatalf("Orphan.Upsert() error = %v, outErr %v", err, test.outErr)
+			}
+			if !test.outErr {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	middleware "optisam-backend/common/optisam/middleware/grpc"
 	"time"
 
+	"go.opencensus.io/plugin/ocgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -27,6 +28,7 @@ func GetGRPCConnections(ctx context.Context, c Config) (map[string]*grpc.ClientC
 		conn, err := grpc.Dial(val, grpc.WithInsecure(),
 			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: c.Timeout * time.Second}),
 			grpc.WithChainUnaryInterceptor(middleware.AddAuthNClientInterceptor(c.ApiKey)),
+			grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
 		)
 		if err != nil {
 			logger.Log.Error("did not connect:", zap.String(key, val), zap.Error(err))
@@ -0,0 +1,201 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"optisam-backend/common/optisam/ctxmanage"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/token/claims"
+	v1 "optisam-backend/common/optisam/workerqueue/api/v1"
+	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type adminServer struct {
+	q *Queue
+}
+
+//NewAdminServer gives the job administration service of a queue, services mount it on their gRPC server and gateway
+func NewAdminServer(q *Queue) v1.JobAdminServiceServer {
+	return &adminServer{q: q}
+}
+
+//ListJobs lists the jobs of queue by status and type, latest first
+func (a *adminServer) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
+	if err := a.authorize(ctx, req.GetQueue()); err != nil {
+		return nil, err
+	}
+	dbresp, err := a.q.repo.ListJobs(ctx, dbgen.ListJobsParams{
+		AllStatus: req.GetStatus() == "",
+		Status:    dbgen.JobStatus(req.GetStatus()),
+		AllTypes:  req.GetType() == "",
+		Type:      req.GetType(),
+		//API expect pagenum from 1 but the offset in DB starts with 0
+		PageNum:  req.GetPageSize() * (req.GetPageNum() - 1),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		logger.Log.Error("Failed to list jobs", zap.String("queue", a.q.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "DBError")
+	}
+	apiresp := &v1.ListJobsResponse{Jobs: make([]*v1.Job, len(dbresp))}
+	if len(dbresp) > 0 {
+		apiresp.TotalRecords = int32(dbresp[0].Totalrecords)
+	}
+	for i := range dbresp {
+		apiresp.Jobs[i] = toAPIJob(dbgen.Job{
+			JobID:          dbresp[i].JobID,
+			Type:           dbresp[i].Type,
+			Status:         dbresp[i].Status,
+			Comments:       dbresp[i].Comments,
+			StartTime:      dbresp[i].StartTime,
+			EndTime:        dbresp[i].EndTime,
+			CreatedAt:      dbresp[i].CreatedAt,
+			RetryCount:     dbresp[i].RetryCount,
+			LeaseOwner:     dbresp[i].LeaseOwner,
+			LeaseExpiresAt: dbresp[i].LeaseExpiresAt,
+		})
+	}
+	return apiresp, nil
+}
+
+//GetJob gives a job of queue with its data
+func (a *adminServer) GetJob(ctx context.Context, req *v1.GetJobRequest) (*v1.Job, error) {
+	if err := a.authorize(ctx, req.GetQueue()); err != nil {
+		return nil, err
+	}
+	j, err := a.getJob(ctx, req.GetJobId())
+	if err != nil {
+		return nil, err
+	}
+	return toAPIJob(j), nil
+}
+
+//RequeueJob puts back a dead or failed job in queue with a fresh retry budget
+func (a *adminServer) RequeueJob(ctx context.Context, req *v1.RequeueJobRequest) (*v1.RequeueJobResponse, error) {
+	if err := a.authorize(ctx, req.GetQueue()); err != nil {
+		return nil, err
+	}
+	j, err := a.getJob(ctx, req.GetJobId())
+	if err != nil {
+		return nil, err
+	}
+	if j.Status != dbgen.JobStatusDEAD && j.Status != dbgen.JobStatusFAILED {
+		return nil, status.Error(codes.FailedPrecondition, "only dead or failed jobs can be requeued")
+	}
+	//worker of a job has the type of job as id
+	if err := a.q.RequeueJob(ctx, j.JobID, j.Type); err != nil {
+		return nil, status.Error(codes.Internal, "DBError")
+	}
+	logger.Log.Info("Job requeued", zap.String("queue", a.q.ID), zap.Int32("jobID", j.JobID))
+	return &v1.RequeueJobResponse{Success: true}, nil
+}
+
+//PurgeJobs deletes the jobs of queue having a status, which are not run anymore
+func (a *adminServer) PurgeJobs(ctx context.Context, req *v1.PurgeJobsRequest) (*v1.PurgeJobsResponse, error) {
+	if err := a.authorize(ctx, req.GetQueue()); err != nil {
+		return nil, err
+	}
+	purged, err := a.q.repo.PurgeJobs(ctx, dbgen.PurgeJobsParams{
+		Status:   dbgen.JobStatus(req.GetStatus()),
+		AllTypes: req.GetType() == "",
+		Type:     req.GetType(),
+		AllJobs:  len(req.GetJobIds()) == 0,
+		JobIds:   req.GetJobIds(),
+	})
+	if err != nil {
+		logger.Log.Error("Failed to purge jobs", zap.String("queue", a.q.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "DBError")
+	}
+	logger.Log.Info("Jobs purged", zap.String("queue", a.q.ID), zap.String("status", req.GetStatus()), zap.Int64("purged", purged))
+	return &v1.PurgeJobsResponse{Purged: purged}, nil
+}
+
+//GetRetention gives the retention policy of queue on ended jobs and the counts of its sweeps
+func (a *adminServer) GetRetention(ctx context.Context, req *v1.GetRetentionRequest) (*v1.Retention, error) {
+	if err := a.authorize(ctx, req.GetQueue()); err != nil {
+		return nil, err
+	}
+	archived, err := a.q.repo.CountArchivedJobs(ctx)
+	if err != nil {
+		logger.Log.Error("Failed to count archived jobs", zap.String("queue", a.q.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "DBError")
+	}
+	apiresp := &v1.Retention{ArchivedJobs: archived}
+	r := a.q.retention
+	if r == nil {
+		return apiresp, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	apiresp.CompletedDays = int32(r.CompletedDays)
+	apiresp.FailedDays = int32(r.FailedDays)
+	apiresp.Archive = r.Archive
+	apiresp.LastSwept = r.lastSwept
+	apiresp.TotalArchived = r.totalArchived
+	apiresp.TotalDeleted = r.totalDeleted
+	if !r.lastSweptOn.IsZero() {
+		apiresp.LastSweptOn, _ = ptypes.TimestampProto(r.lastSweptOn)
+	}
+	return apiresp, nil
+}
+
+//authorize lets only super admins administer the jobs of this queue, jobs of every scope are in it
+func (a *adminServer) authorize(ctx context.Context, queue string) error {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return status.Error(codes.Internal, "cannot find claims in context")
+	}
+	if userClaims.Role != claims.RoleSuperAdmin {
+		return status.Error(codes.PermissionDenied, "RoleValidationError")
+	}
+	if queue != a.q.ID {
+		return status.Error(codes.NotFound, "queue not found")
+	}
+	return nil
+}
+
+func (a *adminServer) getJob(ctx context.Context, jobID int32) (dbgen.Job, error) {
+	j, err := a.q.repo.GetJob(ctx, jobID)
+	if err == sql.ErrNoRows {
+		return j, status.Error(codes.NotFound, "job not found")
+	}
+	if err != nil {
+		logger.Log.Error("Failed to get job", zap.String("queue", a.q.ID), zap.Int32("jobID", jobID), zap.Error(err))
+		return j, status.Error(codes.Internal, "DBError")
+	}
+	return j, nil
+}
+
+func toAPIJob(j dbgen.Job) *v1.Job {
+	apiJob := &v1.Job{
+		JobId:      j.JobID,
+		Type:       j.Type,
+		Status:     string(j.Status),
+		Data:       string(j.Data),
+		RetryCount: j.RetryCount.Int32,
+		LastError:  j.Comments.String,
+		LeaseOwner: j.LeaseOwner.String,
+	}
+	apiJob.CreatedOn, _ = ptypes.TimestampProto(j.CreatedAt)
+	if j.StartTime.Valid {
+		apiJob.StartedOn, _ = ptypes.TimestampProto(j.StartTime.Time)
+	}
+	if j.EndTime.Valid {
+		apiJob.EndedOn, _ = ptypes.TimestampProto(j.EndTime.Time)
+	}
+	if j.LeaseExpiresAt.Valid {
+		apiJob.LeaseExpiresOn, _ = ptypes.TimestampProto(j.LeaseExpiresAt.Time)
+	}
+	return apiJob
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"optisam-backend/common/optisam/ctxmanage"
+	"optisam-backend/common/optisam/token/claims"
+	v1 "optisam-backend/common/optisam/workerqueue/api/v1"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_adminServer_ListJobs(t *testing.T) {
+	ctx := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin})
+	created := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	createdOn, _ := ptypes.TimestampProto(created)
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockWorkerqueue
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *v1.ListJobsRequest
+		setup    func()
+		want     *v1.ListJobsResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "SUCCESS - dead jobs of a type with their last error",
+			ctx:  ctx,
+			req:  &v1.ListJobsRequest{Queue: "q", Status: "DEAD", Type: "aw", PageNum: 2, PageSize: 10},
+			setup: func() {
+				rep.EXPECT().ListJobs(ctx, db.ListJobsParams{Status: db.JobStatusDEAD, Type: "aw", PageNum: 10, PageSize: 10}).Return([]db.ListJobsRow{
+					{Totalrecords: 11, JobID: 3, Type: "aw", Status: db.JobStatusDEAD, Data: []byte(`{"a":1}`), CreatedAt: created,
+						RetryCount: sql.NullInt32{Int32: 3, Valid: true}, Comments: sql.NullString{String: "unavailable", Valid: true}},
+				}, nil)
+			},
+			want: &v1.ListJobsResponse{TotalRecords: 11, Jobs: []*v1.Job{
+				{JobId: 3, Type: "aw", Status: "DEAD", RetryCount: 3, LastError: "unavailable", CreatedOn: createdOn},
+			}},
+		},
+		{
+			name: "SUCCESS - jobs of every status and type",
+			ctx:  ctx,
+			req:  &v1.ListJobsRequest{Queue: "q", PageNum: 1, PageSize: 10},
+			setup: func() {
+				rep.EXPECT().ListJobs(ctx, db.ListJobsParams{AllStatus: true, AllTypes: true, PageSize: 10}).Return(nil, nil)
+			},
+			want: &v1.ListJobsResponse{Jobs: []*v1.Job{}},
+		},
+		{
+			name:     "FAILURE - user is not super admin",
+			ctx:      ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleAdmin}),
+			req:      &v1.ListJobsRequest{Queue: "q", PageNum: 1, PageSize: 10},
+			setup:    func() {},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "FAILURE - claims are not found",
+			ctx:      context.Background(),
+			req:      &v1.ListJobsRequest{Queue: "q", PageNum: 1, PageSize: 10},
+			setup:    func() {},
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "FAILURE - queue is not the queue of service",
+			ctx:      ctx,
+			req:      &v1.ListJobsRequest{Queue: "other", PageNum: 1, PageSize: 10},
+			setup:    func() {},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "FAILURE - db error",
+			ctx:  ctx,
+			req:  &v1.ListJobsRequest{Queue: "q", PageNum: 1, PageSize: 10},
+			setup: func() {
+				rep.EXPECT().ListJobs(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			a := NewAdminServer(&Queue{ID: "q", repo: rep})
+			got, err := a.ListJobs(tt.ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminServer.ListJobs() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("adminServer.ListJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminServer_GetJob(t *testing.T) {
+	ctx := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin})
+	createdOn, _ := ptypes.TimestampProto(time.Time{})
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockWorkerqueue
+	tests := []struct {
+		name     string
+		req      *v1.GetJobRequest
+		setup    func()
+		want     *v1.Job
+		wantCode codes.Code
+	}{
+		{
+			name: "SUCCESS - job is given with its payload",
+			req:  &v1.GetJobRequest{Queue: "q", JobId: 3},
+			setup: func() {
+				rep.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{JobID: 3, Type: "aw", Status: db.JobStatusRUNNING, Data: []byte(`{"a":1}`),
+					LeaseOwner: sql.NullString{String: "replica-1", Valid: true}}, nil)
+			},
+			want: &v1.Job{JobId: 3, Type: "aw", Status: "RUNNING", Data: `{"a":1}`, LeaseOwner: "replica-1", CreatedOn: createdOn},
+		},
+		{
+			name: "FAILURE - job is not found",
+			req:  &v1.GetJobRequest{Queue: "q", JobId: 3},
+			setup: func() {
+				rep.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{}, sql.ErrNoRows)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			a := NewAdminServer(&Queue{ID: "q", repo: rep})
+			got, err := a.GetJob(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminServer.GetJob() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("adminServer.GetJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminServer_RequeueJob(t *testing.T) {
+	ctx := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin})
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockWorkerqueue
+	tests := []struct {
+		name     string
+		req      *v1.RequeueJobRequest
+		setup    func()
+		wantCode codes.Code
+	}{
+		{
+			name: "SUCCESS - dead job is requeued as a due job",
+			req:  &v1.RequeueJobRequest{Queue: "q", JobId: 3},
+			setup: func() {
+				rep.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{JobID: 3, Type: "aw", Status: db.JobStatusDEAD}, nil)
+				rep.EXPECT().RequeueJob(ctx, int32(3)).Return(nil)
+			},
+		},
+		{
+			name: "FAILURE - running job can not be requeued",
+			req:  &v1.RequeueJobRequest{Queue: "q", JobId: 3},
+			setup: func() {
+				rep.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{JobID: 3, Type: "aw", Status: db.JobStatusRUNNING}, nil)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "FAILURE - db error",
+			req:  &v1.RequeueJobRequest{Queue: "q", JobId: 3},
+			setup: func() {
+				rep.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{JobID: 3, Type: "aw", Status: db.JobStatusFAILED}, nil)
+				rep.EXPECT().RequeueJob(ctx, int32(3)).Return(errors.New("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			q := &Queue{ID: "q", repo: rep, notifier: make(chan jobChan, 1)}
+			_, err := NewAdminServer(q).RequeueJob(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminServer.RequeueJob() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			//requeued job is notified by notifier of due jobs, producer is never blocked
+			if len(q.notifier) != 0 {
+				t.Errorf("adminServer.RequeueJob() notified = %v", <-q.notifier)
+			}
+		})
+	}
+}
+
+func Test_adminServer_PurgeJobs(t *testing.T) {
+	ctx := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin})
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockWorkerqueue
+	tests := []struct {
+		name     string
+		req      *v1.PurgeJobsRequest
+		setup    func()
+		want     int64
+		wantCode codes.Code
+	}{
+		{
+			name: "SUCCESS - given dead jobs are purged",
+			req:  &v1.PurgeJobsRequest{Queue: "q", Status: "DEAD", JobIds: []int32{3, 4}},
+			setup: func() {
+				rep.EXPECT().PurgeJobs(ctx, db.PurgeJobsParams{Status: db.JobStatusDEAD, AllTypes: true, JobIds: []int32{3, 4}}).Return(int64(2), nil)
+			},
+			want: 2,
+		},
+		{
+			name: "SUCCESS - completed jobs of a type are purged",
+			req:  &v1.PurgeJobsRequest{Queue: "q", Status: "COMPLETED", Type: "lw"},
+			setup: func() {
+				rep.EXPECT().PurgeJobs(ctx, db.PurgeJobsParams{Status: db.JobStatusCOMPLETED, Type: "lw", AllJobs: true}).Return(int64(40), nil)
+			},
+			want: 40,
+		},
+		{
+			name: "FAILURE - db error",
+			req:  &v1.PurgeJobsRequest{Queue: "q", Status: "DEAD"},
+			setup: func() {
+				rep.EXPECT().PurgeJobs(ctx, gomock.Any()).Return(int64(0), errors.New("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			got, err := NewAdminServer(&Queue{ID: "q", repo: rep}).PurgeJobs(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminServer.PurgeJobs() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if got.GetPurged() != tt.want {
+				t.Errorf("adminServer.PurgeJobs() = %v, want %v", got.GetPurged(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminServer_GetRetention(t *testing.T) {
+	ctx := ctxmanage.AddClaims(context.Background(), &claims.Claims{UserID: "admin@test.com", Role: claims.RoleSuperAdmin})
+	swept := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	sweptOn, _ := ptypes.TimestampProto(swept)
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockWorkerqueue
+	tests := []struct {
+		name      string
+		retention *retention
+		setup     func()
+		want      *v1.Retention
+		wantCode  codes.Code
+	}{
+		{
+			name: "SUCCESS - policy is given with counts of sweeps",
+			retention: &retention{RetentionConfig: RetentionConfig{CompletedDays: 30, FailedDays: 90, Archive: true},
+				lastSweptOn: swept, lastSwept: 12, totalArchived: 40},
+			setup: func() {
+				rep.EXPECT().CountArchivedJobs(ctx).Return(int64(120), nil)
+			},
+			want: &v1.Retention{CompletedDays: 30, FailedDays: 90, Archive: true, LastSweptOn: sweptOn, LastSwept: 12, TotalArchived: 40, ArchivedJobs: 120},
+		},
+		{
+			name:      "SUCCESS - jobs kept forever are never swept",
+			retention: newRetention(RetentionConfig{}),
+			setup: func() {
+				rep.EXPECT().CountArchivedJobs(ctx).Return(int64(0), nil)
+			},
+			want: &v1.Retention{},
+		},
+		{
+			name:      "FAILURE - db error",
+			retention: newRetention(RetentionConfig{}),
+			setup: func() {
+				rep.EXPECT().CountArchivedJobs(ctx).Return(int64(0), errors.New("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			got, err := NewAdminServer(&Queue{ID: "q", repo: rep, retention: tt.retention}).GetRetention(ctx, &v1.GetRetentionRequest{Queue: "q"})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminServer.GetRetention() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("adminServer.GetRetention() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,968 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: workerqueue.proto
+
+// jobs of every service are administered with same messages, package is kept
+// apart from the v1 package of services mounting it
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListJobsRequest struct {
+	Queue string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	// jobs of every status are listed when empty
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// jobs of every type are listed when empty
+	Type                 string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	PageNum              int32    `protobuf:"varint,4,opt,name=page_num,json=pageNum,proto3" json:"page_num,omitempty"`
+	PageSize             int32    `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{0}
+}
+
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+}
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+}
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
+}
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+
+func (m *ListJobsRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetPageNum() int32 {
+	if m != nil {
+		return m.PageNum
+	}
+	return 0
+}
+
+func (m *ListJobsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	TotalRecords int32 `protobuf:"varint,1,opt,name=totalRecords,proto3" json:"totalRecords,omitempty"`
+	// data of jobs is not listed, it is given by GetJob
+	Jobs                 []*Job   `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsResponse) Reset()         { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()    {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{1}
+}
+
+func (m *ListJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponse.Unmarshal(m, b)
+}
+func (m *ListJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponse.Merge(m, src)
+}
+func (m *ListJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponse.Size(m)
+}
+func (m *ListJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsResponse proto.InternalMessageInfo
+
+func (m *ListJobsResponse) GetTotalRecords() int32 {
+	if m != nil {
+		return m.TotalRecords
+	}
+	return 0
+}
+
+func (m *ListJobsResponse) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type Job struct {
+	JobId  int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type   string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// payload of job as pushed by its service
+	Data       string `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	RetryCount int32  `protobuf:"varint,5,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	// error of last attempt of job
+	LastError string               `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedOn *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	StartedOn *timestamp.Timestamp `protobuf:"bytes,8,opt,name=started_on,json=startedOn,proto3" json:"started_on,omitempty"`
+	EndedOn   *timestamp.Timestamp `protobuf:"bytes,9,opt,name=ended_on,json=endedOn,proto3" json:"ended_on,omitempty"`
+	// replica running job when jobs are claimed
+	LeaseOwner           string               `protobuf:"bytes,10,opt,name=lease_owner,json=leaseOwner,proto3" json:"lease_owner,omitempty"`
+	LeaseExpiresOn       *timestamp.Timestamp `protobuf:"bytes,11,opt,name=lease_expires_on,json=leaseExpiresOn,proto3" json:"lease_expires_on,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{2}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetJobId() int32 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *Job) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Job) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Job) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *Job) GetRetryCount() int32 {
+	if m != nil {
+		return m.RetryCount
+	}
+	return 0
+}
+
+func (m *Job) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *Job) GetCreatedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedOn
+	}
+	return nil
+}
+
+func (m *Job) GetStartedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedOn
+	}
+	return nil
+}
+
+func (m *Job) GetEndedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndedOn
+	}
+	return nil
+}
+
+func (m *Job) GetLeaseOwner() string {
+	if m != nil {
+		return m.LeaseOwner
+	}
+	return ""
+}
+
+func (m *Job) GetLeaseExpiresOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.LeaseExpiresOn
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	Queue                string   `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	JobId                int32    `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()    {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{3}
+}
+
+func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
+}
+func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRequest.Merge(m, src)
+}
+func (m *GetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetJobRequest.Size(m)
+}
+func (m *GetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
+
+func (m *GetJobRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *GetJobRequest) GetJobId() int32 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+type RequeueJobRequest struct {
+	Queue                string   `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	JobId                int32    `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequeueJobRequest) Reset()         { *m = RequeueJobRequest{} }
+func (m *RequeueJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RequeueJobRequest) ProtoMessage()    {}
+func (*RequeueJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{4}
+}
+
+func (m *RequeueJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequeueJobRequest.Unmarshal(m, b)
+}
+func (m *RequeueJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequeueJobRequest.Marshal(b, m, deterministic)
+}
+func (m *RequeueJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequeueJobRequest.Merge(m, src)
+}
+func (m *RequeueJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RequeueJobRequest.Size(m)
+}
+func (m *RequeueJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequeueJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequeueJobRequest proto.InternalMessageInfo
+
+func (m *RequeueJobRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *RequeueJobRequest) GetJobId() int32 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+type RequeueJobResponse struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequeueJobResponse) Reset()         { *m = RequeueJobResponse{} }
+func (m *RequeueJobResponse) String() string { return proto.CompactTextString(m) }
+func (*RequeueJobResponse) ProtoMessage()    {}
+func (*RequeueJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{5}
+}
+
+func (m *RequeueJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequeueJobResponse.Unmarshal(m, b)
+}
+func (m *RequeueJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequeueJobResponse.Marshal(b, m, deterministic)
+}
+func (m *RequeueJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequeueJobResponse.Merge(m, src)
+}
+func (m *RequeueJobResponse) XXX_Size() int {
+	return xxx_messageInfo_RequeueJobResponse.Size(m)
+}
+func (m *RequeueJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequeueJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequeueJobResponse proto.InternalMessageInfo
+
+func (m *RequeueJobResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type PurgeJobsRequest struct {
+	Queue string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	// only jobs which are not run anymore can be purged
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// jobs of every type are purged when empty
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// jobs of status are purged whatever their id when empty
+	JobIds               []int32  `protobuf:"varint,4,rep,packed,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeJobsRequest) Reset()         { *m = PurgeJobsRequest{} }
+func (m *PurgeJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeJobsRequest) ProtoMessage()    {}
+func (*PurgeJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{6}
+}
+
+func (m *PurgeJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeJobsRequest.Unmarshal(m, b)
+}
+func (m *PurgeJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeJobsRequest.Merge(m, src)
+}
+func (m *PurgeJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeJobsRequest.Size(m)
+}
+func (m *PurgeJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeJobsRequest proto.InternalMessageInfo
+
+func (m *PurgeJobsRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *PurgeJobsRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PurgeJobsRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PurgeJobsRequest) GetJobIds() []int32 {
+	if m != nil {
+		return m.JobIds
+	}
+	return nil
+}
+
+type PurgeJobsResponse struct {
+	Purged               int64    `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeJobsResponse) Reset()         { *m = PurgeJobsResponse{} }
+func (m *PurgeJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeJobsResponse) ProtoMessage()    {}
+func (*PurgeJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{7}
+}
+
+func (m *PurgeJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeJobsResponse.Unmarshal(m, b)
+}
+func (m *PurgeJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeJobsResponse.Merge(m, src)
+}
+func (m *PurgeJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeJobsResponse.Size(m)
+}
+func (m *PurgeJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeJobsResponse proto.InternalMessageInfo
+
+func (m *PurgeJobsResponse) GetPurged() int64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
+
+type GetRetentionRequest struct {
+	Queue                string   `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRetentionRequest) Reset()         { *m = GetRetentionRequest{} }
+func (m *GetRetentionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRetentionRequest) ProtoMessage()    {}
+func (*GetRetentionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{8}
+}
+
+func (m *GetRetentionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRetentionRequest.Unmarshal(m, b)
+}
+func (m *GetRetentionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRetentionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRetentionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRetentionRequest.Merge(m, src)
+}
+func (m *GetRetentionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRetentionRequest.Size(m)
+}
+func (m *GetRetentionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRetentionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRetentionRequest proto.InternalMessageInfo
+
+func (m *GetRetentionRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+type Retention struct {
+	// days completed jobs are kept, forever when 0
+	CompletedDays int32 `protobuf:"varint,1,opt,name=completed_days,json=completedDays,proto3" json:"completed_days,omitempty"`
+	// days failed, dead and cancelled jobs are kept, forever when 0
+	FailedDays int32 `protobuf:"varint,2,opt,name=failed_days,json=failedDays,proto3" json:"failed_days,omitempty"`
+	// expired jobs are moved to the archive of queue instead of being deleted
+	Archive bool `protobuf:"varint,3,opt,name=archive,proto3" json:"archive,omitempty"`
+	// sweeps of expired jobs are counted by the replica answering
+	LastSweptOn   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_swept_on,json=lastSweptOn,proto3" json:"last_swept_on,omitempty"`
+	LastSwept     int64                `protobuf:"varint,5,opt,name=last_swept,json=lastSwept,proto3" json:"last_swept,omitempty"`
+	TotalArchived int64                `protobuf:"varint,6,opt,name=total_archived,json=totalArchived,proto3" json:"total_archived,omitempty"`
+	TotalDeleted  int64                `protobuf:"varint,7,opt,name=total_deleted,json=totalDeleted,proto3" json:"total_deleted,omitempty"`
+	// jobs in the archive of queue
+	ArchivedJobs         int64    `protobuf:"varint,8,opt,name=archived_jobs,json=archivedJobs,proto3" json:"archived_jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Retention) Reset()         { *m = Retention{} }
+func (m *Retention) String() string { return proto.CompactTextString(m) }
+func (*Retention) ProtoMessage()    {}
+func (*Retention) Descriptor() ([]byte, []int) {
+	return fileDescriptor_878966ba7c756b5e, []int{9}
+}
+
+func (m *Retention) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Retention.Unmarshal(m, b)
+}
+func (m *Retention) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Retention.Marshal(b, m, deterministic)
+}
+func (m *Retention) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Retention.Merge(m, src)
+}
+func (m *Retention) XXX_Size() int {
+	return xxx_messageInfo_Retention.Size(m)
+}
+func (m *Retention) XXX_DiscardUnknown() {
+	xxx_messageInfo_Retention.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Retention proto.InternalMessageInfo
+
+func (m *Retention) GetCompletedDays() int32 {
+	if m != nil {
+		return m.CompletedDays
+	}
+	return 0
+}
+
+func (m *Retention) GetFailedDays() int32 {
+	if m != nil {
+		return m.FailedDays
+	}
+	return 0
+}
+
+func (m *Retention) GetArchive() bool {
+	if m != nil {
+		return m.Archive
+	}
+	return false
+}
+
+func (m *Retention) GetLastSweptOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSweptOn
+	}
+	return nil
+}
+
+func (m *Retention) GetLastSwept() int64 {
+	if m != nil {
+		return m.LastSwept
+	}
+	return 0
+}
+
+func (m *Retention) GetTotalArchived() int64 {
+	if m != nil {
+		return m.TotalArchived
+	}
+	return 0
+}
+
+func (m *Retention) GetTotalDeleted() int64 {
+	if m != nil {
+		return m.TotalDeleted
+	}
+	return 0
+}
+
+func (m *Retention) GetArchivedJobs() int64 {
+	if m != nil {
+		return m.ArchivedJobs
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ListJobsRequest)(nil), "workerqueue.v1.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "workerqueue.v1.ListJobsResponse")
+	proto.RegisterType((*Job)(nil), "workerqueue.v1.Job")
+	proto.RegisterType((*GetJobRequest)(nil), "workerqueue.v1.GetJobRequest")
+	proto.RegisterType((*RequeueJobRequest)(nil), "workerqueue.v1.RequeueJobRequest")
+	proto.RegisterType((*RequeueJobResponse)(nil), "workerqueue.v1.RequeueJobResponse")
+	proto.RegisterType((*PurgeJobsRequest)(nil), "workerqueue.v1.PurgeJobsRequest")
+	proto.RegisterType((*PurgeJobsResponse)(nil), "workerqueue.v1.PurgeJobsResponse")
+	proto.RegisterType((*GetRetentionRequest)(nil), "workerqueue.v1.GetRetentionRequest")
+	proto.RegisterType((*Retention)(nil), "workerqueue.v1.Retention")
+}
+
+func init() { proto.RegisterFile("workerqueue.proto", fileDescriptor_878966ba7c756b5e) }
+
+var fileDescriptor_878966ba7c756b5e = []byte{
+	// 1072 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4f, 0x6f, 0x1b, 0xc5,
+	0x1b, 0x8e, 0xff, 0xae, 0xf7, 0x75, 0x93, 0x26, 0x53, 0xfd, 0xda, 0xfd, 0x2d, 0x84, 0xb8, 0x9b,
+	0x50, 0x4c, 0x49, 0x6d, 0x25, 0x80, 0x10, 0x1c, 0x68, 0xec, 0xd8, 0x44, 0x09, 0xc1, 0x8e, 0x26,
+	0xe1, 0x10, 0x84, 0x64, 0xad, 0xbd, 0x53, 0xb3, 0xc1, 0xde, 0xd9, 0xce, 0xcc, 0x3a, 0x4d, 0xab,
+	0x5e, 0x38, 0x23, 0x21, 0x95, 0x4f, 0xc0, 0x37, 0xe0, 0xc8, 0x85, 0x4f, 0xc1, 0x57, 0xe0, 0xc0,
+	0x11, 0xae, 0x3e, 0xa1, 0x99, 0xd9, 0x75, 0x1d, 0xa7, 0x4d, 0x82, 0xc0, 0x17, 0xcf, 0x3e, 0xfb,
+	0xbc, 0xcf, 0xcc, 0xfb, 0xce, 0xfb, 0xbc, 0x0b, 0x4b, 0xa7, 0x94, 0x7d, 0x4b, 0xd8, 0xe3, 0x88,
+	0x44, 0xa4, 0x12, 0x32, 0x2a, 0x28, 0x5a, 0x98, 0x86, 0x46, 0x1b, 0xf6, 0x9b, 0x7d, 0x4a, 0xfb,
+	0x03, 0x52, 0x75, 0x43, 0xbf, 0xea, 0x06, 0x01, 0x15, 0xae, 0xf0, 0x69, 0xc0, 0x35, 0xdb, 0xbe,
+	0x33, 0x72, 0x07, 0xbe, 0xe7, 0x0a, 0x52, 0x4d, 0x16, 0xf1, 0x8b, 0x95, 0x38, 0x4c, 0x3d, 0x75,
+	0xa3, 0x47, 0x55, 0xe1, 0x0f, 0x09, 0x17, 0xee, 0x30, 0x8c, 0x09, 0xeb, 0xea, 0xaf, 0xf7, 0xa0,
+	0x4f, 0x82, 0x07, 0xfc, 0xd4, 0xed, 0xf7, 0x09, 0xab, 0xd2, 0x50, 0x69, 0x5f, 0xdc, 0xc7, 0xf9,
+	0x25, 0x0d, 0x37, 0xf7, 0x7d, 0x2e, 0xf6, 0x68, 0x97, 0x63, 0xf2, 0x38, 0x22, 0x5c, 0xa0, 0x65,
+	0xc8, 0xa9, 0x53, 0x5a, 0xa9, 0x52, 0xaa, 0x6c, 0xd6, 0x8d, 0x71, 0x3d, 0xcb, 0xd2, 0x8b, 0x29,
+	0xac, 0x51, 0xf4, 0x35, 0xe4, 0xb9, 0x70, 0x45, 0xc4, 0xad, 0xb4, 0x7a, 0xdf, 0x18, 0xd7, 0x6b,
+	0xec, 0x21, 0x9e, 0xc3, 0xc6, 0x41, 0xb3, 0xd5, 0xd8, 0x6d, 0xed, 0x60, 0x03, 0x7f, 0xd9, 0x6a,
+	0xc9, 0x45, 0x0e, 0x37, 0x8f, 0xf0, 0x31, 0x36, 0xb7, 0xdb, 0x5f, 0x1c, 0xec, 0x37, 0x8f, 0x9a,
+	0x0d, 0x9c, 0xff, 0xac, 0xb6, 0xbb, 0xdf, 0x6c, 0x60, 0x73, 0xbb, 0xd6, 0xda, 0x6e, 0xee, 0xcb,
+	0x65, 0xb6, 0xd1, 0xac, 0x35, 0x70, 0xac, 0x89, 0x10, 0x64, 0xc5, 0x59, 0x48, 0xac, 0x8c, 0xd4,
+	0xc6, 0x6a, 0x8d, 0x76, 0xa0, 0x10, 0xba, 0x7d, 0xd2, 0x09, 0xa2, 0xa1, 0x95, 0x2d, 0xa5, 0xca,
+	0xb9, 0xfa, 0xfa, 0x8b, 0xda, 0xca, 0x66, 0xf1, 0xc0, 0xed, 0x93, 0x52, 0x10, 0x0d, 0xbb, 0x84,
+	0x1d, 0xcf, 0xc9, 0xdf, 0xd6, 0x0f, 0x5b, 0xbe, 0x5a, 0xcc, 0xfd, 0xf9, 0x70, 0x5c, 0x37, 0xec,
+	0xdc, 0xe2, 0x1f, 0x46, 0x39, 0x85, 0x0d, 0x19, 0xdd, 0x8a, 0x86, 0xe8, 0x73, 0x30, 0x95, 0x10,
+	0xf7, 0x9f, 0x12, 0x2b, 0xa7, 0x94, 0x2a, 0x2f, 0x6a, 0xce, 0xe6, 0xc2, 0xae, 0x20, 0x43, 0x5e,
+	0x0a, 0x09, 0x2b, 0xc9, 0xf7, 0x5a, 0x6c, 0xee, 0x38, 0x11, 0x5b, 0xdb, 0x1a, 0xd7, 0xf3, 0x76,
+	0xd6, 0xf2, 0xca, 0x80, 0xd5, 0x49, 0x0e, 0xfd, 0xa7, 0xc4, 0xe9, 0xc0, 0xe2, 0xcb, 0xca, 0xf1,
+	0x90, 0x06, 0x9c, 0x20, 0x07, 0x6e, 0x08, 0x2a, 0xdc, 0x01, 0x26, 0x3d, 0xca, 0x3c, 0xae, 0x2a,
+	0x98, 0xc3, 0xe7, 0x30, 0xf4, 0x0e, 0x64, 0x4f, 0x68, 0x57, 0x56, 0x2f, 0x53, 0x2e, 0x6e, 0xde,
+	0xaa, 0x9c, 0xef, 0x8b, 0xca, 0x1e, 0xed, 0x62, 0x45, 0x70, 0x7e, 0xce, 0x40, 0x66, 0x8f, 0x76,
+	0xd1, 0xff, 0x20, 0x7f, 0x42, 0xbb, 0x1d, 0xdf, 0x8b, 0xe5, 0x72, 0x27, 0xb4, 0xbb, 0xeb, 0x4d,
+	0x2a, 0x95, 0x9e, 0xaa, 0xd4, 0xed, 0xc9, 0xdd, 0xe8, 0xfa, 0x4d, 0x55, 0xd5, 0x73, 0x85, 0xab,
+	0xaa, 0x67, 0x62, 0xb5, 0x46, 0x2b, 0x50, 0x64, 0x44, 0xb0, 0xb3, 0x4e, 0x8f, 0x46, 0x81, 0xd0,
+	0xe5, 0xc0, 0xa0, 0xa0, 0x6d, 0x89, 0xa0, 0x65, 0x80, 0x81, 0xcb, 0x45, 0x87, 0x30, 0x46, 0x99,
+	0x95, 0x57, 0xa1, 0xa6, 0x44, 0x9a, 0x12, 0x40, 0x1f, 0x03, 0xf4, 0x18, 0x71, 0x05, 0xf1, 0x3a,
+	0x34, 0xb0, 0x8c, 0x52, 0xaa, 0x5c, 0xdc, 0xb4, 0x2b, 0xba, 0x3d, 0x2b, 0x49, 0x7b, 0x56, 0x8e,
+	0x92, 0xf6, 0xc4, 0x66, 0xcc, 0x6e, 0x07, 0x32, 0x94, 0x0b, 0x97, 0xc5, 0xa1, 0x85, 0xab, 0x43,
+	0x63, 0x76, 0x3b, 0x40, 0x1f, 0x42, 0x81, 0x04, 0x9e, 0x0e, 0x34, 0xaf, 0x0c, 0x34, 0x14, 0xb7,
+	0x1d, 0xc8, 0x64, 0x07, 0xc4, 0xe5, 0xa4, 0x43, 0x4f, 0x03, 0xc2, 0x2c, 0x50, 0xc9, 0x80, 0x82,
+	0xda, 0x12, 0x41, 0x0d, 0x58, 0xd4, 0x04, 0xf2, 0x24, 0xf4, 0x19, 0xe1, 0x52, 0xbf, 0x78, 0xa5,
+	0xfe, 0x82, 0x8a, 0x69, 0xea, 0x90, 0x76, 0xe0, 0xb4, 0x60, 0x7e, 0x87, 0xc8, 0x96, 0xb8, 0xa6,
+	0x97, 0xde, 0x9a, 0x5c, 0x6d, 0x5a, 0x75, 0xa3, 0x7c, 0x6f, 0xa7, 0x4b, 0x73, 0xf1, 0x1d, 0x3b,
+	0x18, 0x96, 0x94, 0x52, 0x44, 0xfe, 0x3b, 0xcd, 0x0a, 0xa0, 0x69, 0xcd, 0xb8, 0x73, 0x2d, 0x30,
+	0x78, 0xd4, 0xeb, 0x11, 0xae, 0x9b, 0xb6, 0x80, 0x93, 0x47, 0xe7, 0xa7, 0x14, 0x2c, 0x1e, 0x44,
+	0xac, 0x4f, 0xfe, 0xc1, 0x8c, 0xa8, 0xcd, 0xcc, 0x88, 0x77, 0xc7, 0xf5, 0x7b, 0x6c, 0xed, 0x5f,
+	0x0f, 0x82, 0x3b, 0x60, 0xe8, 0xd4, 0xb8, 0x95, 0x2d, 0x65, 0xca, 0x39, 0x9c, 0x57, 0x29, 0x71,
+	0xe7, 0x3d, 0x58, 0x9a, 0x3a, 0x62, 0x9c, 0xd2, 0x6d, 0xc8, 0x87, 0x12, 0xd4, 0xbe, 0xc9, 0xe0,
+	0xf8, 0xc9, 0xf9, 0x00, 0x6e, 0xed, 0x10, 0x81, 0x89, 0x20, 0x81, 0x1c, 0x85, 0xd7, 0x4b, 0xc9,
+	0xf9, 0x35, 0x0d, 0xe6, 0x24, 0x06, 0xbd, 0x0d, 0x0b, 0x3d, 0x3a, 0x0c, 0x07, 0x44, 0xf6, 0xb0,
+	0xe7, 0x9e, 0x25, 0x56, 0x9f, 0x9f, 0xa0, 0x0d, 0xf7, 0x8c, 0xcb, 0xb6, 0x7b, 0xe4, 0xfa, 0x83,
+	0x84, 0x93, 0xd6, 0x1e, 0xd3, 0x90, 0x22, 0x58, 0x60, 0xb8, 0xac, 0xf7, 0x8d, 0x3f, 0xd2, 0x89,
+	0x16, 0x70, 0xf2, 0x88, 0x3e, 0x85, 0x79, 0xe5, 0x3e, 0x7e, 0x4a, 0x42, 0x21, 0xbb, 0x31, 0x7b,
+	0x65, 0x37, 0x16, 0x65, 0xc0, 0xa1, 0xe4, 0xb7, 0x83, 0x89, 0x7b, 0x55, 0xbc, 0x72, 0x77, 0x06,
+	0x9b, 0x13, 0x82, 0x4c, 0x40, 0x4d, 0xa5, 0x4e, 0xbc, 0x9f, 0xa7, 0x0c, 0x9e, 0xc1, 0xf3, 0x0a,
+	0xad, 0xc5, 0x20, 0x5a, 0x05, 0x0d, 0x74, 0x3c, 0xa2, 0xb2, 0x52, 0x3e, 0xcf, 0xc4, 0x13, 0xad,
+	0xa1, 0x31, 0x49, 0x4a, 0x54, 0x3a, 0x6a, 0xb4, 0x15, 0x34, 0x29, 0x01, 0xe5, 0xad, 0x6c, 0xfe,
+	0x95, 0x85, 0x9b, 0x7b, 0xb4, 0x5b, 0xf3, 0x86, 0x7e, 0x70, 0x48, 0xd8, 0xc8, 0xef, 0x11, 0xc4,
+	0xa0, 0x90, 0x8c, 0x50, 0xb4, 0x32, 0x3b, 0x08, 0x67, 0x3e, 0x4b, 0x76, 0xe9, 0xf5, 0x04, 0x7d,
+	0xe1, 0xce, 0xea, 0x77, 0xbf, 0xfd, 0xfe, 0x63, 0x7a, 0x19, 0xbd, 0xa1, 0x3e, 0xaa, 0xa3, 0x8d,
+	0xaa, 0xa2, 0xf2, 0xea, 0x33, 0xf5, 0xff, 0xbc, 0x2a, 0xcf, 0x86, 0x4e, 0x20, 0xaf, 0x2d, 0x8a,
+	0x96, 0x67, 0x05, 0xcf, 0x59, 0xd7, 0x7e, 0xd5, 0x64, 0x76, 0xd6, 0xd5, 0x16, 0xf7, 0xd0, 0xda,
+	0x25, 0x5b, 0x54, 0x9f, 0xe9, 0x26, 0x7d, 0x8e, 0xbe, 0x4f, 0x01, 0xbc, 0xf4, 0x1a, 0xba, 0x3b,
+	0xab, 0x78, 0xc1, 0xdb, 0xb6, 0x73, 0x19, 0x25, 0x4e, 0xf3, 0x23, 0x75, 0x86, 0x0d, 0x67, 0xfd,
+	0x3a, 0x67, 0xa8, 0x32, 0x2d, 0xf0, 0x49, 0xea, 0x3e, 0x8a, 0xc0, 0x9c, 0xb8, 0x04, 0x5d, 0x28,
+	0xe7, 0xac, 0xc7, 0xed, 0xbb, 0x97, 0x30, 0xce, 0x57, 0xfc, 0xfe, 0xa5, 0x15, 0x7f, 0x02, 0x37,
+	0xa6, 0xfd, 0x86, 0x56, 0x5f, 0x51, 0xf7, 0x59, 0x37, 0xda, 0xff, 0xbf, 0x58, 0x88, 0x98, 0xe1,
+	0x94, 0xd5, 0xa6, 0x0e, 0x2a, 0xbd, 0x66, 0x53, 0x96, 0x30, 0xeb, 0xd9, 0xaf, 0xd2, 0xa3, 0x8d,
+	0x6e, 0x5e, 0x59, 0xe5, 0xfd, 0xbf, 0x07, 0x00, 0xc9, 0x4a, 0x15, 0x92, 0x95, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// JobAdminServiceClient is the client API for JobAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JobAdminServiceClient interface {
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	RequeueJob(ctx context.Context, in *RequeueJobRequest, opts ...grpc.CallOption) (*RequeueJobResponse, error)
+	PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsResponse, error)
+	GetRetention(ctx context.Context, in *GetRetentionRequest, opts ...grpc.CallOption) (*Retention, error)
+}
+
+type jobAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobAdminServiceClient(cc grpc.ClientConnInterface) JobAdminServiceClient {
+	return &jobAdminServiceClient{cc}
+}
+
+func (c *jobAdminServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/workerqueue.v1.JobAdminService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/workerqueue.v1.JobAdminService/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) RequeueJob(ctx context.Context, in *RequeueJobRequest, opts ...grpc.CallOption) (*RequeueJobResponse, error) {
+	out := new(RequeueJobResponse)
+	err := c.cc.Invoke(ctx, "/workerqueue.v1.JobAdminService/RequeueJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsResponse, error) {
+	out := new(PurgeJobsResponse)
+	err := c.cc.Invoke(ctx, "/workerqueue.v1.JobAdminService/PurgeJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobAdminServiceClient) GetRetention(ctx context.Context, in *GetRetentionRequest, opts ...grpc.CallOption) (*Retention, error) {
+	out := new(Retention)
+	err := c.cc.Invoke(ctx, "/workerqueue.v1.JobAdminService/GetRetention", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobAdminServiceServer is the server API for JobAdminService service.
+type JobAdminServiceServer interface {
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	RequeueJob(context.Context, *RequeueJobRequest) (*RequeueJobResponse, error)
+	PurgeJobs(context.Context, *PurgeJobsRequest) (*PurgeJobsResponse, error)
+	GetRetention(context.Context, *GetRetentionRequest) (*Retention, error)
+}
+
+// UnimplementedJobAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedJobAdminServiceServer struct {
+}
+
+func (*UnimplementedJobAdminServiceServer) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (*UnimplementedJobAdminServiceServer) GetJob(ctx context.Context, req *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedJobAdminServiceServer) RequeueJob(ctx context.Context, req *RequeueJobRequest) (*RequeueJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueJob not implemented")
+}
+func (*UnimplementedJobAdminServiceServer) PurgeJobs(ctx context.Context, req *PurgeJobsRequest) (*PurgeJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeJobs not implemented")
+}
+func (*UnimplementedJobAdminServiceServer) GetRetention(ctx context.Context, req *GetRetentionRequest) (*Retention, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRetention not implemented")
+}
+
+func RegisterJobAdminServiceServer(s *grpc.Server, srv JobAdminServiceServer) {
+	s.RegisterService(&_JobAdminService_serviceDesc, srv)
+}
+
+func _JobAdminService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workerqueue.v1.JobAdminService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workerqueue.v1.JobAdminService/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_RequeueJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).RequeueJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workerqueue.v1.JobAdminService/RequeueJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).RequeueJob(ctx, req.(*RequeueJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_PurgeJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).PurgeJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workerqueue.v1.JobAdminService/PurgeJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).PurgeJobs(ctx, req.(*PurgeJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobAdminService_GetRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobAdminServiceServer).GetRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workerqueue.v1.JobAdminService/GetRetention",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobAdminServiceServer).GetRetention(ctx, req.(*GetRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _JobAdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "workerqueue.v1.JobAdminService",
+	HandlerType: (*JobAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListJobs",
+			Handler:    _JobAdminService_ListJobs_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _JobAdminService_GetJob_Handler,
+		},
+		{
+			MethodName: "RequeueJob",
+			Handler:    _JobAdminService_RequeueJob_Handler,
+		},
+		{
+			MethodName: "PurgeJobs",
+			Handler:    _JobAdminService_PurgeJobs_Handler,
+		},
+		{
+			MethodName: "GetRetention",
+			Handler:    _JobAdminService_GetRetention_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "workerqueue.proto",
+}
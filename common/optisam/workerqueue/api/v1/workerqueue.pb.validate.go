@@ -0,0 +1,897 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: workerqueue.proto
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _workerqueue_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on ListJobsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ListJobsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetQueue()) < 1 {
+		return ListJobsRequestValidationError{
+			field:  "Queue",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if _, ok := _ListJobsRequest_Status_InLookup[m.GetStatus()]; !ok {
+		return ListJobsRequestValidationError{
+			field:  "Status",
+			reason: "value must be in list [ PENDING RUNNING RETRY COMPLETED FAILED CANCELLED DEAD]",
+		}
+	}
+
+	// no validation rules for Type
+
+	if val := m.GetPageNum(); val < 1 || val >= 1000 {
+		return ListJobsRequestValidationError{
+			field:  "PageNum",
+			reason: "value must be inside range [1, 1000)",
+		}
+	}
+
+	if val := m.GetPageSize(); val < 10 || val > 100 {
+		return ListJobsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [10, 100]",
+		}
+	}
+
+	return nil
+}
+
+// ListJobsRequestValidationError is the validation error returned by
+// ListJobsRequest.Validate if the designated constraints aren't met.
+type ListJobsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListJobsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListJobsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListJobsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListJobsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListJobsRequestValidationError) ErrorName() string { return "ListJobsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListJobsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListJobsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListJobsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListJobsRequestValidationError{}
+
+var _ListJobsRequest_Status_InLookup = map[string]struct{}{
+	"":          {},
+	"PENDING":   {},
+	"RUNNING":   {},
+	"RETRY":     {},
+	"COMPLETED": {},
+	"FAILED":    {},
+	"CANCELLED": {},
+	"DEAD":      {},
+}
+
+// Validate checks the field values on ListJobsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ListJobsResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for TotalRecords
+
+	for idx, item := range m.GetJobs() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListJobsResponseValidationError{
+					field:  fmt.Sprintf("Jobs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ListJobsResponseValidationError is the validation error returned by
+// ListJobsResponse.Validate if the designated constraints aren't met.
+type ListJobsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListJobsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListJobsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListJobsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListJobsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListJobsResponseValidationError) ErrorName() string { return "ListJobsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListJobsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListJobsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListJobsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListJobsResponseValidationError{}
+
+// Validate checks the field values on Job with the rules defined in the proto
+// definition for this message. If any rules are violated, an error is returned.
+func (m *Job) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for JobId
+
+	// no validation rules for Type
+
+	// no validation rules for Status
+
+	// no validation rules for Data
+
+	// no validation rules for RetryCount
+
+	// no validation rules for LastError
+
+	if v, ok := interface{}(m.GetCreatedOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobValidationError{
+				field:  "CreatedOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetStartedOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobValidationError{
+				field:  "StartedOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetEndedOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobValidationError{
+				field:  "EndedOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for LeaseOwner
+
+	if v, ok := interface{}(m.GetLeaseExpiresOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return JobValidationError{
+				field:  "LeaseExpiresOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// JobValidationError is the validation error returned by Job.Validate if the
+// designated constraints aren't met.
+type JobValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JobValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JobValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JobValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JobValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JobValidationError) ErrorName() string { return "JobValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JobValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJob.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JobValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JobValidationError{}
+
+// Validate checks the field values on GetJobRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *GetJobRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetQueue()) < 1 {
+		return GetJobRequestValidationError{
+			field:  "Queue",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetJobId() <= 0 {
+		return GetJobRequestValidationError{
+			field:  "JobId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// GetJobRequestValidationError is the validation error returned by
+// GetJobRequest.Validate if the designated constraints aren't met.
+type GetJobRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetJobRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetJobRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetJobRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetJobRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetJobRequestValidationError) ErrorName() string { return "GetJobRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetJobRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetJobRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetJobRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetJobRequestValidationError{}
+
+// Validate checks the field values on RequeueJobRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *RequeueJobRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetQueue()) < 1 {
+		return RequeueJobRequestValidationError{
+			field:  "Queue",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetJobId() <= 0 {
+		return RequeueJobRequestValidationError{
+			field:  "JobId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// RequeueJobRequestValidationError is the validation error returned by
+// RequeueJobRequest.Validate if the designated constraints aren't met.
+type RequeueJobRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequeueJobRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequeueJobRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequeueJobRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequeueJobRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequeueJobRequestValidationError) ErrorName() string {
+	return "RequeueJobRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequeueJobRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequeueJobRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequeueJobRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequeueJobRequestValidationError{}
+
+// Validate checks the field values on RequeueJobResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *RequeueJobResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Success
+
+	return nil
+}
+
+// RequeueJobResponseValidationError is the validation error returned by
+// RequeueJobResponse.Validate if the designated constraints aren't met.
+type RequeueJobResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequeueJobResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequeueJobResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequeueJobResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequeueJobResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequeueJobResponseValidationError) ErrorName() string {
+	return "RequeueJobResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequeueJobResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequeueJobResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequeueJobResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequeueJobResponseValidationError{}
+
+// Validate checks the field values on PurgeJobsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *PurgeJobsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetQueue()) < 1 {
+		return PurgeJobsRequestValidationError{
+			field:  "Queue",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if _, ok := _PurgeJobsRequest_Status_InLookup[m.GetStatus()]; !ok {
+		return PurgeJobsRequestValidationError{
+			field:  "Status",
+			reason: "value must be in list [COMPLETED FAILED CANCELLED DEAD]",
+		}
+	}
+
+	// no validation rules for Type
+
+	return nil
+}
+
+// PurgeJobsRequestValidationError is the validation error returned by
+// PurgeJobsRequest.Validate if the designated constraints aren't met.
+type PurgeJobsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeJobsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeJobsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeJobsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeJobsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeJobsRequestValidationError) ErrorName() string { return "PurgeJobsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PurgeJobsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeJobsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeJobsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeJobsRequestValidationError{}
+
+var _PurgeJobsRequest_Status_InLookup = map[string]struct{}{
+	"COMPLETED": {},
+	"FAILED":    {},
+	"CANCELLED": {},
+	"DEAD":      {},
+}
+
+// Validate checks the field values on PurgeJobsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *PurgeJobsResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Purged
+
+	return nil
+}
+
+// PurgeJobsResponseValidationError is the validation error returned by
+// PurgeJobsResponse.Validate if the designated constraints aren't met.
+type PurgeJobsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeJobsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeJobsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeJobsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeJobsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeJobsResponseValidationError) ErrorName() string {
+	return "PurgeJobsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PurgeJobsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeJobsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeJobsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeJobsResponseValidationError{}
+
+// Validate checks the field values on GetRetentionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *GetRetentionRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetQueue()) < 1 {
+		return GetRetentionRequestValidationError{
+			field:  "Queue",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	return nil
+}
+
+// GetRetentionRequestValidationError is the validation error returned by
+// GetRetentionRequest.Validate if the designated constraints aren't met.
+type GetRetentionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRetentionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRetentionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRetentionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRetentionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRetentionRequestValidationError) ErrorName() string {
+	return "GetRetentionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRetentionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRetentionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRetentionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRetentionRequestValidationError{}
+
+// Validate checks the field values on Retention with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Retention) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for CompletedDays
+
+	// no validation rules for FailedDays
+
+	// no validation rules for Archive
+
+	if v, ok := interface{}(m.GetLastSweptOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RetentionValidationError{
+				field:  "LastSweptOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for LastSwept
+
+	// no validation rules for TotalArchived
+
+	// no validation rules for TotalDeleted
+
+	// no validation rules for ArchivedJobs
+
+	return nil
+}
+
+// RetentionValidationError is the validation error returned by
+// Retention.Validate if the designated constraints aren't met.
+type RetentionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RetentionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RetentionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RetentionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RetentionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RetentionValidationError) ErrorName() string { return "RetentionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RetentionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRetention.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RetentionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RetentionValidationError{}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"errors"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"time"
+)
+
+//ErrNoJob is given by a backend having no due job to claim
+var ErrNoJob = errors.New("workerqueue: no job to claim")
+
+//Backend keeps the jobs of a queue. Workers claim their jobs from it by type, which is the id of worker,
+//and give them back once done: acknowledged when they have succeeded or not acknowledged otherwise.
+//Queues of NewQueue keep their jobs in postgres, NewMemoryBackend keeps them in memory.
+type Backend interface {
+	//Enqueue adds jobs to be claimed once runAfter is reached, at once when it is zero, and gives one id per job in order of jobs.
+	//A job having the idempotency key of a job of its type to run, or recently completed, is a duplicate which is not created, the id of this job is given for it.
+	Enqueue(ctx context.Context, jobs []job.Job, runAfter time.Time) ([]int32, error)
+	//Claim gives the next due job of a type, by priority, to owner until it is given back. ErrNoJob is given when there is none
+	Claim(ctx context.Context, jobType, owner string, lease time.Duration) (*job.Job, error)
+	//Ack gives back a claimed job which is done, it is completed
+	Ack(ctx context.Context, j *job.Job, owner string) error
+	//Nack gives back a claimed job which is not done
+	Nack(ctx context.Context, j *job.Job, owner string, n Nack) error
+	//List gives the jobs matching filter, most recent first
+	List(ctx context.Context, f ListFilter) ([]job.Job, error)
+}
+
+//Nack tells why a job is given back without being done
+type Nack struct {
+	//Err is the error of worker. Job is given back without an attempt counted when it is nil, eg: when it is over its concurrency limit or interrupted
+	Err error
+	//RunAfter is the time job can be claimed again, at once when it is zero. A failed job is dead when it is zero
+	RunAfter time.Time
+}
+
+//ListFilter selects the jobs of List
+type ListFilter struct {
+	//Type of jobs, all types when empty
+	Type string
+	//Status of jobs, all status when empty
+	Status job.JobStatus
+	//IdempotencyKey selects the job to run, or recently completed, having this key
+	IdempotencyKey string
+	//Limit is the max number of jobs given, all jobs when 0
+	Limit int32
+	//Offset is the number of jobs skipped
+	Offset int32
+}
+
+//leaser is a backend whose claims expire, eg: so that jobs of a crashed replica are claimed again.
+//Claims of running jobs are renewed until they end, a job whose claim is lost is left to its new owner.
+type leaser interface {
+	Renew(ctx context.Context, jobID int32, owner string, lease time.Duration) (bool, error)
+}
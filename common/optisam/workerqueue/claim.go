@@ -0,0 +1,129 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue/job"
+	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"optisam-backend/common/optisam/workerqueue/worker"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//claimJobs is the main loop of a worker claiming its jobs from backend.
+//A job is claimed by one replica only, jobs whose lease has expired are claimed again.
+func (q *Queue) claimJobs(ctx context.Context, w worker.Worker) {
+	defer q.wg.Done()
+	logger.Log.Info("Starting up new claiming worker...", zap.String("worker", w.ID()), zap.String("owner", q.owner))
+	for {
+		if q.draining() {
+			logger.Log.Info("Queue is drained, worker stops claiming jobs. Exiting.", zap.String("worker", w.ID()))
+			return
+		}
+		j, err := q.backend.Claim(ctx, w.ID(), q.owner, q.lease)
+		switch {
+		case err == nil:
+			if q.runLeased(ctx, w, j) {
+				continue
+			}
+			//job is over concurrency limit, next claim waits for running ones
+		case err == ErrNoJob:
+		case ctx.Err() == nil:
+			logger.Log.Error("Failed to claim job", zap.String("worker", w.ID()), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			logger.Log.Info("Received signal to shutdown worker. Exiting.")
+			return
+		case <-q.stopped():
+		case <-time.After(q.PollRate):
+		}
+	}
+}
+
+//runLeased runs a claimed job while renewing its lease. Work is cancelled if lease is lost,
+//eg: job is cancelled or it has been claimed by another replica after an expiry.
+//A job interrupted by a drain is put back pending for another replica.
+//A job over concurrency limit is deferred instead, false is given then.
+func (q *Queue) runLeased(ctx context.Context, w worker.Worker, claimed *job.Job) bool {
+	logger.Log.Info("", zap.Int32("Claimed Job", claimed.JobID), zap.String("Picked By worker", w.ID()))
+	freeSlot, ok := q.acquire(w, claimed)
+	if !ok {
+		q.deferJob(ctx, claimed)
+		return false
+	}
+	defer freeSlot()
+	workCtx, cancel := q.workContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if l, ok := q.backend.(leaser); ok {
+			q.renewLease(workCtx, cancel, l, claimed.JobID)
+		}
+	}()
+	spanCtx, span := q.startJobSpan(workCtx, claimed)
+	started := time.Now()
+	err := w.DoWork(spanCtx, claimed)
+	endJobSpan(span, err)
+	interrupted := q.interrupted()
+	lost := workCtx.Err() != nil && ctx.Err() == nil && !interrupted
+	cancel()
+	<-done
+	if err != nil && interrupted {
+		q.interruptJob(ctx, claimed)
+		return true
+	}
+	if lost {
+		logger.Log.Error("Lease of job is lost, job is left to its new owner", zap.Int32("jobID", claimed.JobID))
+		return true
+	}
+	status := dbgen.JobStatusCOMPLETED
+	switch {
+	case err == nil:
+		logger.Log.Info("Worker", zap.Int32("Job Processed", claimed.JobID))
+		err = q.backend.Ack(ctx, claimed, q.owner)
+	case claimed.RetryCount.Int32 < int32(q.retries) && !job.IsPermanent(err):
+		logger.Log.Error("Retry error received from worker retrying ", zap.Error(err), zap.Int32("jobID", claimed.JobID), zap.Int32("retryCount", claimed.RetryCount.Int32+1))
+		status = dbgen.JobStatusRETRY
+		err = q.backend.Nack(ctx, claimed, q.owner, Nack{Err: err, RunAfter: q.retryAt(claimed.RetryCount.Int32).Time})
+	default:
+		logger.Log.Error("Retries execceded for ", zap.Int32("jobId", claimed.JobID), zap.Bool("permanent", job.IsPermanent(err)), zap.Error(err))
+		status = dbgen.JobStatusDEAD
+		err = q.backend.Nack(ctx, claimed, q.owner, Nack{Err: err})
+	}
+	q.observeJob(ctx, claimed.Type.String, started, status)
+	if err != nil {
+		logger.Log.Error("Failed to Update job", zap.Int32("jobID", claimed.JobID), zap.Error(err))
+	}
+	return true
+}
+
+//renewLease renews the lease of a job until ctx is done, lost is called once the job is not leased to this replica anymore
+func (q *Queue) renewLease(ctx context.Context, lost context.CancelFunc, l leaser, jobID int32) {
+	ticker := time.NewTicker(time.Duration(leaseSeconds(q.lease)) * time.Second / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		leased, err := l.Renew(ctx, jobID, q.owner, q.lease)
+		if err != nil {
+			//lease is still valid for a while, renewal is tried again on next tick
+			logger.Log.Error("Failed to renew lease of job", zap.Int32("jobID", jobID), zap.Error(err))
+			continue
+		}
+		if !leased {
+			lost()
+			return
+		}
+	}
+}
@@ -12,5 +12,42 @@ type QueueConfig struct {
 	Qsize       int
 	PollingRate time.Duration
 	Retries     int
-	BaseDelay   time.Duration
+	//BaseDelay is the delay before first retry of a failed job, it doubles on each retry up to MaxDelay
+	BaseDelay time.Duration
+	//MaxDelay caps the delay between two retries of a job
+	MaxDelay time.Duration
+	//Jitter is the fraction of a retry delay picked at random and taken off it, so that jobs failing together are not retried together
+	Jitter float64
+	//ClaimJobs makes workers lease their jobs from database instead of being notified,
+	//many replicas of a service can then share the same jobs table
+	ClaimJobs bool
+	//LeaseDuration is the time a claimed job is leased to a replica, it is renewed while job runs.
+	//Jobs of a crashed replica are claimed again once their lease expires
+	LeaseDuration time.Duration
+	//ReplicaID identifies the replica owning leases, hostname-pid by default
+	ReplicaID string
+	//Concurrency limits the jobs of a worker type running at once on a replica, by id of worker.
+	//Workers giving a concurrency key to their jobs are limited per key, eg: per target service of a job
+	Concurrency map[string]int
+	//MetricsRate is the duration between two counts of jobs in queue by type and status, they are exported as gauges
+	MetricsRate time.Duration
+	//IdempotencyWindow is the time a completed job keeps a job having its idempotency key from being pushed again, 1h by default.
+	//Jobs to run keep their duplicates from being pushed until they end
+	IdempotencyWindow time.Duration
+	//DrainTimeout is the time running jobs are given to end on shutdown before they are interrupted and put back in queue, 30s by default
+	DrainTimeout time.Duration
+	//Retention tells how long ended jobs are kept, they are kept forever by default
+	Retention RetentionConfig
+}
+
+//RetentionConfig is the policy of a queue on its ended jobs, jobs past their retention are swept periodically
+type RetentionConfig struct {
+	//CompletedDays is the number of days completed jobs are kept after they end, forever when 0
+	CompletedDays int
+	//FailedDays is the number of days failed, dead and cancelled jobs are kept after they end, forever when 0
+	FailedDays int
+	//Archive moves expired jobs to the jobs_archive table instead of deleting them
+	Archive bool
+	//SweepRate is the duration between two sweeps of expired jobs, 1h by default
+	SweepRate time.Duration
 }
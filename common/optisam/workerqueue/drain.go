@@ -0,0 +1,129 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"errors"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//interruptTimeout is the time given to interrupted jobs to return from their work and be put back in queue
+const interruptTimeout = 10 * time.Second
+
+//ErrStopped is given by PushJob once the queue is drained, see Shutdown
+var ErrStopped = errors.New("workerqueue: queue is stopped")
+
+//drain stops a queue from taking jobs and gives its running jobs time to end
+type drain struct {
+	//timeout is the time running jobs are given to end before they are interrupted
+	timeout       time.Duration
+	stopOnce      sync.Once
+	interruptOnce sync.Once
+	//stop is closed once workers stop taking jobs
+	stop chan struct{}
+	//interrupt is closed once running jobs are interrupted
+	interrupt chan struct{}
+}
+
+func newDrain(timeout time.Duration) *drain {
+	return &drain{timeout: timeout, stop: make(chan struct{}), interrupt: make(chan struct{})}
+}
+
+//Shutdown drains the queue: workers stop taking jobs and running jobs are given the drain timeout to end.
+//Jobs still running then are interrupted, the context of their work is cancelled and they are put back pending
+//so that they are run again on next start or by another replica. Jobs waiting in queue are left pending.
+//Shutdown gives an error when jobs have not returned once interrupted or ctx is done before.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	if q.drain == nil {
+		return nil
+	}
+	logger.Log.Info("Draining worker queue", zap.String("queue", q.ID), zap.Duration("timeout", q.drain.timeout))
+	q.drain.stopOnce.Do(func() { close(q.drain.stop) })
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logger.Log.Info("Worker queue drained", zap.String("queue", q.ID))
+		return nil
+	case <-ctx.Done():
+	case <-time.After(q.drain.timeout):
+	}
+	logger.Log.Info("Interrupting running jobs", zap.String("queue", q.ID))
+	q.drain.interruptOnce.Do(func() { close(q.drain.interrupt) })
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interruptTimeout):
+		return errors.New("workerqueue: running jobs have not returned once interrupted")
+	}
+}
+
+//draining tells if workers have been asked to stop taking jobs
+func (q *Queue) draining() bool {
+	select {
+	case <-q.stopped():
+		return true
+	default:
+		return false
+	}
+}
+
+//stopped gives the channel closed once workers stop taking jobs, it is never closed for a queue without drain
+func (q *Queue) stopped() <-chan struct{} {
+	if q.drain == nil {
+		return nil
+	}
+	return q.drain.stop
+}
+
+//interrupted tells if running jobs have been interrupted by a drain
+func (q *Queue) interrupted() bool {
+	if q.drain == nil {
+		return false
+	}
+	select {
+	case <-q.drain.interrupt:
+		return true
+	default:
+		return false
+	}
+}
+
+//workContext gives the context of work on a job, it is cancelled with ctx or when drain interrupts running jobs
+func (q *Queue) workContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if q.drain == nil {
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-q.drain.interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+//interruptJob puts back a job interrupted by a drain in queue as pending, its attempt is not counted as a retry
+func (q *Queue) interruptJob(ctx context.Context, j *job.Job) {
+	logger.Log.Info("Job interrupted by shutdown, it is put back in queue", zap.Int32("jobID", j.JobID))
+	if err := q.backend.Nack(ctx, j, q.owner, Nack{}); err != nil {
+		logger.Log.Error("Failed to put back interrupted job", zap.Int32("jobID", j.JobID), zap.Error(err))
+	}
+}
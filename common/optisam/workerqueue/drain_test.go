@@ -0,0 +1,131 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"optisam-backend/common/optisam/workerqueue/worker"
+	workermock "optisam-backend/common/optisam/workerqueue/worker/mock"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestQueue_Shutdown(t *testing.T) {
+	var mockRepo *mock.MockWorkerqueue
+	var mockworker *workermock.MockWorker
+	tests := []struct {
+		name  string
+		claim bool
+		//setup expects the work on job 1, started is closed once it runs
+		setup func(started chan struct{})
+	}{
+		{
+			name: "SUCCESS - running job ends before drain timeout",
+			setup: func(started chan struct{}) {
+				mockRepo.EXPECT().GetJob(gomock.Any(), int32(1)).Return(db.Job{JobID: 1, Type: "w", Status: db.JobStatusPENDING}, nil)
+				mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *job.Job) error {
+					close(started)
+					time.Sleep(50 * time.Millisecond)
+					return ctx.Err()
+				})
+				mockRepo.EXPECT().UpdateJobStatusCompleted(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "SUCCESS - job running after drain timeout is interrupted and put back pending",
+			setup: func(started chan struct{}) {
+				mockRepo.EXPECT().GetJob(gomock.Any(), int32(1)).Return(db.Job{JobID: 1, Type: "w", Status: db.JobStatusRETRY, RetryCount: sql.NullInt32{Int32: 1, Valid: true}}, nil)
+				mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *job.Job) error {
+					close(started)
+					<-ctx.Done()
+					return ctx.Err()
+				})
+				mockRepo.EXPECT().InterruptJob(gomock.Any(), db.InterruptJobParams{JobID: 1, LeaseOwner: sql.NullString{String: "r1", Valid: true}}).Return(nil)
+			},
+		},
+		{
+			name:  "SUCCESS - claimed job running after drain timeout is interrupted and put back pending",
+			claim: true,
+			setup: func(started chan struct{}) {
+				mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(db.Job{JobID: 1, Type: "w", Status: db.JobStatusRUNNING}, nil)
+				mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *job.Job) error {
+					close(started)
+					<-ctx.Done()
+					return ctx.Err()
+				})
+				mockRepo.EXPECT().InterruptJob(gomock.Any(), db.InterruptJobParams{JobID: 1, LeaseOwner: sql.NullString{String: "r1", Valid: true}}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo = mock.NewMockWorkerqueue(mockCtrl)
+			mockworker = workermock.NewMockWorker(mockCtrl)
+			mockworker.EXPECT().ID().AnyTimes().Return("w")
+			started := make(chan struct{})
+			tt.setup(started)
+			q := &Queue{
+				repo:     mockRepo,
+				notifier: make(chan jobChan, 2),
+				workers:  make(map[string][]worker.Worker),
+				wg:       &sync.WaitGroup{},
+				PollRate: 10 * time.Millisecond,
+				claim:    tt.claim,
+				lease:    time.Minute,
+				owner:    "r1",
+				drain:    newDrain(100 * time.Millisecond),
+			}
+			q.backend = newPostgresBackend(q)
+			q.notifier <- jobChan{1, "w"}
+			q.RegisterWorker(context.Background(), mockworker)
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("job is not run")
+			}
+			//job 2 is waiting in queue when drain starts, it is left pending
+			q.notifier <- jobChan{2, "w"}
+			if err := q.Shutdown(context.Background()); err != nil {
+				t.Errorf("Queue.Shutdown() error = %v", err)
+			}
+			if !tt.claim && len(q.notifier) != 1 {
+				t.Errorf("Queue.Shutdown() jobs waiting in queue = %d, want 1", len(q.notifier))
+			}
+		})
+	}
+}
+
+func TestQueue_Close(t *testing.T) {
+	q := &Queue{
+		notifier: make(chan jobChan, 2),
+		urgent:   make(chan jobChan, 2),
+		wg:       &sync.WaitGroup{},
+		drain:    newDrain(10 * time.Millisecond),
+	}
+	q.backend = NewMemoryBackend()
+	//a sender still running once drain has timed out
+	q.wg.Add(1)
+	defer q.wg.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	q.Close(ctx)
+	if _, err := q.PushJob(context.Background(), typed("w", 1, ""), "w"); err != ErrStopped {
+		t.Errorf("Queue.PushJob() error = %v, want ErrStopped", err)
+	}
+	//notifiers are left open for the senders still running
+	q.notifier <- jobChan{1, "w"}
+	q.urgent <- jobChan{2, "w"}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+)
+
+//IdempotencyKeyHeader is the grpc metadata carrying the idempotency key of the job of a caller
+//so that jobs pushed by callee while serving it are not duplicated when the job is retried
+const IdempotencyKeyHeader = "x-idempotency-key"
+
+//WithIdempotencyKey gives a context sending the idempotency key of a job to the services called with it
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, IdempotencyKeyHeader, key)
+}
+
+//IdempotencyKey gives the idempotency key sent by caller, empty when there is none
+func IdempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	keys := md.Get(IdempotencyKeyHeader)
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+//JobIdempotencyKey gives the idempotency key of a job pushed while serving ctx with data,
+//the key sent by caller when there is one, else one derived from data so that a retried call
+//pushing the same data does not push its job twice
+func JobIdempotencyKey(ctx context.Context, data []byte) string {
+	if key := IdempotencyKey(ctx); key != "" {
+		return key
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestQueue_PushJob_idempotencyKey(t *testing.T) {
+	var mockRepo *mock.MockWorkerqueue
+	key := sql.NullString{String: "1:abc", Valid: true}
+	tests := []struct {
+		name       string
+		setup      func()
+		wantID     int32
+		wantNotify bool
+		wantErr    bool
+	}{
+		{
+			name: "SUCCESS - job with a new key is created as a due job",
+			setup: func() {
+				mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+					want := time.Now().Add(-time.Hour)
+					if len(arg.IdempotencyKeys) != 1 || arg.IdempotencyKeys[0] != key.String || arg.Types[0] != "aw" || !arg.Due {
+						t.Errorf("Queue.PushJob() created job %+v, want job with key %v", arg, key)
+					}
+					if arg.CompletedAfter.After(want) || arg.CompletedAfter.Before(want.Add(-time.Minute)) {
+						t.Errorf("Queue.PushJob() completed jobs are duplicates after %v, want an hour ago", arg.CompletedAfter)
+					}
+					return []db.CreateJobsRow{{JobID: 2, Type: "aw", IdempotencyKey: key}}, nil
+				})
+			},
+			wantID: 2,
+		},
+		{
+			name: "SUCCESS - duplicate of a job in queue gives its id and is not notified",
+			setup: func() {
+				gomock.InOrder(
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Return([]db.CreateJobsRow{}, nil),
+					mockRepo.EXPECT().ListJobsByIdempotencyKeys(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.ListJobsByIdempotencyKeysParams) ([]db.ListJobsByIdempotencyKeysRow, error) {
+						if len(arg.Types) != 1 || arg.Types[0] != "aw" || arg.IdempotencyKeys[0] != key.String {
+							t.Errorf("Queue.PushJob() looked up %+v", arg)
+						}
+						return []db.ListJobsByIdempotencyKeysRow{{JobID: 1, Type: "aw", IdempotencyKey: key}}, nil
+					}),
+				)
+			},
+			wantID: 1,
+		},
+		{
+			name: "FAILURE - job is not pushed",
+			setup: func() {
+				mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1), idempotencyWindow: time.Hour}
+			q.backend = newPostgresBackend(q)
+			got, err := q.PushJob(context.Background(), job.Job{Type: sql.NullString{String: "aw", Valid: true}, Data: []byte(`{}`), IdempotencyKey: key.String}, "aw")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Queue.PushJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantID {
+				t.Errorf("Queue.PushJob() = %d, want %d", got, tt.wantID)
+			}
+			if notified := len(q.notifier) == 1; notified != tt.wantNotify {
+				t.Errorf("Queue.PushJob() notified = %v, want %v", notified, tt.wantNotify)
+			}
+		})
+	}
+}
+
+func TestQueue_PushJobs_idempotencyKeys(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	jobs := []job.Job{
+		{Type: sql.NullString{String: "aw", Valid: true}, Data: []byte(`{}`), IdempotencyKey: "1:a"},
+		{Type: sql.NullString{String: "aw", Valid: true}, Data: []byte(`{}`)},
+	}
+	mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+		if len(arg.IdempotencyKeys) != 2 || arg.IdempotencyKeys[0] != "1:a" || arg.IdempotencyKeys[1] != "" {
+			t.Errorf("Queue.PushJobs() keys = %v, want [1:a ]", arg.IdempotencyKeys)
+		}
+		if arg.CompletedAfter.After(time.Now().Add(-time.Hour)) {
+			t.Errorf("Queue.PushJobs() completed jobs are duplicates after %v, want an hour ago", arg.CompletedAfter)
+		}
+		//first job is a duplicate
+		return []db.CreateJobsRow{{JobID: 2, Type: "aw"}}, nil
+	})
+	mockRepo.EXPECT().ListJobsByIdempotencyKeys(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.ListJobsByIdempotencyKeysParams) ([]db.ListJobsByIdempotencyKeysRow, error) {
+		if len(arg.IdempotencyKeys) != 1 || arg.IdempotencyKeys[0] != "1:a" || arg.Types[0] != "aw" {
+			t.Errorf("Queue.PushJobs() looked up keys %v of %v, want [1:a] of [aw]", arg.IdempotencyKeys, arg.Types)
+		}
+		return []db.ListJobsByIdempotencyKeysRow{{JobID: 1, Type: "aw", IdempotencyKey: sql.NullString{String: "1:a", Valid: true}}}, nil
+	})
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 10), idempotencyWindow: time.Hour}
+	q.backend = newPostgresBackend(q)
+	got, err := q.PushJobs(context.Background(), jobs, "aw")
+	if err != nil || len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Queue.PushJobs() = %v, %v, want the id of duplicate and of created job [1 2]", got, err)
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "SUCCESS - key of caller is given to callee", key: "1:abc"},
+		{name: "SUCCESS - caller without key sends none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _ := metadata.FromOutgoingContext(WithIdempotencyKey(context.Background(), tt.key))
+			ctx := metadata.NewIncomingContext(context.Background(), out)
+			if got := IdempotencyKey(ctx); got != tt.key {
+				t.Errorf("IdempotencyKey() = %q, want %q", got, tt.key)
+			}
+		})
+	}
+}
+
+func TestJobIdempotencyKey(t *testing.T) {
+	data := []byte(`{"type":"UpsertProduct"}`)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		data []byte
+		want string
+	}{
+		{name: "SUCCESS - key of caller is kept",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, "1:abc")),
+			data: data,
+			want: "1:abc",
+		},
+		{name: "SUCCESS - key is derived from data without caller key",
+			ctx:  context.Background(),
+			data: data,
+			want: JobIdempotencyKey(context.Background(), []byte(`{"type":"UpsertProduct"}`)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JobIdempotencyKey(tt.ctx, tt.data); got != tt.want {
+				t.Errorf("JobIdempotencyKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if JobIdempotencyKey(context.Background(), data) == JobIdempotencyKey(context.Background(), []byte(`{}`)) {
+		t.Errorf("JobIdempotencyKey() jobs of different data have the same key")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue/job"
+	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+	"go.uber.org/zap"
+)
+
+//Measures of jobs
+var (
+	MJobs       = stats.Int64("workerqueue/jobs", "Number of jobs in queue", stats.UnitDimensionless)
+	MJobLatency = stats.Float64("workerqueue/job_latency", "Time spent working on a job", stats.UnitMilliseconds)
+	MJobRetries = stats.Int64("workerqueue/job_retries", "Number of failed attempts of jobs having retries left", stats.UnitDimensionless)
+)
+
+//Tags of job measures
+var (
+	KeyQueue     = tag.MustNewKey("queue")
+	KeyJobType   = tag.MustNewKey("job_type")
+	KeyJobStatus = tag.MustNewKey("job_status")
+)
+
+//Views of job measures
+var (
+	JobsView = &view.View{
+		Name:        "workerqueue/jobs",
+		Description: "Number of pending, retried, running and dead jobs by type",
+		Measure:     MJobs,
+		TagKeys:     []tag.Key{KeyQueue, KeyJobType, KeyJobStatus},
+		Aggregation: view.LastValue(),
+	}
+	JobLatencyView = &view.View{
+		Name:        "workerqueue/job_latency",
+		Description: "Distribution of time spent working on jobs by type and outcome",
+		Measure:     MJobLatency,
+		TagKeys:     []tag.Key{KeyQueue, KeyJobType, KeyJobStatus},
+		Aggregation: view.Distribution(1, 5, 10, 50, 100, 500, 1000, 5000, 10000, 30000, 60000, 300000),
+	}
+	JobRetriesView = &view.View{
+		Name:        "workerqueue/job_retries",
+		Description: "Number of retries of jobs by type",
+		Measure:     MJobRetries,
+		TagKeys:     []tag.Key{KeyQueue, KeyJobType},
+		Aggregation: view.Count(),
+	}
+)
+
+//DefaultViews are the views of job measures, services register them along with their server views
+var DefaultViews = []*view.View{JobsView, JobLatencyView, JobRetriesView}
+
+type jobCount struct {
+	jobType string
+	status  dbgen.JobStatus
+}
+
+//recordJobs records the number of jobs waiting, running or dead in queue by type.
+//Jobs of all replicas are counted as they share the jobs table.
+func (q *Queue) recordJobs(ctx context.Context) {
+	defer q.wg.Done()
+	seen := make(map[jobCount]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stopped():
+			return
+		case <-time.After(q.metricsRate):
+		}
+		q.recordCounts(ctx, seen)
+	}
+}
+
+//recordCounts records the current number of jobs, gauges of the types and status seen before and gone now are reset
+func (q *Queue) recordCounts(ctx context.Context, seen map[jobCount]bool) {
+	counts, err := q.repo.CountJobs(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Log.Error("Failed to count jobs", zap.Error(err))
+		}
+		return
+	}
+	current := make(map[jobCount]int64, len(counts))
+	for _, c := range counts {
+		current[jobCount{c.Type, c.Status}] = c.Total
+	}
+	for c := range seen {
+		if _, found := current[c]; !found {
+			current[c] = 0
+		}
+	}
+	for c, total := range current {
+		seen[c] = true
+		q.record(ctx, c.jobType, c.status, MJobs.M(total))
+	}
+}
+
+//observeJob records the time spent working on a job and its outcome
+func (q *Queue) observeJob(ctx context.Context, jobType string, started time.Time, status dbgen.JobStatus) {
+	ms := []stats.Measurement{MJobLatency.M(float64(time.Since(started)) / float64(time.Millisecond))}
+	if status == dbgen.JobStatusRETRY {
+		ms = append(ms, MJobRetries.M(1))
+	}
+	q.record(ctx, jobType, status, ms...)
+}
+
+func (q *Queue) record(ctx context.Context, jobType string, status dbgen.JobStatus, ms ...stats.Measurement) {
+	err := stats.RecordWithTags(ctx, []tag.Mutator{
+		tag.Upsert(KeyQueue, q.ID),
+		tag.Upsert(KeyJobType, jobType),
+		tag.Upsert(KeyJobStatus, string(status)),
+	}, ms...)
+	if err != nil {
+		logger.Log.Error("Failed to record job metrics", zap.String("type", jobType), zap.Error(err))
+	}
+}
+
+//traceContext gives the span context of a pusher to be kept with its jobs, nil when it is not traced
+func traceContext(ctx context.Context) []byte {
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return nil
+	}
+	return propagation.Binary(span.SpanContext())
+}
+
+//startJobSpan starts the span of work on a job, as a child of the span job was pushed in when there is one.
+//Workers calling other services with ctx carry on the trace, eg: from an upload to the services it feeds.
+func (q *Queue) startJobSpan(ctx context.Context, j *job.Job) (context.Context, *trace.Span) {
+	name := "workerqueue/" + j.Type.String
+	var span *trace.Span
+	if parent, ok := propagation.FromBinary(j.TraceContext); ok {
+		ctx, span = trace.StartSpanWithRemoteParent(ctx, name, parent)
+	} else {
+		ctx, span = trace.StartSpan(ctx, name)
+	}
+	span.AddAttributes(
+		trace.StringAttribute("queue", q.ID),
+		trace.Int64Attribute("job_id", int64(j.JobID)),
+		trace.Int64Attribute("retry_count", int64(j.RetryCount.Int32)),
+	)
+	return ctx, span
+}
+
+//endJobSpan ends the span of work on a job with the error of worker
+func endJobSpan(span *trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.End()
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+)
+
+func TestQueue_recordCounts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().CountJobs(gomock.Any()).Return([]db.CountJobsRow{
+			{Type: "aw", Status: db.JobStatusPENDING, Total: 3},
+			{Type: "aw", Status: db.JobStatusDEAD, Total: 1},
+		}, nil),
+		mockRepo.EXPECT().CountJobs(gomock.Any()).Return([]db.CountJobsRow{
+			{Type: "aw", Status: db.JobStatusPENDING, Total: 2},
+		}, nil),
+	)
+	if err := view.Register(JobsView); err != nil {
+		t.Fatalf("view.Register() error = %v", err)
+	}
+	defer view.Unregister(JobsView)
+	q := &Queue{ID: "count-queue", repo: mockRepo}
+	seen := make(map[jobCount]bool)
+	q.recordCounts(context.Background(), seen)
+	q.recordCounts(context.Background(), seen)
+	rows, err := view.RetrieveData(JobsView.Name)
+	if err != nil {
+		t.Fatalf("view.RetrieveData() error = %v", err)
+	}
+	want := map[string]float64{"PENDING": 2, "DEAD": 0}
+	got := make(map[string]float64)
+	for _, row := range rows {
+		tags := make(map[tag.Key]string)
+		for _, tg := range row.Tags {
+			tags[tg.Key] = tg.Value
+		}
+		if tags[KeyQueue] != q.ID || tags[KeyJobType] != "aw" {
+			continue
+		}
+		got[tags[KeyJobStatus]] = row.Data.(*view.LastValueData).Value
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Queue.recordCounts() gauges = %v, want %v", got, want)
+	}
+	for status, value := range want {
+		if got[status] != value {
+			t.Errorf("Queue.recordCounts() %v jobs = %v, want %v", status, got[status], value)
+		}
+	}
+}
+
+func TestQueue_PushJob_traceContext(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	ctx, span := trace.StartSpan(context.Background(), "upload", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	mockRepo.EXPECT().CreateJob(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobParams) (int32, error) {
+		sc, ok := propagation.FromBinary(arg.TraceContext)
+		if !ok || sc.TraceID != span.SpanContext().TraceID || sc.SpanID != span.SpanContext().SpanID {
+			t.Errorf("Queue.PushJob() trace context = %v, want span context of pusher %v", sc, span.SpanContext())
+		}
+		return 1, nil
+	})
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1)}
+	q.backend = newPostgresBackend(q)
+	if _, err := q.PushJob(ctx, job.Job{Data: []byte(`{}`)}, "aw"); err != nil {
+		t.Fatalf("Queue.PushJob() error = %v", err)
+	}
+}
+
+func TestQueue_startJobSpan(t *testing.T) {
+	_, pusher := trace.StartSpan(context.Background(), "upload", trace.WithSampler(trace.AlwaysSample()))
+	pusher.End()
+	tests := []struct {
+		name         string
+		traceContext []byte
+		wantParent   bool
+	}{
+		{name: "SUCCESS - work on job is a part of the trace of its pusher", traceContext: propagation.Binary(pusher.SpanContext()), wantParent: true},
+		{name: "SUCCESS - work on job without trace context starts a trace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queue{ID: "q"}
+			ctx, span := q.startJobSpan(context.Background(), &job.Job{JobID: 1, Type: sql.NullString{String: "aw", Valid: true}, TraceContext: tt.traceContext})
+			defer endJobSpan(span, nil)
+			if trace.FromContext(ctx) != span {
+				t.Errorf("Queue.startJobSpan() span is not in context given to worker")
+			}
+			if got := span.SpanContext().TraceID == pusher.SpanContext().TraceID; got != tt.wantParent {
+				t.Errorf("Queue.startJobSpan() in trace of pusher = %v, want %v", got, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestQueue_observeJob(t *testing.T) {
+	if err := view.Register(JobRetriesView, JobLatencyView); err != nil {
+		t.Fatalf("view.Register() error = %v", err)
+	}
+	defer view.Unregister(JobRetriesView, JobLatencyView)
+	q := &Queue{ID: "observe-queue"}
+	started := time.Now()
+	q.observeJob(context.Background(), "aw", started, db.JobStatusRETRY)
+	q.observeJob(context.Background(), "aw", started, db.JobStatusRETRY)
+	q.observeJob(context.Background(), "aw", started, db.JobStatusCOMPLETED)
+	rows, err := view.RetrieveData(JobRetriesView.Name)
+	if err != nil || len(rows) != 1 || rows[0].Data.(*view.CountData).Value != 2 {
+		t.Errorf("Queue.observeJob() retries = %v, %v, want 2", rows, err)
+	}
+	rows, err = view.RetrieveData(JobLatencyView.Name)
+	if err != nil || len(rows) != 2 {
+		t.Errorf("Queue.observeJob() latency rows = %v, %v, want one by outcome", rows, err)
+	}
+}
@@ -9,6 +9,7 @@ package job
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
 )
 
@@ -20,6 +21,9 @@ const (
 	JobStatusFAILED    JobStatus = "FAILED"
 	JobStatusRETRY     JobStatus = "RETRY"
 	JobStatusRUNNING   JobStatus = "RUNNING"
+	JobStatusCANCELLED JobStatus = "CANCELLED"
+	//JobStatusDEAD is the status of a job failing after all its retries
+	JobStatusDEAD JobStatus = "DEAD"
 )
 
 func (e *JobStatus) Scan(src interface{}) error {
@@ -38,32 +42,72 @@ type Job struct {
 	EndTime    sql.NullTime    `json:"end_time"`
 	CreatedAt  sql.NullTime    `json:"created_at"`
 	RetryCount sql.NullInt32   `json:"retry_count"`
+	//Priority orders jobs waiting in queue, higher first
+	Priority int32 `json:"priority"`
+	//IdempotencyKey identifies the work of a job for its producer, eg: upload and line of a file.
+	//A job pushed again with the key of a job to run or recently completed is not created twice
+	IdempotencyKey string `json:"idempotency_key"`
+	//TraceContext is the span context of the pusher of job, work on job is traced as a part of it
+	TraceContext []byte `json:"trace_context"`
 }
 
 //ToRepoJob handles data modelling from queue job to repo job
 func ToRepoJob(j *Job) *dbgen.Job {
 	return &dbgen.Job{JobID: j.JobID,
-		Type:       j.Type.String,
-		Comments:   j.Comments,
-		Status:     dbgen.JobStatus(j.Status),
-		Data:       j.Data,
-		CreatedAt:  j.CreatedAt.Time,
-		StartTime:  j.StartTime,
-		EndTime:    j.EndTime,
-		RetryCount: j.RetryCount,
+		Type:           j.Type.String,
+		Comments:       j.Comments,
+		Status:         dbgen.JobStatus(j.Status),
+		Data:           j.Data,
+		CreatedAt:      j.CreatedAt.Time,
+		StartTime:      j.StartTime,
+		EndTime:        j.EndTime,
+		RetryCount:     j.RetryCount,
+		Priority:       j.Priority,
+		IdempotencyKey: sql.NullString{String: j.IdempotencyKey, Valid: j.IdempotencyKey != ""},
+		TraceContext:   j.TraceContext,
 	}
 }
 
 //FromRepoJob handles data modelling from repo job to queue job
 func FromRepoJob(j *dbgen.Job) *Job {
 	return &Job{JobID: j.JobID,
-		Type:       sql.NullString{String: j.Type},
-		Comments:   j.Comments,
-		Status:     JobStatus(j.Status),
-		Data:       j.Data,
-		CreatedAt:  sql.NullTime{Time: j.CreatedAt},
-		StartTime:  j.StartTime,
-		EndTime:    j.EndTime,
-		RetryCount: j.RetryCount,
+		Type:           sql.NullString{String: j.Type},
+		Comments:       j.Comments,
+		Status:         JobStatus(j.Status),
+		Data:           j.Data,
+		CreatedAt:      sql.NullTime{Time: j.CreatedAt},
+		StartTime:      j.StartTime,
+		EndTime:        j.EndTime,
+		RetryCount:     j.RetryCount,
+		Priority:       j.Priority,
+		IdempotencyKey: j.IdempotencyKey.String,
+		TraceContext:   j.TraceContext,
 	}
 }
+
+//Permanent marks an error of a worker as failing again when job is retried, eg: for invalid data of job.
+//Such a job is dead at once, it is not retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+//IsPermanent tells if err is, or wraps, an error marked as permanent
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/common/optisam/workerqueue/worker"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//limiter holds the running jobs of limited worker types, or of keys of them
+type limiter struct {
+	//limits is the max number of running jobs by lowercased worker id
+	limits map[string]int
+	mu     sync.Mutex
+	slots  map[string]chan struct{}
+}
+
+func newLimiter(limits map[string]int) *limiter {
+	l := &limiter{limits: make(map[string]int, len(limits)), slots: make(map[string]chan struct{})}
+	//config keys are lowercased by viper, worker ids are matched whatever their case
+	for id, limit := range limits {
+		l.limits[strings.ToLower(id)] = limit
+	}
+	return l
+}
+
+//acquire takes a running slot for a job of worker, release gives it back once job is done.
+//It is not ok when worker type, or key of job for a keyed worker, already runs its limit of jobs on this replica.
+func (q *Queue) acquire(w worker.Worker, j *job.Job) (release func(), ok bool) {
+	if q.limits == nil {
+		return func() {}, true
+	}
+	limit := q.limits.limits[strings.ToLower(w.ID())]
+	if limit <= 0 {
+		return func() {}, true
+	}
+	key := w.ID()
+	if k, isKeyed := w.(worker.Keyed); isKeyed {
+		key += "/" + k.ConcurrencyKey(j)
+	}
+	q.limits.mu.Lock()
+	slot, found := q.limits.slots[key]
+	if !found {
+		slot = make(chan struct{}, limit)
+		q.limits.slots[key] = slot
+	}
+	q.limits.mu.Unlock()
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, true
+	default:
+		return nil, false
+	}
+}
+
+//deferJob puts back a job over its concurrency limit in queue, it is given to workers again once due.
+//Worker is not blocked meanwhile and takes jobs of other types or keys.
+func (q *Queue) deferJob(ctx context.Context, j *job.Job) {
+	logger.Log.Info("Concurrency limit reached, job is deferred", zap.Int32("jobID", j.JobID))
+	if err := q.backend.Nack(ctx, j, q.owner, Nack{RunAfter: time.Now().Add(q.PollRate)}); err != nil {
+		logger.Log.Error("Failed to defer job", zap.Int32("jobID", j.JobID), zap.Error(err))
+	}
+}
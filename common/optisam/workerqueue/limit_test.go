@@ -0,0 +1,132 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"optisam-backend/common/optisam/workerqueue/worker"
+	workermock "optisam-backend/common/optisam/workerqueue/worker/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+//keyedWorker limits its jobs by their comments
+type keyedWorker struct {
+	worker.Worker
+}
+
+func (keyedWorker) ConcurrencyKey(j *job.Job) string {
+	return j.Comments.String
+}
+
+func TestQueue_acquire(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         map[string]int
+		keyed          bool
+		running        []string
+		releaseRunning bool
+		key            string
+		want           bool
+	}{
+		{
+			name:    "SUCCESS - worker type without limit runs any number of jobs",
+			limits:  map[string]int{"other_worker": 1},
+			running: []string{"product", "product", "product"},
+			key:     "product",
+			want:    true,
+		},
+		{
+			name:    "FAILURE - worker type runs its limit of jobs",
+			limits:  map[string]int{"api_worker": 2},
+			running: []string{"product", "application"},
+			key:     "equipment",
+		},
+		{
+			name:           "SUCCESS - slot of a done job is taken again",
+			limits:         map[string]int{"api_worker": 2},
+			running:        []string{"product", "application"},
+			releaseRunning: true,
+			key:            "equipment",
+			want:           true,
+		},
+		{
+			name:    "SUCCESS - keyed worker is limited per key",
+			limits:  map[string]int{"api_worker": 2},
+			keyed:   true,
+			running: []string{"product", "product"},
+			key:     "application",
+			want:    true,
+		},
+		{
+			name:    "FAILURE - key of keyed worker runs its limit of jobs",
+			limits:  map[string]int{"api_worker": 2},
+			keyed:   true,
+			running: []string{"product", "product"},
+			key:     "product",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockworker := workermock.NewMockWorker(mockCtrl)
+			mockworker.EXPECT().ID().AnyTimes().Return("API_WORKER")
+			var w worker.Worker = mockworker
+			if tt.keyed {
+				w = keyedWorker{mockworker}
+			}
+			q := &Queue{limits: newLimiter(tt.limits)}
+			for _, key := range tt.running {
+				release, ok := q.acquire(w, &job.Job{Comments: sql.NullString{String: key, Valid: true}})
+				if !ok {
+					t.Fatalf("Queue.acquire() running job %v is over limit", key)
+				}
+				if tt.releaseRunning {
+					release()
+				}
+			}
+			if _, got := q.acquire(w, &job.Job{Comments: sql.NullString{String: tt.key, Valid: true}}); got != tt.want {
+				t.Errorf("Queue.acquire() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueue_runNotified_overLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	mockworker := workermock.NewMockWorker(mockCtrl)
+	ctx := context.Background()
+	mockworker.EXPECT().ID().AnyTimes().Return("w")
+	mockRepo.EXPECT().GetJob(ctx, int32(3)).Return(db.Job{JobID: 3, Type: "w", Status: db.JobStatusPENDING}, nil)
+	mockRepo.EXPECT().DeferJob(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, arg db.DeferJobParams) error {
+		if arg.JobID != 3 || !arg.RunAfter.Valid || arg.RunAfter.Time.Before(time.Now()) {
+			t.Errorf("Queue.runNotified() deferred = %+v, want job 3 run after a poll", arg)
+		}
+		return nil
+	})
+	q := &Queue{
+		repo:     mockRepo,
+		workers:  map[string][]worker.Worker{"w": {mockworker}},
+		PollRate: time.Second,
+		limits:   newLimiter(map[string]int{"w": 1}),
+	}
+	q.backend = newPostgresBackend(q)
+	if _, ok := q.acquire(mockworker, &job.Job{}); !ok {
+		t.Fatal("Queue.acquire() first job is over limit")
+	}
+	//worker is not called for a job over limit
+	q.runNotified(ctx, jobChan{3, "w"})
+}
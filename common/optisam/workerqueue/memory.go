@@ -0,0 +1,175 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"sort"
+	"sync"
+	"time"
+)
+
+//memoryBackend keeps jobs in memory, they are lost when process ends
+type memoryBackend struct {
+	mu     sync.Mutex
+	lastID int32
+	jobs   map[int32]*memoryJob
+}
+
+type memoryJob struct {
+	job.Job
+	runAfter time.Time
+	owner    string
+}
+
+//NewMemoryBackend gives a backend keeping jobs in memory, eg: for tests of services or a single binary in development.
+//Claims do not expire and completed jobs keep their idempotency key as long as the backend lives.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{jobs: make(map[int32]*memoryJob)}
+}
+
+func (b *memoryBackend) Enqueue(ctx context.Context, jobs []job.Job, runAfter time.Time) ([]int32, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ids := make([]int32, 0, len(jobs))
+	for _, j := range jobs {
+		if j.IdempotencyKey != "" {
+			if existing := b.keyed(j.Type.String, j.IdempotencyKey); existing != nil {
+				ids = append(ids, existing.JobID)
+				continue
+			}
+		}
+		b.lastID++
+		j.JobID = b.lastID
+		j.Status = job.JobStatusPENDING
+		j.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		j.TraceContext = traceContext(ctx)
+		b.jobs[j.JobID] = &memoryJob{Job: j, runAfter: runAfter}
+		ids = append(ids, j.JobID)
+	}
+	return ids, nil
+}
+
+//keyed gives the job to run or completed having an idempotency key, nil when there is none
+func (b *memoryBackend) keyed(jobType, key string) *memoryJob {
+	var found *memoryJob
+	for _, j := range b.jobs {
+		if j.Type.String != jobType || j.IdempotencyKey != key {
+			continue
+		}
+		switch j.Status {
+		case job.JobStatusPENDING, job.JobStatusRETRY, job.JobStatusRUNNING, job.JobStatusCOMPLETED:
+			if found == nil || j.JobID > found.JobID {
+				found = j
+			}
+		}
+	}
+	return found
+}
+
+func (b *memoryBackend) Claim(ctx context.Context, jobType, owner string, lease time.Duration) (*job.Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	var next *memoryJob
+	for _, j := range b.jobs {
+		if j.Type.String != jobType || (j.Status != job.JobStatusPENDING && j.Status != job.JobStatusRETRY) || j.runAfter.After(now) {
+			continue
+		}
+		if next == nil || j.Priority > next.Priority || (j.Priority == next.Priority && j.JobID < next.JobID) {
+			next = j
+		}
+	}
+	if next == nil {
+		return nil, ErrNoJob
+	}
+	next.Status = job.JobStatusRUNNING
+	next.owner = owner
+	next.runAfter = time.Time{}
+	if !next.StartTime.Valid {
+		next.StartTime = sql.NullTime{Time: now, Valid: true}
+	}
+	claimed := next.Job
+	return &claimed, nil
+}
+
+func (b *memoryBackend) Ack(ctx context.Context, j *job.Job, owner string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if mj := b.claimed(j.JobID, owner); mj != nil {
+		mj.Status = job.JobStatusCOMPLETED
+		mj.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+		mj.owner = ""
+	}
+	return nil
+}
+
+func (b *memoryBackend) Nack(ctx context.Context, j *job.Job, owner string, n Nack) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	mj := b.claimed(j.JobID, owner)
+	if mj == nil {
+		return nil
+	}
+	mj.owner = ""
+	mj.runAfter = n.RunAfter
+	switch {
+	case n.Err == nil && n.RunAfter.IsZero():
+		mj.Status = job.JobStatusPENDING
+		mj.StartTime = sql.NullTime{}
+	case n.Err == nil && mj.RetryCount.Int32 > 0:
+		mj.Status = job.JobStatusRETRY
+	case n.Err == nil:
+		mj.Status = job.JobStatusPENDING
+	case n.RunAfter.IsZero():
+		mj.Status = job.JobStatusDEAD
+		mj.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+		mj.Comments = lastError(n.Err)
+	default:
+		mj.Status = job.JobStatusRETRY
+		mj.RetryCount = sql.NullInt32{Int32: mj.RetryCount.Int32 + 1, Valid: true}
+		mj.Comments = lastError(n.Err)
+	}
+	return nil
+}
+
+//claimed gives a running job claimed by owner, nil when it is not
+func (b *memoryBackend) claimed(jobID int32, owner string) *memoryJob {
+	mj, found := b.jobs[jobID]
+	if !found || mj.Status != job.JobStatusRUNNING || mj.owner != owner {
+		return nil
+	}
+	return mj
+}
+
+func (b *memoryBackend) List(ctx context.Context, f ListFilter) ([]job.Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if f.IdempotencyKey != "" {
+		if mj := b.keyed(f.Type, f.IdempotencyKey); mj != nil {
+			return []job.Job{mj.Job}, nil
+		}
+		return nil, nil
+	}
+	jobs := make([]job.Job, 0, len(b.jobs))
+	for _, mj := range b.jobs {
+		if (f.Type == "" || mj.Type.String == f.Type) && (f.Status == "" || mj.Status == f.Status) {
+			jobs = append(jobs, mj.Job)
+		}
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].JobID > jobs[k].JobID })
+	if int(f.Offset) >= len(jobs) {
+		return nil, nil
+	}
+	jobs = jobs[f.Offset:]
+	if f.Limit > 0 && int(f.Limit) < len(jobs) {
+		jobs = jobs[:f.Limit]
+	}
+	return jobs, nil
+}
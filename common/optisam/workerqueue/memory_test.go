@@ -0,0 +1,186 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"optisam-backend/common/optisam/workerqueue/job"
+	workermock "optisam-backend/common/optisam/workerqueue/worker/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func typed(jobType string, priority int32, key string) job.Job {
+	return job.Job{Type: sql.NullString{String: jobType, Valid: true}, Data: []byte(`{}`), Priority: priority, IdempotencyKey: key}
+}
+
+func TestMemoryBackend_Claim(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend()
+	ids, err := b.Enqueue(ctx, []job.Job{typed("aw", 0, "k1"), typed("aw", 1, ""), typed("fw", 0, ""), typed("aw", 0, "k1")}, time.Time{})
+	if err != nil || len(ids) != 4 || ids[3] != ids[0] {
+		t.Fatalf("MemoryBackend.Enqueue() = %v, %v, want 3 jobs created and the id of first job for duplicate", ids, err)
+	}
+	if _, err := b.Enqueue(ctx, []job.Job{typed("aw", 5, "")}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("MemoryBackend.Enqueue() error = %v", err)
+	}
+	//job having a priority first, then oldest, scheduled job is not due
+	for _, want := range []int32{ids[1], ids[0]} {
+		got, err := b.Claim(ctx, "aw", "r1", time.Minute)
+		if err != nil || got.JobID != want || got.Status != job.JobStatusRUNNING {
+			t.Fatalf("MemoryBackend.Claim() = %+v, %v, want job %d running", got, err, want)
+		}
+	}
+	if _, err := b.Claim(ctx, "aw", "r1", time.Minute); err != ErrNoJob {
+		t.Errorf("MemoryBackend.Claim() error = %v, want ErrNoJob", err)
+	}
+	//job is a duplicate while it runs
+	if got, _ := b.Enqueue(ctx, []job.Job{typed("aw", 0, "k1")}, time.Time{}); len(got) != 1 || got[0] != ids[0] {
+		t.Errorf("MemoryBackend.Enqueue() = %v for duplicate of running job %d", got, ids[0])
+	}
+}
+
+func TestMemoryBackend_Nack(t *testing.T) {
+	cause := errors.New("worker error")
+	later := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		nack      Nack
+		want      job.JobStatus
+		wantRetry int32
+		claimable bool
+	}{
+		{name: "SUCCESS - interrupted job is pending at once", nack: Nack{}, want: job.JobStatusPENDING, claimable: true},
+		{name: "SUCCESS - deferred job waits without an attempt counted", nack: Nack{RunAfter: later}, want: job.JobStatusPENDING},
+		{name: "SUCCESS - failed job is retried later", nack: Nack{Err: cause, RunAfter: later}, want: job.JobStatusRETRY, wantRetry: 1},
+		{name: "SUCCESS - failed job without retry is dead", nack: Nack{Err: cause}, want: job.JobStatusDEAD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			b := NewMemoryBackend()
+			if _, err := b.Enqueue(ctx, []job.Job{typed("aw", 0, "")}, time.Time{}); err != nil {
+				t.Fatalf("MemoryBackend.Enqueue() error = %v", err)
+			}
+			claimed, err := b.Claim(ctx, "aw", "r1", time.Minute)
+			if err != nil {
+				t.Fatalf("MemoryBackend.Claim() error = %v", err)
+			}
+			//only owner of a claim gives it back
+			if err := b.Nack(ctx, claimed, "r2", Nack{Err: cause}); err != nil {
+				t.Fatalf("MemoryBackend.Nack() error = %v", err)
+			}
+			if err := b.Nack(ctx, claimed, "r1", tt.nack); err != nil {
+				t.Fatalf("MemoryBackend.Nack() error = %v", err)
+			}
+			jobs, _ := b.List(ctx, ListFilter{Type: "aw"})
+			if len(jobs) != 1 || jobs[0].Status != tt.want || jobs[0].RetryCount.Int32 != tt.wantRetry {
+				t.Errorf("MemoryBackend.Nack() jobs = %+v, want %s after %d retries", jobs, tt.want, tt.wantRetry)
+			}
+			if _, err := b.Claim(ctx, "aw", "r1", time.Minute); (err == nil) != tt.claimable {
+				t.Errorf("MemoryBackend.Claim() error = %v, want claimable %v", err, tt.claimable)
+			}
+		})
+	}
+}
+
+func TestMemoryBackend_List(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend()
+	if _, err := b.Enqueue(ctx, []job.Job{typed("aw", 0, "k1"), typed("aw", 0, ""), typed("fw", 0, ""), typed("aw", 0, "")}, time.Time{}); err != nil {
+		t.Fatalf("MemoryBackend.Enqueue() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   []int32
+	}{
+		{name: "SUCCESS - all jobs, most recent first", want: []int32{4, 3, 2, 1}},
+		{name: "SUCCESS - jobs of a type", filter: ListFilter{Type: "aw"}, want: []int32{4, 2, 1}},
+		{name: "SUCCESS - page of jobs", filter: ListFilter{Type: "aw", Limit: 1, Offset: 1}, want: []int32{2}},
+		{name: "SUCCESS - jobs in a status", filter: ListFilter{Status: job.JobStatusCOMPLETED}},
+		{name: "SUCCESS - job having an idempotency key", filter: ListFilter{Type: "aw", IdempotencyKey: "k1"}, want: []int32{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("MemoryBackend.List() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("MemoryBackend.List() = %d jobs, want %v", len(got), tt.want)
+			}
+			for i := range got {
+				if got[i].JobID != tt.want[i] {
+					t.Errorf("MemoryBackend.List() job %d = %d, want %d", i, got[i].JobID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewQueueWithBackend(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := context.Background()
+	b := NewMemoryBackend()
+	q := NewQueueWithBackend("q", b, QueueConfig{PollingRate: 5 * time.Millisecond, Retries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	mockworker := workermock.NewMockWorker(mockCtrl)
+	mockworker.EXPECT().ID().AnyTimes().Return("aw")
+	mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, j *job.Job) error {
+		//comments of a job are its last error once it has failed
+		if string(j.Data) == `"fail"` || (string(j.Data) == `"once"` && j.RetryCount.Int32 == 0) {
+			return errors.New("worker error")
+		}
+		if string(j.Data) == `"invalid"` {
+			return job.Permanent(errors.New("invalid job"))
+		}
+		return nil
+	})
+	q.RegisterWorker(ctx, mockworker)
+	ids := map[job.JobStatus][]int32{}
+	for _, data := range []string{`"ok"`, `"once"`, `"fail"`, `"invalid"`} {
+		want := job.JobStatusCOMPLETED
+		if data == `"fail"` || data == `"invalid"` {
+			want = job.JobStatusDEAD
+		}
+		j := typed("aw", 0, "")
+		j.Data = []byte(data)
+		id, err := q.PushJob(ctx, j, "aw")
+		if err != nil {
+			t.Fatalf("Queue.PushJob() error = %v", err)
+		}
+		ids[want] = append(ids[want], id)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for status := range ids {
+		for {
+			jobs, _ := b.List(ctx, ListFilter{Status: status})
+			if len(jobs) == len(ids[status]) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Queue jobs %s = %d, want %v", status, len(jobs), ids[status])
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	dead, _ := b.List(ctx, ListFilter{Status: job.JobStatusDEAD})
+	for _, j := range dead {
+		//a job failing with a permanent error is not retried
+		if want := map[string]int32{`"fail"`: 1, `"invalid"`: 0}[string(j.Data)]; j.RetryCount.Int32 != want {
+			t.Errorf("Queue dead job %s retries = %d, want %d", j.Data, j.RetryCount.Int32, want)
+		}
+	}
+	if err := q.Shutdown(ctx); err != nil {
+		t.Errorf("Queue.Shutdown() error = %v", err)
+	}
+}
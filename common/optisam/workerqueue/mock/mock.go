@@ -16,6 +16,7 @@ import (
 	job "optisam-backend/common/optisam/workerqueue/job"
 	worker "optisam-backend/common/optisam/workerqueue/worker"
 	reflect "reflect"
+	time "time"
 )
 
 // MockWorkerqueue is a mock of Workerqueue interface
@@ -82,6 +83,36 @@ func (mr *MockWorkerqueueMockRecorder) PushJob(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJob", reflect.TypeOf((*MockWorkerqueue)(nil).PushJob), arg0, arg1, arg2)
 }
 
+// PushJobAt mocks base method
+func (m *MockWorkerqueue) PushJobAt(arg0 context.Context, arg1 job.Job, arg2 string, arg3 time.Time) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushJobAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushJobAt indicates an expected call of PushJobAt
+func (mr *MockWorkerqueueMockRecorder) PushJobAt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJobAt", reflect.TypeOf((*MockWorkerqueue)(nil).PushJobAt), arg0, arg1, arg2, arg3)
+}
+
+// PushJobs mocks base method
+func (m *MockWorkerqueue) PushJobs(arg0 context.Context, arg1 []job.Job, arg2 string) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushJobs indicates an expected call of PushJobs
+func (mr *MockWorkerqueueMockRecorder) PushJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJobs", reflect.TypeOf((*MockWorkerqueue)(nil).PushJobs), arg0, arg1, arg2)
+}
+
 // RegisterWorker mocks base method
 func (m *MockWorkerqueue) RegisterWorker(arg0 context.Context, arg1 worker.Worker) {
 	m.ctrl.T.Helper()
@@ -94,6 +125,20 @@ func (mr *MockWorkerqueueMockRecorder) RegisterWorker(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterWorker", reflect.TypeOf((*MockWorkerqueue)(nil).RegisterWorker), arg0, arg1)
 }
 
+// RequeueJob mocks base method
+func (m *MockWorkerqueue) RequeueJob(arg0 context.Context, arg1 int32, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueJob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueJob indicates an expected call of RequeueJob
+func (mr *MockWorkerqueueMockRecorder) RequeueJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueJob", reflect.TypeOf((*MockWorkerqueue)(nil).RequeueJob), arg0, arg1, arg2)
+}
+
 // ResumePendingJobs mocks base method
 func (m *MockWorkerqueue) ResumePendingJobs(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,280 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue/job"
+	repoInterface "optisam-backend/common/optisam/workerqueue/repository"
+	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//maxJobsPerInsert is the number of jobs inserted by one statement of a bulk push
+const maxJobsPerInsert = 1000
+
+//postgresBackend keeps jobs in the jobs table, replicas sharing a database share their jobs
+type postgresBackend struct {
+	repo repoInterface.Workerqueue
+	//retries is the number of retries of a job, a job whose lease expires after them is dead
+	retries int32
+	//notify tells that jobs are notified to workers rather than claimed, a single job keeps the status it is pushed with then
+	notify bool
+	//idempotencyWindow is the time a completed job keeps a duplicate from being created
+	idempotencyWindow time.Duration
+}
+
+//newPostgresBackend gives the backend of a queue on its repo
+func newPostgresBackend(q *Queue) *postgresBackend {
+	return &postgresBackend{repo: q.repo, retries: int32(q.retries), notify: !q.claim, idempotencyWindow: q.idempotencyWindow}
+}
+
+//Enqueue creates jobs in batches, a single job without idempotency key is created as it is pushed.
+//Jobs of a batch having an idempotency key without a job created are found by it.
+func (b *postgresBackend) Enqueue(ctx context.Context, jobs []job.Job, runAfter time.Time) ([]int32, error) {
+	if len(jobs) == 1 && jobs[0].IdempotencyKey == "" {
+		jobID, err := b.createJob(ctx, jobs[0], runAfter)
+		if err != nil {
+			return nil, err
+		}
+		return []int32{jobID}, nil
+	}
+	ids := make([]int32, 0, len(jobs))
+	for start := 0; start < len(jobs); start += maxJobsPerInsert {
+		end := start + maxJobsPerInsert
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+		params := dbgen.CreateJobsParams{
+			Due:            !runAfter.IsZero(),
+			RunAfter:       runAfter,
+			TraceContext:   traceContext(ctx),
+			CompletedAfter: time.Now().Add(-b.idempotencyWindow),
+		}
+		for i := range jobs[start:end] {
+			repoJob := job.ToRepoJob(&jobs[start+i])
+			//jobs are created pending, whatever status they have, as only pending jobs are claimed or notified when due
+			params.Types = append(params.Types, repoJob.Type)
+			params.Data = append(params.Data, string(repoJob.Data))
+			params.Comments = append(params.Comments, repoJob.Comments.String)
+			params.Priorities = append(params.Priorities, repoJob.Priority)
+			params.IdempotencyKeys = append(params.IdempotencyKeys, repoJob.IdempotencyKey.String)
+		}
+		created, err := b.repo.CreateJobs(ctx, params)
+		if err != nil {
+			logger.Log.Error("Failed To push jobs in bulk", zap.Int("pushed", len(ids)), zap.Error(err))
+			return ids, err
+		}
+		batch, err := b.jobIDs(ctx, jobs[start:end], created)
+		if err != nil {
+			logger.Log.Error("Failed to find duplicate jobs", zap.Int("pushed", len(ids)), zap.Error(err))
+			return ids, err
+		}
+		ids = append(ids, batch...)
+	}
+	return ids, nil
+}
+
+//idempotencyKey is the idempotency key of a job of a type
+type idempotencyKey struct {
+	jobType string
+	key     string
+}
+
+//jobIDs gives the ids of jobs in order from the jobs created of them,
+//a duplicate has the id of the job having its idempotency key, 0 when this job has ended meanwhile
+func (b *postgresBackend) jobIDs(ctx context.Context, jobs []job.Job, created []dbgen.CreateJobsRow) ([]int32, error) {
+	keyed := make(map[idempotencyKey]int32)
+	var unkeyed []int32
+	for _, c := range created {
+		if !c.IdempotencyKey.Valid {
+			unkeyed = append(unkeyed, c.JobID)
+			continue
+		}
+		keyed[idempotencyKey{c.Type, c.IdempotencyKey.String}] = c.JobID
+	}
+	params := dbgen.ListJobsByIdempotencyKeysParams{CompletedAfter: time.Now().Add(-b.idempotencyWindow)}
+	for _, j := range jobs {
+		if _, ok := keyed[idempotencyKey{j.Type.String, j.IdempotencyKey}]; ok || j.IdempotencyKey == "" {
+			continue
+		}
+		params.Types = append(params.Types, j.Type.String)
+		params.IdempotencyKeys = append(params.IdempotencyKeys, j.IdempotencyKey)
+	}
+	if len(params.Types) > 0 {
+		existing, err := b.repo.ListJobsByIdempotencyKeys(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range existing {
+			keyed[idempotencyKey{e.Type, e.IdempotencyKey.String}] = e.JobID
+		}
+	}
+	ids := make([]int32, len(jobs))
+	for i, j := range jobs {
+		if j.IdempotencyKey != "" {
+			ids[i] = keyed[idempotencyKey{j.Type.String, j.IdempotencyKey}]
+			continue
+		}
+		if len(unkeyed) > 0 {
+			ids[i], unkeyed = unkeyed[0], unkeyed[1:]
+		}
+	}
+	return ids, nil
+}
+
+func (b *postgresBackend) createJob(ctx context.Context, j job.Job, runAfter time.Time) (int32, error) {
+	repoJob := job.ToRepoJob(&j)
+	if !b.notify || !runAfter.IsZero() {
+		//only pending jobs are claimed or notified when due, whatever status they are pushed with
+		repoJob.Status = dbgen.JobStatusPENDING
+	}
+	jobID, err := b.repo.CreateJob(ctx, dbgen.CreateJobParams{Type: repoJob.Type, Status: repoJob.Status, Data: repoJob.Data,
+		Comments: repoJob.Comments, StartTime: repoJob.StartTime, EndTime: repoJob.EndTime, RunAfter: sql.NullTime{Time: runAfter, Valid: !runAfter.IsZero()},
+		Priority: repoJob.Priority, TraceContext: traceContext(ctx)})
+	if err != nil {
+		logger.Log.Error("Unable to push job to queue: %s", zap.Error(err))
+		return 0, err
+	}
+	return jobID, nil
+}
+
+//Claim leases a job, jobs of crashed replicas without retries left are dead when there is no job to claim
+func (b *postgresBackend) Claim(ctx context.Context, jobType, owner string, lease time.Duration) (*job.Job, error) {
+	j, err := b.repo.ClaimJob(ctx, dbgen.ClaimJobParams{
+		LeaseOwner:   sql.NullString{String: owner, Valid: true},
+		LeaseSeconds: leaseSeconds(lease),
+		Type:         jobType,
+		Retries:      b.retries,
+	})
+	if err == sql.ErrNoRows {
+		if _, err := b.repo.FailExpiredLeases(ctx, b.retries); err != nil && ctx.Err() == nil {
+			logger.Log.Error("Failed to fail expired jobs", zap.Error(err))
+		}
+		return nil, ErrNoJob
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.FromRepoJob(&j), nil
+}
+
+//Renew renews the lease of a running job, false is given when job is not leased to owner anymore
+func (b *postgresBackend) Renew(ctx context.Context, jobID int32, owner string, lease time.Duration) (bool, error) {
+	n, err := b.repo.RenewJobLease(ctx, dbgen.RenewJobLeaseParams{
+		LeaseSeconds: leaseSeconds(lease),
+		JobID:        jobID,
+		LeaseOwner:   sql.NullString{String: owner, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (b *postgresBackend) Ack(ctx context.Context, j *job.Job, owner string) error {
+	return b.repo.ReleaseJob(ctx, dbgen.ReleaseJobParams{
+		JobID:      j.JobID,
+		Status:     dbgen.JobStatusCOMPLETED,
+		EndTime:    sql.NullTime{Time: time.Now(), Valid: true},
+		Comments:   j.Comments,
+		LeaseOwner: sql.NullString{String: owner, Valid: true},
+	})
+}
+
+//Nack releases a failed job for a retry or as dead. A job not attempted is deferred to run after,
+//or put back pending at once when it has been interrupted. Jobs notified to workers are not leased, they are given back by id.
+func (b *postgresBackend) Nack(ctx context.Context, j *job.Job, owner string, n Nack) error {
+	switch {
+	case n.Err == nil && n.RunAfter.IsZero():
+		return b.repo.InterruptJob(ctx, dbgen.InterruptJobParams{
+			JobID:      j.JobID,
+			LeaseOwner: sql.NullString{String: owner, Valid: true},
+		})
+	case n.Err == nil:
+		return b.repo.DeferJob(ctx, dbgen.DeferJobParams{
+			JobID:    j.JobID,
+			RunAfter: sql.NullTime{Time: n.RunAfter, Valid: true},
+		})
+	}
+	release := dbgen.ReleaseJobParams{
+		JobID:      j.JobID,
+		Status:     dbgen.JobStatusRETRY,
+		Comments:   lastError(n.Err),
+		LeaseOwner: sql.NullString{String: owner, Valid: true},
+	}
+	if n.RunAfter.IsZero() {
+		release.Status = dbgen.JobStatusDEAD
+		release.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+	} else {
+		release.RetryIncrement = 1
+		release.RunAfter = sql.NullTime{Time: n.RunAfter, Valid: true}
+	}
+	return b.repo.ReleaseJob(ctx, release)
+}
+
+func (b *postgresBackend) List(ctx context.Context, f ListFilter) ([]job.Job, error) {
+	if f.IdempotencyKey != "" {
+		j, err := b.repo.GetJobByIdempotencyKey(ctx, dbgen.GetJobByIdempotencyKeyParams{
+			Type:           f.Type,
+			IdempotencyKey: sql.NullString{String: f.IdempotencyKey, Valid: true},
+			CompletedAfter: time.Now().Add(-b.idempotencyWindow),
+		})
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []job.Job{*job.FromRepoJob(&j)}, nil
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	rows, err := b.repo.ListJobs(ctx, dbgen.ListJobsParams{
+		AllStatus: f.Status == "",
+		Status:    dbgen.JobStatus(f.Status),
+		AllTypes:  f.Type == "",
+		Type:      f.Type,
+		PageSize:  limit,
+		PageNum:   f.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]job.Job, len(rows))
+	for i, r := range rows {
+		jobs[i] = *job.FromRepoJob(&dbgen.Job{
+			JobID:          r.JobID,
+			Type:           r.Type,
+			Status:         r.Status,
+			Data:           r.Data,
+			Comments:       r.Comments,
+			StartTime:      r.StartTime,
+			EndTime:        r.EndTime,
+			CreatedAt:      r.CreatedAt,
+			RetryCount:     r.RetryCount,
+			Priority:       r.Priority,
+			TraceContext:   r.TraceContext,
+			IdempotencyKey: r.IdempotencyKey,
+		})
+	}
+	return jobs, nil
+}
+
+func leaseSeconds(lease time.Duration) int32 {
+	if s := int32(lease / time.Second); s > 0 {
+		return s
+	}
+	return 1
+}
@@ -9,6 +9,8 @@ package workerqueue
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"math/rand"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/workerqueue/job"
@@ -17,7 +19,6 @@ import (
 	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
 	"optisam-backend/common/optisam/workerqueue/worker"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -33,10 +34,14 @@ type jobChan struct {
 type Queue struct {
 	//ID is a unique identifier for a Queue
 	ID string
-	//repo represents a handle to a repo struct wrapper to *sql.DB and generated queries
+	//repo represents a handle to a repo struct wrapper to *sql.DB and generated queries, nil for a queue on another backend
 	repo repoInterface.Workerqueue
+	//backend keeps the jobs of queue, workers claim their jobs from it
+	backend Backend
 	//notifier is a chan used to signal workers there is a job to begin working
 	notifier chan jobChan
+	//urgent is the notifier of jobs having a priority, workers take them before the others
+	urgent chan jobChan
 	//queueSize is the size of notification channel
 	queueSize int
 
@@ -45,6 +50,8 @@ type Queue struct {
 
 	//exponential backoff for retires
 	baseDelay time.Duration
+	maxDelay  time.Duration
+	jitter    float64
 	//workers is a list of *Workers
 	workers map[string][]worker.Worker
 
@@ -54,16 +61,71 @@ type Queue struct {
 	//PollRate the duration to Sleep each worker before checking the queue for jobs again
 	//queue for jobs again.
 	PollRate time.Duration
+	//metricsRate is the duration between two counts of jobs in queue
+	metricsRate time.Duration
+	//idempotencyWindow is the time a completed job keeps a duplicate from being pushed
+	idempotencyWindow time.Duration
+
+	//claim tells that workers lease jobs from database, notifier is not used then
+	claim bool
+	//lease is the duration of lease on a claimed job
+	lease time.Duration
+	//owner identifies this replica on leased jobs
+	owner string
+
+	//limits is the concurrency limits of workers, none when nil
+	limits *limiter
+	//retention is the policy on ended jobs, they are kept forever when nil
+	retention *retention
+	//drain stops workers on shutdown, workers run until their context is done when nil
+	drain *drain
 }
 
 //NewQueue creates a connection to the internal database and initializes the Queue type
 func NewQueue(ctx context.Context, queueID string, db *sql.DB, conf QueueConfig) (*Queue, error) {
-	q := &Queue{ID: queueID}
+	q := newQueue(queueID, conf)
 	q.repo = repo.NewRepository(db)
+	q.backend = newPostgresBackend(q)
+	q.retention = newRetention(conf.Retention)
+	//resume stopped jobs
+	err := q.ResumePendingJobs(ctx)
+	if err != nil {
+		logger.Log.Error("Unable to resume jobs from bucket: %s", zap.Error(err))
+		//Don't fail out, this isn't really fatal. But maybe it should be?
+	}
+	if !q.claim {
+		q.wg.Add(1)
+		go q.notifyDueJobs(ctx)
+	}
+	q.wg.Add(1)
+	go q.recordJobs(ctx)
+	if q.retention.enabled() {
+		q.wg.Add(1)
+		go q.sweepJobs(ctx)
+	}
+	return q, nil
+}
+
+//NewQueueWithBackend initializes a Queue keeping its jobs in backend, eg: a memory backend in tests.
+//Workers of the queue claim their jobs. Admin api, metrics of jobs in queue and retention are for queues of NewQueue only.
+func NewQueueWithBackend(queueID string, b Backend, conf QueueConfig) *Queue {
+	conf.ClaimJobs = true
+	q := newQueue(queueID, conf)
+	q.backend = b
+	return q
+}
+
+//newQueue initializes a Queue with the defaults of conf
+func newQueue(queueID string, conf QueueConfig) *Queue {
+	q := &Queue{ID: queueID}
 	q.PollRate = time.Duration(100 * time.Millisecond)  //Default
 	q.queueSize = 1000                                  //Default
 	q.retries = 3                                       //default
 	q.baseDelay = time.Duration(100 * time.Millisecond) //Default
+	q.maxDelay = time.Duration(10 * time.Minute)        //Default
+	q.jitter = 0.2                                      //Default
+	q.metricsRate = time.Duration(15 * time.Second)     //Default
+	q.idempotencyWindow = time.Duration(time.Hour)      //Default
 
 	if conf.PollingRate > 0 {
 		q.PollRate = conf.PollingRate
@@ -74,30 +136,54 @@ func NewQueue(ctx context.Context, queueID string, db *sql.DB, conf QueueConfig)
 	if conf.BaseDelay > 0 {
 		q.baseDelay = conf.BaseDelay
 	}
+	if conf.MaxDelay > 0 {
+		q.maxDelay = conf.MaxDelay
+	}
+	if conf.Jitter > 0 && conf.Jitter <= 1 {
+		q.jitter = conf.Jitter
+	}
 	if conf.Retries > 0 {
 		q.retries = conf.Retries
 	}
+	if conf.MetricsRate > 0 {
+		q.metricsRate = conf.MetricsRate
+	}
+	if conf.IdempotencyWindow > 0 {
+		q.idempotencyWindow = conf.IdempotencyWindow
+	}
+	q.claim = conf.ClaimJobs
+	q.lease = time.Minute //Default
+	if conf.LeaseDuration > 0 {
+		q.lease = conf.LeaseDuration
+	}
+	q.owner = conf.ReplicaID
+	if q.owner == "" {
+		host, _ := os.Hostname()
+		q.owner = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	q.limits = newLimiter(conf.Concurrency)
+	drainTimeout := 30 * time.Second //Default
+	if conf.DrainTimeout > 0 {
+		drainTimeout = conf.DrainTimeout
+	}
+	q.drain = newDrain(drainTimeout)
 	// Make notification channels
 	c := make(chan jobChan, q.queueSize) //TODO: channel probably isn't the best way to handle the queue buffer
 	q.notifier = c
+	q.urgent = make(chan jobChan, q.queueSize)
 	m := make(map[string][]worker.Worker)
 	q.workers = m
 	var wg sync.WaitGroup
 	q.wg = &wg
-	//resume stopped jobs
-	err := q.ResumePendingJobs(ctx)
-	if err != nil {
-		logger.Log.Error("Unable to resume jobs from bucket: %s", zap.Error(err))
-		//Don't fail out, this isn't really fatal. But maybe it should be?
-	}
-	return q, nil
+	return q
 }
 
-//Close attempts to gracefull shutdown all workers in a queue and shutdown the db connection
+//Close drains the queue, as Shutdown does. PushJob gives ErrStopped then.
+//Notifiers are left open as producers and notifier of due jobs may still be running when drain times out.
 func (q *Queue) Close(ctx context.Context) {
-	q.wg.Wait()
-	close(q.notifier)
-	q.workers = nil
+	if err := q.Shutdown(ctx); err != nil {
+		logger.Log.Error("Failed to drain worker queue", zap.String("queue", q.ID), zap.Error(err))
+	}
 }
 
 //GetRetries return queue conf retry param
@@ -106,84 +192,43 @@ func (q *Queue) GetRetries() int32 {
 }
 
 //RegisterWorker contains the main loop for all Workers.
+//Worker stops once ctx is done or queue is drained, see Shutdown, after the job it runs.
 func (q *Queue) RegisterWorker(ctx context.Context, w worker.Worker) {
 	logger.Log.Info("", zap.String("Registering worker with ID", w.ID()))
-	ctx, cancelFunc := context.WithCancel(ctx)
-
-	// graceful shutdown
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-	go func() {
-		for range c {
-			logger.Log.Info("Shutdown Signal Receieved - QUEUE")
-			cancelFunc()
-			q.wg.Wait()
-			q.workers = nil
-		}
-	}()
-
 	q.workers[w.ID()] = append(q.workers[w.ID()], w)
 	q.wg.Add(1)
+	if q.claim {
+		go q.claimJobs(ctx, w)
+		return
+	}
 	//The big __main loop__ for workers.
 	go func() {
 		logger.Log.Info("Starting up new worker...")
-		var jobC jobChan
 		for {
+			if q.draining() {
+				logger.Log.Info("Queue is drained, worker stops taking jobs. Exiting.")
+				q.wg.Done()
+				return
+			}
+			//jobs having a priority are taken before the others
+			select {
+			case jobC := <-q.urgent:
+				q.runNotified(ctx, jobC)
+				continue
+			default:
+			}
 			// receive a notification from the queue chan
 			select {
 			case <-ctx.Done():
 				logger.Log.Info("Received signal to shutdown worker. Exiting.")
 				q.wg.Done()
 				return
-			case jobC = <-q.notifier:
-				lenWorker := len(q.workers[jobC.workerName])
-				if lenWorker == 0 {
-					return
-				}
-				worker := q.workers[jobC.workerName][rand.Intn(lenWorker)]
-				logger.Log.Info("", zap.Int32("Received Job", jobC.jobId), zap.String("Picked By worker", worker.ID()))
-				// err := q.repo.UpdateJobStatusRunning(ctx, dbgen.UpdateJobStatusRunningParams{JobID: jobC.jobId, Status: "RUNNING", StartTime: sql.NullTime{Time: time.Now(), Valid: true}})
-				// if err != nil {
-				// 	logger.Log.Error("Unable to update job status: %s", zap.Error(err))
-				// 	continue
-				// }
-				//If subsequent calls to updateJobStatus fail, the whole thing is probably hosed and
-				//it should probably do something more drastic for error handling.
-				j, err := q.repo.GetJob(ctx, jobC.jobId)
-				if err != nil {
-					logger.Log.Error("Error processing job: %s", zap.Error(err))
-					q.repo.UpdateJobStatusCompleted(ctx, dbgen.UpdateJobStatusCompletedParams{JobID: jobC.jobId, Status: "FAILED", EndTime: sql.NullTime{Time: time.Now(), Valid: true}})
-					if err != nil {
-						logger.Log.Error("Error update status to failed for job: %s", zap.Error(err))
-					}
-					continue
-				}
-				// Call the worker func handling this job
-				// go func() {
-
-				err = worker.DoWork(ctx, job.FromRepoJob(&j))
-				if err != nil {
-					if j.RetryCount.Int32 < int32(q.retries) {
-						logger.Log.Error("Retry error received from worker retrying ", zap.Error(err), zap.Int32("jobID", j.JobID), zap.Int32("retryCount", j.RetryCount.Int32+1))
-						err = q.repo.UpdateJobStatusRetry(ctx, dbgen.UpdateJobStatusRetryParams{JobID: jobC.jobId, Status: "RETRY"})
-						if err != nil {
-							logger.Log.Error("Failed to Update job", zap.Error(err))
-						}
-						time.Sleep(q.baseDelay * time.Duration(j.RetryCount.Int32) * time.Millisecond)
-						q.notifier <- jobChan{jobC.jobId, jobC.workerName}
-					} else {
-						logger.Log.Error("Retries execceded for ", zap.Int32("jobId", j.JobID))
-					}
-
-				} else {
-					logger.Log.Info("Worker", zap.Int32("Job Processed", jobC.jobId))
-					err = q.repo.UpdateJobStatusCompleted(ctx, dbgen.UpdateJobStatusCompletedParams{JobID: jobC.jobId, Status: "COMPLETED", EndTime: sql.NullTime{Time: time.Now(), Valid: true}})
-					if err != nil {
-						logger.Log.Error("Failed to Update job", zap.Error(err))
-					}
-				}
-				// }()
-				logger.Log.Info("Finished processing job ", zap.Int32("jobID", jobC.jobId))
+			case <-q.stopped():
+				//drain is handled on next loop
+			case jobC := <-q.urgent:
+				q.runNotified(ctx, jobC)
+			case jobC := <-q.notifier:
+				q.runNotified(ctx, jobC)
 			default:
 				//logger.Log.Info("Worker: %s. No message to queue. Sleeping 500ms", w.ID())
 				//logger.Log.Info("Worker: %s. No message to queue. Sleeping 1s", w.ID())
@@ -194,67 +239,242 @@ func (q *Queue) RegisterWorker(ctx context.Context, w worker.Worker) {
 	// time.Sleep(100 * time.Millisecond)
 }
 
-//PushJob pushes a job to the queue and notifies workers
+//runNotified runs a job a worker is notified of
+func (q *Queue) runNotified(ctx context.Context, jobC jobChan) {
+	lenWorker := len(q.workers[jobC.workerName])
+	if lenWorker == 0 {
+		logger.Log.Error("No worker registered for job", zap.Int32("jobID", jobC.jobId), zap.String("worker", jobC.workerName))
+		return
+	}
+	worker := q.workers[jobC.workerName][rand.Intn(lenWorker)]
+	logger.Log.Info("", zap.Int32("Received Job", jobC.jobId), zap.String("Picked By worker", worker.ID()))
+	// err := q.repo.UpdateJobStatusRunning(ctx, dbgen.UpdateJobStatusRunningParams{JobID: jobC.jobId, Status: "RUNNING", StartTime: sql.NullTime{Time: time.Now(), Valid: true}})
+	// if err != nil {
+	// 	logger.Log.Error("Unable to update job status: %s", zap.Error(err))
+	// 	return
+	// }
+	//If subsequent calls to updateJobStatus fail, the whole thing is probably hosed and
+	//it should probably do something more drastic for error handling.
+	j, err := q.repo.GetJob(ctx, jobC.jobId)
+	if err != nil {
+		logger.Log.Error("Error processing job: %s", zap.Error(err))
+		q.repo.UpdateJobStatusCompleted(ctx, dbgen.UpdateJobStatusCompletedParams{JobID: jobC.jobId, Status: "FAILED", EndTime: sql.NullTime{Time: time.Now(), Valid: true}})
+		if err != nil {
+			logger.Log.Error("Error update status to failed for job: %s", zap.Error(err))
+		}
+		return
+	}
+	//job cancelled while waiting in queue is not processed
+	if j.Status == dbgen.JobStatusCANCELLED {
+		logger.Log.Info("Skipping cancelled job", zap.Int32("jobID", j.JobID))
+		return
+	}
+	notified := job.FromRepoJob(&j)
+	release, ok := q.acquire(worker, notified)
+	if !ok {
+		q.deferJob(ctx, notified)
+		return
+	}
+	defer release()
+	// Call the worker func handling this job
+	workCtx, cancel := q.workContext(ctx)
+	defer cancel()
+	workCtx, span := q.startJobSpan(workCtx, notified)
+	started := time.Now()
+	err = worker.DoWork(workCtx, notified)
+	endJobSpan(span, err)
+	if err != nil && q.interrupted() {
+		q.interruptJob(ctx, notified)
+		return
+	}
+	if err != nil {
+		if j.RetryCount.Int32 < int32(q.retries) && !job.IsPermanent(err) {
+			logger.Log.Error("Retry error received from worker retrying ", zap.Error(err), zap.Int32("jobID", j.JobID), zap.Int32("retryCount", j.RetryCount.Int32+1))
+			q.observeJob(ctx, j.Type, started, dbgen.JobStatusRETRY)
+			//job is notified again once due, worker is free meanwhile
+			err = q.repo.UpdateJobStatusRetry(ctx, dbgen.UpdateJobStatusRetryParams{JobID: jobC.jobId, Status: "RETRY", Comments: lastError(err), RunAfter: q.retryAt(j.RetryCount.Int32)})
+			if err != nil {
+				logger.Log.Error("Failed to Update job", zap.Error(err))
+			}
+		} else {
+			logger.Log.Error("Retries execceded for ", zap.Int32("jobId", j.JobID), zap.Bool("permanent", job.IsPermanent(err)), zap.Error(err))
+			q.observeJob(ctx, j.Type, started, dbgen.JobStatusDEAD)
+			err = q.repo.UpdateJobStatusDead(ctx, dbgen.UpdateJobStatusDeadParams{JobID: jobC.jobId, Comments: lastError(err)})
+			if err != nil {
+				logger.Log.Error("Failed to Update job", zap.Error(err))
+			}
+		}
+
+	} else {
+		logger.Log.Info("Worker", zap.Int32("Job Processed", jobC.jobId))
+		q.observeJob(ctx, j.Type, started, dbgen.JobStatusCOMPLETED)
+		err = q.repo.UpdateJobStatusCompleted(ctx, dbgen.UpdateJobStatusCompletedParams{JobID: jobC.jobId, Status: "COMPLETED", EndTime: sql.NullTime{Time: time.Now(), Valid: true}})
+		if err != nil {
+			logger.Log.Error("Failed to Update job", zap.Error(err))
+		}
+	}
+	logger.Log.Info("Finished processing job ", zap.Int32("jobID", jobC.jobId))
+}
+
+// PushJob pushes a job to the queue and notifies workers.
+//The id of the job in queue is given for a duplicate job, by idempotency key, which is not pushed.
 func (q *Queue) PushJob(ctx context.Context, j job.Job, workerName string) (int32, error) {
-	repoJob := job.ToRepoJob(&j)
-	jobID, err := q.repo.CreateJob(ctx, dbgen.CreateJobParams{Type: repoJob.Type, Status: repoJob.Status, Data: repoJob.Data,
-		Comments: repoJob.Comments, StartTime: repoJob.StartTime, EndTime: repoJob.EndTime})
+	if q.draining() {
+		return 0, ErrStopped
+	}
+	notifier := q.notifierOf(j.Priority)
+	if !q.claim && (j.IdempotencyKey != "" || len(notifier) >= cap(notifier)) {
+		//workers are busy, or job may be a duplicate which is not notified again:
+		//job is notified as a due job once they have room
+		return q.createJob(ctx, j, time.Now())
+	}
+	ids, err := q.backend.Enqueue(ctx, []job.Job{j}, time.Time{})
 	if err != nil {
-		logger.Log.Error("Unable to push job to queue: %s", zap.Error(err))
 		return 0, err
 	}
-	q.notifier <- jobChan{jobID, workerName}
-	return jobID, nil
+	if !q.claim {
+		notifier <- jobChan{ids[0], workerName}
+	}
+	return ids[0], nil
 }
 
-/*
-//PushJob pushes a job to the queue and notifies workers
-func (q *Queue) PushJobs(ctx context.Context, j []job.Job, workerName string) (int, error) {
-	query := getBulkJobQuery(j)
-	jobIDs, err := q.repo.CreateJobs(ctx, query)
+//PushJobAt pushes a job to the queue to be run once at is reached, eg: to schedule a future work.
+//Due jobs are given to the worker having the type of job as id, as resumed jobs are, workerName is the type of an untyped job.
+func (q *Queue) PushJobAt(ctx context.Context, j job.Job, workerName string, at time.Time) (int32, error) {
+	if j.Type.String == "" {
+		j.Type = sql.NullString{String: workerName, Valid: true}
+	}
+	return q.createJob(ctx, j, at)
+}
+
+//createJob creates a job to run once runAfter is reached, the id of the job in queue is given for a duplicate job
+func (q *Queue) createJob(ctx context.Context, j job.Job, runAfter time.Time) (int32, error) {
+	ids, err := q.backend.Enqueue(ctx, []job.Job{j}, runAfter)
 	if err != nil {
-		logger.Log.Error("Failed To push jobs in bulk err %v", zap.Error(err))
 		return 0, err
 	}
-	for _, jobID := range jobIDs {
-		logger.Log.Info("Job pushed ", zap.Int("jobID", jobID))
-		q.notifier <- jobChan{int32(jobID), workerName}
+	return ids[0], nil
+}
+
+//RequeueJob puts back an existing job in queue with a fresh retry budget, eg: to replay a failed job
+func (q *Queue) RequeueJob(ctx context.Context, jobID int32, workerName string) error {
+	if q.repo == nil {
+		return errors.New("workerqueue: jobs are requeued in postgres only")
+	}
+	//job is due now, it is notified as a due job once workers have room or claimed
+	if err := q.repo.RequeueJob(ctx, jobID); err != nil {
+		logger.Log.Error("Unable to requeue job", zap.Int32("jobID", jobID), zap.String("worker", workerName), zap.Error(err))
+		return err
 	}
-	return len(jobIDs), nil
+	return nil
 }
 
-func getBulkJobQuery(j []job.Job) (query string) {
-	query = "insert into jobs (type,status,data,comments) values  "
-	for _, data := range j {
-		query += fmt.Sprintf("('%s','%s','%s','%s') ,", data.Type.String, data.Status, string(data.Data), data.Comments.String)
+//PushJobs pushes jobs to the queue in batches and gives the ids of the jobs created in order of jobs.
+//Workers are notified of the jobs as they have room for them so that producer is not blocked by a big push.
+//Duplicates of jobs in queue, by their idempotency key, are not created and have the id of the job in queue given.
+//On error, ids of the jobs pushed before it are given.
+func (q *Queue) PushJobs(ctx context.Context, jobs []job.Job, workerName string) ([]int32, error) {
+	typed := make([]job.Job, len(jobs))
+	for i := range jobs {
+		typed[i] = jobs[i]
+		if typed[i].Type.String == "" {
+			typed[i].Type = sql.NullString{String: workerName, Valid: true}
+		}
+	}
+	//jobs are given to workers by notifier of due jobs or claimed
+	var runAfter time.Time
+	if !q.claim {
+		runAfter = time.Now()
 	}
-	query = query[0 : len(query)-1]
-	query += " returning job_id ;"
-	return
+	return q.backend.Enqueue(ctx, typed, runAfter)
 }
 
+/*
 //CurrentSize tells total msgs in queue
 func (q *Queue) CurrentSize() int {
 	return len(q.notifier)
 }
 */
 
-//ResumePendingJobs loops through all pending jobs
+//ResumePendingJobs makes the jobs interrupted by a stop due now, they are notified as due jobs once workers have room.
+//Claimed jobs need no resume as workers find them in database.
 func (q *Queue) ResumePendingJobs(ctx context.Context) error {
-	jobs, err := q.repo.GetJobs(ctx)
+	if q.claim {
+		return nil
+	}
+	n, err := q.repo.ResumeJobs(ctx, int32(q.retries))
 	if err != nil {
-		logger.Log.Error("Error getting jobs from DB %v", zap.Error(err))
+		logger.Log.Error("Error resuming jobs", zap.Error(err))
 		return err
 	}
-	for _, j := range jobs {
-		if j.Status == "PENDING" || j.Status == "RETRY" || j.Status == "RUNNING" {
-			if j.RetryCount.Int32 < int32(q.retries) {
-				logger.Log.Info("Job not processed. Retrying...", zap.Int32("jobID", j.JobID))
-				q.notifier <- jobChan{j.JobID, j.Type}
-			} else {
-				logger.Log.Error("Error already retires execeeded for ", zap.Int32("jobID", j.JobID))
+	logger.Log.Info("Jobs not processed are resumed", zap.Int64("jobs", n))
+	return nil
+}
+
+//lastError gives the error of a job to be kept with it
+func lastError(err error) sql.NullString {
+	return sql.NullString{String: err.Error(), Valid: true}
+}
+
+//retryAt gives the time of next attempt of a job which has failed retryCount times already.
+//Delay doubles on each retry up to max delay, jitter takes a random part of it off.
+func (q *Queue) retryAt(retryCount int32) sql.NullTime {
+	delay := q.maxDelay
+	if retryCount < 32 {
+		if d := q.baseDelay << uint(retryCount); d > 0 && d < delay {
+			delay = d
+		}
+	}
+	delay -= time.Duration(rand.Float64() * q.jitter * float64(delay))
+	return sql.NullTime{Time: time.Now().Add(delay), Valid: true}
+}
+
+//notifyDueJobs notifies workers of the retried and scheduled jobs once they are due,
+//a due job is notified by one replica only
+func (q *Queue) notifyDueJobs(ctx context.Context) {
+	defer q.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stopped():
+			//jobs due after drain are released on next start
+			return
+		case <-time.After(q.PollRate):
+		}
+		//jobs are released as workers have room for them, others wait in database
+		free := q.room()
+		if free <= 0 {
+			continue
+		}
+		jobs, err := q.repo.ReleaseDueJobs(ctx, int32(free))
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Log.Error("Failed to get due jobs", zap.Error(err))
 			}
+			continue
+		}
+		for _, j := range jobs {
+			q.notifierOf(j.Priority) <- jobChan{j.JobID, j.Type}
 		}
 	}
-	return nil
+}
+
+//notifierOf gives the notifier of jobs having a priority
+func (q *Queue) notifierOf(priority int32) chan jobChan {
+	if priority > 0 && q.urgent != nil {
+		return q.urgent
+	}
+	return q.notifier
+}
+
+//room gives the number of jobs both notifiers can take without blocking
+func (q *Queue) room() int {
+	free := cap(q.notifier) - len(q.notifier)
+	if q.urgent != nil {
+		if urgentFree := cap(q.urgent) - len(q.urgent); urgentFree < free {
+			free = urgentFree
+		}
+	}
+	return free
 }
@@ -8,7 +8,10 @@ package workerqueue
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"optisam-backend/common/optisam/workerqueue/job"
 	"optisam-backend/common/optisam/workerqueue/repository"
 	"optisam-backend/common/optisam/workerqueue/repository/mock"
 	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
@@ -167,6 +170,7 @@ func TestQueue_RegisterWorker(t *testing.T) {
 				wg:        tt.fields.wg,
 				PollRate:  tt.fields.PollRate,
 			}
+			q.backend = newPostgresBackend(q)
 			fmt.Printf("Queue ID:%s Worker ID:%s\n", q.ID, w.ID())
 			//TODO assert on logs maybe
 			q.RegisterWorker(tt.args.ctx, w)
@@ -179,3 +183,384 @@ func TestQueue_RegisterWorker(t *testing.T) {
 		})
 	}
 }
+
+func TestQueue_ClaimJobs(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryCount int32
+		workErr    error
+		wantStatus db.JobStatus
+		wantRetry  int32
+	}{
+		{
+			name:       "SUCCESS - Claimed job is completed",
+			wantStatus: db.JobStatusCOMPLETED,
+		},
+		{
+			name:       "SUCCESS - Claimed job failing with retries left is released for retry",
+			workErr:    errors.New("failed"),
+			wantStatus: db.JobStatusRETRY,
+			wantRetry:  1,
+		},
+		{
+			name:       "SUCCESS - Claimed job failing without retries left is dead",
+			retryCount: 3,
+			workErr:    errors.New("failed"),
+			wantStatus: db.JobStatusDEAD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+			mockworker := workermock.NewMockWorker(mockCtrl)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			owner := sql.NullString{String: "replica-1", Valid: true}
+			claimed := db.Job{JobID: 7, Type: "w", Status: db.JobStatusRUNNING, RetryCount: sql.NullInt32{Int32: tt.retryCount, Valid: true}}
+			released := make(chan db.ReleaseJobParams, 1)
+			mockworker.EXPECT().ID().AnyTimes().Return("w")
+			gomock.InOrder(
+				mockRepo.EXPECT().ClaimJob(gomock.Any(), db.ClaimJobParams{LeaseOwner: owner, LeaseSeconds: 60, Type: "w", Retries: 3}).Return(claimed, nil),
+				mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).AnyTimes().Return(db.Job{}, sql.ErrNoRows),
+			)
+			mockRepo.EXPECT().FailExpiredLeases(gomock.Any(), int32(3)).AnyTimes().Return(int64(0), nil)
+			mockRepo.EXPECT().RenewJobLease(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(1), nil)
+			mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).Return(tt.workErr)
+			mockRepo.EXPECT().ReleaseJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.ReleaseJobParams) error {
+				released <- arg
+				return nil
+			})
+			var wg sync.WaitGroup
+			q := &Queue{
+				ID:        "test-queue",
+				repo:      mockRepo,
+				workers:   make(map[string][]worker.Worker),
+				wg:        &wg,
+				PollRate:  10 * time.Millisecond,
+				retries:   3,
+				baseDelay: time.Second,
+				maxDelay:  time.Minute,
+				claim:     true,
+				lease:     time.Minute,
+				owner:     owner.String,
+			}
+			q.backend = newPostgresBackend(q)
+			q.RegisterWorker(ctx, mockworker)
+			select {
+			case got := <-released:
+				if got.JobID != claimed.JobID || got.LeaseOwner != owner || got.Status != tt.wantStatus || got.RetryIncrement != tt.wantRetry {
+					t.Errorf("Queue.claimJobs() released = %+v, want status %v retry %v", got, tt.wantStatus, tt.wantRetry)
+				}
+				if got.EndTime.Valid == (tt.wantStatus == db.JobStatusRETRY) {
+					t.Errorf("Queue.claimJobs() end time = %v for status %v", got.EndTime, got.Status)
+				}
+				if tt.workErr != nil && got.Comments.String != tt.workErr.Error() {
+					t.Errorf("Queue.claimJobs() last error = %v, want %v", got.Comments, tt.workErr)
+				}
+				if got.RunAfter.Valid != (tt.wantStatus == db.JobStatusRETRY) || (got.RunAfter.Valid && !got.RunAfter.Time.After(time.Now())) {
+					t.Errorf("Queue.claimJobs() run after = %v for status %v", got.RunAfter, got.Status)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Queue.claimJobs() job is not released")
+			}
+			cancel()
+			wg.Wait()
+		})
+	}
+}
+
+func TestQueue_retryAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryCount int32
+		jitter     float64
+		wantMin    time.Duration
+		wantMax    time.Duration
+	}{
+		{name: "SUCCESS - first retry waits base delay", retryCount: 0, wantMin: 100 * time.Millisecond, wantMax: 100 * time.Millisecond},
+		{name: "SUCCESS - delay doubles on each retry", retryCount: 2, wantMin: 400 * time.Millisecond, wantMax: 400 * time.Millisecond},
+		{name: "SUCCESS - delay is capped by max delay", retryCount: 5, wantMin: time.Second, wantMax: time.Second},
+		{name: "SUCCESS - delay of many retries does not overflow", retryCount: 70, wantMin: time.Second, wantMax: time.Second},
+		{name: "SUCCESS - jitter takes a part of delay off", retryCount: 1, jitter: 0.5, wantMin: 100 * time.Millisecond, wantMax: 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queue{baseDelay: 100 * time.Millisecond, maxDelay: time.Second, jitter: tt.jitter}
+			before := time.Now()
+			got := q.retryAt(tt.retryCount)
+			after := time.Now()
+			if !got.Valid || got.Time.Before(before.Add(tt.wantMin)) || got.Time.After(after.Add(tt.wantMax)) {
+				t.Errorf("Queue.retryAt() = %v, want between %v and %v from now", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestQueue_PushJobAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour)
+	mockRepo.EXPECT().CreateJob(ctx, db.CreateJobParams{
+		Type:     "rw",
+		Status:   db.JobStatusPENDING,
+		Data:     []byte(`{}`),
+		RunAfter: sql.NullTime{Time: at, Valid: true},
+	}).Return(int32(5), nil)
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1)}
+	q.backend = newPostgresBackend(q)
+	got, err := q.PushJobAt(ctx, job.Job{Status: job.JobStatusFAILED, Data: []byte(`{}`)}, "rw", at)
+	if err != nil || got != 5 {
+		t.Fatalf("Queue.PushJobAt() = %v, %v, want 5", got, err)
+	}
+	if len(q.notifier) != 0 {
+		t.Errorf("Queue.PushJobAt() scheduled job is notified before being due")
+	}
+}
+
+func TestQueue_notifyDueJobs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		mockRepo.EXPECT().ReleaseDueJobs(gomock.Any(), int32(10)).Return([]db.ReleaseDueJobsRow{{JobID: 3, Type: "aw"}, {JobID: 4, Type: "lw"}}, nil),
+		mockRepo.EXPECT().ReleaseDueJobs(gomock.Any(), int32(10)).AnyTimes().Return(nil, nil),
+	)
+	var wg sync.WaitGroup
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 10), queueSize: 10, wg: &wg, PollRate: 10 * time.Millisecond}
+	wg.Add(1)
+	go q.notifyDueJobs(ctx)
+	for _, want := range []jobChan{{3, "aw"}, {4, "lw"}} {
+		select {
+		case got := <-q.notifier:
+			if got != want {
+				t.Errorf("Queue.notifyDueJobs() notified = %v, want %v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Queue.notifyDueJobs() due job is not notified")
+		}
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestQueue_ResumePendingJobs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	ctx := context.Background()
+	mockRepo.EXPECT().ResumeJobs(ctx, int32(3)).Return(int64(2), nil)
+	//notifier is full, resumed jobs wait in database until workers have room
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1), retries: 3}
+	q.notifier <- jobChan{1, "aw"}
+	done := make(chan error)
+	go func() {
+		done <- q.ResumePendingJobs(ctx)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Queue.ResumePendingJobs() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Queue.ResumePendingJobs() is blocked by a full notifier")
+	}
+	if len(q.notifier) != 1 {
+		t.Errorf("Queue.ResumePendingJobs() notified resumed jobs")
+	}
+
+	claimed := &Queue{repo: mockRepo, claim: true}
+	if err := claimed.ResumePendingJobs(ctx); err != nil {
+		t.Errorf("Queue.ResumePendingJobs() error = %v", err)
+	}
+}
+
+func TestQueue_PushJobs(t *testing.T) {
+	jobs := make([]job.Job, 2500)
+	for i := range jobs {
+		jobs[i] = job.Job{Type: sql.NullString{String: "aw", Valid: true}, Status: job.JobStatusFAILED, Data: []byte(`{}`)}
+	}
+	batchIDs := func(from, n int) []db.CreateJobsRow {
+		rows := make([]db.CreateJobsRow, n)
+		for i := range rows {
+			rows[i] = db.CreateJobsRow{JobID: int32(from + i), Type: "aw"}
+		}
+		return rows
+	}
+	var mockCtrl *gomock.Controller
+	var mockRepo *mock.MockWorkerqueue
+	tests := []struct {
+		name    string
+		claim   bool
+		setup   func()
+		wantIDs int
+		wantErr bool
+	}{
+		{
+			name: "SUCCESS - jobs are pushed in batches as due jobs",
+			setup: func() {
+				gomock.InOrder(
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+						if !arg.Due || len(arg.Types) != 1000 || len(arg.Data) != 1000 || len(arg.Comments) != 1000 || arg.Types[0] != "aw" {
+							t.Errorf("Queue.PushJobs() batch = due %v of %d jobs", arg.Due, len(arg.Types))
+						}
+						return batchIDs(1, 1000), nil
+					}),
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Return(batchIDs(1001, 1000), nil),
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+						if len(arg.Types) != 500 {
+							t.Errorf("Queue.PushJobs() last batch has %d jobs, want 500", len(arg.Types))
+						}
+						return batchIDs(2001, 500), nil
+					}),
+				)
+			},
+			wantIDs: 2500,
+		},
+		{
+			name:  "SUCCESS - jobs of a claiming queue are not due jobs",
+			claim: true,
+			setup: func() {
+				next := 1
+				mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(_ context.Context, arg db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+					if arg.Due {
+						t.Errorf("Queue.PushJobs() jobs of a claiming queue are due")
+					}
+					next += len(arg.Types)
+					return batchIDs(next-len(arg.Types), len(arg.Types)), nil
+				})
+			},
+			wantIDs: 2500,
+		},
+		{
+			name: "FAILURE - ids of jobs pushed before error are given",
+			setup: func() {
+				gomock.InOrder(
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Return(batchIDs(1, 1000), nil),
+					mockRepo.EXPECT().CreateJobs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error")),
+				)
+			},
+			wantIDs: 1000,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup()
+			q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 10), claim: tt.claim}
+			q.backend = newPostgresBackend(q)
+			got, err := q.PushJobs(context.Background(), jobs, "aw")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Queue.PushJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantIDs || got[len(got)-1] != int32(tt.wantIDs) {
+				t.Errorf("Queue.PushJobs() gave %d ids, want %d", len(got), tt.wantIDs)
+			}
+			if len(q.notifier) != 0 {
+				t.Errorf("Queue.PushJobs() notified %d jobs, want none", len(q.notifier))
+			}
+		})
+	}
+}
+
+func TestQueue_PushJob_busyWorkers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	mockRepo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateJobParams) (int32, error) {
+		if !arg.RunAfter.Valid || arg.Status != db.JobStatusPENDING {
+			t.Errorf("Queue.PushJob() job = %+v, want a pending due job", arg)
+		}
+		return 2, nil
+	})
+	q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1)}
+	q.backend = newPostgresBackend(q)
+	q.notifier <- jobChan{1, "aw"}
+	if _, err := q.PushJob(context.Background(), job.Job{Type: sql.NullString{String: "aw", Valid: true}, Data: []byte(`{}`)}, "aw"); err != nil {
+		t.Fatalf("Queue.PushJob() error = %v", err)
+	}
+	if len(q.notifier) != 1 {
+		t.Errorf("Queue.PushJob() notified job to busy workers")
+	}
+}
+
+func TestQueue_PushJob_priority(t *testing.T) {
+	tests := []struct {
+		name       string
+		priority   int32
+		wantUrgent bool
+	}{
+		{name: "SUCCESS - job without priority is notified in order", priority: 0},
+		{name: "SUCCESS - job having a priority is notified as urgent", priority: 2, wantUrgent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+			mockRepo.EXPECT().CreateJob(gomock.Any(), db.CreateJobParams{Type: "aw", Status: db.JobStatusPENDING, Data: []byte(`{}`), Priority: tt.priority}).Return(int32(4), nil)
+			q := &Queue{repo: mockRepo, notifier: make(chan jobChan, 1), urgent: make(chan jobChan, 1)}
+			q.backend = newPostgresBackend(q)
+			if _, err := q.PushJob(context.Background(), job.Job{Type: sql.NullString{String: "aw", Valid: true}, Status: job.JobStatusPENDING, Data: []byte(`{}`), Priority: tt.priority}, "aw"); err != nil {
+				t.Fatalf("Queue.PushJob() error = %v", err)
+			}
+			if len(q.urgent) == 0 != !tt.wantUrgent || len(q.notifier) == 0 != tt.wantUrgent {
+				t.Errorf("Queue.PushJob() notified %d jobs, %d urgent jobs, want urgent %v", len(q.notifier), len(q.urgent), tt.wantUrgent)
+			}
+		})
+	}
+}
+
+func TestQueue_RegisterWorker_urgentFirst(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := mock.NewMockWorkerqueue(mockCtrl)
+	mockworker := workermock.NewMockWorker(mockCtrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan int32, 2)
+	mockworker.EXPECT().ID().AnyTimes().Return("w")
+	gomock.InOrder(
+		mockRepo.EXPECT().GetJob(gomock.Any(), int32(2)).Return(db.Job{JobID: 2, Type: "w", Status: db.JobStatusPENDING, Priority: 1}, nil),
+		mockRepo.EXPECT().GetJob(gomock.Any(), int32(1)).Return(db.Job{JobID: 1, Type: "w", Status: db.JobStatusPENDING}, nil),
+	)
+	mockworker.EXPECT().DoWork(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, j *job.Job) error {
+		done <- j.JobID
+		return nil
+	})
+	mockRepo.EXPECT().UpdateJobStatusCompleted(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	var wg sync.WaitGroup
+	q := &Queue{
+		ID:       "test-queue",
+		repo:     mockRepo,
+		notifier: make(chan jobChan, 1),
+		urgent:   make(chan jobChan, 1),
+		workers:  make(map[string][]worker.Worker),
+		wg:       &wg,
+		PollRate: 10 * time.Millisecond,
+	}
+	q.backend = newPostgresBackend(q)
+	q.notifier <- jobChan{1, "w"}
+	q.urgent <- jobChan{2, "w"}
+	q.RegisterWorker(ctx, mockworker)
+	for _, want := range []int32{2, 1} {
+		select {
+		case got := <-done:
+			if got != want {
+				t.Errorf("Queue.RegisterWorker() worked on job %v, want %v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Queue.RegisterWorker() job %v is not worked on", want)
+		}
+	}
+	cancel()
+	wg.Wait()
+}
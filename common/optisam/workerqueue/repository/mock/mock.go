@@ -40,6 +40,66 @@ func (m *MockWorkerqueue) EXPECT() *MockWorkerqueueMockRecorder {
 	return m.recorder
 }
 
+// ArchiveExpiredJobs mocks base method
+func (m *MockWorkerqueue) ArchiveExpiredJobs(arg0 context.Context, arg1 db.ArchiveExpiredJobsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveExpiredJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveExpiredJobs indicates an expected call of ArchiveExpiredJobs
+func (mr *MockWorkerqueueMockRecorder) ArchiveExpiredJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveExpiredJobs", reflect.TypeOf((*MockWorkerqueue)(nil).ArchiveExpiredJobs), arg0, arg1)
+}
+
+// ClaimJob mocks base method
+func (m *MockWorkerqueue) ClaimJob(arg0 context.Context, arg1 db.ClaimJobParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", arg0, arg1)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob
+func (mr *MockWorkerqueueMockRecorder) ClaimJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockWorkerqueue)(nil).ClaimJob), arg0, arg1)
+}
+
+// CountArchivedJobs mocks base method
+func (m *MockWorkerqueue) CountArchivedJobs(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountArchivedJobs", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountArchivedJobs indicates an expected call of CountArchivedJobs
+func (mr *MockWorkerqueueMockRecorder) CountArchivedJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountArchivedJobs", reflect.TypeOf((*MockWorkerqueue)(nil).CountArchivedJobs), arg0)
+}
+
+// CountJobs mocks base method
+func (m *MockWorkerqueue) CountJobs(arg0 context.Context) ([]db.CountJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountJobs", arg0)
+	ret0, _ := ret[0].([]db.CountJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountJobs indicates an expected call of CountJobs
+func (mr *MockWorkerqueueMockRecorder) CountJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountJobs", reflect.TypeOf((*MockWorkerqueue)(nil).CountJobs), arg0)
+}
+
 // CreateJob mocks base method
 func (m *MockWorkerqueue) CreateJob(arg0 context.Context, arg1 db.CreateJobParams) (int32, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +115,65 @@ func (mr *MockWorkerqueueMockRecorder) CreateJob(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockWorkerqueue)(nil).CreateJob), arg0, arg1)
 }
 
+// CreateJobs mocks base method
+func (m *MockWorkerqueue) CreateJobs(arg0 context.Context, arg1 db.CreateJobsParams) ([]db.CreateJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.CreateJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJobs indicates an expected call of CreateJobs
+func (mr *MockWorkerqueueMockRecorder) CreateJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobs", reflect.TypeOf((*MockWorkerqueue)(nil).CreateJobs), arg0, arg1)
+}
+
+// DeferJob mocks base method
+func (m *MockWorkerqueue) DeferJob(arg0 context.Context, arg1 db.DeferJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeferJob indicates an expected call of DeferJob
+func (mr *MockWorkerqueueMockRecorder) DeferJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferJob", reflect.TypeOf((*MockWorkerqueue)(nil).DeferJob), arg0, arg1)
+}
+
+// DeleteExpiredJobs mocks base method
+func (m *MockWorkerqueue) DeleteExpiredJobs(arg0 context.Context, arg1 db.DeleteExpiredJobsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredJobs indicates an expected call of DeleteExpiredJobs
+func (mr *MockWorkerqueueMockRecorder) DeleteExpiredJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredJobs", reflect.TypeOf((*MockWorkerqueue)(nil).DeleteExpiredJobs), arg0, arg1)
+}
+
+// FailExpiredLeases mocks base method
+func (m *MockWorkerqueue) FailExpiredLeases(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExpiredLeases", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExpiredLeases indicates an expected call of FailExpiredLeases
+func (mr *MockWorkerqueueMockRecorder) FailExpiredLeases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExpiredLeases", reflect.TypeOf((*MockWorkerqueue)(nil).FailExpiredLeases), arg0, arg1)
+}
+
 // GetJob mocks base method
 func (m *MockWorkerqueue) GetJob(arg0 context.Context, arg1 int32) (db.Job, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +189,21 @@ func (mr *MockWorkerqueueMockRecorder) GetJob(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockWorkerqueue)(nil).GetJob), arg0, arg1)
 }
 
+// GetJobByIdempotencyKey mocks base method
+func (m *MockWorkerqueue) GetJobByIdempotencyKey(arg0 context.Context, arg1 db.GetJobByIdempotencyKeyParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobByIdempotencyKey", arg0, arg1)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobByIdempotencyKey indicates an expected call of GetJobByIdempotencyKey
+func (mr *MockWorkerqueueMockRecorder) GetJobByIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByIdempotencyKey", reflect.TypeOf((*MockWorkerqueue)(nil).GetJobByIdempotencyKey), arg0, arg1)
+}
+
 // GetJobs mocks base method
 func (m *MockWorkerqueue) GetJobs(arg0 context.Context) ([]db.Job, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +219,138 @@ func (mr *MockWorkerqueueMockRecorder) GetJobs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockWorkerqueue)(nil).GetJobs), arg0)
 }
 
+// InterruptJob mocks base method
+func (m *MockWorkerqueue) InterruptJob(arg0 context.Context, arg1 db.InterruptJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InterruptJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InterruptJob indicates an expected call of InterruptJob
+func (mr *MockWorkerqueueMockRecorder) InterruptJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InterruptJob", reflect.TypeOf((*MockWorkerqueue)(nil).InterruptJob), arg0, arg1)
+}
+
+// ListJobs mocks base method
+func (m *MockWorkerqueue) ListJobs(arg0 context.Context, arg1 db.ListJobsParams) ([]db.ListJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs
+func (mr *MockWorkerqueueMockRecorder) ListJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockWorkerqueue)(nil).ListJobs), arg0, arg1)
+}
+
+// ListJobsByIdempotencyKeys mocks base method
+func (m *MockWorkerqueue) ListJobsByIdempotencyKeys(arg0 context.Context, arg1 db.ListJobsByIdempotencyKeysParams) ([]db.ListJobsByIdempotencyKeysRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobsByIdempotencyKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobsByIdempotencyKeysRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobsByIdempotencyKeys indicates an expected call of ListJobsByIdempotencyKeys
+func (mr *MockWorkerqueueMockRecorder) ListJobsByIdempotencyKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsByIdempotencyKeys", reflect.TypeOf((*MockWorkerqueue)(nil).ListJobsByIdempotencyKeys), arg0, arg1)
+}
+
+// PurgeJobs mocks base method
+func (m *MockWorkerqueue) PurgeJobs(arg0 context.Context, arg1 db.PurgeJobsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeJobs indicates an expected call of PurgeJobs
+func (mr *MockWorkerqueueMockRecorder) PurgeJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeJobs", reflect.TypeOf((*MockWorkerqueue)(nil).PurgeJobs), arg0, arg1)
+}
+
+// ReleaseDueJobs mocks base method
+func (m *MockWorkerqueue) ReleaseDueJobs(arg0 context.Context, arg1 int32) ([]db.ReleaseDueJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDueJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.ReleaseDueJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseDueJobs indicates an expected call of ReleaseDueJobs
+func (mr *MockWorkerqueueMockRecorder) ReleaseDueJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDueJobs", reflect.TypeOf((*MockWorkerqueue)(nil).ReleaseDueJobs), arg0, arg1)
+}
+
+// ReleaseJob mocks base method
+func (m *MockWorkerqueue) ReleaseJob(arg0 context.Context, arg1 db.ReleaseJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseJob indicates an expected call of ReleaseJob
+func (mr *MockWorkerqueueMockRecorder) ReleaseJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseJob", reflect.TypeOf((*MockWorkerqueue)(nil).ReleaseJob), arg0, arg1)
+}
+
+// RenewJobLease mocks base method
+func (m *MockWorkerqueue) RenewJobLease(arg0 context.Context, arg1 db.RenewJobLeaseParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewJobLease", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewJobLease indicates an expected call of RenewJobLease
+func (mr *MockWorkerqueueMockRecorder) RenewJobLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewJobLease", reflect.TypeOf((*MockWorkerqueue)(nil).RenewJobLease), arg0, arg1)
+}
+
+// RequeueJob mocks base method
+func (m *MockWorkerqueue) RequeueJob(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueJob indicates an expected call of RequeueJob
+func (mr *MockWorkerqueueMockRecorder) RequeueJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueJob", reflect.TypeOf((*MockWorkerqueue)(nil).RequeueJob), arg0, arg1)
+}
+
+// ResumeJobs mocks base method
+func (m *MockWorkerqueue) ResumeJobs(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeJobs indicates an expected call of ResumeJobs
+func (mr *MockWorkerqueueMockRecorder) ResumeJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeJobs", reflect.TypeOf((*MockWorkerqueue)(nil).ResumeJobs), arg0, arg1)
+}
+
 // UpdateJobStatusCompleted mocks base method
 func (m *MockWorkerqueue) UpdateJobStatusCompleted(arg0 context.Context, arg1 db.UpdateJobStatusCompletedParams) error {
 	m.ctrl.T.Helper()
@@ -99,6 +365,20 @@ func (mr *MockWorkerqueueMockRecorder) UpdateJobStatusCompleted(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatusCompleted", reflect.TypeOf((*MockWorkerqueue)(nil).UpdateJobStatusCompleted), arg0, arg1)
 }
 
+// UpdateJobStatusDead mocks base method
+func (m *MockWorkerqueue) UpdateJobStatusDead(arg0 context.Context, arg1 db.UpdateJobStatusDeadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobStatusDead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobStatusDead indicates an expected call of UpdateJobStatusDead
+func (mr *MockWorkerqueueMockRecorder) UpdateJobStatusDead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatusDead", reflect.TypeOf((*MockWorkerqueue)(nil).UpdateJobStatusDead), arg0, arg1)
+}
+
 // UpdateJobStatusRetry mocks base method
 func (m *MockWorkerqueue) UpdateJobStatusRetry(arg0 context.Context, arg1 db.UpdateJobStatusRetryParams) error {
 	m.ctrl.T.Helper()
@@ -23,6 +23,8 @@ const (
 	JobStatusFAILED    JobStatus = "FAILED"
 	JobStatusRETRY     JobStatus = "RETRY"
 	JobStatusRUNNING   JobStatus = "RUNNING"
+	JobStatusCANCELLED JobStatus = "CANCELLED"
+	JobStatusDEAD      JobStatus = "DEAD"
 )
 
 func (e *JobStatus) Scan(src interface{}) error {
@@ -38,6 +40,24 @@ func (e *JobStatus) Scan(src interface{}) error {
 }
 
 type Job struct {
+	JobID          int32           `json:"job_id"`
+	Type           string          `json:"type"`
+	Status         JobStatus       `json:"status"`
+	Data           json.RawMessage `json:"data"`
+	Comments       sql.NullString  `json:"comments"`
+	StartTime      sql.NullTime    `json:"start_time"`
+	EndTime        sql.NullTime    `json:"end_time"`
+	CreatedAt      time.Time       `json:"created_at"`
+	RetryCount     sql.NullInt32   `json:"retry_count"`
+	LeaseOwner     sql.NullString  `json:"lease_owner"`
+	LeaseExpiresAt sql.NullTime    `json:"lease_expires_at"`
+	RunAfter       sql.NullTime    `json:"run_after"`
+	Priority       int32           `json:"priority"`
+	TraceContext   []byte          `json:"trace_context"`
+	IdempotencyKey sql.NullString  `json:"idempotency_key"`
+}
+
+type JobsArchive struct {
 	JobID      int32           `json:"job_id"`
 	Type       string          `json:"type"`
 	Status     JobStatus       `json:"status"`
@@ -47,4 +67,5 @@ type Job struct {
 	EndTime    sql.NullTime    `json:"end_time"`
 	CreatedAt  time.Time       `json:"created_at"`
 	RetryCount sql.NullInt32   `json:"retry_count"`
+	ArchivedAt time.Time       `json:"archived_at"`
 }
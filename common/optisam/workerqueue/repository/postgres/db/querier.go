@@ -13,10 +13,29 @@ import (
 )
 
 type Querier interface {
+	ArchiveExpiredJobs(ctx context.Context, arg ArchiveExpiredJobsParams) (int64, error)
+	ClaimJob(ctx context.Context, arg ClaimJobParams) (Job, error)
+	CountArchivedJobs(ctx context.Context) (int64, error)
+	CountJobs(ctx context.Context) ([]CountJobsRow, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (int32, error)
+	CreateJobs(ctx context.Context, arg CreateJobsParams) ([]CreateJobsRow, error)
+	DeferJob(ctx context.Context, arg DeferJobParams) error
+	DeleteExpiredJobs(ctx context.Context, arg DeleteExpiredJobsParams) (int64, error)
+	FailExpiredLeases(ctx context.Context, retries int32) (int64, error)
 	GetJob(ctx context.Context, jobID int32) (Job, error)
+	GetJobByIdempotencyKey(ctx context.Context, arg GetJobByIdempotencyKeyParams) (Job, error)
 	GetJobs(ctx context.Context) ([]Job, error)
+	InterruptJob(ctx context.Context, arg InterruptJobParams) error
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]ListJobsRow, error)
+	ListJobsByIdempotencyKeys(ctx context.Context, arg ListJobsByIdempotencyKeysParams) ([]ListJobsByIdempotencyKeysRow, error)
+	PurgeJobs(ctx context.Context, arg PurgeJobsParams) (int64, error)
+	ReleaseDueJobs(ctx context.Context, maxJobs int32) ([]ReleaseDueJobsRow, error)
+	ReleaseJob(ctx context.Context, arg ReleaseJobParams) error
+	RenewJobLease(ctx context.Context, arg RenewJobLeaseParams) (int64, error)
+	RequeueJob(ctx context.Context, jobID int32) error
+	ResumeJobs(ctx context.Context, retries int32) (int64, error)
 	UpdateJobStatusCompleted(ctx context.Context, arg UpdateJobStatusCompletedParams) error
+	UpdateJobStatusDead(ctx context.Context, arg UpdateJobStatusDeadParams) error
 	UpdateJobStatusRetry(ctx context.Context, arg UpdateJobStatusRetryParams) error
 	UpdateJobStatusRunning(ctx context.Context, arg UpdateJobStatusRunningParams) error
 }
@@ -13,19 +13,162 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const archiveExpiredJobs = `-- name: ArchiveExpiredJobs :execrows
+WITH expired AS (
+    DELETE FROM jobs
+    WHERE job_id IN (
+        SELECT e.job_id FROM jobs e
+        WHERE
+            e.status IN ('COMPLETED','FAILED','DEAD','CANCELLED')
+            AND (e.status = 'COMPLETED') = $1::bool
+            AND COALESCE(e.end_time, e.created_at) < $2::TIMESTAMP
+        LIMIT $3::INTEGER
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING job_id,type,status,data,comments,start_time,end_time,created_at,retry_count
 )
+INSERT INTO jobs_archive (job_id,type,status,data,comments,start_time,end_time,created_at,retry_count)
+SELECT job_id,type,status,data,comments,start_time,end_time,created_at,retry_count FROM expired
+ON CONFLICT (job_id) DO NOTHING
+`
+
+type ArchiveExpiredJobsParams struct {
+	Completed   bool      `json:"completed"`
+	EndedBefore time.Time `json:"ended_before"`
+	MaxJobs     int32     `json:"max_jobs"`
+}
+
+func (q *Queries) ArchiveExpiredJobs(ctx context.Context, arg ArchiveExpiredJobsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, archiveExpiredJobs, arg.Completed, arg.EndedBefore, arg.MaxJobs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimJob = `-- name: ClaimJob :one
+UPDATE jobs SET
+    status = 'RUNNING',
+    lease_owner = $1,
+    lease_expires_at = NOW() + make_interval(secs => $2::INTEGER),
+    start_time = COALESCE(start_time, NOW()),
+    run_after = NULL,
+    retry_count = CASE WHEN status = 'RUNNING' THEN retry_count + 1 ELSE retry_count END
+WHERE job_id = (
+    SELECT c.job_id FROM jobs c
+    WHERE
+        c.type = $3
+        AND ((c.status IN ('PENDING','RETRY') AND COALESCE(c.run_after, NOW()) <= NOW()) OR (c.status = 'RUNNING' AND COALESCE(c.lease_expires_at, NOW()) <= NOW() AND c.retry_count < $4::INTEGER))
+    ORDER BY c.priority DESC, c.job_id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key
+`
+
+type ClaimJobParams struct {
+	LeaseOwner   sql.NullString `json:"lease_owner"`
+	LeaseSeconds int32          `json:"lease_seconds"`
+	Type         string         `json:"type"`
+	Retries      int32          `json:"retries"`
+}
+
+func (q *Queries) ClaimJob(ctx context.Context, arg ClaimJobParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, claimJob,
+		arg.LeaseOwner,
+		arg.LeaseSeconds,
+		arg.Type,
+		arg.Retries,
+	)
+	var i Job
+	err := row.Scan(
+		&i.JobID,
+		&i.Type,
+		&i.Status,
+		&i.Data,
+		&i.Comments,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.RetryCount,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.RunAfter,
+		&i.Priority,
+		&i.TraceContext,
+		&i.IdempotencyKey,
+	)
+	return i, err
+}
+
+const countArchivedJobs = `-- name: CountArchivedJobs :one
+SELECT count(*) FROM jobs_archive
+`
+
+func (q *Queries) CountArchivedJobs(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countArchivedJobs)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countJobs = `-- name: CountJobs :many
+SELECT type, status, count(*) AS total FROM jobs
+WHERE status IN ('PENDING','RETRY','RUNNING','DEAD')
+GROUP BY type, status
+`
+
+type CountJobsRow struct {
+	Type   string    `json:"type"`
+	Status JobStatus `json:"status"`
+	Total  int64     `json:"total"`
+}
+
+func (q *Queries) CountJobs(ctx context.Context) ([]CountJobsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountJobsRow
+	for rows.Next() {
+		var i CountJobsRow
+		if err := rows.Scan(&i.Type, &i.Status, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
 const createJob = `-- name: CreateJob :one
-INSERT INTO jobs (type,status,data,comments,start_time,end_time) VALUES ($1,$2,$3,$4,$5,$6) RETURNING job_id
+INSERT INTO jobs (type,status,data,comments,start_time,end_time,run_after,priority,trace_context,idempotency_key) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+ON CONFLICT (type,idempotency_key) WHERE idempotency_key IS NOT NULL AND status IN ('PENDING','RETRY','RUNNING') DO NOTHING
+RETURNING job_id
 `
 
 type CreateJobParams struct {
-	Type      string          `json:"type"`
-	Status    JobStatus       `json:"status"`
-	Data      json.RawMessage `json:"data"`
-	Comments  sql.NullString  `json:"comments"`
-	StartTime sql.NullTime    `json:"start_time"`
-	EndTime   sql.NullTime    `json:"end_time"`
+	Type           string          `json:"type"`
+	Status         JobStatus       `json:"status"`
+	Data           json.RawMessage `json:"data"`
+	Comments       sql.NullString  `json:"comments"`
+	StartTime      sql.NullTime    `json:"start_time"`
+	EndTime        sql.NullTime    `json:"end_time"`
+	RunAfter       sql.NullTime    `json:"run_after"`
+	Priority       int32           `json:"priority"`
+	TraceContext   []byte          `json:"trace_context"`
+	IdempotencyKey sql.NullString  `json:"idempotency_key"`
 }
 
 func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (int32, error) {
@@ -36,14 +179,141 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (int32, er
 		arg.Comments,
 		arg.StartTime,
 		arg.EndTime,
+		arg.RunAfter,
+		arg.Priority,
+		arg.TraceContext,
+		arg.IdempotencyKey,
 	)
 	var job_id int32
 	err := row.Scan(&job_id)
 	return job_id, err
 }
 
+const createJobs = `-- name: CreateJobs :many
+INSERT INTO jobs (type,status,data,comments,run_after,priority,trace_context,idempotency_key)
+SELECT t.type, 'PENDING', t.data::JSONB, NULLIF(t.comments,''), CASE WHEN $1::bool THEN $2::TIMESTAMP END, t.priority, $3::BYTEA, NULLIF(t.idempotency_key,'')
+FROM unnest($4::VARCHAR[], $5::VARCHAR[], $6::VARCHAR[], $7::INTEGER[], $8::VARCHAR[]) WITH ORDINALITY AS t(type,data,comments,priority,idempotency_key,n)
+WHERE NOT EXISTS (
+    SELECT 1 FROM jobs c
+    WHERE c.type = t.type AND c.idempotency_key = t.idempotency_key AND c.status = 'COMPLETED' AND c.end_time >= $9::TIMESTAMP
+)
+ORDER BY t.n
+ON CONFLICT (type,idempotency_key) WHERE idempotency_key IS NOT NULL AND status IN ('PENDING','RETRY','RUNNING') DO NOTHING
+RETURNING job_id,type,idempotency_key
+`
+
+type CreateJobsParams struct {
+	Due             bool      `json:"due"`
+	RunAfter        time.Time `json:"run_after"`
+	TraceContext    []byte    `json:"trace_context"`
+	Types           []string  `json:"types"`
+	Data            []string  `json:"data"`
+	Comments        []string  `json:"comments"`
+	Priorities      []int32   `json:"priorities"`
+	IdempotencyKeys []string  `json:"idempotency_keys"`
+	CompletedAfter  time.Time `json:"completed_after"`
+}
+
+type CreateJobsRow struct {
+	JobID          int32          `json:"job_id"`
+	Type           string         `json:"type"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+}
+
+func (q *Queries) CreateJobs(ctx context.Context, arg CreateJobsParams) ([]CreateJobsRow, error) {
+	rows, err := q.db.QueryContext(ctx, createJobs,
+		arg.Due,
+		arg.RunAfter,
+		arg.TraceContext,
+		pq.Array(arg.Types),
+		pq.Array(arg.Data),
+		pq.Array(arg.Comments),
+		pq.Array(arg.Priorities),
+		pq.Array(arg.IdempotencyKeys),
+		arg.CompletedAfter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CreateJobsRow
+	for rows.Next() {
+		var i CreateJobsRow
+		if err := rows.Scan(&i.JobID, &i.Type, &i.IdempotencyKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deferJob = `-- name: DeferJob :exec
+UPDATE jobs SET
+    status = CASE WHEN retry_count > 0 THEN 'RETRY'::job_status ELSE 'PENDING'::job_status END,
+    run_after = $1,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = $2 AND status <> 'CANCELLED'
+`
+
+type DeferJobParams struct {
+	RunAfter sql.NullTime `json:"run_after"`
+	JobID    int32        `json:"job_id"`
+}
+
+func (q *Queries) DeferJob(ctx context.Context, arg DeferJobParams) error {
+	_, err := q.db.ExecContext(ctx, deferJob, arg.RunAfter, arg.JobID)
+	return err
+}
+
+const deleteExpiredJobs = `-- name: DeleteExpiredJobs :execrows
+DELETE FROM jobs
+WHERE job_id IN (
+    SELECT e.job_id FROM jobs e
+    WHERE
+        e.status IN ('COMPLETED','FAILED','DEAD','CANCELLED')
+        AND (e.status = 'COMPLETED') = $1::bool
+        AND COALESCE(e.end_time, e.created_at) < $2::TIMESTAMP
+    LIMIT $3::INTEGER
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type DeleteExpiredJobsParams struct {
+	Completed   bool      `json:"completed"`
+	EndedBefore time.Time `json:"ended_before"`
+	MaxJobs     int32     `json:"max_jobs"`
+}
+
+func (q *Queries) DeleteExpiredJobs(ctx context.Context, arg DeleteExpiredJobsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredJobs, arg.Completed, arg.EndedBefore, arg.MaxJobs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const failExpiredLeases = `-- name: FailExpiredLeases :execrows
+UPDATE jobs SET status = 'DEAD', end_time = NOW(), comments = 'lease expired after all retries', lease_owner = NULL, lease_expires_at = NULL
+WHERE status = 'RUNNING' AND COALESCE(lease_expires_at, NOW()) <= NOW() AND retry_count >= $1::INTEGER
+`
+
+func (q *Queries) FailExpiredLeases(ctx context.Context, retries int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failExpiredLeases, retries)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getJob = `-- name: GetJob :one
-SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count FROM jobs
+SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key FROM jobs
 WHERE job_id = $1
 `
 
@@ -60,12 +330,57 @@ func (q *Queries) GetJob(ctx context.Context, jobID int32) (Job, error) {
 		&i.EndTime,
 		&i.CreatedAt,
 		&i.RetryCount,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.RunAfter,
+		&i.Priority,
+		&i.TraceContext,
+		&i.IdempotencyKey,
+	)
+	return i, err
+}
+
+const getJobByIdempotencyKey = `-- name: GetJobByIdempotencyKey :one
+SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key FROM jobs
+WHERE
+    type = $1
+    AND idempotency_key = $2
+    AND (status IN ('PENDING','RETRY','RUNNING') OR (status = 'COMPLETED' AND end_time >= $3::TIMESTAMP))
+ORDER BY job_id DESC
+LIMIT 1
+`
+
+type GetJobByIdempotencyKeyParams struct {
+	Type           string         `json:"type"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+	CompletedAfter time.Time      `json:"completed_after"`
+}
+
+func (q *Queries) GetJobByIdempotencyKey(ctx context.Context, arg GetJobByIdempotencyKeyParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, getJobByIdempotencyKey, arg.Type, arg.IdempotencyKey, arg.CompletedAfter)
+	var i Job
+	err := row.Scan(
+		&i.JobID,
+		&i.Type,
+		&i.Status,
+		&i.Data,
+		&i.Comments,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.RetryCount,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.RunAfter,
+		&i.Priority,
+		&i.TraceContext,
+		&i.IdempotencyKey,
 	)
 	return i, err
 }
 
 const getJobs = `-- name: GetJobs :many
-SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count FROM jobs
+SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key FROM jobs
 `
 
 func (q *Queries) GetJobs(ctx context.Context) ([]Job, error) {
@@ -87,6 +402,116 @@ func (q *Queries) GetJobs(ctx context.Context) ([]Job, error) {
 			&i.EndTime,
 			&i.CreatedAt,
 			&i.RetryCount,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.RunAfter,
+			&i.Priority,
+			&i.TraceContext,
+			&i.IdempotencyKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const interruptJob = `-- name: InterruptJob :exec
+UPDATE jobs SET
+    status = 'PENDING',
+    start_time = NULL,
+    run_after = NULL,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = $1 AND status IN ('PENDING','RETRY','RUNNING') AND (lease_owner IS NULL OR lease_owner = $2)
+`
+
+type InterruptJobParams struct {
+	JobID      int32          `json:"job_id"`
+	LeaseOwner sql.NullString `json:"lease_owner"`
+}
+
+func (q *Queries) InterruptJob(ctx context.Context, arg InterruptJobParams) error {
+	_, err := q.db.ExecContext(ctx, interruptJob, arg.JobID, arg.LeaseOwner)
+	return err
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT count(*) OVER() AS totalRecords,job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key FROM jobs
+WHERE
+    ($1::bool OR status = $2)
+    AND ($3::bool OR type = $4)
+ORDER BY job_id DESC
+LIMIT $6 OFFSET $5
+`
+
+type ListJobsParams struct {
+	AllStatus bool      `json:"all_status"`
+	Status    JobStatus `json:"status"`
+	AllTypes  bool      `json:"all_types"`
+	Type      string    `json:"type"`
+	PageNum   int32     `json:"page_num"`
+	PageSize  int32     `json:"page_size"`
+}
+
+type ListJobsRow struct {
+	Totalrecords   int64           `json:"totalrecords"`
+	JobID          int32           `json:"job_id"`
+	Type           string          `json:"type"`
+	Status         JobStatus       `json:"status"`
+	Data           json.RawMessage `json:"data"`
+	Comments       sql.NullString  `json:"comments"`
+	StartTime      sql.NullTime    `json:"start_time"`
+	EndTime        sql.NullTime    `json:"end_time"`
+	CreatedAt      time.Time       `json:"created_at"`
+	RetryCount     sql.NullInt32   `json:"retry_count"`
+	LeaseOwner     sql.NullString  `json:"lease_owner"`
+	LeaseExpiresAt sql.NullTime    `json:"lease_expires_at"`
+	RunAfter       sql.NullTime    `json:"run_after"`
+	Priority       int32           `json:"priority"`
+	TraceContext   []byte          `json:"trace_context"`
+	IdempotencyKey sql.NullString  `json:"idempotency_key"`
+}
+
+func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]ListJobsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobs,
+		arg.AllStatus,
+		arg.Status,
+		arg.AllTypes,
+		arg.Type,
+		arg.PageNum,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListJobsRow
+	for rows.Next() {
+		var i ListJobsRow
+		if err := rows.Scan(
+			&i.Totalrecords,
+			&i.JobID,
+			&i.Type,
+			&i.Status,
+			&i.Data,
+			&i.Comments,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.RetryCount,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.RunAfter,
+			&i.Priority,
+			&i.TraceContext,
+			&i.IdempotencyKey,
 		); err != nil {
 			return nil, err
 		}
@@ -101,6 +526,197 @@ func (q *Queries) GetJobs(ctx context.Context) ([]Job, error) {
 	return items, nil
 }
 
+const listJobsByIdempotencyKeys = `-- name: ListJobsByIdempotencyKeys :many
+SELECT DISTINCT ON (j.type,j.idempotency_key) j.job_id, j.type, j.idempotency_key FROM jobs j
+JOIN unnest($1::VARCHAR[], $2::VARCHAR[]) AS k(type,idempotency_key) ON j.type = k.type AND j.idempotency_key = k.idempotency_key
+WHERE j.status IN ('PENDING','RETRY','RUNNING') OR (j.status = 'COMPLETED' AND j.end_time >= $3::TIMESTAMP)
+ORDER BY j.type, j.idempotency_key, j.job_id DESC
+`
+
+type ListJobsByIdempotencyKeysParams struct {
+	Types           []string  `json:"types"`
+	IdempotencyKeys []string  `json:"idempotency_keys"`
+	CompletedAfter  time.Time `json:"completed_after"`
+}
+
+type ListJobsByIdempotencyKeysRow struct {
+	JobID          int32          `json:"job_id"`
+	Type           string         `json:"type"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+}
+
+func (q *Queries) ListJobsByIdempotencyKeys(ctx context.Context, arg ListJobsByIdempotencyKeysParams) ([]ListJobsByIdempotencyKeysRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsByIdempotencyKeys, pq.Array(arg.Types), pq.Array(arg.IdempotencyKeys), arg.CompletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListJobsByIdempotencyKeysRow
+	for rows.Next() {
+		var i ListJobsByIdempotencyKeysRow
+		if err := rows.Scan(&i.JobID, &i.Type, &i.IdempotencyKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeJobs = `-- name: PurgeJobs :execrows
+DELETE FROM jobs
+WHERE
+    status = $1
+    AND ($2::bool OR type = $3)
+    AND ($4::bool OR job_id = ANY($5::INTEGER[]))
+`
+
+type PurgeJobsParams struct {
+	Status   JobStatus `json:"status"`
+	AllTypes bool      `json:"all_types"`
+	Type     string    `json:"type"`
+	AllJobs  bool      `json:"all_jobs"`
+	JobIds   []int32   `json:"job_ids"`
+}
+
+func (q *Queries) PurgeJobs(ctx context.Context, arg PurgeJobsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeJobs,
+		arg.Status,
+		arg.AllTypes,
+		arg.Type,
+		arg.AllJobs,
+		pq.Array(arg.JobIds),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseDueJobs = `-- name: ReleaseDueJobs :many
+UPDATE jobs SET run_after = NULL
+WHERE job_id IN (
+    SELECT d.job_id FROM jobs d
+    WHERE d.status IN ('PENDING','RETRY') AND d.run_after <= NOW()
+    ORDER BY d.priority DESC, d.run_after
+    LIMIT $1::INTEGER
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING job_id,type,priority
+`
+
+type ReleaseDueJobsRow struct {
+	JobID    int32  `json:"job_id"`
+	Type     string `json:"type"`
+	Priority int32  `json:"priority"`
+}
+
+func (q *Queries) ReleaseDueJobs(ctx context.Context, maxJobs int32) ([]ReleaseDueJobsRow, error) {
+	rows, err := q.db.QueryContext(ctx, releaseDueJobs, maxJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReleaseDueJobsRow
+	for rows.Next() {
+		var i ReleaseDueJobsRow
+		if err := rows.Scan(&i.JobID, &i.Type, &i.Priority); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseJob = `-- name: ReleaseJob :exec
+UPDATE jobs SET
+    status = $1,
+    end_time = $2,
+    retry_count = retry_count + $3::INTEGER,
+    comments = $4,
+    run_after = $5,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = $6 AND lease_owner = $7 AND status = 'RUNNING'
+`
+
+type ReleaseJobParams struct {
+	Status         JobStatus      `json:"status"`
+	EndTime        sql.NullTime   `json:"end_time"`
+	RetryIncrement int32          `json:"retry_increment"`
+	Comments       sql.NullString `json:"comments"`
+	RunAfter       sql.NullTime   `json:"run_after"`
+	JobID          int32          `json:"job_id"`
+	LeaseOwner     sql.NullString `json:"lease_owner"`
+}
+
+func (q *Queries) ReleaseJob(ctx context.Context, arg ReleaseJobParams) error {
+	_, err := q.db.ExecContext(ctx, releaseJob,
+		arg.Status,
+		arg.EndTime,
+		arg.RetryIncrement,
+		arg.Comments,
+		arg.RunAfter,
+		arg.JobID,
+		arg.LeaseOwner,
+	)
+	return err
+}
+
+const renewJobLease = `-- name: RenewJobLease :execrows
+UPDATE jobs SET lease_expires_at = NOW() + make_interval(secs => $1::INTEGER)
+WHERE job_id = $2 AND lease_owner = $3 AND status = 'RUNNING'
+`
+
+type RenewJobLeaseParams struct {
+	LeaseSeconds int32          `json:"lease_seconds"`
+	JobID        int32          `json:"job_id"`
+	LeaseOwner   sql.NullString `json:"lease_owner"`
+}
+
+func (q *Queries) RenewJobLease(ctx context.Context, arg RenewJobLeaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, renewJobLease, arg.LeaseSeconds, arg.JobID, arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const requeueJob = `-- name: RequeueJob :exec
+UPDATE jobs SET status = 'PENDING',retry_count = 0,start_time = NULL,end_time = NULL,lease_owner = NULL,lease_expires_at = NULL,run_after = NOW() WHERE job_id = $1
+`
+
+func (q *Queries) RequeueJob(ctx context.Context, jobID int32) error {
+	_, err := q.db.ExecContext(ctx, requeueJob, jobID)
+	return err
+}
+
+const resumeJobs = `-- name: ResumeJobs :execrows
+UPDATE jobs SET
+    status = CASE WHEN status <> 'RUNNING' THEN status WHEN retry_count > 0 THEN 'RETRY'::job_status ELSE 'PENDING'::job_status END,
+    run_after = NOW()
+WHERE ((status IN ('PENDING','RETRY') AND run_after IS NULL) OR status = 'RUNNING') AND retry_count < $1::INTEGER
+`
+
+func (q *Queries) ResumeJobs(ctx context.Context, retries int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resumeJobs, retries)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateJobStatusCompleted = `-- name: UpdateJobStatusCompleted :exec
 UPDATE jobs SET status = $2,end_time = $3 WHERE job_id = $1
 `
@@ -116,17 +732,38 @@ func (q *Queries) UpdateJobStatusCompleted(ctx context.Context, arg UpdateJobSta
 	return err
 }
 
+const updateJobStatusDead = `-- name: UpdateJobStatusDead :exec
+UPDATE jobs SET status = 'DEAD',end_time = NOW(),comments = $2,lease_owner = NULL,lease_expires_at = NULL WHERE job_id = $1
+`
+
+type UpdateJobStatusDeadParams struct {
+	JobID    int32          `json:"job_id"`
+	Comments sql.NullString `json:"comments"`
+}
+
+func (q *Queries) UpdateJobStatusDead(ctx context.Context, arg UpdateJobStatusDeadParams) error {
+	_, err := q.db.ExecContext(ctx, updateJobStatusDead, arg.JobID, arg.Comments)
+	return err
+}
+
 const updateJobStatusRetry = `-- name: UpdateJobStatusRetry :exec
-UPDATE jobs SET status = $2,retry_count = retry_count + 1 WHERE job_id = $1
+UPDATE jobs SET status = $2,retry_count = retry_count + 1,comments = $3,run_after = $4 WHERE job_id = $1
 `
 
 type UpdateJobStatusRetryParams struct {
-	JobID  int32     `json:"job_id"`
-	Status JobStatus `json:"status"`
+	JobID    int32          `json:"job_id"`
+	Status   JobStatus      `json:"status"`
+	Comments sql.NullString `json:"comments"`
+	RunAfter sql.NullTime   `json:"run_after"`
 }
 
 func (q *Queries) UpdateJobStatusRetry(ctx context.Context, arg UpdateJobStatusRetryParams) error {
-	_, err := q.db.ExecContext(ctx, updateJobStatusRetry, arg.JobID, arg.Status)
+	_, err := q.db.ExecContext(ctx, updateJobStatusRetry,
+		arg.JobID,
+		arg.Status,
+		arg.Comments,
+		arg.RunAfter,
+	)
 	return err
 }
 
@@ -6,7 +6,9 @@ WHERE job_id = $1;
 SELECT * FROM jobs;
 
 -- name: CreateJob :one
-INSERT INTO jobs (type,status,data,comments,start_time,end_time) VALUES ($1,$2,$3,$4,$5,$6) RETURNING job_id;
+INSERT INTO jobs (type,status,data,comments,start_time,end_time,run_after,priority,trace_context,idempotency_key) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+ON CONFLICT (type,idempotency_key) WHERE idempotency_key IS NOT NULL AND status IN ('PENDING','RETRY','RUNNING') DO NOTHING
+RETURNING job_id;
 
 
 -- name: UpdateJobStatusRunning :exec
@@ -16,4 +18,162 @@ UPDATE jobs SET status = $2,start_time = $3 WHERE job_id = $1;
 UPDATE jobs SET status = $2,end_time = $3 WHERE job_id = $1;
 
 -- name: UpdateJobStatusRetry :exec
-UPDATE jobs SET status = $2,retry_count = retry_count + 1 WHERE job_id = $1;
\ No newline at end of file
+UPDATE jobs SET status = $2,retry_count = retry_count + 1,comments = $3,run_after = $4 WHERE job_id = $1;
+
+-- name: UpdateJobStatusDead :exec
+UPDATE jobs SET status = 'DEAD',end_time = NOW(),comments = $2,lease_owner = NULL,lease_expires_at = NULL WHERE job_id = $1;
+
+-- name: RequeueJob :exec
+UPDATE jobs SET status = 'PENDING',retry_count = 0,start_time = NULL,end_time = NULL,lease_owner = NULL,lease_expires_at = NULL,run_after = NOW() WHERE job_id = $1;
+
+-- name: ResumeJobs :execrows
+UPDATE jobs SET
+    status = CASE WHEN status <> 'RUNNING' THEN status WHEN retry_count > 0 THEN 'RETRY'::job_status ELSE 'PENDING'::job_status END,
+    run_after = NOW()
+WHERE ((status IN ('PENDING','RETRY') AND run_after IS NULL) OR status = 'RUNNING') AND retry_count < @retries::INTEGER;
+
+-- name: ClaimJob :one
+UPDATE jobs SET
+    status = 'RUNNING',
+    lease_owner = @lease_owner,
+    lease_expires_at = NOW() + make_interval(secs => @lease_seconds::INTEGER),
+    start_time = COALESCE(start_time, NOW()),
+    run_after = NULL,
+    retry_count = CASE WHEN status = 'RUNNING' THEN retry_count + 1 ELSE retry_count END
+WHERE job_id = (
+    SELECT c.job_id FROM jobs c
+    WHERE
+        c.type = @type
+        AND ((c.status IN ('PENDING','RETRY') AND COALESCE(c.run_after, NOW()) <= NOW()) OR (c.status = 'RUNNING' AND COALESCE(c.lease_expires_at, NOW()) <= NOW() AND c.retry_count < @retries::INTEGER))
+    ORDER BY c.priority DESC, c.job_id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RenewJobLease :execrows
+UPDATE jobs SET lease_expires_at = NOW() + make_interval(secs => @lease_seconds::INTEGER)
+WHERE job_id = @job_id AND lease_owner = @lease_owner AND status = 'RUNNING';
+
+-- name: ReleaseJob :exec
+UPDATE jobs SET
+    status = @status,
+    end_time = @end_time,
+    retry_count = retry_count + @retry_increment::INTEGER,
+    comments = @comments,
+    run_after = @run_after,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = @job_id AND lease_owner = @lease_owner AND status = 'RUNNING';
+
+-- name: FailExpiredLeases :execrows
+UPDATE jobs SET status = 'DEAD', end_time = NOW(), comments = 'lease expired after all retries', lease_owner = NULL, lease_expires_at = NULL
+WHERE status = 'RUNNING' AND COALESCE(lease_expires_at, NOW()) <= NOW() AND retry_count >= @retries::INTEGER;
+
+-- name: ListJobs :many
+SELECT count(*) OVER() AS totalRecords,* FROM jobs
+WHERE
+    (@all_status::bool OR status = @status)
+    AND (@all_types::bool OR type = @type)
+ORDER BY job_id DESC
+LIMIT @page_size OFFSET @page_num;
+
+-- name: PurgeJobs :execrows
+DELETE FROM jobs
+WHERE
+    status = @status
+    AND (@all_types::bool OR type = @type)
+    AND (@all_jobs::bool OR job_id = ANY(@job_ids::INTEGER[]));
+
+-- name: ReleaseDueJobs :many
+UPDATE jobs SET run_after = NULL
+WHERE job_id IN (
+    SELECT d.job_id FROM jobs d
+    WHERE d.status IN ('PENDING','RETRY') AND d.run_after <= NOW()
+    ORDER BY d.priority DESC, d.run_after
+    LIMIT @max_jobs::INTEGER
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING job_id,type,priority;
+
+-- name: CreateJobs :many
+INSERT INTO jobs (type,status,data,comments,run_after,priority,trace_context,idempotency_key)
+SELECT t.type, 'PENDING', t.data::JSONB, NULLIF(t.comments,''), CASE WHEN @due::bool THEN @run_after::TIMESTAMP END, t.priority, @trace_context::BYTEA, NULLIF(t.idempotency_key,'')
+FROM unnest(@types::VARCHAR[], @data::VARCHAR[], @comments::VARCHAR[], @priorities::INTEGER[], @idempotency_keys::VARCHAR[]) WITH ORDINALITY AS t(type,data,comments,priority,idempotency_key,n)
+WHERE NOT EXISTS (
+    SELECT 1 FROM jobs c
+    WHERE c.type = t.type AND c.idempotency_key = t.idempotency_key AND c.status = 'COMPLETED' AND c.end_time >= @completed_after::TIMESTAMP
+)
+ORDER BY t.n
+ON CONFLICT (type,idempotency_key) WHERE idempotency_key IS NOT NULL AND status IN ('PENDING','RETRY','RUNNING') DO NOTHING
+RETURNING job_id,type,idempotency_key;
+
+-- name: DeferJob :exec
+UPDATE jobs SET
+    status = CASE WHEN retry_count > 0 THEN 'RETRY'::job_status ELSE 'PENDING'::job_status END,
+    run_after = @run_after,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = @job_id AND status <> 'CANCELLED';
+
+-- name: InterruptJob :exec
+UPDATE jobs SET
+    status = 'PENDING',
+    start_time = NULL,
+    run_after = NULL,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE job_id = @job_id AND status IN ('PENDING','RETRY','RUNNING') AND (lease_owner IS NULL OR lease_owner = @lease_owner);
+
+-- name: CountJobs :many
+SELECT type, status, count(*) AS total FROM jobs
+WHERE status IN ('PENDING','RETRY','RUNNING','DEAD')
+GROUP BY type, status;
+
+-- name: DeleteExpiredJobs :execrows
+DELETE FROM jobs
+WHERE job_id IN (
+    SELECT e.job_id FROM jobs e
+    WHERE
+        e.status IN ('COMPLETED','FAILED','DEAD','CANCELLED')
+        AND (e.status = 'COMPLETED') = @completed::bool
+        AND COALESCE(e.end_time, e.created_at) < @ended_before::TIMESTAMP
+    LIMIT @max_jobs::INTEGER
+    FOR UPDATE SKIP LOCKED
+);
+
+-- name: ArchiveExpiredJobs :execrows
+WITH expired AS (
+    DELETE FROM jobs
+    WHERE job_id IN (
+        SELECT e.job_id FROM jobs e
+        WHERE
+            e.status IN ('COMPLETED','FAILED','DEAD','CANCELLED')
+            AND (e.status = 'COMPLETED') = @completed::bool
+            AND COALESCE(e.end_time, e.created_at) < @ended_before::TIMESTAMP
+        LIMIT @max_jobs::INTEGER
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING job_id,type,status,data,comments,start_time,end_time,created_at,retry_count
+)
+INSERT INTO jobs_archive (job_id,type,status,data,comments,start_time,end_time,created_at,retry_count)
+SELECT job_id,type,status,data,comments,start_time,end_time,created_at,retry_count FROM expired
+ON CONFLICT (job_id) DO NOTHING;
+
+-- name: CountArchivedJobs :one
+SELECT count(*) FROM jobs_archive;
+
+-- name: ListJobsByIdempotencyKeys :many
+SELECT DISTINCT ON (j.type,j.idempotency_key) j.job_id, j.type, j.idempotency_key FROM jobs j
+JOIN unnest(@types::VARCHAR[], @idempotency_keys::VARCHAR[]) AS k(type,idempotency_key) ON j.type = k.type AND j.idempotency_key = k.idempotency_key
+WHERE j.status IN ('PENDING','RETRY','RUNNING') OR (j.status = 'COMPLETED' AND j.end_time >= @completed_after::TIMESTAMP)
+ORDER BY j.type, j.idempotency_key, j.job_id DESC;
+
+-- name: GetJobByIdempotencyKey :one
+SELECT * FROM jobs
+WHERE
+    type = @type
+    AND idempotency_key = @idempotency_key
+    AND (status IN ('PENDING','RETRY','RUNNING') OR (status = 'COMPLETED' AND end_time >= @completed_after::TIMESTAMP))
+ORDER BY job_id DESC
+LIMIT 1;
@@ -0,0 +1,9 @@
+-- +migrate Up notransaction
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- jobs cancelled before being processed are skipped by workers
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'CANCELLED';
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+-- values of an enum can not be removed
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- jobs of higher priority are run first, jobs of same priority in order of their creation
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
+
+DROP INDEX IF EXISTS jobs_claimable_idx;
+CREATE INDEX IF NOT EXISTS jobs_claimable_idx ON jobs (type,priority DESC,job_id) WHERE status IN ('PENDING','RETRY','RUNNING');
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS jobs_claimable_idx;
+CREATE INDEX IF NOT EXISTS jobs_claimable_idx ON jobs (type,job_id) WHERE status IN ('PENDING','RETRY','RUNNING');
+ALTER TABLE jobs DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,188 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+var (
+	createJobStatus = regexp.MustCompile(`(?i)CREATE\s+TYPE\s+job_status\s+AS\s+ENUM\s*\(([^)]*)\)`)
+	alterJobStatus  = regexp.MustCompile(`(?i)ALTER\s+TYPE\s+job_status\s+ADD\s+VALUE\s+(?:IF\s+NOT\s+EXISTS\s+)?'(\w+)'`)
+	jobsTable       = regexp.MustCompile(`(?i)\bjobs(_archive)?\b`)
+	statusIn        = regexp.MustCompile(`(?i)\bstatus\s+(?:NOT\s+)?IN\s*\(([^)]*)\)`)
+	statusCompared  = regexp.MustCompile(`(?i)\bstatus\s*(?:=|<>|!=)\s*'(\w+)'`)
+	statusCast      = regexp.MustCompile(`'(\w+)'::job_status`)
+	quoted          = regexp.MustCompile(`'(\w+)'`)
+)
+
+//schemaDirs gives the migrations of workerqueue and of every service running it
+func schemaDirs(t *testing.T) []string {
+	services, err := filepath.Glob("../../../../../*-service/pkg/repository/v1/postgres/schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirs := []string{"schema"}
+	for _, dir := range services {
+		files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if createJobStatus.Match(data) {
+				dirs = append(dirs, dir)
+				break
+			}
+		}
+	}
+	return dirs
+}
+
+//jobStatuses gives the values of job status compared in statement
+func jobStatuses(statement string) []string {
+	var statuses []string
+	for _, match := range statusIn.FindAllStringSubmatch(statement, -1) {
+		for _, value := range quoted.FindAllStringSubmatch(match[1], -1) {
+			statuses = append(statuses, value[1])
+		}
+	}
+	for _, re := range []*regexp.Regexp{statusCompared, statusCast} {
+		for _, match := range re.FindAllStringSubmatch(statement, -1) {
+			statuses = append(statuses, match[1])
+		}
+	}
+	return statuses
+}
+
+//replayJobStatus applies the migrations of dir in the order of sql-migrate and gives the values of job status they define.
+//It fails when a statement on jobs uses a value not yet defined or when a value is added inside a transaction.
+func replayJobStatus(t *testing.T, dir string) map[string]bool {
+	migrations, err := (&migrate.FileMigrationSource{Dir: dir}).FindMigrations()
+	if err != nil {
+		t.Fatalf("cannot read migrations of %s: %v", dir, err)
+	}
+	defined := make(map[string]bool)
+	for _, m := range migrations {
+		for _, statement := range m.Up {
+			if match := createJobStatus.FindStringSubmatch(statement); match != nil {
+				for _, value := range quoted.FindAllStringSubmatch(match[1], -1) {
+					defined[value[1]] = true
+				}
+				continue
+			}
+			if match := alterJobStatus.FindStringSubmatch(statement); match != nil {
+				if !m.DisableTransactionUp {
+					t.Errorf("%s/%s: job status %s is added inside a transaction, migration must be notransaction", dir, m.Id, match[1])
+				}
+				defined[match[1]] = true
+				continue
+			}
+			if !jobsTable.MatchString(statement) {
+				continue
+			}
+			for _, status := range jobStatuses(statement) {
+				if !defined[status] {
+					t.Errorf("%s/%s: job status %s is used before being added", dir, m.Id, status)
+				}
+			}
+		}
+	}
+	return defined
+}
+
+func TestMigrations_jobStatus(t *testing.T) {
+	for _, dir := range schemaDirs(t) {
+		t.Run(dir, func(t *testing.T) {
+			replayJobStatus(t, dir)
+		})
+	}
+}
+
+//TestMigrations_versions checks no two migrations of workerqueue or of a service running it share a version
+func TestMigrations_versions(t *testing.T) {
+	for _, dir := range schemaDirs(t) {
+		t.Run(dir, func(t *testing.T) {
+			migrations, err := (&migrate.FileMigrationSource{Dir: dir}).FindMigrations()
+			if err != nil {
+				t.Fatalf("cannot read migrations of %s: %v", dir, err)
+			}
+			ids := make(map[int64]string)
+			for _, m := range migrations {
+				if id, ok := ids[m.VersionInt()]; ok {
+					t.Errorf("%s: migrations %s and %s have the same version", dir, id, m.Id)
+				}
+				ids[m.VersionInt()] = m.Id
+			}
+		})
+	}
+}
+
+//TestQueries_jobStatus checks the queries of workerqueue only use job status values defined by every service running it
+func TestQueries_jobStatus(t *testing.T) {
+	queries, err := ioutil.ReadFile("query/query.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]bool)
+	for _, status := range jobStatuses(string(queries)) {
+		statuses[status] = true
+	}
+	for _, dir := range schemaDirs(t) {
+		t.Run(dir, func(t *testing.T) {
+			defined := replayJobStatus(t, dir)
+			for status := range statuses {
+				if !defined[status] {
+					t.Errorf("job status %s of queries is not added by migrations of %s", status, dir)
+				}
+			}
+		})
+	}
+}
+
+//TestMigrations_apply applies in order the migrations of every service running workerqueue,
+//each one in its own postgres schema. It is run when POSTGRES_TEST_DSN gives a database to use.
+func TestMigrations_apply(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+	for i, dir := range schemaDirs(t) {
+		t.Run(dir, func(t *testing.T) {
+			db, err := sql.Open("postgres", dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			//search path is set on the only connection of db
+			db.SetMaxOpenConns(1)
+			schema := fmt.Sprintf("workerqueue_migrations_%d", i)
+			if _, err := db.Exec("CREATE SCHEMA " + schema); err != nil {
+				t.Fatal(err)
+			}
+			defer db.Exec("DROP SCHEMA " + schema + " CASCADE") // nolint: errcheck
+			if _, err := db.Exec("SET search_path TO " + schema); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := migrate.Exec(db, "postgres", &migrate.FileMigrationSource{Dir: dir}, migrate.Up); err != nil {
+				t.Fatalf("cannot apply migrations of %s: %v", dir, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"optisam-backend/common/optisam/logger"
+	dbgen "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//maxJobsPerSweep is the number of expired jobs removed by one statement, a sweep repeats it until none is left
+const maxJobsPerSweep = 1000
+
+//retention holds the policy of queue on its ended jobs and the counts of its sweeps on this replica
+type retention struct {
+	RetentionConfig
+	mu            sync.Mutex
+	lastSweptOn   time.Time
+	lastSwept     int64
+	totalArchived int64
+	totalDeleted  int64
+}
+
+func newRetention(conf RetentionConfig) *retention {
+	r := &retention{RetentionConfig: conf}
+	if r.SweepRate <= 0 {
+		r.SweepRate = time.Hour //Default
+	}
+	return r
+}
+
+func (r *retention) enabled() bool {
+	return r.CompletedDays > 0 || r.FailedDays > 0
+}
+
+//sweepJobs sweeps expired jobs periodically, replicas sweep together without removing a job twice
+func (q *Queue) sweepJobs(ctx context.Context) {
+	defer q.wg.Done()
+	for {
+		q.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stopped():
+			return
+		case <-time.After(q.retention.SweepRate):
+		}
+	}
+}
+
+//sweep archives or deletes the jobs ended before their retention and gives their number
+func (q *Queue) sweep(ctx context.Context) int64 {
+	now := time.Now()
+	var swept int64
+	for _, policy := range []struct {
+		completed bool
+		days      int
+	}{
+		{completed: true, days: q.retention.CompletedDays},
+		{completed: false, days: q.retention.FailedDays},
+	} {
+		if policy.days <= 0 {
+			continue
+		}
+		endedBefore := now.AddDate(0, 0, -policy.days)
+		for {
+			n, err := q.removeExpired(ctx, policy.completed, endedBefore)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Log.Error("Failed to sweep expired jobs", zap.Bool("completed", policy.completed), zap.Error(err))
+				}
+				break
+			}
+			swept += n
+			if n < maxJobsPerSweep {
+				break
+			}
+		}
+	}
+	q.retention.mu.Lock()
+	q.retention.lastSweptOn = now
+	q.retention.lastSwept = swept
+	if q.retention.Archive {
+		q.retention.totalArchived += swept
+	} else {
+		q.retention.totalDeleted += swept
+	}
+	q.retention.mu.Unlock()
+	if swept > 0 {
+		logger.Log.Info("Expired jobs swept", zap.String("queue", q.ID), zap.Int64("swept", swept), zap.Bool("archived", q.retention.Archive))
+	}
+	return swept
+}
+
+func (q *Queue) removeExpired(ctx context.Context, completed bool, endedBefore time.Time) (int64, error) {
+	if q.retention.Archive {
+		return q.repo.ArchiveExpiredJobs(ctx, dbgen.ArchiveExpiredJobsParams{Completed: completed, EndedBefore: endedBefore, MaxJobs: maxJobsPerSweep})
+	}
+	return q.repo.DeleteExpiredJobs(ctx, dbgen.DeleteExpiredJobsParams{Completed: completed, EndedBefore: endedBefore, MaxJobs: maxJobsPerSweep})
+}
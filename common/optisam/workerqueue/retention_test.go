@@ -0,0 +1,102 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package workerqueue
+
+import (
+	"context"
+	"errors"
+	"optisam-backend/common/optisam/workerqueue/repository/mock"
+	"optisam-backend/common/optisam/workerqueue/repository/postgres/db"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestQueue_sweep(t *testing.T) {
+	var mockRepo *mock.MockWorkerqueue
+	//expired gives the jobs removed by a statement once its cut-off is checked
+	expired := func(t *testing.T, days int, n int64, err error) func(context.Context, db.DeleteExpiredJobsParams) (int64, error) {
+		return func(_ context.Context, arg db.DeleteExpiredJobsParams) (int64, error) {
+			want := time.Now().AddDate(0, 0, -days)
+			if arg.EndedBefore.After(want) || arg.EndedBefore.Before(want.Add(-time.Minute)) || arg.MaxJobs != maxJobsPerSweep {
+				t.Errorf("Queue.sweep() params = %+v, want jobs ended %d days ago", arg, days)
+			}
+			return n, err
+		}
+	}
+	tests := []struct {
+		name         string
+		conf         RetentionConfig
+		setup        func(t *testing.T)
+		want         int64
+		wantArchived int64
+		wantDeleted  int64
+	}{
+		{
+			name: "SUCCESS - completed jobs are deleted batch by batch, failed jobs are kept",
+			conf: RetentionConfig{CompletedDays: 30},
+			setup: func(t *testing.T) {
+				gomock.InOrder(
+					mockRepo.EXPECT().DeleteExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(expired(t, 30, maxJobsPerSweep, nil)),
+					mockRepo.EXPECT().DeleteExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(expired(t, 30, 5, nil)),
+				)
+			},
+			want:        1005,
+			wantDeleted: 1005,
+		},
+		{
+			name: "SUCCESS - completed and failed jobs are archived",
+			conf: RetentionConfig{CompletedDays: 30, FailedDays: 90, Archive: true},
+			setup: func(t *testing.T) {
+				gomock.InOrder(
+					mockRepo.EXPECT().ArchiveExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.ArchiveExpiredJobsParams) (int64, error) {
+						if !arg.Completed {
+							t.Errorf("Queue.sweep() archived failed jobs first")
+						}
+						return 3, nil
+					}),
+					mockRepo.EXPECT().ArchiveExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.ArchiveExpiredJobsParams) (int64, error) {
+						if arg.Completed {
+							t.Errorf("Queue.sweep() archived completed jobs twice")
+						}
+						return 2, nil
+					}),
+				)
+			},
+			want:         5,
+			wantArchived: 5,
+		},
+		{
+			name: "FAILURE - failed jobs are swept even if completed jobs can not be",
+			conf: RetentionConfig{CompletedDays: 30, FailedDays: 90},
+			setup: func(t *testing.T) {
+				gomock.InOrder(
+					mockRepo.EXPECT().DeleteExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(expired(t, 30, 0, errors.New("db error"))),
+					mockRepo.EXPECT().DeleteExpiredJobs(gomock.Any(), gomock.Any()).DoAndReturn(expired(t, 90, 1, nil)),
+				)
+			},
+			want:        1,
+			wantDeleted: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRepo = mock.NewMockWorkerqueue(mockCtrl)
+			tt.setup(t)
+			q := &Queue{ID: "q", repo: mockRepo, retention: newRetention(tt.conf)}
+			if got := q.sweep(context.Background()); got != tt.want {
+				t.Errorf("Queue.sweep() = %v, want %v", got, tt.want)
+			}
+			if q.retention.lastSwept != tt.want || q.retention.totalArchived != tt.wantArchived || q.retention.totalDeleted != tt.wantDeleted || q.retention.lastSweptOn.IsZero() {
+				t.Errorf("Queue.sweep() counts = %+v, want archived %v deleted %v", q.retention, tt.wantArchived, tt.wantDeleted)
+			}
+		})
+	}
+}
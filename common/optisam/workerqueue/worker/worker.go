@@ -11,8 +11,9 @@ import (
 	"optisam-backend/common/optisam/workerqueue/job"
 )
 
+// Worker represents a worker for handling Jobs
+//
 //go:generate mockgen -destination=mock/mock.go -package=mock optisam-backend/common/optisam/workerqueue/worker Worker
-//Worker represents a worker for handling Jobs
 type Worker interface {
 	//DoWork is called when a worker picks up a job from the queue
 	DoWork(context.Context, *job.Job) error
@@ -20,3 +21,9 @@ type Worker interface {
 	//it is primarily used for logging purposes
 	ID() string
 }
+
+//Keyed is implemented by workers whose concurrency limit applies per key of job rather than per worker type
+type Keyed interface {
+	//ConcurrencyKey gives the key a job is limited by, eg: the service a job sends its data to
+	ConcurrencyKey(*job.Job) string
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"optisam-backend/common/optisam/workerqueue/job"
 	"optisam-backend/common/optisam/workerqueue/worker"
+	"time"
 )
 
 //go:generate mockgen -destination=mock/mock.go -package=mock optisam-backend/common/optisam/workerqueue Workerqueue
@@ -18,6 +19,9 @@ type Workerqueue interface {
 	Close(ctx context.Context)
 	RegisterWorker(ctx context.Context, w worker.Worker)
 	PushJob(ctx context.Context, j job.Job, workerName string) (int32, error)
+	PushJobAt(ctx context.Context, j job.Job, workerName string, at time.Time) (int32, error)
+	PushJobs(ctx context.Context, jobs []job.Job, workerName string) ([]int32, error)
+	RequeueJob(ctx context.Context, jobID int32, workerName string) error
 	ResumePendingJobs(ctx context.Context) error
 	GetRetries() int32
 }
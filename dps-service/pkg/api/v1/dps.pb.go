@@ -15,8 +15,10 @@ import (
 	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -68,7 +70,7 @@ func (x ListUploadRequest_SortBy) String() string {
 }
 
 func (ListUploadRequest_SortBy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_a611899297971007, []int{2, 0}
+	return fileDescriptor_a611899297971007, []int{12, 0}
 }
 
 type ListUploadRequest_SortOrder int32
@@ -93,15 +95,23 @@ func (x ListUploadRequest_SortOrder) String() string {
 }
 
 func (ListUploadRequest_SortOrder) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_a611899297971007, []int{2, 1}
+	return fileDescriptor_a611899297971007, []int{12, 1}
 }
 
 type NotifyUploadRequest struct {
-	Scope                string   `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
-	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	UploadId             int32    `protobuf:"varint,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
-	UploadedBy           string   `protobuf:"bytes,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
-	Files                []string `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	Scope      string   `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Type       string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	UploadId   int32    `protobuf:"varint,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	UploadedBy string   `protobuf:"bytes,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	Files      []string `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	DryRun     bool     `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// files are one upload processed one after another in the given order
+	Ordered bool `protobuf:"varint,7,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	// only the rows changed since the last applied file of same type are processed,
+	// with dry_run the changes are reported without being applied.
+	// Equipment and metadata files are applied in full, rows removed from products
+	// and acquired rights files are not deleted
+	Delta                bool     `protobuf:"varint,8,opt,name=delta,proto3" json:"delta,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -167,11 +177,35 @@ func (m *NotifyUploadRequest) GetFiles() []string {
 	return nil
 }
 
+func (m *NotifyUploadRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *NotifyUploadRequest) GetOrdered() bool {
+	if m != nil {
+		return m.Ordered
+	}
+	return false
+}
+
+func (m *NotifyUploadRequest) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
 type NotifyUploadResponse struct {
-	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Success              bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reports              []*FileValidationReport `protobuf:"bytes,2,rep,name=reports,proto3" json:"reports,omitempty"`
+	UploadId             int32                   `protobuf:"varint,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Deltas               []*FileDelta            `protobuf:"bytes,4,rep,name=deltas,proto3" json:"deltas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *NotifyUploadResponse) Reset()         { *m = NotifyUploadResponse{} }
@@ -206,223 +240,1267 @@ func (m *NotifyUploadResponse) GetSuccess() bool {
 	return false
 }
 
-type ListUploadRequest struct {
-	PageNum              int32                       `protobuf:"varint,1,opt,name=page_num,json=pageNum,proto3" json:"page_num,omitempty"`
-	PageSize             int32                       `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	SortBy               ListUploadRequest_SortBy    `protobuf:"varint,3,opt,name=sort_by,json=sortBy,proto3,enum=v1.ListUploadRequest_SortBy" json:"sort_by,omitempty"`
-	SortOrder            ListUploadRequest_SortOrder `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=v1.ListUploadRequest_SortOrder" json:"sort_order,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+func (m *NotifyUploadResponse) GetReports() []*FileValidationReport {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
 }
 
-func (m *ListUploadRequest) Reset()         { *m = ListUploadRequest{} }
-func (m *ListUploadRequest) String() string { return proto.CompactTextString(m) }
-func (*ListUploadRequest) ProtoMessage()    {}
-func (*ListUploadRequest) Descriptor() ([]byte, []int) {
+func (m *NotifyUploadResponse) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+func (m *NotifyUploadResponse) GetDeltas() []*FileDelta {
+	if m != nil {
+		return m.Deltas
+	}
+	return nil
+}
+
+type FileDelta struct {
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileType string `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	// upload of the file compared with, 0 when no file of the type is applied yet
+	PreviousUploadId int32 `protobuf:"varint,3,opt,name=previous_upload_id,json=previousUploadId,proto3" json:"previous_upload_id,omitempty"`
+	// delta is not supported for the type, whole file is applied
+	Full                 bool        `protobuf:"varint,4,opt,name=full,proto3" json:"full,omitempty"`
+	Added                int32       `protobuf:"varint,5,opt,name=added,proto3" json:"added,omitempty"`
+	Changed              int32       `protobuf:"varint,6,opt,name=changed,proto3" json:"changed,omitempty"`
+	Removed              int32       `protobuf:"varint,7,opt,name=removed,proto3" json:"removed,omitempty"`
+	Unchanged            int32       `protobuf:"varint,8,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Rows                 []*DeltaRow `protobuf:"bytes,9,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FileDelta) Reset()         { *m = FileDelta{} }
+func (m *FileDelta) String() string { return proto.CompactTextString(m) }
+func (*FileDelta) ProtoMessage()    {}
+func (*FileDelta) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a611899297971007, []int{2}
 }
 
-func (m *ListUploadRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListUploadRequest.Unmarshal(m, b)
+func (m *FileDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileDelta.Unmarshal(m, b)
 }
-func (m *ListUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListUploadRequest.Marshal(b, m, deterministic)
+func (m *FileDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileDelta.Marshal(b, m, deterministic)
 }
-func (m *ListUploadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListUploadRequest.Merge(m, src)
+func (m *FileDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileDelta.Merge(m, src)
 }
-func (m *ListUploadRequest) XXX_Size() int {
-	return xxx_messageInfo_ListUploadRequest.Size(m)
+func (m *FileDelta) XXX_Size() int {
+	return xxx_messageInfo_FileDelta.Size(m)
 }
-func (m *ListUploadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListUploadRequest.DiscardUnknown(m)
+func (m *FileDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileDelta.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListUploadRequest proto.InternalMessageInfo
+var xxx_messageInfo_FileDelta proto.InternalMessageInfo
 
-func (m *ListUploadRequest) GetPageNum() int32 {
+func (m *FileDelta) GetFileName() string {
 	if m != nil {
-		return m.PageNum
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *FileDelta) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *FileDelta) GetPreviousUploadId() int32 {
+	if m != nil {
+		return m.PreviousUploadId
 	}
 	return 0
 }
 
-func (m *ListUploadRequest) GetPageSize() int32 {
+func (m *FileDelta) GetFull() bool {
 	if m != nil {
-		return m.PageSize
+		return m.Full
+	}
+	return false
+}
+
+func (m *FileDelta) GetAdded() int32 {
+	if m != nil {
+		return m.Added
 	}
 	return 0
 }
 
-func (m *ListUploadRequest) GetSortBy() ListUploadRequest_SortBy {
+func (m *FileDelta) GetChanged() int32 {
 	if m != nil {
-		return m.SortBy
+		return m.Changed
 	}
-	return ListUploadRequest_upload_id
+	return 0
 }
 
-func (m *ListUploadRequest) GetSortOrder() ListUploadRequest_SortOrder {
+func (m *FileDelta) GetRemoved() int32 {
 	if m != nil {
-		return m.SortOrder
+		return m.Removed
 	}
-	return ListUploadRequest_asc
+	return 0
 }
 
-type ListUploadResponse struct {
-	TotalRecords         int32     `protobuf:"varint,1,opt,name=totalRecords,proto3" json:"totalRecords,omitempty"`
-	Uploads              []*Upload `protobuf:"bytes,2,rep,name=uploads,proto3" json:"uploads,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *FileDelta) GetUnchanged() int32 {
+	if m != nil {
+		return m.Unchanged
+	}
+	return 0
 }
 
-func (m *ListUploadResponse) Reset()         { *m = ListUploadResponse{} }
-func (m *ListUploadResponse) String() string { return proto.CompactTextString(m) }
-func (*ListUploadResponse) ProtoMessage()    {}
-func (*ListUploadResponse) Descriptor() ([]byte, []int) {
+func (m *FileDelta) GetRows() []*DeltaRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type DeltaRow struct {
+	// line of file, 0 for a removed row
+	Line                 int32    `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Change               string   `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeltaRow) Reset()         { *m = DeltaRow{} }
+func (m *DeltaRow) String() string { return proto.CompactTextString(m) }
+func (*DeltaRow) ProtoMessage()    {}
+func (*DeltaRow) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a611899297971007, []int{3}
 }
 
-func (m *ListUploadResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListUploadResponse.Unmarshal(m, b)
+func (m *DeltaRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaRow.Unmarshal(m, b)
+}
+func (m *DeltaRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaRow.Marshal(b, m, deterministic)
+}
+func (m *DeltaRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaRow.Merge(m, src)
+}
+func (m *DeltaRow) XXX_Size() int {
+	return xxx_messageInfo_DeltaRow.Size(m)
+}
+func (m *DeltaRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeltaRow proto.InternalMessageInfo
+
+func (m *DeltaRow) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *DeltaRow) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *DeltaRow) GetChange() string {
+	if m != nil {
+		return m.Change
+	}
+	return ""
+}
+
+type GetUploadRequest struct {
+	UploadId             int32    `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUploadRequest) Reset()         { *m = GetUploadRequest{} }
+func (m *GetUploadRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUploadRequest) ProtoMessage()    {}
+func (*GetUploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{4}
+}
+
+func (m *GetUploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUploadRequest.Unmarshal(m, b)
+}
+func (m *GetUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUploadRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUploadRequest.Merge(m, src)
+}
+func (m *GetUploadRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUploadRequest.Size(m)
+}
+func (m *GetUploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUploadRequest proto.InternalMessageInfo
+
+func (m *GetUploadRequest) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+type GetUploadResponse struct {
+	UploadId int32  `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Scope    string `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	DataType string `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	// status of upload derived from the status of its files and their jobs
+	Status     string               `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UploadedBy string               `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	UploadedOn *timestamp.Timestamp `protobuf:"bytes,6,opt,name=uploaded_on,json=uploadedOn,proto3" json:"uploaded_on,omitempty"`
+	TotalFiles int32                `protobuf:"varint,7,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	// files processed with all their jobs
+	DoneFiles int32 `protobuf:"varint,8,opt,name=done_files,json=doneFiles,proto3" json:"done_files,omitempty"`
+	// percentage of files processed
+	Progress             float32              `protobuf:"fixed32,9,opt,name=progress,proto3" json:"progress,omitempty"`
+	PendingJobs          int32                `protobuf:"varint,10,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
+	TotalRecords         int32                `protobuf:"varint,11,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	ProcessedRecords     int32                `protobuf:"varint,12,opt,name=processed_records,json=processedRecords,proto3" json:"processed_records,omitempty"`
+	SuccessRecords       int32                `protobuf:"varint,13,opt,name=success_records,json=successRecords,proto3" json:"success_records,omitempty"`
+	FailedRecords        int32                `protobuf:"varint,14,opt,name=failed_records,json=failedRecords,proto3" json:"failed_records,omitempty"`
+	InvalidRecords       int32                `protobuf:"varint,15,opt,name=invalid_records,json=invalidRecords,proto3" json:"invalid_records,omitempty"`
+	CancelledBy          string               `protobuf:"bytes,16,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	CancelledOn          *timestamp.Timestamp `protobuf:"bytes,17,opt,name=cancelled_on,json=cancelledOn,proto3" json:"cancelled_on,omitempty"`
+	Files                []*Upload            `protobuf:"bytes,18,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetUploadResponse) Reset()         { *m = GetUploadResponse{} }
+func (m *GetUploadResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUploadResponse) ProtoMessage()    {}
+func (*GetUploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{5}
+}
+
+func (m *GetUploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUploadResponse.Unmarshal(m, b)
+}
+func (m *GetUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUploadResponse.Marshal(b, m, deterministic)
+}
+func (m *GetUploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUploadResponse.Merge(m, src)
+}
+func (m *GetUploadResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUploadResponse.Size(m)
+}
+func (m *GetUploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUploadResponse proto.InternalMessageInfo
+
+func (m *GetUploadResponse) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *GetUploadResponse) GetDataType() string {
+	if m != nil {
+		return m.DataType
+	}
+	return ""
+}
+
+func (m *GetUploadResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetUploadResponse) GetUploadedBy() string {
+	if m != nil {
+		return m.UploadedBy
+	}
+	return ""
+}
+
+func (m *GetUploadResponse) GetUploadedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.UploadedOn
+	}
+	return nil
+}
+
+func (m *GetUploadResponse) GetTotalFiles() int32 {
+	if m != nil {
+		return m.TotalFiles
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetDoneFiles() int32 {
+	if m != nil {
+		return m.DoneFiles
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetProgress() float32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetPendingJobs() int32 {
+	if m != nil {
+		return m.PendingJobs
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetTotalRecords() int32 {
+	if m != nil {
+		return m.TotalRecords
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetProcessedRecords() int32 {
+	if m != nil {
+		return m.ProcessedRecords
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetSuccessRecords() int32 {
+	if m != nil {
+		return m.SuccessRecords
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetFailedRecords() int32 {
+	if m != nil {
+		return m.FailedRecords
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetInvalidRecords() int32 {
+	if m != nil {
+		return m.InvalidRecords
+	}
+	return 0
+}
+
+func (m *GetUploadResponse) GetCancelledBy() string {
+	if m != nil {
+		return m.CancelledBy
+	}
+	return ""
+}
+
+func (m *GetUploadResponse) GetCancelledOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.CancelledOn
+	}
+	return nil
+}
+
+func (m *GetUploadResponse) GetFiles() []*Upload {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type CancelUploadRequest struct {
+	UploadId             int32    `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelUploadRequest) Reset()         { *m = CancelUploadRequest{} }
+func (m *CancelUploadRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelUploadRequest) ProtoMessage()    {}
+func (*CancelUploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{6}
+}
+
+func (m *CancelUploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelUploadRequest.Unmarshal(m, b)
+}
+func (m *CancelUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelUploadRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelUploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelUploadRequest.Merge(m, src)
+}
+func (m *CancelUploadRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelUploadRequest.Size(m)
+}
+func (m *CancelUploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelUploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelUploadRequest proto.InternalMessageInfo
+
+func (m *CancelUploadRequest) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+type CancelUploadResponse struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	JobsCancelled        int32    `protobuf:"varint,2,opt,name=jobs_cancelled,json=jobsCancelled,proto3" json:"jobs_cancelled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelUploadResponse) Reset()         { *m = CancelUploadResponse{} }
+func (m *CancelUploadResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelUploadResponse) ProtoMessage()    {}
+func (*CancelUploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{7}
+}
+
+func (m *CancelUploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelUploadResponse.Unmarshal(m, b)
+}
+func (m *CancelUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelUploadResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelUploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelUploadResponse.Merge(m, src)
+}
+func (m *CancelUploadResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelUploadResponse.Size(m)
+}
+func (m *CancelUploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelUploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelUploadResponse proto.InternalMessageInfo
+
+func (m *CancelUploadResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *CancelUploadResponse) GetJobsCancelled() int32 {
+	if m != nil {
+		return m.JobsCancelled
+	}
+	return 0
+}
+
+type ValidateUploadRequest struct {
+	Scope                string   `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Files                []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateUploadRequest) Reset()         { *m = ValidateUploadRequest{} }
+func (m *ValidateUploadRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateUploadRequest) ProtoMessage()    {}
+func (*ValidateUploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{8}
+}
+
+func (m *ValidateUploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateUploadRequest.Unmarshal(m, b)
+}
+func (m *ValidateUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateUploadRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidateUploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateUploadRequest.Merge(m, src)
+}
+func (m *ValidateUploadRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateUploadRequest.Size(m)
+}
+func (m *ValidateUploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateUploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateUploadRequest proto.InternalMessageInfo
+
+func (m *ValidateUploadRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *ValidateUploadRequest) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type ValidateUploadResponse struct {
+	Valid                bool                    `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Reports              []*FileValidationReport `protobuf:"bytes,2,rep,name=reports,proto3" json:"reports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ValidateUploadResponse) Reset()         { *m = ValidateUploadResponse{} }
+func (m *ValidateUploadResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateUploadResponse) ProtoMessage()    {}
+func (*ValidateUploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{9}
+}
+
+func (m *ValidateUploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateUploadResponse.Unmarshal(m, b)
+}
+func (m *ValidateUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateUploadResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidateUploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateUploadResponse.Merge(m, src)
+}
+func (m *ValidateUploadResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateUploadResponse.Size(m)
+}
+func (m *ValidateUploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateUploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateUploadResponse proto.InternalMessageInfo
+
+func (m *ValidateUploadResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateUploadResponse) GetReports() []*FileValidationReport {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
+}
+
+type FileValidationReport struct {
+	FileName             string      `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileType             string      `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	TotalRecords         int32       `protobuf:"varint,3,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	InvalidRecords       int32       `protobuf:"varint,4,opt,name=invalid_records,json=invalidRecords,proto3" json:"invalid_records,omitempty"`
+	Errors               []*RowError `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FileValidationReport) Reset()         { *m = FileValidationReport{} }
+func (m *FileValidationReport) String() string { return proto.CompactTextString(m) }
+func (*FileValidationReport) ProtoMessage()    {}
+func (*FileValidationReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{10}
+}
+
+func (m *FileValidationReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileValidationReport.Unmarshal(m, b)
+}
+func (m *FileValidationReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileValidationReport.Marshal(b, m, deterministic)
+}
+func (m *FileValidationReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileValidationReport.Merge(m, src)
+}
+func (m *FileValidationReport) XXX_Size() int {
+	return xxx_messageInfo_FileValidationReport.Size(m)
+}
+func (m *FileValidationReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileValidationReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileValidationReport proto.InternalMessageInfo
+
+func (m *FileValidationReport) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *FileValidationReport) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *FileValidationReport) GetTotalRecords() int32 {
+	if m != nil {
+		return m.TotalRecords
+	}
+	return 0
+}
+
+func (m *FileValidationReport) GetInvalidRecords() int32 {
+	if m != nil {
+		return m.InvalidRecords
+	}
+	return 0
+}
+
+func (m *FileValidationReport) GetErrors() []*RowError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type RowError struct {
+	Line                 int32    `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Column               string   `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowError) Reset()         { *m = RowError{} }
+func (m *RowError) String() string { return proto.CompactTextString(m) }
+func (*RowError) ProtoMessage()    {}
+func (*RowError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{11}
+}
+
+func (m *RowError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowError.Unmarshal(m, b)
+}
+func (m *RowError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowError.Marshal(b, m, deterministic)
+}
+func (m *RowError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowError.Merge(m, src)
+}
+func (m *RowError) XXX_Size() int {
+	return xxx_messageInfo_RowError.Size(m)
+}
+func (m *RowError) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowError proto.InternalMessageInfo
+
+func (m *RowError) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *RowError) GetColumn() string {
+	if m != nil {
+		return m.Column
+	}
+	return ""
+}
+
+func (m *RowError) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ListUploadRequest struct {
+	PageNum              int32                       `protobuf:"varint,1,opt,name=page_num,json=pageNum,proto3" json:"page_num,omitempty"`
+	PageSize             int32                       `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy               ListUploadRequest_SortBy    `protobuf:"varint,3,opt,name=sort_by,json=sortBy,proto3,enum=v1.ListUploadRequest_SortBy" json:"sort_by,omitempty"`
+	SortOrder            ListUploadRequest_SortOrder `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,enum=v1.ListUploadRequest_SortOrder" json:"sort_order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ListUploadRequest) Reset()         { *m = ListUploadRequest{} }
+func (m *ListUploadRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUploadRequest) ProtoMessage()    {}
+func (*ListUploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{12}
+}
+
+func (m *ListUploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUploadRequest.Unmarshal(m, b)
+}
+func (m *ListUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUploadRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUploadRequest.Merge(m, src)
+}
+func (m *ListUploadRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUploadRequest.Size(m)
+}
+func (m *ListUploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUploadRequest proto.InternalMessageInfo
+
+func (m *ListUploadRequest) GetPageNum() int32 {
+	if m != nil {
+		return m.PageNum
+	}
+	return 0
+}
+
+func (m *ListUploadRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListUploadRequest) GetSortBy() ListUploadRequest_SortBy {
+	if m != nil {
+		return m.SortBy
+	}
+	return ListUploadRequest_upload_id
+}
+
+func (m *ListUploadRequest) GetSortOrder() ListUploadRequest_SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return ListUploadRequest_asc
+}
+
+type ListUploadResponse struct {
+	TotalRecords         int32     `protobuf:"varint,1,opt,name=totalRecords,proto3" json:"totalRecords,omitempty"`
+	Uploads              []*Upload `protobuf:"bytes,2,rep,name=uploads,proto3" json:"uploads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListUploadResponse) Reset()         { *m = ListUploadResponse{} }
+func (m *ListUploadResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUploadResponse) ProtoMessage()    {}
+func (*ListUploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{13}
+}
+
+func (m *ListUploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUploadResponse.Unmarshal(m, b)
+}
+func (m *ListUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUploadResponse.Marshal(b, m, deterministic)
+}
+func (m *ListUploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUploadResponse.Merge(m, src)
+}
+func (m *ListUploadResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUploadResponse.Size(m)
+}
+func (m *ListUploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUploadResponse proto.InternalMessageInfo
+
+func (m *ListUploadResponse) GetTotalRecords() int32 {
+	if m != nil {
+		return m.TotalRecords
+	}
+	return 0
+}
+
+func (m *ListUploadResponse) GetUploads() []*Upload {
+	if m != nil {
+		return m.Uploads
+	}
+	return nil
+}
+
+type Upload struct {
+	UploadId             int32                `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Scope                string               `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	FileName             string               `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Status               string               `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UploadedBy           string               `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	UploadedOn           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=uploaded_on,json=uploadedOn,proto3" json:"uploaded_on,omitempty"`
+	TotalRecords         int32                `protobuf:"varint,7,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	SuccessRecords       int32                `protobuf:"varint,8,opt,name=success_records,json=successRecords,proto3" json:"success_records,omitempty"`
+	FailedRecords        int32                `protobuf:"varint,9,opt,name=failed_records,json=failedRecords,proto3" json:"failed_records,omitempty"`
+	InvalidRecords       int32                `protobuf:"varint,10,opt,name=invalid_records,json=invalidRecords,proto3" json:"invalid_records,omitempty"`
+	ProcessedRecords     int32                `protobuf:"varint,11,opt,name=processed_records,json=processedRecords,proto3" json:"processed_records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Upload) Reset()         { *m = Upload{} }
+func (m *Upload) String() string { return proto.CompactTextString(m) }
+func (*Upload) ProtoMessage()    {}
+func (*Upload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{14}
+}
+
+func (m *Upload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Upload.Unmarshal(m, b)
+}
+func (m *Upload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Upload.Marshal(b, m, deterministic)
+}
+func (m *Upload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Upload.Merge(m, src)
+}
+func (m *Upload) XXX_Size() int {
+	return xxx_messageInfo_Upload.Size(m)
+}
+func (m *Upload) XXX_DiscardUnknown() {
+	xxx_messageInfo_Upload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Upload proto.InternalMessageInfo
+
+func (m *Upload) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+func (m *Upload) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *Upload) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *Upload) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Upload) GetUploadedBy() string {
+	if m != nil {
+		return m.UploadedBy
+	}
+	return ""
+}
+
+func (m *Upload) GetUploadedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.UploadedOn
+	}
+	return nil
+}
+
+func (m *Upload) GetTotalRecords() int32 {
+	if m != nil {
+		return m.TotalRecords
+	}
+	return 0
+}
+
+func (m *Upload) GetSuccessRecords() int32 {
+	if m != nil {
+		return m.SuccessRecords
+	}
+	return 0
+}
+
+func (m *Upload) GetFailedRecords() int32 {
+	if m != nil {
+		return m.FailedRecords
+	}
+	return 0
+}
+
+func (m *Upload) GetInvalidRecords() int32 {
+	if m != nil {
+		return m.InvalidRecords
+	}
+	return 0
+}
+
+func (m *Upload) GetProcessedRecords() int32 {
+	if m != nil {
+		return m.ProcessedRecords
+	}
+	return 0
+}
+
+type ListUploadFailuresRequest struct {
+	UploadId             int32    `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	PageNum              int32    `protobuf:"varint,2,opt,name=page_num,json=pageNum,proto3" json:"page_num,omitempty"`
+	PageSize             int32    `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUploadFailuresRequest) Reset()         { *m = ListUploadFailuresRequest{} }
+func (m *ListUploadFailuresRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUploadFailuresRequest) ProtoMessage()    {}
+func (*ListUploadFailuresRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{15}
+}
+
+func (m *ListUploadFailuresRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUploadFailuresRequest.Unmarshal(m, b)
+}
+func (m *ListUploadFailuresRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUploadFailuresRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUploadFailuresRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUploadFailuresRequest.Merge(m, src)
+}
+func (m *ListUploadFailuresRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUploadFailuresRequest.Size(m)
+}
+func (m *ListUploadFailuresRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUploadFailuresRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUploadFailuresRequest proto.InternalMessageInfo
+
+func (m *ListUploadFailuresRequest) GetUploadId() int32 {
+	if m != nil {
+		return m.UploadId
+	}
+	return 0
+}
+
+func (m *ListUploadFailuresRequest) GetPageNum() int32 {
+	if m != nil {
+		return m.PageNum
+	}
+	return 0
+}
+
+func (m *ListUploadFailuresRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ListUploadFailuresResponse struct {
+	TotalRecords         int32            `protobuf:"varint,1,opt,name=totalRecords,proto3" json:"totalRecords,omitempty"`
+	Failures             []*UploadFailure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
-func (m *ListUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListUploadResponse.Marshal(b, m, deterministic)
+
+func (m *ListUploadFailuresResponse) Reset()         { *m = ListUploadFailuresResponse{} }
+func (m *ListUploadFailuresResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUploadFailuresResponse) ProtoMessage()    {}
+func (*ListUploadFailuresResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{16}
 }
-func (m *ListUploadResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListUploadResponse.Merge(m, src)
+
+func (m *ListUploadFailuresResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUploadFailuresResponse.Unmarshal(m, b)
 }
-func (m *ListUploadResponse) XXX_Size() int {
-	return xxx_messageInfo_ListUploadResponse.Size(m)
+func (m *ListUploadFailuresResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUploadFailuresResponse.Marshal(b, m, deterministic)
 }
-func (m *ListUploadResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListUploadResponse.DiscardUnknown(m)
+func (m *ListUploadFailuresResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUploadFailuresResponse.Merge(m, src)
+}
+func (m *ListUploadFailuresResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUploadFailuresResponse.Size(m)
+}
+func (m *ListUploadFailuresResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUploadFailuresResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListUploadResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListUploadFailuresResponse proto.InternalMessageInfo
 
-func (m *ListUploadResponse) GetTotalRecords() int32 {
+func (m *ListUploadFailuresResponse) GetTotalRecords() int32 {
 	if m != nil {
 		return m.TotalRecords
 	}
 	return 0
 }
 
-func (m *ListUploadResponse) GetUploads() []*Upload {
+func (m *ListUploadFailuresResponse) GetFailures() []*UploadFailure {
 	if m != nil {
-		return m.Uploads
+		return m.Failures
 	}
 	return nil
 }
 
-type Upload struct {
+type UploadFailure struct {
 	UploadId             int32                `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
-	Scope                string               `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
-	FileName             string               `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	Status               string               `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	UploadedBy           string               `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
-	UploadedOn           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=uploaded_on,json=uploadedOn,proto3" json:"uploaded_on,omitempty"`
-	TotalRecords         int32                `protobuf:"varint,7,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
-	SuccessRecords       int32                `protobuf:"varint,8,opt,name=success_records,json=successRecords,proto3" json:"success_records,omitempty"`
-	FailedRecords        int32                `protobuf:"varint,9,opt,name=failed_records,json=failedRecords,proto3" json:"failed_records,omitempty"`
-	InvalidRecords       int32                `protobuf:"varint,10,opt,name=invalid_records,json=invalidRecords,proto3" json:"invalid_records,omitempty"`
+	FileName             string               `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Line                 int32                `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	FailureType          string               `protobuf:"bytes,4,opt,name=failure_type,json=failureType,proto3" json:"failure_type,omitempty"`
+	Payload              string               `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	GrpcCode             string               `protobuf:"bytes,6,opt,name=grpc_code,json=grpcCode,proto3" json:"grpc_code,omitempty"`
+	Reason               string               `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	FailedOn             *timestamp.Timestamp `protobuf:"bytes,8,opt,name=failed_on,json=failedOn,proto3" json:"failed_on,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *Upload) Reset()         { *m = Upload{} }
-func (m *Upload) String() string { return proto.CompactTextString(m) }
-func (*Upload) ProtoMessage()    {}
-func (*Upload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a611899297971007, []int{4}
+func (m *UploadFailure) Reset()         { *m = UploadFailure{} }
+func (m *UploadFailure) String() string { return proto.CompactTextString(m) }
+func (*UploadFailure) ProtoMessage()    {}
+func (*UploadFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{17}
 }
 
-func (m *Upload) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Upload.Unmarshal(m, b)
+func (m *UploadFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadFailure.Unmarshal(m, b)
 }
-func (m *Upload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Upload.Marshal(b, m, deterministic)
+func (m *UploadFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadFailure.Marshal(b, m, deterministic)
 }
-func (m *Upload) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Upload.Merge(m, src)
+func (m *UploadFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadFailure.Merge(m, src)
 }
-func (m *Upload) XXX_Size() int {
-	return xxx_messageInfo_Upload.Size(m)
+func (m *UploadFailure) XXX_Size() int {
+	return xxx_messageInfo_UploadFailure.Size(m)
 }
-func (m *Upload) XXX_DiscardUnknown() {
-	xxx_messageInfo_Upload.DiscardUnknown(m)
+func (m *UploadFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadFailure.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Upload proto.InternalMessageInfo
+var xxx_messageInfo_UploadFailure proto.InternalMessageInfo
 
-func (m *Upload) GetUploadId() int32 {
+func (m *UploadFailure) GetUploadId() int32 {
 	if m != nil {
 		return m.UploadId
 	}
 	return 0
 }
 
-func (m *Upload) GetScope() string {
+func (m *UploadFailure) GetFileName() string {
 	if m != nil {
-		return m.Scope
+		return m.FileName
 	}
 	return ""
 }
 
-func (m *Upload) GetFileName() string {
+func (m *UploadFailure) GetLine() int32 {
 	if m != nil {
-		return m.FileName
+		return m.Line
+	}
+	return 0
+}
+
+func (m *UploadFailure) GetFailureType() string {
+	if m != nil {
+		return m.FailureType
 	}
 	return ""
 }
 
-func (m *Upload) GetStatus() string {
+func (m *UploadFailure) GetPayload() string {
 	if m != nil {
-		return m.Status
+		return m.Payload
 	}
 	return ""
 }
 
-func (m *Upload) GetUploadedBy() string {
+func (m *UploadFailure) GetGrpcCode() string {
 	if m != nil {
-		return m.UploadedBy
+		return m.GrpcCode
 	}
 	return ""
 }
 
-func (m *Upload) GetUploadedOn() *timestamp.Timestamp {
+func (m *UploadFailure) GetReason() string {
 	if m != nil {
-		return m.UploadedOn
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *UploadFailure) GetFailedOn() *timestamp.Timestamp {
+	if m != nil {
+		return m.FailedOn
 	}
 	return nil
 }
 
-func (m *Upload) GetTotalRecords() int32 {
+type ExportUploadFailuresRequest struct {
+	UploadId             int32    `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportUploadFailuresRequest) Reset()         { *m = ExportUploadFailuresRequest{} }
+func (m *ExportUploadFailuresRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportUploadFailuresRequest) ProtoMessage()    {}
+func (*ExportUploadFailuresRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{18}
+}
+
+func (m *ExportUploadFailuresRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportUploadFailuresRequest.Unmarshal(m, b)
+}
+func (m *ExportUploadFailuresRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportUploadFailuresRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportUploadFailuresRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportUploadFailuresRequest.Merge(m, src)
+}
+func (m *ExportUploadFailuresRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportUploadFailuresRequest.Size(m)
+}
+func (m *ExportUploadFailuresRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportUploadFailuresRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportUploadFailuresRequest proto.InternalMessageInfo
+
+func (m *ExportUploadFailuresRequest) GetUploadId() int32 {
 	if m != nil {
-		return m.TotalRecords
+		return m.UploadId
 	}
 	return 0
 }
 
-func (m *Upload) GetSuccessRecords() int32 {
+type ReprocessUploadRequest struct {
+	UploadId int32 `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// replays only the failed records when not given
+	OnlyFailed           *wrappers.BoolValue `protobuf:"bytes,2,opt,name=only_failed,json=onlyFailed,proto3" json:"only_failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ReprocessUploadRequest) Reset()         { *m = ReprocessUploadRequest{} }
+func (m *ReprocessUploadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReprocessUploadRequest) ProtoMessage()    {}
+func (*ReprocessUploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{19}
+}
+
+func (m *ReprocessUploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReprocessUploadRequest.Unmarshal(m, b)
+}
+func (m *ReprocessUploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReprocessUploadRequest.Marshal(b, m, deterministic)
+}
+func (m *ReprocessUploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReprocessUploadRequest.Merge(m, src)
+}
+func (m *ReprocessUploadRequest) XXX_Size() int {
+	return xxx_messageInfo_ReprocessUploadRequest.Size(m)
+}
+func (m *ReprocessUploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReprocessUploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReprocessUploadRequest proto.InternalMessageInfo
+
+func (m *ReprocessUploadRequest) GetUploadId() int32 {
 	if m != nil {
-		return m.SuccessRecords
+		return m.UploadId
 	}
 	return 0
 }
 
-func (m *Upload) GetFailedRecords() int32 {
+func (m *ReprocessUploadRequest) GetOnlyFailed() *wrappers.BoolValue {
 	if m != nil {
-		return m.FailedRecords
+		return m.OnlyFailed
 	}
-	return 0
+	return nil
 }
 
-func (m *Upload) GetInvalidRecords() int32 {
+type ReprocessUploadResponse struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	JobsRequeued         int32    `protobuf:"varint,2,opt,name=jobs_requeued,json=jobsRequeued,proto3" json:"jobs_requeued,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReprocessUploadResponse) Reset()         { *m = ReprocessUploadResponse{} }
+func (m *ReprocessUploadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReprocessUploadResponse) ProtoMessage()    {}
+func (*ReprocessUploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a611899297971007, []int{20}
+}
+
+func (m *ReprocessUploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReprocessUploadResponse.Unmarshal(m, b)
+}
+func (m *ReprocessUploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReprocessUploadResponse.Marshal(b, m, deterministic)
+}
+func (m *ReprocessUploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReprocessUploadResponse.Merge(m, src)
+}
+func (m *ReprocessUploadResponse) XXX_Size() int {
+	return xxx_messageInfo_ReprocessUploadResponse.Size(m)
+}
+func (m *ReprocessUploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReprocessUploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReprocessUploadResponse proto.InternalMessageInfo
+
+func (m *ReprocessUploadResponse) GetSuccess() bool {
 	if m != nil {
-		return m.InvalidRecords
+		return m.Success
+	}
+	return false
+}
+
+func (m *ReprocessUploadResponse) GetJobsRequeued() int32 {
+	if m != nil {
+		return m.JobsRequeued
 	}
 	return 0
 }
@@ -432,66 +1510,146 @@ func init() {
 	proto.RegisterEnum("v1.ListUploadRequest_SortOrder", ListUploadRequest_SortOrder_name, ListUploadRequest_SortOrder_value)
 	proto.RegisterType((*NotifyUploadRequest)(nil), "v1.NotifyUploadRequest")
 	proto.RegisterType((*NotifyUploadResponse)(nil), "v1.NotifyUploadResponse")
+	proto.RegisterType((*FileDelta)(nil), "v1.FileDelta")
+	proto.RegisterType((*DeltaRow)(nil), "v1.DeltaRow")
+	proto.RegisterType((*GetUploadRequest)(nil), "v1.GetUploadRequest")
+	proto.RegisterType((*GetUploadResponse)(nil), "v1.GetUploadResponse")
+	proto.RegisterType((*CancelUploadRequest)(nil), "v1.CancelUploadRequest")
+	proto.RegisterType((*CancelUploadResponse)(nil), "v1.CancelUploadResponse")
+	proto.RegisterType((*ValidateUploadRequest)(nil), "v1.ValidateUploadRequest")
+	proto.RegisterType((*ValidateUploadResponse)(nil), "v1.ValidateUploadResponse")
+	proto.RegisterType((*FileValidationReport)(nil), "v1.FileValidationReport")
+	proto.RegisterType((*RowError)(nil), "v1.RowError")
 	proto.RegisterType((*ListUploadRequest)(nil), "v1.ListUploadRequest")
 	proto.RegisterType((*ListUploadResponse)(nil), "v1.ListUploadResponse")
 	proto.RegisterType((*Upload)(nil), "v1.Upload")
+	proto.RegisterType((*ListUploadFailuresRequest)(nil), "v1.ListUploadFailuresRequest")
+	proto.RegisterType((*ListUploadFailuresResponse)(nil), "v1.ListUploadFailuresResponse")
+	proto.RegisterType((*UploadFailure)(nil), "v1.UploadFailure")
+	proto.RegisterType((*ExportUploadFailuresRequest)(nil), "v1.ExportUploadFailuresRequest")
+	proto.RegisterType((*ReprocessUploadRequest)(nil), "v1.ReprocessUploadRequest")
+	proto.RegisterType((*ReprocessUploadResponse)(nil), "v1.ReprocessUploadResponse")
 }
 
 func init() { proto.RegisterFile("dps.proto", fileDescriptor_a611899297971007) }
 
 var fileDescriptor_a611899297971007 = []byte{
-	// 811 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x41, 0x8f, 0xdb, 0x44,
-	0x14, 0x8e, 0x9d, 0x38, 0xb6, 0xdf, 0x76, 0x53, 0x33, 0x6c, 0x53, 0xcb, 0xbb, 0x6a, 0x8c, 0x29,
-	0x22, 0xa0, 0x36, 0x21, 0xe1, 0x06, 0x87, 0x2e, 0x56, 0x25, 0x58, 0x09, 0xb6, 0xc8, 0x0b, 0x87,
-	0x22, 0x41, 0x34, 0xb1, 0x27, 0x91, 0xa5, 0xd8, 0x63, 0x3c, 0xe3, 0xa0, 0xf4, 0xd8, 0x3f, 0x80,
-	0x54, 0x7e, 0x0a, 0x1c, 0xf8, 0x1f, 0xfc, 0x05, 0x0e, 0x1c, 0x38, 0x70, 0xce, 0x09, 0x79, 0x3c,
-	0xce, 0x3a, 0xc9, 0x72, 0x21, 0x97, 0xcc, 0x7c, 0xf3, 0xcd, 0xf7, 0x9e, 0xbf, 0xf7, 0xde, 0x80,
-	0x19, 0x65, 0x6c, 0x94, 0xe5, 0x94, 0x53, 0xa4, 0xae, 0x27, 0xce, 0xc5, 0x92, 0xd2, 0xe5, 0x8a,
-	0x8c, 0x71, 0x16, 0x8f, 0x71, 0x9a, 0x52, 0x8e, 0x79, 0x4c, 0x53, 0xc9, 0x70, 0x1e, 0xae, 0xf1,
-	0x2a, 0x8e, 0x30, 0x27, 0xe3, 0x7a, 0x21, 0x0f, 0x06, 0xf2, 0x9a, 0xd8, 0xcd, 0x8b, 0xc5, 0x98,
-	0xc7, 0x09, 0x61, 0x1c, 0x27, 0x99, 0x24, 0x3c, 0x11, 0x7f, 0xe1, 0xd3, 0x25, 0x49, 0x9f, 0xb2,
-	0x9f, 0xf0, 0x72, 0x49, 0xf2, 0x31, 0xcd, 0x84, 0xf6, 0x71, 0x1c, 0xef, 0x37, 0x05, 0xde, 0xbe,
-	0xa6, 0x3c, 0x5e, 0x6c, 0xbe, 0xcd, 0x56, 0x14, 0x47, 0x01, 0xf9, 0xb1, 0x20, 0x8c, 0xa3, 0x33,
-	0xd0, 0x58, 0x48, 0x33, 0x62, 0x2b, 0xae, 0x32, 0x34, 0x83, 0x6a, 0x83, 0x3e, 0x80, 0x0e, 0xdf,
-	0x64, 0xc4, 0x56, 0x4b, 0xd0, 0x7f, 0xb0, 0xf5, 0x51, 0x6e, 0x05, 0x9d, 0x08, 0x73, 0x1c, 0x18,
-	0x09, 0xe1, 0x58, 0xac, 0x04, 0x05, 0x9d, 0x83, 0x59, 0x08, 0xc5, 0x59, 0x1c, 0xd9, 0x6d, 0x57,
-	0x19, 0x6a, 0x81, 0x51, 0x01, 0x57, 0x11, 0x1a, 0xc0, 0x49, 0xb5, 0x26, 0xd1, 0x6c, 0xbe, 0xb1,
-	0x3b, 0x22, 0x06, 0xd4, 0x90, 0xbf, 0x41, 0x8f, 0x40, 0x5b, 0xc4, 0x2b, 0xc2, 0x6c, 0xcd, 0x6d,
-	0x0f, 0x4d, 0xdf, 0xd8, 0xfa, 0xda, 0x1b, 0x45, 0x35, 0x94, 0xa0, 0x82, 0xbd, 0x8f, 0xe0, 0x6c,
-	0x3f, 0x6b, 0x96, 0xd1, 0x94, 0x11, 0x64, 0x83, 0xce, 0x8a, 0x30, 0x24, 0x8c, 0x89, 0xc4, 0x8d,
-	0xa0, 0xde, 0x7a, 0xbf, 0xb6, 0xe1, 0xad, 0x2f, 0x63, 0xc6, 0xf7, 0x3f, 0xf3, 0x73, 0x30, 0x32,
-	0xbc, 0x24, 0xb3, 0xb4, 0x48, 0xc4, 0x05, 0xcd, 0x7f, 0xf2, 0xe6, 0xb3, 0xc1, 0xf4, 0xe4, 0x6b,
-	0xbc, 0x24, 0x6e, 0x5a, 0x24, 0x73, 0x92, 0xbf, 0x6c, 0x95, 0xbf, 0xcb, 0x9f, 0x2f, 0x63, 0xb1,
-	0x68, 0xfd, 0xf3, 0x6c, 0xeb, 0xeb, 0x8e, 0x66, 0xfd, 0xa5, 0x0f, 0x95, 0x40, 0x2f, 0x6f, 0x5f,
-	0x17, 0x09, 0xba, 0x02, 0x53, 0x08, 0xb1, 0xf8, 0x55, 0x65, 0x8f, 0x50, 0xf2, 0xa6, 0xbd, 0x2b,
-	0x4e, 0x12, 0xe6, 0x66, 0x24, 0x77, 0xcb, 0xf3, 0x4a, 0xac, 0xf5, 0xb2, 0x16, 0x7b, 0x7c, 0xb9,
-	0xf5, 0x3b, 0x8e, 0x3a, 0x84, 0x40, 0xe4, 0x71, 0x13, 0xbf, 0x22, 0xe8, 0x19, 0xe8, 0x8c, 0xe6,
-	0xbc, 0x34, 0xa6, 0xf4, 0xad, 0x37, 0xbd, 0x18, 0xad, 0x27, 0xa3, 0xa3, 0xdc, 0x47, 0x37, 0x34,
-	0xe7, 0xfe, 0x46, 0x78, 0xf3, 0x5a, 0x51, 0x2d, 0x25, 0xe8, 0x32, 0x81, 0xa0, 0x2f, 0x00, 0x84,
-	0x00, 0xcd, 0x23, 0x92, 0x0b, 0x73, 0x7b, 0xd3, 0xc1, 0x7f, 0x6b, 0xbc, 0x28, 0x69, 0x0d, 0x19,
-	0x93, 0xd5, 0xa0, 0x37, 0x83, 0x6e, 0x15, 0x05, 0x9d, 0x36, 0xca, 0x69, 0xb5, 0x90, 0x29, 0xdb,
-	0xc3, 0x52, 0xca, 0x93, 0xb2, 0x26, 0xb3, 0x14, 0x27, 0xc4, 0x52, 0x11, 0x40, 0x97, 0x71, 0xcc,
-	0x0b, 0x66, 0xb5, 0xd1, 0xfd, 0xbd, 0x32, 0x5b, 0x9d, 0x3d, 0x80, 0xa6, 0x96, 0xe6, 0x3d, 0x02,
-	0x73, 0x97, 0x02, 0xd2, 0xa1, 0x8d, 0x59, 0x68, 0xb5, 0x90, 0x01, 0x9d, 0x88, 0xb0, 0xd0, 0x52,
-	0xbc, 0x1f, 0x00, 0x35, 0x93, 0x96, 0x55, 0xf6, 0xe0, 0x1e, 0xa7, 0x1c, 0xaf, 0x02, 0x12, 0xd2,
-	0x3c, 0xaa, 0x4a, 0xad, 0x05, 0x7b, 0x18, 0x7a, 0x0c, 0x7a, 0x15, 0x8a, 0xd9, 0xaa, 0xdb, 0x1e,
-	0x9e, 0x4c, 0xa1, 0x74, 0x40, 0x0a, 0xd5, 0x47, 0xde, 0xdf, 0x2a, 0x74, 0x2b, 0x6c, 0xbf, 0x61,
-	0x95, 0x83, 0x86, 0xdd, 0x8d, 0x83, 0xda, 0x1c, 0x87, 0xf3, 0xc6, 0xa7, 0x8b, 0x5a, 0x99, 0x81,
-	0x51, 0x02, 0xd7, 0x38, 0x21, 0xa8, 0x5f, 0x1b, 0x21, 0xdb, 0x5b, 0xee, 0x0e, 0x7b, 0x5f, 0x3b,
-	0xea, 0xfd, 0x4f, 0xf7, 0x4c, 0xb2, 0xbb, 0xae, 0x32, 0x3c, 0x99, 0x3a, 0xa3, 0x6a, 0xee, 0x47,
-	0xf5, 0xdc, 0x8f, 0xbe, 0xa9, 0xe7, 0xfe, 0xf6, 0xf2, 0x8b, 0x14, 0xbd, 0x0b, 0xa7, 0xc2, 0x86,
-	0x59, 0x2e, 0xbd, 0xd1, 0xef, 0xf0, 0xe6, 0x7d, 0xb8, 0x2f, 0xc7, 0x62, 0x47, 0x33, 0x04, 0xad,
-	0x27, 0xe1, 0x9a, 0xf8, 0x1e, 0xf4, 0x16, 0x38, 0x5e, 0x91, 0x68, 0xc7, 0x33, 0x05, 0xef, 0xb4,
-	0x42, 0x1b, 0x7a, 0x71, 0x2a, 0xde, 0xa9, 0x1d, 0x0f, 0x2a, 0x3d, 0x09, 0x4b, 0xe2, 0xf4, 0x77,
-	0x15, 0xe0, 0x79, 0xc6, 0x6e, 0x48, 0xbe, 0x8e, 0x43, 0x82, 0x22, 0xb8, 0xd7, 0x9c, 0x62, 0xf4,
-	0xb0, 0x2c, 0xd1, 0x1d, 0xaf, 0x91, 0x63, 0x1f, 0x1f, 0x54, 0xad, 0xe0, 0xbd, 0xf3, 0xfa, 0x8f,
-	0x3f, 0x7f, 0x51, 0xcf, 0xbd, 0xbe, 0x78, 0x47, 0xd7, 0x93, 0xb1, 0xac, 0xec, 0x38, 0x15, 0xec,
-	0x4f, 0x94, 0x0f, 0xd1, 0xf7, 0xd0, 0xbb, 0xed, 0xa1, 0xe7, 0x98, 0x63, 0xf4, 0xe0, 0xce, 0x61,
-	0x70, 0xfa, 0x87, 0xb0, 0x8c, 0x71, 0x21, 0x62, 0xf4, 0xd1, 0xd9, 0x61, 0x8c, 0xf2, 0xb9, 0x43,
-	0xa4, 0xd9, 0xa2, 0x5f, 0x11, 0x8e, 0xff, 0x4f, 0x08, 0x57, 0x84, 0x70, 0x90, 0x7d, 0x18, 0xa2,
-	0x7e, 0x55, 0xfd, 0xce, 0x77, 0xea, 0x7a, 0x32, 0xef, 0x8a, 0xf2, 0x7f, 0xfc, 0x6f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x2d, 0xd7, 0x43, 0xd3, 0x4c, 0x06, 0x00, 0x00,
+	// 1825 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x92, 0xdb, 0xc6,
+	0x11, 0x5e, 0x80, 0x7f, 0x40, 0x93, 0x4b, 0x71, 0xc7, 0xd4, 0x0a, 0xc2, 0xea, 0x87, 0x82, 0x56,
+	0xf1, 0x46, 0xd6, 0x2e, 0xa3, 0xf5, 0x21, 0x89, 0x5c, 0x29, 0x29, 0x94, 0x2c, 0x4b, 0x29, 0x5b,
+	0x4a, 0x41, 0xb6, 0x13, 0xbb, 0x2a, 0x61, 0x61, 0x89, 0x59, 0x1a, 0x09, 0x88, 0x41, 0xf0, 0xb3,
+	0x1b, 0xda, 0xc9, 0x21, 0x3a, 0xf9, 0xe6, 0x8a, 0x73, 0x4a, 0xe5, 0x01, 0x92, 0x7b, 0x5e, 0x21,
+	0x6f, 0x90, 0x4b, 0x1e, 0x20, 0x07, 0x1f, 0x73, 0x56, 0x55, 0xaa, 0x52, 0xd3, 0x33, 0x43, 0x82,
+	0x24, 0x76, 0x25, 0xaf, 0x0e, 0xd9, 0xcb, 0x62, 0xbe, 0xee, 0xe9, 0xee, 0x99, 0xee, 0xaf, 0x1b,
+	0x20, 0x98, 0x7e, 0x9c, 0xee, 0xc5, 0x09, 0xcb, 0x18, 0xd1, 0x8f, 0x6e, 0xdb, 0x97, 0xc6, 0x8c,
+	0x8d, 0x43, 0xda, 0xf7, 0xe2, 0xa0, 0xef, 0x45, 0x11, 0xcb, 0xbc, 0x2c, 0x60, 0x91, 0xd4, 0xb0,
+	0x2f, 0x16, 0xa4, 0x9f, 0x65, 0x59, 0x7c, 0xc0, 0xfc, 0xa9, 0x14, 0x5d, 0x38, 0xf2, 0xc2, 0xc0,
+	0xf7, 0x32, 0xda, 0x57, 0x0f, 0x52, 0x70, 0x55, 0xee, 0xc1, 0xd5, 0x41, 0x7e, 0xd8, 0xcf, 0x82,
+	0x09, 0x4d, 0x33, 0x6f, 0x12, 0x4b, 0x85, 0x2b, 0xcb, 0x0a, 0xc7, 0x89, 0x17, 0xc7, 0x34, 0x51,
+	0x4e, 0x6f, 0xe1, 0xbf, 0xd1, 0xee, 0x98, 0x46, 0xbb, 0xe9, 0xb1, 0x37, 0x1e, 0xd3, 0xa4, 0xcf,
+	0x62, 0x0c, 0x6b, 0x35, 0x44, 0xe7, 0xbf, 0x1a, 0xbc, 0xf1, 0x84, 0x65, 0xc1, 0xe1, 0xf4, 0xa3,
+	0x38, 0x64, 0x9e, 0xef, 0xd2, 0xdf, 0xe4, 0x34, 0xcd, 0x48, 0x17, 0x6a, 0xe9, 0x88, 0xc5, 0xd4,
+	0xd2, 0x7a, 0xda, 0x8e, 0xe9, 0x8a, 0x05, 0xf9, 0x2e, 0x54, 0xb3, 0x69, 0x4c, 0x2d, 0x9d, 0x83,
+	0x83, 0xf3, 0x2f, 0x06, 0x24, 0xe9, 0xb8, 0x55, 0xdf, 0xcb, 0x3c, 0xd7, 0x98, 0xd0, 0xcc, 0xc3,
+	0x27, 0x54, 0x21, 0x5b, 0x60, 0xe6, 0x68, 0x71, 0x18, 0xf8, 0x56, 0xa5, 0xa7, 0xed, 0xd4, 0x5c,
+	0x43, 0x00, 0x8f, 0x7d, 0x72, 0x15, 0x9a, 0xe2, 0x99, 0xfa, 0xc3, 0x83, 0xa9, 0x55, 0x45, 0x1f,
+	0xa0, 0xa0, 0xc1, 0x94, 0x5c, 0x81, 0xda, 0x61, 0x10, 0xd2, 0xd4, 0xaa, 0xf5, 0x2a, 0x3b, 0xe6,
+	0xc0, 0x78, 0x31, 0xa8, 0x7d, 0xad, 0xe9, 0x86, 0xe6, 0x0a, 0x98, 0x5c, 0x80, 0x86, 0x9f, 0x4c,
+	0x87, 0x49, 0x1e, 0x59, 0xf5, 0x9e, 0xb6, 0x63, 0xb8, 0x75, 0x3f, 0x99, 0xba, 0x79, 0x44, 0x2c,
+	0x68, 0xb0, 0xc4, 0xa7, 0x09, 0xf5, 0xad, 0x06, 0x0a, 0xd4, 0x92, 0x9f, 0xc8, 0xa7, 0x61, 0xe6,
+	0x59, 0x06, 0xe2, 0x62, 0xe1, 0xfc, 0x4d, 0x83, 0xee, 0xe2, 0xf9, 0xd3, 0x98, 0x45, 0x29, 0xe5,
+	0x86, 0xd2, 0x7c, 0x34, 0xa2, 0x69, 0x8a, 0x57, 0x60, 0xb8, 0x6a, 0x49, 0xf6, 0xa1, 0x91, 0xd0,
+	0x98, 0x25, 0x59, 0x6a, 0xe9, 0xbd, 0xca, 0x4e, 0x73, 0xdf, 0xda, 0x3b, 0xba, 0xbd, 0xf7, 0x30,
+	0x08, 0xe9, 0xc7, 0x22, 0x95, 0x01, 0x8b, 0x5c, 0x54, 0x70, 0x95, 0xe2, 0xe9, 0xb7, 0x71, 0x03,
+	0xea, 0x18, 0x4c, 0x6a, 0x55, 0xd1, 0xde, 0xba, 0xb2, 0xf7, 0x80, 0xa3, 0xae, 0x14, 0x3a, 0x7f,
+	0xd4, 0xc1, 0x9c, 0xa1, 0xdc, 0x22, 0xbf, 0x8a, 0x61, 0xe4, 0x4d, 0x54, 0x92, 0x0c, 0x0e, 0x3c,
+	0xf1, 0x26, 0x74, 0x26, 0x9c, 0x27, 0x4b, 0x08, 0x3f, 0xe4, 0x99, 0xb9, 0x05, 0x24, 0x4e, 0xe8,
+	0x51, 0xc0, 0xf2, 0x74, 0xb8, 0x1c, 0x54, 0x47, 0x49, 0x3e, 0x52, 0xc1, 0x11, 0xa8, 0x1e, 0xe6,
+	0x61, 0x88, 0x39, 0x32, 0x5c, 0x7c, 0xe6, 0x57, 0xe9, 0xf9, 0x3e, 0xf5, 0xad, 0x1a, 0x6e, 0x12,
+	0x0b, 0x7e, 0x63, 0xa3, 0xcf, 0xbc, 0x68, 0x4c, 0x7d, 0xcc, 0x49, 0xcd, 0x55, 0x4b, 0x2e, 0x49,
+	0xe8, 0x84, 0x1d, 0xc9, 0xa4, 0xd4, 0x5c, 0xb5, 0x24, 0x97, 0xc0, 0xcc, 0x23, 0xb5, 0xcb, 0x40,
+	0xd9, 0x1c, 0x20, 0x3d, 0xa8, 0x26, 0xec, 0x38, 0xb5, 0x4c, 0xbc, 0x96, 0x16, 0xbf, 0x16, 0x71,
+	0x25, 0xec, 0xd8, 0x45, 0x89, 0xf3, 0x08, 0x0c, 0x85, 0xf0, 0x48, 0xc3, 0x20, 0x12, 0x97, 0x51,
+	0x73, 0xf1, 0x99, 0x74, 0xa0, 0xf2, 0x6b, 0x3a, 0x95, 0x57, 0xc0, 0x1f, 0xc9, 0x26, 0xd4, 0x85,
+	0x79, 0x3c, 0xb1, 0xe9, 0xca, 0x95, 0xf3, 0x03, 0xe8, 0xbc, 0x47, 0xb3, 0x45, 0x12, 0x6c, 0x17,
+	0xb3, 0x86, 0x66, 0x07, 0x8d, 0x17, 0x83, 0xaa, 0xad, 0xf7, 0xd6, 0xe6, 0xe9, 0x73, 0xfe, 0x5a,
+	0x83, 0x8d, 0xc2, 0x56, 0x59, 0x3f, 0x5b, 0x2b, 0x7b, 0x0b, 0x19, 0x9f, 0xb1, 0x4b, 0x2f, 0xb2,
+	0x6b, 0x0b, 0x4c, 0x4e, 0x20, 0x91, 0x35, 0x11, 0x9d, 0xc1, 0x01, 0xcc, 0xda, 0x26, 0xd4, 0xd3,
+	0xcc, 0xcb, 0xf2, 0x54, 0xb2, 0x45, 0xae, 0x96, 0xa9, 0x54, 0x5b, 0xa1, 0xd2, 0x3b, 0x05, 0x05,
+	0x26, 0xe8, 0xd2, 0xdc, 0xb7, 0xf7, 0x44, 0x17, 0xd9, 0x53, 0x5d, 0x64, 0xef, 0x43, 0xd5, 0x66,
+	0xe6, 0x9b, 0x9f, 0x46, 0xdc, 0x7a, 0xc6, 0x32, 0x2f, 0x1c, 0x0a, 0x36, 0x8a, 0xec, 0x01, 0x42,
+	0xbc, 0x14, 0x53, 0x72, 0x19, 0xc0, 0x67, 0x11, 0x95, 0x72, 0x99, 0x41, 0x8e, 0x08, 0xb1, 0x0d,
+	0x46, 0x9c, 0xb0, 0x71, 0xc2, 0x69, 0x64, 0xf6, 0xb4, 0x1d, 0xdd, 0x9d, 0xad, 0xc9, 0x35, 0x68,
+	0xc5, 0x34, 0xf2, 0x83, 0x68, 0x3c, 0xfc, 0x15, 0x3b, 0x48, 0x2d, 0xc0, 0xcd, 0x4d, 0x89, 0xfd,
+	0x84, 0x1d, 0xa4, 0xe4, 0x3a, 0xac, 0x0b, 0xf7, 0x09, 0x1d, 0xb1, 0xc4, 0x4f, 0xad, 0x26, 0xea,
+	0xb4, 0x10, 0x74, 0x05, 0x46, 0xde, 0x82, 0x8d, 0x38, 0x61, 0x9c, 0x9a, 0xd4, 0x9f, 0x29, 0xb6,
+	0x54, 0x39, 0x4b, 0x81, 0x52, 0x7e, 0x13, 0xce, 0x49, 0x1e, 0xcf, 0x54, 0xd7, 0x51, 0xb5, 0x2d,
+	0x61, 0xa5, 0x78, 0x03, 0xda, 0x87, 0x5e, 0x10, 0x16, 0x4c, 0xb6, 0x51, 0x6f, 0x5d, 0xa0, 0x05,
+	0x7b, 0x41, 0x84, 0x2d, 0x7c, 0xa6, 0x77, 0x4e, 0xd8, 0x93, 0xb0, 0x52, 0xbc, 0x06, 0xad, 0x91,
+	0x17, 0x8d, 0x68, 0x18, 0x8a, 0x44, 0x75, 0x30, 0x51, 0xcd, 0x19, 0x36, 0x98, 0x92, 0x1f, 0x15,
+	0x55, 0x58, 0x64, 0x6d, 0xbc, 0x34, 0x55, 0xf3, 0xed, 0x4f, 0x23, 0xd2, 0x53, 0x3d, 0x93, 0x20,
+	0x5d, 0x80, 0xd3, 0x45, 0x16, 0xa5, 0x10, 0x38, 0xef, 0xc0, 0x1b, 0xf7, 0x71, 0xc3, 0x59, 0xca,
+	0xfc, 0x67, 0xd0, 0x5d, 0xdc, 0xfc, 0xd2, 0x46, 0x79, 0x03, 0xda, 0x3c, 0xb1, 0xc3, 0x59, 0x90,
+	0x58, 0xee, 0x35, 0x77, 0x9d, 0xa3, 0xf7, 0x15, 0xe8, 0x7c, 0x00, 0xe7, 0x65, 0xe3, 0xa4, 0xaf,
+	0x32, 0x83, 0x66, 0xa3, 0x41, 0x2f, 0x1d, 0x0d, 0xce, 0x01, 0x6c, 0x2e, 0x9b, 0x93, 0x91, 0x76,
+	0xa1, 0x86, 0x29, 0x91, 0x71, 0x8a, 0xc5, 0x59, 0xda, 0xb9, 0xf3, 0x0f, 0x0d, 0xba, 0x65, 0x1a,
+	0xaf, 0xd1, 0x95, 0x57, 0x4a, 0xbd, 0x52, 0x52, 0xea, 0x25, 0xd5, 0x56, 0x2d, 0xad, 0xb6, 0x6d,
+	0xa8, 0xd3, 0x24, 0x61, 0x89, 0x18, 0xa0, 0xb2, 0x77, 0xba, 0xec, 0xf8, 0x5d, 0x0e, 0xba, 0x52,
+	0xe6, 0x3c, 0x01, 0x43, 0x61, 0xa5, 0xdd, 0x93, 0xf7, 0x4a, 0x16, 0xe6, 0x93, 0x48, 0x46, 0x2b,
+	0x57, 0x1c, 0x4f, 0xa8, 0x97, 0xb2, 0x48, 0xf5, 0x50, 0xb1, 0x72, 0xfe, 0x5e, 0x81, 0x8d, 0xf7,
+	0x83, 0x74, 0xa9, 0x8b, 0xbe, 0x07, 0x46, 0xec, 0x8d, 0xe9, 0x30, 0xca, 0x27, 0xb2, 0xba, 0x6e,
+	0x7d, 0xfd, 0xe3, 0xab, 0xfb, 0xcd, 0x9f, 0x7a, 0x63, 0xda, 0x8b, 0xf2, 0xc9, 0x01, 0x4d, 0x3e,
+	0x59, 0xe3, 0x7f, 0xf7, 0xbe, 0xba, 0x17, 0xe0, 0xc3, 0xda, 0x7f, 0xee, 0xbe, 0x18, 0x34, 0xec,
+	0x5a, 0xe7, 0x9b, 0xc6, 0x8e, 0xe6, 0x36, 0xf8, 0xee, 0x27, 0xf9, 0x84, 0x3c, 0x06, 0x13, 0x0d,
+	0xa5, 0xc1, 0xe7, 0xe2, 0xfe, 0xd0, 0x92, 0xb3, 0xdf, 0x7e, 0x9c, 0xd1, 0x49, 0xda, 0x8b, 0x69,
+	0xd2, 0xe3, 0x72, 0x61, 0x6c, 0xed, 0x13, 0x65, 0x6c, 0xfb, 0x1e, 0x16, 0xf3, 0x0e, 0xb8, 0x18,
+	0xc7, 0xb3, 0xe0, 0x73, 0x4a, 0xee, 0x42, 0x23, 0x65, 0x49, 0xc6, 0x89, 0xc8, 0x8f, 0xd0, 0xde,
+	0xbf, 0xc4, 0x2f, 0x68, 0x25, 0xf6, 0xbd, 0x67, 0x2c, 0xc9, 0x06, 0x53, 0x2c, 0xb2, 0xe7, 0x9a,
+	0xde, 0xd1, 0xdc, 0x7a, 0x8a, 0x08, 0x79, 0x04, 0x80, 0x06, 0xf0, 0xed, 0x02, 0x93, 0xd0, 0xde,
+	0xbf, 0x7a, 0xb2, 0x8d, 0xa7, 0x5c, 0xad, 0x60, 0xc6, 0x4c, 0x15, 0xe8, 0x0c, 0xa1, 0x2e, 0xbc,
+	0x90, 0xf5, 0x02, 0x0f, 0x3b, 0x6b, 0xc4, 0x94, 0xe5, 0xdf, 0xd1, 0xb8, 0x64, 0x56, 0x56, 0x1d,
+	0x9d, 0x80, 0x9a, 0x05, 0x9d, 0x0a, 0x39, 0xb7, 0xd0, 0xff, 0x3b, 0xd5, 0x05, 0x80, 0x45, 0x9d,
+	0x9a, 0x73, 0x05, 0xcc, 0x59, 0x08, 0xa4, 0x01, 0x15, 0x2f, 0x1d, 0x75, 0xd6, 0x88, 0x01, 0x55,
+	0x9f, 0xa6, 0xa3, 0x8e, 0xe6, 0xfc, 0x12, 0x48, 0x31, 0x68, 0x49, 0x16, 0x07, 0x16, 0x4a, 0x4f,
+	0xd6, 0xc5, 0x02, 0x46, 0xb6, 0xa1, 0x21, 0x5c, 0x29, 0xea, 0x14, 0x7b, 0x8e, 0x12, 0x39, 0x7f,
+	0xae, 0x40, 0x5d, 0x60, 0x67, 0x1c, 0x8a, 0x73, 0x46, 0x55, 0x96, 0x18, 0xf5, 0xff, 0x19, 0x8a,
+	0x2b, 0x54, 0x6d, 0x94, 0x53, 0x75, 0x79, 0xd0, 0x18, 0xaf, 0x38, 0x68, 0xcc, 0x57, 0x1c, 0x34,
+	0x50, 0x4a, 0xfd, 0xd2, 0x71, 0xd8, 0x2c, 0x1f, 0x87, 0xce, 0xbf, 0x34, 0xb8, 0x38, 0x4f, 0xfe,
+	0x43, 0x2f, 0x08, 0xf3, 0x84, 0xa6, 0xdf, 0x6a, 0x30, 0x2c, 0xf0, 0x5b, 0x7f, 0x1d, 0x7e, 0xbf,
+	0x5f, 0xe4, 0x37, 0xb6, 0xbf, 0x41, 0xff, 0x44, 0x7e, 0xdf, 0xfb, 0xaa, 0xc8, 0xef, 0x86, 0x5d,
+	0xb3, 0xbe, 0x69, 0x14, 0x29, 0xee, 0x30, 0xb0, 0xcb, 0x4e, 0xf6, 0x2d, 0xca, 0x7b, 0x17, 0x8c,
+	0x43, 0xb9, 0x4f, 0xd6, 0xf7, 0xc6, 0xbc, 0xbe, 0xa5, 0x45, 0x77, 0xa6, 0xe2, 0x7c, 0xa9, 0xc3,
+	0xfa, 0x82, 0xec, 0xf4, 0x72, 0x5f, 0x28, 0x6c, 0x7d, 0xa9, 0xb0, 0x55, 0x37, 0xae, 0x14, 0xba,
+	0xf1, 0x35, 0x68, 0x49, 0x5f, 0x62, 0x82, 0x88, 0x92, 0x6f, 0x4a, 0x0c, 0x87, 0x88, 0x05, 0x8d,
+	0xd8, 0x9b, 0x72, 0x07, 0xb2, 0xe6, 0xd5, 0x92, 0x7b, 0x1b, 0x27, 0xf1, 0x68, 0x38, 0x62, 0x3e,
+	0xc5, 0x72, 0x37, 0x5d, 0x83, 0x03, 0xf7, 0x99, 0x4f, 0x0b, 0xfd, 0xbc, 0x51, 0xec, 0xe7, 0xe4,
+	0xfb, 0x60, 0xca, 0xd2, 0x64, 0x91, 0x65, 0xbc, 0x94, 0x23, 0x86, 0x50, 0x7e, 0x1a, 0x39, 0xf7,
+	0x61, 0xeb, 0xdd, 0xdf, 0xf2, 0x81, 0xf8, 0x1a, 0x75, 0xe5, 0x7c, 0x01, 0x9b, 0x2e, 0x95, 0x15,
+	0x7b, 0x86, 0x17, 0x16, 0xce, 0x71, 0x16, 0x85, 0xd3, 0xa1, 0x88, 0xca, 0xd2, 0x4f, 0x88, 0x7f,
+	0xc0, 0x58, 0xf8, 0xb1, 0x17, 0xe6, 0xd4, 0x05, 0xae, 0xfe, 0x10, 0xb5, 0x9d, 0x9f, 0xc3, 0x85,
+	0x15, 0xe7, 0x2f, 0x7d, 0xe1, 0xb9, 0x0e, 0xf8, 0x6a, 0x33, 0x4c, 0x78, 0x9c, 0xf9, 0xec, 0x7d,
+	0xa7, 0xc5, 0x41, 0x57, 0x62, 0xfb, 0x7f, 0x31, 0x00, 0x1e, 0xc4, 0xe9, 0x33, 0x9a, 0x1c, 0x05,
+	0x23, 0x4a, 0x7c, 0x68, 0x15, 0xbf, 0x3f, 0xc9, 0x05, 0x5e, 0x62, 0x25, 0x5f, 0xe4, 0xb6, 0xb5,
+	0x2a, 0x10, 0x01, 0x39, 0xd7, 0x9e, 0xff, 0xf3, 0xdf, 0x7f, 0xd2, 0xb7, 0x9c, 0x4d, 0xfc, 0xa1,
+	0xe1, 0xe8, 0x76, 0x5f, 0x76, 0xde, 0x7e, 0x84, 0xda, 0x77, 0xb4, 0x9b, 0xe4, 0x17, 0xd0, 0x9e,
+	0x93, 0xe1, 0x81, 0x97, 0x79, 0xe4, 0x7c, 0xe9, 0xb0, 0xb2, 0x37, 0x97, 0x61, 0xe9, 0xe3, 0x12,
+	0xfa, 0xd8, 0x24, 0xdd, 0x65, 0x1f, 0x3e, 0x37, 0x46, 0x8b, 0x23, 0xe4, 0x03, 0x9a, 0x79, 0x67,
+	0x71, 0xd1, 0x43, 0x17, 0x36, 0xb1, 0x96, 0x5d, 0xa8, 0x5f, 0x16, 0xc8, 0x97, 0x1a, 0xb4, 0x17,
+	0xdf, 0xed, 0xc8, 0x45, 0x6e, 0xac, 0xf4, 0xf5, 0xd1, 0xb6, 0xcb, 0x44, 0xd2, 0xd7, 0x5d, 0xf4,
+	0xf5, 0x43, 0x67, 0xc5, 0x97, 0xfa, 0x35, 0xe6, 0x8e, 0x76, 0xf3, 0x53, 0x9b, 0x9c, 0x28, 0x26,
+	0x7f, 0xd0, 0x8a, 0x47, 0x56, 0x05, 0x4e, 0x2e, 0x2f, 0x9e, 0x6d, 0xa9, 0xf0, 0xed, 0x2b, 0x27,
+	0x89, 0x65, 0x58, 0xb7, 0x30, 0xac, 0xef, 0x90, 0xed, 0x65, 0xbf, 0x5f, 0xcc, 0xea, 0xfd, 0xf7,
+	0x7d, 0xd5, 0x70, 0xc8, 0x73, 0x0d, 0xba, 0x65, 0x34, 0x23, 0xf8, 0x22, 0x72, 0x0a, 0x01, 0xed,
+	0xae, 0x62, 0x81, 0x17, 0x07, 0x7b, 0x8f, 0xb2, 0x2c, 0x1e, 0x30, 0x7f, 0xea, 0xbc, 0x8d, 0xde,
+	0x77, 0xc9, 0x5b, 0xaf, 0xe2, 0xbd, 0x4f, 0xd1, 0x3e, 0xf9, 0x1d, 0x9c, 0x5b, 0x22, 0x0a, 0xc1,
+	0x8b, 0x2f, 0xa7, 0xae, 0xbd, 0x55, 0x2a, 0x93, 0xc7, 0xff, 0x1e, 0x06, 0x70, 0xd3, 0xb9, 0x71,
+	0x5a, 0x00, 0x89, 0xda, 0x2c, 0xea, 0xda, 0x9c, 0x7d, 0x7a, 0x93, 0x2e, 0xb7, 0xbd, 0xfc, 0x11,
+	0x6f, 0x9f, 0x5f, 0x42, 0xa5, 0xaf, 0xeb, 0xe8, 0xeb, 0x32, 0xd9, 0x3a, 0xc5, 0x17, 0x89, 0xa1,
+	0x55, 0xfc, 0xe6, 0x11, 0xe4, 0x2c, 0xf9, 0x84, 0xb2, 0xad, 0x55, 0x81, 0xf4, 0xb3, 0x8b, 0x7e,
+	0xde, 0x74, 0x9c, 0xd3, 0xce, 0x24, 0xbe, 0x90, 0xee, 0x68, 0x37, 0x07, 0xd5, 0x4f, 0xf5, 0xa3,
+	0xdb, 0x07, 0x75, 0xec, 0x4e, 0x6f, 0xff, 0x6f, 0x00, 0xe2, 0x3d, 0xbb, 0xa5, 0x6e, 0x14, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -509,6 +1667,13 @@ type DpsServiceClient interface {
 	NotifyUpload(ctx context.Context, in *NotifyUploadRequest, opts ...grpc.CallOption) (*NotifyUploadResponse, error)
 	ListUploadData(ctx context.Context, in *ListUploadRequest, opts ...grpc.CallOption) (*ListUploadResponse, error)
 	ListUploadMetaData(ctx context.Context, in *ListUploadRequest, opts ...grpc.CallOption) (*ListUploadResponse, error)
+	ValidateUpload(ctx context.Context, in *ValidateUploadRequest, opts ...grpc.CallOption) (*ValidateUploadResponse, error)
+	ListUploadFailures(ctx context.Context, in *ListUploadFailuresRequest, opts ...grpc.CallOption) (*ListUploadFailuresResponse, error)
+	ExportUploadFailures(ctx context.Context, in *ExportUploadFailuresRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	ReprocessUpload(ctx context.Context, in *ReprocessUploadRequest, opts ...grpc.CallOption) (*ReprocessUploadResponse, error)
+	// declared last, so that REST paths under /api/v1/uploads are matched first
+	GetUpload(ctx context.Context, in *GetUploadRequest, opts ...grpc.CallOption) (*GetUploadResponse, error)
+	CancelUpload(ctx context.Context, in *CancelUploadRequest, opts ...grpc.CallOption) (*CancelUploadResponse, error)
 }
 
 type dpsServiceClient struct {
@@ -546,11 +1711,72 @@ func (c *dpsServiceClient) ListUploadMetaData(ctx context.Context, in *ListUploa
 	return out, nil
 }
 
+func (c *dpsServiceClient) ValidateUpload(ctx context.Context, in *ValidateUploadRequest, opts ...grpc.CallOption) (*ValidateUploadResponse, error) {
+	out := new(ValidateUploadResponse)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/ValidateUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dpsServiceClient) ListUploadFailures(ctx context.Context, in *ListUploadFailuresRequest, opts ...grpc.CallOption) (*ListUploadFailuresResponse, error) {
+	out := new(ListUploadFailuresResponse)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/ListUploadFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dpsServiceClient) ExportUploadFailures(ctx context.Context, in *ExportUploadFailuresRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/ExportUploadFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dpsServiceClient) ReprocessUpload(ctx context.Context, in *ReprocessUploadRequest, opts ...grpc.CallOption) (*ReprocessUploadResponse, error) {
+	out := new(ReprocessUploadResponse)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/ReprocessUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dpsServiceClient) GetUpload(ctx context.Context, in *GetUploadRequest, opts ...grpc.CallOption) (*GetUploadResponse, error) {
+	out := new(GetUploadResponse)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/GetUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dpsServiceClient) CancelUpload(ctx context.Context, in *CancelUploadRequest, opts ...grpc.CallOption) (*CancelUploadResponse, error) {
+	out := new(CancelUploadResponse)
+	err := c.cc.Invoke(ctx, "/v1.DpsService/CancelUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DpsServiceServer is the server API for DpsService service.
 type DpsServiceServer interface {
 	NotifyUpload(context.Context, *NotifyUploadRequest) (*NotifyUploadResponse, error)
 	ListUploadData(context.Context, *ListUploadRequest) (*ListUploadResponse, error)
 	ListUploadMetaData(context.Context, *ListUploadRequest) (*ListUploadResponse, error)
+	ValidateUpload(context.Context, *ValidateUploadRequest) (*ValidateUploadResponse, error)
+	ListUploadFailures(context.Context, *ListUploadFailuresRequest) (*ListUploadFailuresResponse, error)
+	ExportUploadFailures(context.Context, *ExportUploadFailuresRequest) (*httpbody.HttpBody, error)
+	ReprocessUpload(context.Context, *ReprocessUploadRequest) (*ReprocessUploadResponse, error)
+	// declared last, so that REST paths under /api/v1/uploads are matched first
+	GetUpload(context.Context, *GetUploadRequest) (*GetUploadResponse, error)
+	CancelUpload(context.Context, *CancelUploadRequest) (*CancelUploadResponse, error)
 }
 
 // UnimplementedDpsServiceServer can be embedded to have forward compatible implementations.
@@ -566,6 +1792,24 @@ func (*UnimplementedDpsServiceServer) ListUploadData(ctx context.Context, req *L
 func (*UnimplementedDpsServiceServer) ListUploadMetaData(ctx context.Context, req *ListUploadRequest) (*ListUploadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUploadMetaData not implemented")
 }
+func (*UnimplementedDpsServiceServer) ValidateUpload(ctx context.Context, req *ValidateUploadRequest) (*ValidateUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateUpload not implemented")
+}
+func (*UnimplementedDpsServiceServer) ListUploadFailures(ctx context.Context, req *ListUploadFailuresRequest) (*ListUploadFailuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUploadFailures not implemented")
+}
+func (*UnimplementedDpsServiceServer) ExportUploadFailures(ctx context.Context, req *ExportUploadFailuresRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUploadFailures not implemented")
+}
+func (*UnimplementedDpsServiceServer) ReprocessUpload(ctx context.Context, req *ReprocessUploadRequest) (*ReprocessUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReprocessUpload not implemented")
+}
+func (*UnimplementedDpsServiceServer) GetUpload(ctx context.Context, req *GetUploadRequest) (*GetUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpload not implemented")
+}
+func (*UnimplementedDpsServiceServer) CancelUpload(ctx context.Context, req *CancelUploadRequest) (*CancelUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelUpload not implemented")
+}
 
 func RegisterDpsServiceServer(s *grpc.Server, srv DpsServiceServer) {
 	s.RegisterService(&_DpsService_serviceDesc, srv)
@@ -625,6 +1869,114 @@ func _DpsService_ListUploadMetaData_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DpsService_ValidateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).ValidateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/ValidateUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).ValidateUpload(ctx, req.(*ValidateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DpsService_ListUploadFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUploadFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).ListUploadFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/ListUploadFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).ListUploadFailures(ctx, req.(*ListUploadFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DpsService_ExportUploadFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUploadFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).ExportUploadFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/ExportUploadFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).ExportUploadFailures(ctx, req.(*ExportUploadFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DpsService_ReprocessUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReprocessUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).ReprocessUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/ReprocessUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).ReprocessUpload(ctx, req.(*ReprocessUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DpsService_GetUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).GetUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/GetUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).GetUpload(ctx, req.(*GetUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DpsService_CancelUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DpsServiceServer).CancelUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.DpsService/CancelUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DpsServiceServer).CancelUpload(ctx, req.(*CancelUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DpsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.DpsService",
 	HandlerType: (*DpsServiceServer)(nil),
@@ -641,6 +1993,30 @@ var _DpsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListUploadMetaData",
 			Handler:    _DpsService_ListUploadMetaData_Handler,
 		},
+		{
+			MethodName: "ValidateUpload",
+			Handler:    _DpsService_ValidateUpload_Handler,
+		},
+		{
+			MethodName: "ListUploadFailures",
+			Handler:    _DpsService_ListUploadFailures_Handler,
+		},
+		{
+			MethodName: "ExportUploadFailures",
+			Handler:    _DpsService_ExportUploadFailures_Handler,
+		},
+		{
+			MethodName: "ReprocessUpload",
+			Handler:    _DpsService_ReprocessUpload_Handler,
+		},
+		{
+			MethodName: "GetUpload",
+			Handler:    _DpsService_GetUpload_Handler,
+		},
+		{
+			MethodName: "CancelUpload",
+			Handler:    _DpsService_CancelUpload_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dps.proto",
@@ -70,6 +70,12 @@ func (m *NotifyUploadRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for DryRun
+
+	// no validation rules for Ordered
+
+	// no validation rules for Delta
+
 	return nil
 }
 
@@ -144,6 +150,38 @@ func (m *NotifyUploadResponse) Validate() error {
 
 	// no validation rules for Success
 
+	for idx, item := range m.GetReports() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NotifyUploadResponseValidationError{
+					field:  fmt.Sprintf("Reports[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for UploadId
+
+	for idx, item := range m.GetDeltas() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NotifyUploadResponseValidationError{
+					field:  fmt.Sprintf("Deltas[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -203,48 +241,120 @@ var _ interface {
 	ErrorName() string
 } = NotifyUploadResponseValidationError{}
 
-// Validate checks the field values on ListUploadRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, an
-// error is returned.
-func (m *ListUploadRequest) Validate() error {
+// Validate checks the field values on FileDelta with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *FileDelta) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if val := m.GetPageNum(); val < 1 || val >= 1000 {
-		return ListUploadRequestValidationError{
-			field:  "PageNum",
-			reason: "value must be inside range [1, 1000)",
+	// no validation rules for FileName
+
+	// no validation rules for FileType
+
+	// no validation rules for PreviousUploadId
+
+	// no validation rules for Full
+
+	// no validation rules for Added
+
+	// no validation rules for Changed
+
+	// no validation rules for Removed
+
+	// no validation rules for Unchanged
+
+	for idx, item := range m.GetRows() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileDeltaValidationError{
+					field:  fmt.Sprintf("Rows[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
 		}
+
 	}
 
-	if m.GetPageSize() < 10 {
-		return ListUploadRequestValidationError{
-			field:  "PageSize",
-			reason: "value must be greater than or equal to 10",
-		}
+	return nil
+}
+
+// FileDeltaValidationError is the validation error returned by
+// FileDelta.Validate if the designated constraints aren't met.
+type FileDeltaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileDeltaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileDeltaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileDeltaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileDeltaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileDeltaValidationError) ErrorName() string { return "FileDeltaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FileDeltaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
 	}
 
-	if _, ok := ListUploadRequest_SortBy_name[int32(m.GetSortBy())]; !ok {
-		return ListUploadRequestValidationError{
-			field:  "SortBy",
-			reason: "value must be one of the defined enum values",
-		}
+	key := ""
+	if e.key {
+		key = "key for "
 	}
 
-	if _, ok := ListUploadRequest_SortOrder_name[int32(m.GetSortOrder())]; !ok {
-		return ListUploadRequestValidationError{
-			field:  "SortOrder",
-			reason: "value must be one of the defined enum values",
-		}
+	return fmt.Sprintf(
+		"invalid %sFileDelta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileDeltaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileDeltaValidationError{}
+
+// Validate checks the field values on DeltaRow with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *DeltaRow) Validate() error {
+	if m == nil {
+		return nil
 	}
 
+	// no validation rules for Line
+
+	// no validation rules for Key
+
+	// no validation rules for Change
+
 	return nil
 }
 
-// ListUploadRequestValidationError is the validation error returned by
-// ListUploadRequest.Validate if the designated constraints aren't met.
-type ListUploadRequestValidationError struct {
+// DeltaRowValidationError is the validation error returned by
+// DeltaRow.Validate if the designated constraints aren't met.
+type DeltaRowValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -252,24 +362,22 @@ type ListUploadRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUploadRequestValidationError) Field() string { return e.field }
+func (e DeltaRowValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUploadRequestValidationError) Reason() string { return e.reason }
+func (e DeltaRowValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUploadRequestValidationError) Cause() error { return e.cause }
+func (e DeltaRowValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUploadRequestValidationError) Key() bool { return e.key }
+func (e DeltaRowValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUploadRequestValidationError) ErrorName() string {
-	return "ListUploadRequestValidationError"
-}
+func (e DeltaRowValidationError) ErrorName() string { return "DeltaRowValidationError" }
 
 // Error satisfies the builtin error interface
-func (e ListUploadRequestValidationError) Error() string {
+func (e DeltaRowValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -281,14 +389,14 @@ func (e ListUploadRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUploadRequest.%s: %s%s",
+		"invalid %sDeltaRow.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUploadRequestValidationError{}
+var _ error = DeltaRowValidationError{}
 
 var _ interface {
 	Field() string
@@ -296,39 +404,29 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUploadRequestValidationError{}
+} = DeltaRowValidationError{}
 
-// Validate checks the field values on ListUploadResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
-func (m *ListUploadResponse) Validate() error {
+// Validate checks the field values on GetUploadRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *GetUploadRequest) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for TotalRecords
-
-	for idx, item := range m.GetUploads() {
-		_, _ = idx, item
-
-		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListUploadResponseValidationError{
-					field:  fmt.Sprintf("Uploads[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
+	if m.GetUploadId() <= 0 {
+		return GetUploadRequestValidationError{
+			field:  "UploadId",
+			reason: "value must be greater than 0",
 		}
-
 	}
 
 	return nil
 }
 
-// ListUploadResponseValidationError is the validation error returned by
-// ListUploadResponse.Validate if the designated constraints aren't met.
-type ListUploadResponseValidationError struct {
+// GetUploadRequestValidationError is the validation error returned by
+// GetUploadRequest.Validate if the designated constraints aren't met.
+type GetUploadRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -336,24 +434,22 @@ type ListUploadResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUploadResponseValidationError) Field() string { return e.field }
+func (e GetUploadRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUploadResponseValidationError) Reason() string { return e.reason }
+func (e GetUploadRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUploadResponseValidationError) Cause() error { return e.cause }
+func (e GetUploadRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUploadResponseValidationError) Key() bool { return e.key }
+func (e GetUploadRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUploadResponseValidationError) ErrorName() string {
-	return "ListUploadResponseValidationError"
-}
+func (e GetUploadRequestValidationError) ErrorName() string { return "GetUploadRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e ListUploadResponseValidationError) Error() string {
+func (e GetUploadRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -365,14 +461,14 @@ func (e ListUploadResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUploadResponse.%s: %s%s",
+		"invalid %sGetUploadRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUploadResponseValidationError{}
+var _ error = GetUploadRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -380,11 +476,12 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUploadResponseValidationError{}
+} = GetUploadRequestValidationError{}
 
-// Validate checks the field values on Upload with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Upload) Validate() error {
+// Validate checks the field values on GetUploadResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *GetUploadResponse) Validate() error {
 	if m == nil {
 		return nil
 	}
@@ -393,7 +490,7 @@ func (m *Upload) Validate() error {
 
 	// no validation rules for Scope
 
-	// no validation rules for FileName
+	// no validation rules for DataType
 
 	// no validation rules for Status
 
@@ -401,7 +498,7 @@ func (m *Upload) Validate() error {
 
 	if v, ok := interface{}(m.GetUploadedOn()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UploadValidationError{
+			return GetUploadResponseValidationError{
 				field:  "UploadedOn",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -409,20 +506,57 @@ func (m *Upload) Validate() error {
 		}
 	}
 
+	// no validation rules for TotalFiles
+
+	// no validation rules for DoneFiles
+
+	// no validation rules for Progress
+
+	// no validation rules for PendingJobs
+
 	// no validation rules for TotalRecords
 
+	// no validation rules for ProcessedRecords
+
 	// no validation rules for SuccessRecords
 
 	// no validation rules for FailedRecords
 
 	// no validation rules for InvalidRecords
 
+	// no validation rules for CancelledBy
+
+	if v, ok := interface{}(m.GetCancelledOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUploadResponseValidationError{
+				field:  "CancelledOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetUploadResponseValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	return nil
 }
 
-// UploadValidationError is the validation error returned by Upload.Validate if
-// the designated constraints aren't met.
-type UploadValidationError struct {
+// GetUploadResponseValidationError is the validation error returned by
+// GetUploadResponse.Validate if the designated constraints aren't met.
+type GetUploadResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -430,22 +564,24 @@ type UploadValidationError struct {
 }
 
 // Field function returns field value.
-func (e UploadValidationError) Field() string { return e.field }
+func (e GetUploadResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UploadValidationError) Reason() string { return e.reason }
+func (e GetUploadResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UploadValidationError) Cause() error { return e.cause }
+func (e GetUploadResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UploadValidationError) Key() bool { return e.key }
+func (e GetUploadResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UploadValidationError) ErrorName() string { return "UploadValidationError" }
+func (e GetUploadResponseValidationError) ErrorName() string {
+	return "GetUploadResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e UploadValidationError) Error() string {
+func (e GetUploadResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -457,14 +593,14 @@ func (e UploadValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpload.%s: %s%s",
+		"invalid %sGetUploadResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UploadValidationError{}
+var _ error = GetUploadResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -472,4 +608,1233 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UploadValidationError{}
+} = GetUploadResponseValidationError{}
+
+// Validate checks the field values on CancelUploadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *CancelUploadRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetUploadId() <= 0 {
+		return CancelUploadRequestValidationError{
+			field:  "UploadId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// CancelUploadRequestValidationError is the validation error returned by
+// CancelUploadRequest.Validate if the designated constraints aren't met.
+type CancelUploadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CancelUploadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CancelUploadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CancelUploadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CancelUploadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CancelUploadRequestValidationError) ErrorName() string {
+	return "CancelUploadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CancelUploadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCancelUploadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CancelUploadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CancelUploadRequestValidationError{}
+
+// Validate checks the field values on CancelUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *CancelUploadResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Success
+
+	// no validation rules for JobsCancelled
+
+	return nil
+}
+
+// CancelUploadResponseValidationError is the validation error returned by
+// CancelUploadResponse.Validate if the designated constraints aren't met.
+type CancelUploadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CancelUploadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CancelUploadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CancelUploadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CancelUploadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CancelUploadResponseValidationError) ErrorName() string {
+	return "CancelUploadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CancelUploadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCancelUploadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CancelUploadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CancelUploadResponseValidationError{}
+
+// Validate checks the field values on ValidateUploadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ValidateUploadRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Scope
+
+	if len(m.GetFiles()) < 1 {
+		return ValidateUploadRequestValidationError{
+			field:  "Files",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	return nil
+}
+
+// ValidateUploadRequestValidationError is the validation error returned by
+// ValidateUploadRequest.Validate if the designated constraints aren't met.
+type ValidateUploadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidateUploadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidateUploadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidateUploadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidateUploadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidateUploadRequestValidationError) ErrorName() string {
+	return "ValidateUploadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ValidateUploadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidateUploadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidateUploadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidateUploadRequestValidationError{}
+
+// Validate checks the field values on ValidateUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ValidateUploadResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Valid
+
+	for idx, item := range m.GetReports() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValidateUploadResponseValidationError{
+					field:  fmt.Sprintf("Reports[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ValidateUploadResponseValidationError is the validation error returned by
+// ValidateUploadResponse.Validate if the designated constraints aren't met.
+type ValidateUploadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ValidateUploadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ValidateUploadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ValidateUploadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ValidateUploadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ValidateUploadResponseValidationError) ErrorName() string {
+	return "ValidateUploadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ValidateUploadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sValidateUploadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ValidateUploadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ValidateUploadResponseValidationError{}
+
+// Validate checks the field values on FileValidationReport with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *FileValidationReport) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for FileName
+
+	// no validation rules for FileType
+
+	// no validation rules for TotalRecords
+
+	// no validation rules for InvalidRecords
+
+	for idx, item := range m.GetErrors() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileValidationReportValidationError{
+					field:  fmt.Sprintf("Errors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// FileValidationReportValidationError is the validation error returned by
+// FileValidationReport.Validate if the designated constraints aren't met.
+type FileValidationReportValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileValidationReportValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileValidationReportValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileValidationReportValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileValidationReportValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileValidationReportValidationError) ErrorName() string {
+	return "FileValidationReportValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FileValidationReportValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFileValidationReport.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileValidationReportValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileValidationReportValidationError{}
+
+// Validate checks the field values on RowError with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *RowError) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Line
+
+	// no validation rules for Column
+
+	// no validation rules for Reason
+
+	return nil
+}
+
+// RowErrorValidationError is the validation error returned by
+// RowError.Validate if the designated constraints aren't met.
+type RowErrorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RowErrorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RowErrorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RowErrorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RowErrorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RowErrorValidationError) ErrorName() string { return "RowErrorValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RowErrorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRowError.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RowErrorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RowErrorValidationError{}
+
+// Validate checks the field values on ListUploadRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ListUploadRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if val := m.GetPageNum(); val < 1 || val >= 1000 {
+		return ListUploadRequestValidationError{
+			field:  "PageNum",
+			reason: "value must be inside range [1, 1000)",
+		}
+	}
+
+	if m.GetPageSize() < 10 {
+		return ListUploadRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be greater than or equal to 10",
+		}
+	}
+
+	if _, ok := ListUploadRequest_SortBy_name[int32(m.GetSortBy())]; !ok {
+		return ListUploadRequestValidationError{
+			field:  "SortBy",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	if _, ok := ListUploadRequest_SortOrder_name[int32(m.GetSortOrder())]; !ok {
+		return ListUploadRequestValidationError{
+			field:  "SortOrder",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	return nil
+}
+
+// ListUploadRequestValidationError is the validation error returned by
+// ListUploadRequest.Validate if the designated constraints aren't met.
+type ListUploadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUploadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUploadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUploadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUploadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUploadRequestValidationError) ErrorName() string {
+	return "ListUploadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUploadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUploadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUploadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUploadRequestValidationError{}
+
+// Validate checks the field values on ListUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ListUploadResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for TotalRecords
+
+	for idx, item := range m.GetUploads() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUploadResponseValidationError{
+					field:  fmt.Sprintf("Uploads[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ListUploadResponseValidationError is the validation error returned by
+// ListUploadResponse.Validate if the designated constraints aren't met.
+type ListUploadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUploadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUploadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUploadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUploadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUploadResponseValidationError) ErrorName() string {
+	return "ListUploadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUploadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUploadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUploadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUploadResponseValidationError{}
+
+// Validate checks the field values on Upload with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Upload) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for UploadId
+
+	// no validation rules for Scope
+
+	// no validation rules for FileName
+
+	// no validation rules for Status
+
+	// no validation rules for UploadedBy
+
+	if v, ok := interface{}(m.GetUploadedOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UploadValidationError{
+				field:  "UploadedOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for TotalRecords
+
+	// no validation rules for SuccessRecords
+
+	// no validation rules for FailedRecords
+
+	// no validation rules for InvalidRecords
+
+	// no validation rules for ProcessedRecords
+
+	return nil
+}
+
+// UploadValidationError is the validation error returned by Upload.Validate if
+// the designated constraints aren't met.
+type UploadValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadValidationError) ErrorName() string { return "UploadValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UploadValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpload.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadValidationError{}
+
+// Validate checks the field values on ListUploadFailuresRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ListUploadFailuresRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetUploadId() <= 0 {
+		return ListUploadFailuresRequestValidationError{
+			field:  "UploadId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	if val := m.GetPageNum(); val < 1 || val >= 1000 {
+		return ListUploadFailuresRequestValidationError{
+			field:  "PageNum",
+			reason: "value must be inside range [1, 1000)",
+		}
+	}
+
+	if val := m.GetPageSize(); val < 10 || val > 1000 {
+		return ListUploadFailuresRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [10, 1000]",
+		}
+	}
+
+	return nil
+}
+
+// ListUploadFailuresRequestValidationError is the validation error returned by
+// ListUploadFailuresRequest.Validate if the designated constraints aren't met.
+type ListUploadFailuresRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUploadFailuresRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUploadFailuresRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUploadFailuresRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUploadFailuresRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUploadFailuresRequestValidationError) ErrorName() string {
+	return "ListUploadFailuresRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUploadFailuresRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUploadFailuresRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUploadFailuresRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUploadFailuresRequestValidationError{}
+
+// Validate checks the field values on ListUploadFailuresResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ListUploadFailuresResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for TotalRecords
+
+	for idx, item := range m.GetFailures() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUploadFailuresResponseValidationError{
+					field:  fmt.Sprintf("Failures[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ListUploadFailuresResponseValidationError is the validation error returned
+// by ListUploadFailuresResponse.Validate if the designated constraints aren't met.
+type ListUploadFailuresResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUploadFailuresResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUploadFailuresResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUploadFailuresResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUploadFailuresResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUploadFailuresResponseValidationError) ErrorName() string {
+	return "ListUploadFailuresResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUploadFailuresResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUploadFailuresResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUploadFailuresResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUploadFailuresResponseValidationError{}
+
+// Validate checks the field values on UploadFailure with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *UploadFailure) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for UploadId
+
+	// no validation rules for FileName
+
+	// no validation rules for Line
+
+	// no validation rules for FailureType
+
+	// no validation rules for Payload
+
+	// no validation rules for GrpcCode
+
+	// no validation rules for Reason
+
+	if v, ok := interface{}(m.GetFailedOn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UploadFailureValidationError{
+				field:  "FailedOn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// UploadFailureValidationError is the validation error returned by
+// UploadFailure.Validate if the designated constraints aren't met.
+type UploadFailureValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadFailureValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadFailureValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadFailureValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadFailureValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadFailureValidationError) ErrorName() string { return "UploadFailureValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UploadFailureValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadFailure.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadFailureValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadFailureValidationError{}
+
+// Validate checks the field values on ExportUploadFailuresRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ExportUploadFailuresRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetUploadId() <= 0 {
+		return ExportUploadFailuresRequestValidationError{
+			field:  "UploadId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// ExportUploadFailuresRequestValidationError is the validation error returned
+// by ExportUploadFailuresRequest.Validate if the designated constraints
+// aren't met.
+type ExportUploadFailuresRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportUploadFailuresRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportUploadFailuresRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportUploadFailuresRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportUploadFailuresRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportUploadFailuresRequestValidationError) ErrorName() string {
+	return "ExportUploadFailuresRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportUploadFailuresRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportUploadFailuresRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportUploadFailuresRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportUploadFailuresRequestValidationError{}
+
+// Validate checks the field values on ReprocessUploadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ReprocessUploadRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetUploadId() <= 0 {
+		return ReprocessUploadRequestValidationError{
+			field:  "UploadId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	if v, ok := interface{}(m.GetOnlyFailed()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReprocessUploadRequestValidationError{
+				field:  "OnlyFailed",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReprocessUploadRequestValidationError is the validation error returned by
+// ReprocessUploadRequest.Validate if the designated constraints aren't met.
+type ReprocessUploadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReprocessUploadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReprocessUploadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReprocessUploadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReprocessUploadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReprocessUploadRequestValidationError) ErrorName() string {
+	return "ReprocessUploadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReprocessUploadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReprocessUploadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReprocessUploadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReprocessUploadRequestValidationError{}
+
+// Validate checks the field values on ReprocessUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ReprocessUploadResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Success
+
+	// no validation rules for JobsRequeued
+
+	return nil
+}
+
+// ReprocessUploadResponseValidationError is the validation error returned by
+// ReprocessUploadResponse.Validate if the designated constraints aren't met.
+type ReprocessUploadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReprocessUploadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReprocessUploadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReprocessUploadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReprocessUploadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReprocessUploadResponseValidationError) ErrorName() string {
+	return "ReprocessUploadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReprocessUploadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReprocessUploadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReprocessUploadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReprocessUploadResponseValidationError{}
@@ -165,6 +165,8 @@ func RunServer() error {
 	if err != nil {
 		return fmt.Errorf("failed to create worker queue: %v", err)
 	}
+	//running jobs are given time to end once server has stopped
+	defer Queue.Close(ctx)
 
 	for i := 0; i < cfg.MaxFileWorker; i++ {
 		w := fileworker.NewWorker(constants.FILEWORKER, Queue, db)
@@ -240,6 +242,9 @@ func RunServer() error {
 	if err != nil {
 		logger.Log.Error("Failed to register server stats view")
 	}
+	if err := view.Register(workerqueue.DefaultViews...); err != nil {
+		logger.Log.Error("Failed to register worker queue stats view")
+	}
 
 	// Run Instumentation Server
 	instrumentationServer := &http.Server{
@@ -250,7 +255,7 @@ func RunServer() error {
 		_ = instrumentationServer.ListenAndServe()
 	}()
 
-	v1API := v1.NewDpsServiceServer(dbObj.Queries, *Queue)
+	v1API := v1.NewDpsServiceServer(&dbObj, *Queue, grpcClientMap)
 	// get the verify key to validate jwt
 	verifyKey, err := iam.GetVerifyKey(cfg.IAM)
 	if err != nil {
@@ -268,5 +273,5 @@ func RunServer() error {
 		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, verifyKey)
 	}()
 
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
+	return grpc.RunServer(ctx, v1API, workerqueue.NewAdminServer(Queue), cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
 }
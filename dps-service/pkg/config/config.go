@@ -79,6 +79,9 @@ type Config struct {
 	//MaxApiWorker
 	MaxApiWorker int
 
+	//BatchSize is the number of records of a file read in memory at once
+	BatchSize int
+
 	// Instrumentation configuration
 	Instrumentation InstrumentationConfig
 
@@ -26,6 +26,7 @@ func init() {
 	ErrMap["InvalidFileName"] = &CustomError{2007, "InvalidFileName", "File name is not as exxpected, required scope_filename.csv", http.StatusBadRequest}
 	ErrMap["FileNotSupported"] = &CustomError{2008, "FileNotSupported", "This file is not supported", http.StatusBadRequest}
 	ErrMap["TargetServiceNotSupported"] = &CustomError{2009, "TargetServiceNotSupported", "This target service is not supported", http.StatusBadRequest}
+	ErrMap["UploadCancelled"] = &CustomError{2010, "UploadCancelled", "Upload is cancelled", http.StatusConflict}
 }
 
 var (
@@ -13,9 +13,11 @@ import (
 	"net"
 	"optisam-backend/common/optisam/logger"
 	mw "optisam-backend/common/optisam/middleware/grpc"
+	wqv1 "optisam-backend/common/optisam/workerqueue/api/v1"
 	v1 "optisam-backend/dps-service/pkg/api/v1"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/open-policy-agent/opa/rego"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -23,7 +25,7 @@ import (
 )
 
 // RunServer runs gRPC service to publish Auth service
-func RunServer(ctx context.Context, v1API v1.DpsServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
+func RunServer(ctx context.Context, v1API v1.DpsServiceServer, adminAPI wqv1.JobAdminServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
@@ -37,10 +39,11 @@ func RunServer(ctx context.Context, v1API v1.DpsServiceServer, port string, veri
 	// register service
 	server := grpc.NewServer(opts...)
 	v1.RegisterDpsServiceServer(server, v1API)
+	wqv1.RegisterJobAdminServiceServer(server, adminAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			// sig is a ^C, handle it
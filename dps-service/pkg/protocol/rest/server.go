@@ -13,6 +13,7 @@ import (
 	"net/http/pprof"
 	"optisam-backend/common/optisam/logger"
 	rest_middleware "optisam-backend/common/optisam/middleware/rest"
+	wqv1 "optisam-backend/common/optisam/workerqueue/api/v1"
 	v1 "optisam-backend/dps-service/pkg/api/v1"
 	"os"
 	"os/signal"
@@ -67,7 +68,8 @@ func RunServer(ctx context.Context, grpcPort, httpPort string, verifyKey *rsa.Pu
 }
 
 func newGateway(ctx context.Context, grpcPort string) (http.Handler, error) {
-	mux_gateway := runtime.NewServeMux()
+	//HTTPBodyMarshaler writes google.api.HttpBody responses (eg: failures export) as raw content
+	mux_gateway := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{OrigName: true}}))
 	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithStatsHandler(&ocgrpc.ClientHandler{})}
 	conn, err := grpc.DialContext(ctx, "localhost:"+grpcPort, opts...)
 	if err != nil {
@@ -77,5 +79,8 @@ func newGateway(ctx context.Context, grpcPort string) (http.Handler, error) {
 	if err := v1.RegisterDpsServiceHandler(ctx, mux_gateway, conn); err != nil {
 		return nil, err
 	}
+	if err := wqv1.RegisterJobAdminServiceHandler(ctx, mux_gateway, conn); err != nil {
+		return nil, err
+	}
 	return mux_gateway, err
 }
@@ -6,9 +6,13 @@
 
 package v1
 
-import "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+import (
+	"context"
+	"optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+)
 
-//go:generate mockgen -destination=mock/mock.go -package=mock optisam-backend/common/optisam/Dps/repository/v1 Dps
+//go:generate mockgen -destination=mock/mock.go -package=mock optisam-backend/dps-service/pkg/repository/v1 Dps
 type Dps interface {
 	db.Querier
+	InsertUploadTX(ctx context.Context, upload db.InsertUploadParams, files []db.InsertUploadedDataInSetParams, deps []db.InsertUploadFileDependencyParams) (int32, error)
 }
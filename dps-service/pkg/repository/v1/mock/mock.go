@@ -0,0 +1,651 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: optisam-backend/dps-service/pkg/repository/v1 (interfaces: Dps)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	db "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	reflect "reflect"
+)
+
+// MockDps is a mock of Dps interface
+type MockDps struct {
+	ctrl     *gomock.Controller
+	recorder *MockDpsMockRecorder
+}
+
+// MockDpsMockRecorder is the mock recorder for MockDps
+type MockDpsMockRecorder struct {
+	mock *MockDps
+}
+
+// NewMockDps creates a new mock instance
+func NewMockDps(ctrl *gomock.Controller) *MockDps {
+	mock := &MockDps{ctrl: ctrl}
+	mock.recorder = &MockDpsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDps) EXPECT() *MockDpsMockRecorder {
+	return m.recorder
+}
+
+// AddFileJobs mocks base method
+func (m *MockDps) AddFileJobs(arg0 context.Context, arg1 db.AddFileJobsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFileJobs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFileJobs indicates an expected call of AddFileJobs
+func (mr *MockDpsMockRecorder) AddFileJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFileJobs", reflect.TypeOf((*MockDps)(nil).AddFileJobs), arg0, arg1)
+}
+
+// AddFilePendingJobs mocks base method
+func (m *MockDps) AddFilePendingJobs(arg0 context.Context, arg1 db.AddFilePendingJobsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilePendingJobs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilePendingJobs indicates an expected call of AddFilePendingJobs
+func (mr *MockDpsMockRecorder) AddFilePendingJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilePendingJobs", reflect.TypeOf((*MockDps)(nil).AddFilePendingJobs), arg0, arg1)
+}
+
+// CancelUpload mocks base method
+func (m *MockDps) CancelUpload(arg0 context.Context, arg1 db.CancelUploadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUpload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUpload indicates an expected call of CancelUpload
+func (mr *MockDpsMockRecorder) CancelUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpload", reflect.TypeOf((*MockDps)(nil).CancelUpload), arg0, arg1)
+}
+
+// CancelUploadFiles mocks base method
+func (m *MockDps) CancelUploadFiles(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUploadFiles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUploadFiles indicates an expected call of CancelUploadFiles
+func (mr *MockDpsMockRecorder) CancelUploadFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUploadFiles", reflect.TypeOf((*MockDps)(nil).CancelUploadFiles), arg0, arg1)
+}
+
+// CancelUploadJobs mocks base method
+func (m *MockDps) CancelUploadJobs(arg0 context.Context, arg1 db.CancelUploadJobsParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUploadJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUploadJobs indicates an expected call of CancelUploadJobs
+func (mr *MockDpsMockRecorder) CancelUploadJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUploadJobs", reflect.TypeOf((*MockDps)(nil).CancelUploadJobs), arg0, arg1)
+}
+
+// DeleteFailedUploadRecords mocks base method
+func (m *MockDps) DeleteFailedUploadRecords(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFailedUploadRecords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFailedUploadRecords indicates an expected call of DeleteFailedUploadRecords
+func (mr *MockDpsMockRecorder) DeleteFailedUploadRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFailedUploadRecords", reflect.TypeOf((*MockDps)(nil).DeleteFailedUploadRecords), arg0, arg1)
+}
+
+// DeleteStaleFingerprints mocks base method
+func (m *MockDps) DeleteStaleFingerprints(arg0 context.Context, arg1 db.DeleteStaleFingerprintsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleFingerprints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStaleFingerprints indicates an expected call of DeleteStaleFingerprints
+func (mr *MockDpsMockRecorder) DeleteStaleFingerprints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleFingerprints", reflect.TypeOf((*MockDps)(nil).DeleteStaleFingerprints), arg0, arg1)
+}
+
+// DoneFilePendingJob mocks base method
+func (m *MockDps) DoneFilePendingJob(arg0 context.Context, arg1 db.DoneFilePendingJobParams) (db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoneFilePendingJob", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoneFilePendingJob indicates an expected call of DoneFilePendingJob
+func (mr *MockDpsMockRecorder) DoneFilePendingJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoneFilePendingJob", reflect.TypeOf((*MockDps)(nil).DoneFilePendingJob), arg0, arg1)
+}
+
+// ExportUploadRecordFailures mocks base method
+func (m *MockDps) ExportUploadRecordFailures(arg0 context.Context, arg1 db.ExportUploadRecordFailuresParams) ([]db.UploadRecordFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUploadRecordFailures", arg0, arg1)
+	ret0, _ := ret[0].([]db.UploadRecordFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUploadRecordFailures indicates an expected call of ExportUploadRecordFailures
+func (mr *MockDpsMockRecorder) ExportUploadRecordFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUploadRecordFailures", reflect.TypeOf((*MockDps)(nil).ExportUploadRecordFailures), arg0, arg1)
+}
+
+// FailDependentFiles mocks base method
+func (m *MockDps) FailDependentFiles(arg0 context.Context, arg1 db.FailDependentFilesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailDependentFiles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailDependentFiles indicates an expected call of FailDependentFiles
+func (mr *MockDpsMockRecorder) FailDependentFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailDependentFiles", reflect.TypeOf((*MockDps)(nil).FailDependentFiles), arg0, arg1)
+}
+
+// GetAppliedFile mocks base method
+func (m *MockDps) GetAppliedFile(arg0 context.Context, arg1 db.GetAppliedFileParams) (db.AppliedFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppliedFile", arg0, arg1)
+	ret0, _ := ret[0].(db.AppliedFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppliedFile indicates an expected call of GetAppliedFile
+func (mr *MockDpsMockRecorder) GetAppliedFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppliedFile", reflect.TypeOf((*MockDps)(nil).GetAppliedFile), arg0, arg1)
+}
+
+// GetFileStatus mocks base method
+func (m *MockDps) GetFileStatus(arg0 context.Context, arg1 db.GetFileStatusParams) (db.UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileStatus indicates an expected call of GetFileStatus
+func (mr *MockDpsMockRecorder) GetFileStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileStatus", reflect.TypeOf((*MockDps)(nil).GetFileStatus), arg0, arg1)
+}
+
+// GetUpload mocks base method
+func (m *MockDps) GetUpload(arg0 context.Context, arg1 int32) (db.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", arg0, arg1)
+	ret0, _ := ret[0].(db.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload
+func (mr *MockDpsMockRecorder) GetUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockDps)(nil).GetUpload), arg0, arg1)
+}
+
+// GetUploadProgress mocks base method
+func (m *MockDps) GetUploadProgress(arg0 context.Context, arg1 int32) (db.GetUploadProgressRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadProgress", arg0, arg1)
+	ret0, _ := ret[0].(db.GetUploadProgressRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadProgress indicates an expected call of GetUploadProgress
+func (mr *MockDpsMockRecorder) GetUploadProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadProgress", reflect.TypeOf((*MockDps)(nil).GetUploadProgress), arg0, arg1)
+}
+
+// GetUploadedDataFiles mocks base method
+func (m *MockDps) GetUploadedDataFiles(arg0 context.Context, arg1 int32) ([]db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadedDataFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadedDataFiles indicates an expected call of GetUploadedDataFiles
+func (mr *MockDpsMockRecorder) GetUploadedDataFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadedDataFiles", reflect.TypeOf((*MockDps)(nil).GetUploadedDataFiles), arg0, arg1)
+}
+
+// InsertFileFingerprints mocks base method
+func (m *MockDps) InsertFileFingerprints(arg0 context.Context, arg1 db.InsertFileFingerprintsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFileFingerprints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertFileFingerprints indicates an expected call of InsertFileFingerprints
+func (mr *MockDpsMockRecorder) InsertFileFingerprints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFileFingerprints", reflect.TypeOf((*MockDps)(nil).InsertFileFingerprints), arg0, arg1)
+}
+
+// InsertUpload mocks base method
+func (m *MockDps) InsertUpload(arg0 context.Context, arg1 db.InsertUploadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUpload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUpload indicates an expected call of InsertUpload
+func (mr *MockDpsMockRecorder) InsertUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUpload", reflect.TypeOf((*MockDps)(nil).InsertUpload), arg0, arg1)
+}
+
+// InsertUploadFileDependency mocks base method
+func (m *MockDps) InsertUploadFileDependency(arg0 context.Context, arg1 db.InsertUploadFileDependencyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadFileDependency", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUploadFileDependency indicates an expected call of InsertUploadFileDependency
+func (mr *MockDpsMockRecorder) InsertUploadFileDependency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadFileDependency", reflect.TypeOf((*MockDps)(nil).InsertUploadFileDependency), arg0, arg1)
+}
+
+// InsertUploadRecordFailure mocks base method
+func (m *MockDps) InsertUploadRecordFailure(arg0 context.Context, arg1 db.InsertUploadRecordFailureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadRecordFailure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUploadRecordFailure indicates an expected call of InsertUploadRecordFailure
+func (mr *MockDpsMockRecorder) InsertUploadRecordFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadRecordFailure", reflect.TypeOf((*MockDps)(nil).InsertUploadRecordFailure), arg0, arg1)
+}
+
+// InsertUploadReplay mocks base method
+func (m *MockDps) InsertUploadReplay(arg0 context.Context, arg1 db.InsertUploadReplayParams) (db.UploadReplay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadReplay", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadReplay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadReplay indicates an expected call of InsertUploadReplay
+func (mr *MockDpsMockRecorder) InsertUploadReplay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadReplay", reflect.TypeOf((*MockDps)(nil).InsertUploadReplay), arg0, arg1)
+}
+
+// InsertUploadTX mocks base method
+func (m *MockDps) InsertUploadTX(arg0 context.Context, arg1 db.InsertUploadParams, arg2 []db.InsertUploadedDataInSetParams, arg3 []db.InsertUploadFileDependencyParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadTX", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadTX indicates an expected call of InsertUploadTX
+func (mr *MockDpsMockRecorder) InsertUploadTX(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadTX", reflect.TypeOf((*MockDps)(nil).InsertUploadTX), arg0, arg1, arg2, arg3)
+}
+
+// InsertUploadedData mocks base method
+func (m *MockDps) InsertUploadedData(arg0 context.Context, arg1 db.InsertUploadedDataParams) (db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadedData", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadedData indicates an expected call of InsertUploadedData
+func (mr *MockDpsMockRecorder) InsertUploadedData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadedData", reflect.TypeOf((*MockDps)(nil).InsertUploadedData), arg0, arg1)
+}
+
+// InsertUploadedDataInSet mocks base method
+func (m *MockDps) InsertUploadedDataInSet(arg0 context.Context, arg1 db.InsertUploadedDataInSetParams) (db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadedDataInSet", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadedDataInSet indicates an expected call of InsertUploadedDataInSet
+func (mr *MockDpsMockRecorder) InsertUploadedDataInSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadedDataInSet", reflect.TypeOf((*MockDps)(nil).InsertUploadedDataInSet), arg0, arg1)
+}
+
+// InsertUploadedMetaData mocks base method
+func (m *MockDps) InsertUploadedMetaData(arg0 context.Context, arg1 db.InsertUploadedMetaDataParams) (db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadedMetaData", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadedMetaData indicates an expected call of InsertUploadedMetaData
+func (mr *MockDpsMockRecorder) InsertUploadedMetaData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadedMetaData", reflect.TypeOf((*MockDps)(nil).InsertUploadedMetaData), arg0, arg1)
+}
+
+// ListFileFingerprints mocks base method
+func (m *MockDps) ListFileFingerprints(arg0 context.Context, arg1 db.ListFileFingerprintsParams) ([]db.ListFileFingerprintsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileFingerprints", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFileFingerprintsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileFingerprints indicates an expected call of ListFileFingerprints
+func (mr *MockDpsMockRecorder) ListFileFingerprints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileFingerprints", reflect.TypeOf((*MockDps)(nil).ListFileFingerprints), arg0, arg1)
+}
+
+// ListRemovedFingerprints mocks base method
+func (m *MockDps) ListRemovedFingerprints(arg0 context.Context, arg1 db.ListRemovedFingerprintsParams) ([]db.ListRemovedFingerprintsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRemovedFingerprints", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRemovedFingerprintsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRemovedFingerprints indicates an expected call of ListRemovedFingerprints
+func (mr *MockDpsMockRecorder) ListRemovedFingerprints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRemovedFingerprints", reflect.TypeOf((*MockDps)(nil).ListRemovedFingerprints), arg0, arg1)
+}
+
+// ListReplayableUploadJobs mocks base method
+func (m *MockDps) ListReplayableUploadJobs(arg0 context.Context, arg1 db.ListReplayableUploadJobsParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplayableUploadJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplayableUploadJobs indicates an expected call of ListReplayableUploadJobs
+func (mr *MockDpsMockRecorder) ListReplayableUploadJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplayableUploadJobs", reflect.TypeOf((*MockDps)(nil).ListReplayableUploadJobs), arg0, arg1)
+}
+
+// ListStartableFiles mocks base method
+func (m *MockDps) ListStartableFiles(arg0 context.Context, arg1 int32) ([]db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStartableFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStartableFiles indicates an expected call of ListStartableFiles
+func (mr *MockDpsMockRecorder) ListStartableFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStartableFiles", reflect.TypeOf((*MockDps)(nil).ListStartableFiles), arg0, arg1)
+}
+
+// ListUploadRecordFailures mocks base method
+func (m *MockDps) ListUploadRecordFailures(arg0 context.Context, arg1 db.ListUploadRecordFailuresParams) ([]db.ListUploadRecordFailuresRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadRecordFailures", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUploadRecordFailuresRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadRecordFailures indicates an expected call of ListUploadRecordFailures
+func (mr *MockDpsMockRecorder) ListUploadRecordFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadRecordFailures", reflect.TypeOf((*MockDps)(nil).ListUploadRecordFailures), arg0, arg1)
+}
+
+// ListUploadedDataFiles mocks base method
+func (m *MockDps) ListUploadedDataFiles(arg0 context.Context, arg1 db.ListUploadedDataFilesParams) ([]db.ListUploadedDataFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadedDataFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUploadedDataFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadedDataFiles indicates an expected call of ListUploadedDataFiles
+func (mr *MockDpsMockRecorder) ListUploadedDataFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadedDataFiles", reflect.TypeOf((*MockDps)(nil).ListUploadedDataFiles), arg0, arg1)
+}
+
+// ListUploadedMetaDataFiles mocks base method
+func (m *MockDps) ListUploadedMetaDataFiles(arg0 context.Context, arg1 db.ListUploadedMetaDataFilesParams) ([]db.ListUploadedMetaDataFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadedMetaDataFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUploadedMetaDataFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadedMetaDataFiles indicates an expected call of ListUploadedMetaDataFiles
+func (mr *MockDpsMockRecorder) ListUploadedMetaDataFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadedMetaDataFiles", reflect.TypeOf((*MockDps)(nil).ListUploadedMetaDataFiles), arg0, arg1)
+}
+
+// NextUploadID mocks base method
+func (m *MockDps) NextUploadID(arg0 context.Context) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextUploadID", arg0)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextUploadID indicates an expected call of NextUploadID
+func (mr *MockDpsMockRecorder) NextUploadID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextUploadID", reflect.TypeOf((*MockDps)(nil).NextUploadID), arg0)
+}
+
+// ResetFileRecords mocks base method
+func (m *MockDps) ResetFileRecords(arg0 context.Context, arg1 db.ResetFileRecordsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFileRecords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFileRecords indicates an expected call of ResetFileRecords
+func (mr *MockDpsMockRecorder) ResetFileRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFileRecords", reflect.TypeOf((*MockDps)(nil).ResetFileRecords), arg0, arg1)
+}
+
+// SetAppliedFile mocks base method
+func (m *MockDps) SetAppliedFile(arg0 context.Context, arg1 db.SetAppliedFileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAppliedFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAppliedFile indicates an expected call of SetAppliedFile
+func (mr *MockDpsMockRecorder) SetAppliedFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppliedFile", reflect.TypeOf((*MockDps)(nil).SetAppliedFile), arg0, arg1)
+}
+
+// StartFile mocks base method
+func (m *MockDps) StartFile(arg0 context.Context, arg1 db.StartFileParams) (db.UploadedDataFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFile", arg0, arg1)
+	ret0, _ := ret[0].(db.UploadedDataFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartFile indicates an expected call of StartFile
+func (mr *MockDpsMockRecorder) StartFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFile", reflect.TypeOf((*MockDps)(nil).StartFile), arg0, arg1)
+}
+
+// UpdateFileFailedRecord mocks base method
+func (m *MockDps) UpdateFileFailedRecord(arg0 context.Context, arg1 db.UpdateFileFailedRecordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileFailedRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileFailedRecord indicates an expected call of UpdateFileFailedRecord
+func (mr *MockDpsMockRecorder) UpdateFileFailedRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileFailedRecord", reflect.TypeOf((*MockDps)(nil).UpdateFileFailedRecord), arg0, arg1)
+}
+
+// UpdateFileInvalidRecord mocks base method
+func (m *MockDps) UpdateFileInvalidRecord(arg0 context.Context, arg1 db.UpdateFileInvalidRecordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileInvalidRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileInvalidRecord indicates an expected call of UpdateFileInvalidRecord
+func (mr *MockDpsMockRecorder) UpdateFileInvalidRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileInvalidRecord", reflect.TypeOf((*MockDps)(nil).UpdateFileInvalidRecord), arg0, arg1)
+}
+
+// UpdateFileProcessedRecord mocks base method
+func (m *MockDps) UpdateFileProcessedRecord(arg0 context.Context, arg1 db.UpdateFileProcessedRecordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileProcessedRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileProcessedRecord indicates an expected call of UpdateFileProcessedRecord
+func (mr *MockDpsMockRecorder) UpdateFileProcessedRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileProcessedRecord", reflect.TypeOf((*MockDps)(nil).UpdateFileProcessedRecord), arg0, arg1)
+}
+
+// UpdateFileRecordsForReplay mocks base method
+func (m *MockDps) UpdateFileRecordsForReplay(arg0 context.Context, arg1 db.UpdateFileRecordsForReplayParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileRecordsForReplay", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileRecordsForReplay indicates an expected call of UpdateFileRecordsForReplay
+func (mr *MockDpsMockRecorder) UpdateFileRecordsForReplay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileRecordsForReplay", reflect.TypeOf((*MockDps)(nil).UpdateFileRecordsForReplay), arg0, arg1)
+}
+
+// UpdateFileStatus mocks base method
+func (m *MockDps) UpdateFileStatus(arg0 context.Context, arg1 db.UpdateFileStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileStatus indicates an expected call of UpdateFileStatus
+func (mr *MockDpsMockRecorder) UpdateFileStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileStatus", reflect.TypeOf((*MockDps)(nil).UpdateFileStatus), arg0, arg1)
+}
+
+// UpdateFileSuccessRecord mocks base method
+func (m *MockDps) UpdateFileSuccessRecord(arg0 context.Context, arg1 db.UpdateFileSuccessRecordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileSuccessRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileSuccessRecord indicates an expected call of UpdateFileSuccessRecord
+func (mr *MockDpsMockRecorder) UpdateFileSuccessRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileSuccessRecord", reflect.TypeOf((*MockDps)(nil).UpdateFileSuccessRecord), arg0, arg1)
+}
+
+// UpdateFileTotalRecord mocks base method
+func (m *MockDps) UpdateFileTotalRecord(arg0 context.Context, arg1 db.UpdateFileTotalRecordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileTotalRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileTotalRecord indicates an expected call of UpdateFileTotalRecord
+func (mr *MockDpsMockRecorder) UpdateFileTotalRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileTotalRecord", reflect.TypeOf((*MockDps)(nil).UpdateFileTotalRecord), arg0, arg1)
+}
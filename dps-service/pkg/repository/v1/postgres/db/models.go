@@ -34,6 +34,25 @@ func (e *DataType) Scan(src interface{}) error {
 	return nil
 }
 
+type FailureType string
+
+const (
+	FailureTypeINVALID FailureType = "INVALID"
+	FailureTypeFAILED  FailureType = "FAILED"
+)
+
+func (e *FailureType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FailureType(s)
+	case string:
+		*e = FailureType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FailureType: %T", src)
+	}
+	return nil
+}
+
 type JobStatus string
 
 const (
@@ -42,6 +61,8 @@ const (
 	JobStatusFAILED    JobStatus = "FAILED"
 	JobStatusRETRY     JobStatus = "RETRY"
 	JobStatusRUNNING   JobStatus = "RUNNING"
+	JobStatusCANCELLED JobStatus = "CANCELLED"
+	JobStatusDEAD      JobStatus = "DEAD"
 )
 
 func (e *JobStatus) Scan(src interface{}) error {
@@ -63,6 +84,7 @@ const (
 	UploadStatusCOMPLETED  UploadStatus = "COMPLETED"
 	UploadStatusFAILED     UploadStatus = "FAILED"
 	UploadStatusINPROGRESS UploadStatus = "INPROGRESS"
+	UploadStatusCANCELLED  UploadStatus = "CANCELLED"
 )
 
 func (e *UploadStatus) Scan(src interface{}) error {
@@ -77,27 +99,111 @@ func (e *UploadStatus) Scan(src interface{}) error {
 	return nil
 }
 
+type AppliedFile struct {
+	Scope     string    `json:"scope"`
+	FileType  string    `json:"file_type"`
+	UploadID  int32     `json:"upload_id"`
+	FileName  string    `json:"file_name"`
+	AppliedOn time.Time `json:"applied_on"`
+}
+
+type FileFingerprint struct {
+	Scope    string          `json:"scope"`
+	FileType string          `json:"file_type"`
+	UploadID int32           `json:"upload_id"`
+	RowKey   string          `json:"row_key"`
+	RowHash  string          `json:"row_hash"`
+	RowData  json.RawMessage `json:"row_data"`
+}
+
 type Job struct {
-	JobID     int32           `json:"job_id"`
-	Type      string          `json:"type"`
-	Status    JobStatus       `json:"status"`
-	Data      json.RawMessage `json:"data"`
-	Comments  sql.NullString  `json:"comments"`
-	StartTime sql.NullTime    `json:"start_time"`
-	EndTime   sql.NullTime    `json:"end_time"`
-	CreatedAt time.Time       `json:"created_at"`
+	JobID          int32           `json:"job_id"`
+	Type           string          `json:"type"`
+	Status         JobStatus       `json:"status"`
+	Data           json.RawMessage `json:"data"`
+	Comments       sql.NullString  `json:"comments"`
+	StartTime      sql.NullTime    `json:"start_time"`
+	EndTime        sql.NullTime    `json:"end_time"`
+	CreatedAt      time.Time       `json:"created_at"`
+	RetryCount     sql.NullInt32   `json:"retry_count"`
+	LeaseOwner     sql.NullString  `json:"lease_owner"`
+	LeaseExpiresAt sql.NullTime    `json:"lease_expires_at"`
+	RunAfter       sql.NullTime    `json:"run_after"`
+	Priority       int32           `json:"priority"`
+	TraceContext   []byte          `json:"trace_context"`
+	IdempotencyKey sql.NullString  `json:"idempotency_key"`
+}
+
+type JobsArchive struct {
+	JobID      int32           `json:"job_id"`
+	Type       string          `json:"type"`
+	Status     JobStatus       `json:"status"`
+	Data       json.RawMessage `json:"data"`
+	Comments   sql.NullString  `json:"comments"`
+	StartTime  sql.NullTime    `json:"start_time"`
+	EndTime    sql.NullTime    `json:"end_time"`
+	CreatedAt  time.Time       `json:"created_at"`
+	RetryCount sql.NullInt32   `json:"retry_count"`
+	ArchivedAt time.Time       `json:"archived_at"`
+}
+
+type Upload struct {
+	UploadID    int32          `json:"upload_id"`
+	Scope       string         `json:"scope"`
+	DataType    DataType       `json:"data_type"`
+	UploadedBy  string         `json:"uploaded_by"`
+	UploadedOn  time.Time      `json:"uploaded_on"`
+	CancelledBy sql.NullString `json:"cancelled_by"`
+	CancelledOn sql.NullTime   `json:"cancelled_on"`
+}
+
+type UploadFileDependency struct {
+	UploadID  int32  `json:"upload_id"`
+	FileName  string `json:"file_name"`
+	DependsOn string `json:"depends_on"`
+}
+
+type UploadFileJob struct {
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+	JobID    int32  `json:"job_id"`
+}
+
+type UploadRecordFailure struct {
+	FailureID   int32           `json:"failure_id"`
+	UploadID    int32           `json:"upload_id"`
+	FileName    string          `json:"file_name"`
+	Line        int32           `json:"line"`
+	FailureType FailureType     `json:"failure_type"`
+	Payload     json.RawMessage `json:"payload"`
+	GrpcCode    string          `json:"grpc_code"`
+	Reason      string          `json:"reason"`
+	FailedOn    time.Time       `json:"failed_on"`
+}
+
+type UploadReplay struct {
+	ReplayID   int32     `json:"replay_id"`
+	UploadID   int32     `json:"upload_id"`
+	OnlyFailed bool      `json:"only_failed"`
+	JobsCount  int32     `json:"jobs_count"`
+	ReplayedBy string    `json:"replayed_by"`
+	ReplayedOn time.Time `json:"replayed_on"`
 }
 
 type UploadedDataFile struct {
-	UploadID       int32        `json:"upload_id"`
-	Scope          string       `json:"scope"`
-	DataType       DataType     `json:"data_type"`
-	FileName       string       `json:"file_name"`
-	Status         UploadStatus `json:"status"`
-	UploadedBy     string       `json:"uploaded_by"`
-	UploadedOn     time.Time    `json:"uploaded_on"`
-	TotalRecords   int32        `json:"total_records"`
-	SuccessRecords int32        `json:"success_records"`
-	FailedRecords  int32        `json:"failed_records"`
-	InvalidRecords int32        `json:"invalid_records"`
+	UploadID         int32        `json:"upload_id"`
+	Scope            string       `json:"scope"`
+	DataType         DataType     `json:"data_type"`
+	FileName         string       `json:"file_name"`
+	Status           UploadStatus `json:"status"`
+	UploadedBy       string       `json:"uploaded_by"`
+	UploadedOn       time.Time    `json:"uploaded_on"`
+	TotalRecords     int32        `json:"total_records"`
+	SuccessRecords   int32        `json:"success_records"`
+	FailedRecords    int32        `json:"failed_records"`
+	InvalidRecords   int32        `json:"invalid_records"`
+	ProcessedRecords int32        `json:"processed_records"`
+	FileOrder        int32        `json:"file_order"`
+	PendingJobs      int32        `json:"pending_jobs"`
+	Delta            bool         `json:"delta"`
 }
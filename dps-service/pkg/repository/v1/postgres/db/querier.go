@@ -13,12 +13,44 @@ import (
 )
 
 type Querier interface {
+	AddFileJobs(ctx context.Context, arg AddFileJobsParams) error
+	AddFilePendingJobs(ctx context.Context, arg AddFilePendingJobsParams) error
+	CancelUpload(ctx context.Context, arg CancelUploadParams) error
+	CancelUploadFiles(ctx context.Context, uploadID int32) error
+	CancelUploadJobs(ctx context.Context, arg CancelUploadJobsParams) ([]Job, error)
+	DeleteFailedUploadRecords(ctx context.Context, uploadID int32) error
+	DeleteStaleFingerprints(ctx context.Context, arg DeleteStaleFingerprintsParams) error
+	DoneFilePendingJob(ctx context.Context, arg DoneFilePendingJobParams) (UploadedDataFile, error)
+	ExportUploadRecordFailures(ctx context.Context, arg ExportUploadRecordFailuresParams) ([]UploadRecordFailure, error)
+	FailDependentFiles(ctx context.Context, arg FailDependentFilesParams) error
+	GetAppliedFile(ctx context.Context, arg GetAppliedFileParams) (AppliedFile, error)
 	GetFileStatus(ctx context.Context, arg GetFileStatusParams) (UploadStatus, error)
+	GetUpload(ctx context.Context, uploadID int32) (Upload, error)
+	GetUploadProgress(ctx context.Context, uploadID int32) (GetUploadProgressRow, error)
+	GetUploadedDataFiles(ctx context.Context, uploadID int32) ([]UploadedDataFile, error)
+	InsertFileFingerprints(ctx context.Context, arg InsertFileFingerprintsParams) error
+	InsertUpload(ctx context.Context, arg InsertUploadParams) error
+	InsertUploadFileDependency(ctx context.Context, arg InsertUploadFileDependencyParams) error
+	InsertUploadRecordFailure(ctx context.Context, arg InsertUploadRecordFailureParams) error
+	InsertUploadReplay(ctx context.Context, arg InsertUploadReplayParams) (UploadReplay, error)
 	InsertUploadedData(ctx context.Context, arg InsertUploadedDataParams) (UploadedDataFile, error)
+	InsertUploadedDataInSet(ctx context.Context, arg InsertUploadedDataInSetParams) (UploadedDataFile, error)
 	InsertUploadedMetaData(ctx context.Context, arg InsertUploadedMetaDataParams) (UploadedDataFile, error)
+	ListFileFingerprints(ctx context.Context, arg ListFileFingerprintsParams) ([]ListFileFingerprintsRow, error)
+	ListRemovedFingerprints(ctx context.Context, arg ListRemovedFingerprintsParams) ([]ListRemovedFingerprintsRow, error)
+	ListReplayableUploadJobs(ctx context.Context, arg ListReplayableUploadJobsParams) ([]Job, error)
+	ListStartableFiles(ctx context.Context, uploadID int32) ([]UploadedDataFile, error)
+	ListUploadRecordFailures(ctx context.Context, arg ListUploadRecordFailuresParams) ([]ListUploadRecordFailuresRow, error)
 	ListUploadedDataFiles(ctx context.Context, arg ListUploadedDataFilesParams) ([]ListUploadedDataFilesRow, error)
 	ListUploadedMetaDataFiles(ctx context.Context, arg ListUploadedMetaDataFilesParams) ([]ListUploadedMetaDataFilesRow, error)
+	NextUploadID(ctx context.Context) (int32, error)
+	ResetFileRecords(ctx context.Context, arg ResetFileRecordsParams) error
+	SetAppliedFile(ctx context.Context, arg SetAppliedFileParams) error
+	StartFile(ctx context.Context, arg StartFileParams) (UploadedDataFile, error)
 	UpdateFileFailedRecord(ctx context.Context, arg UpdateFileFailedRecordParams) error
+	UpdateFileInvalidRecord(ctx context.Context, arg UpdateFileInvalidRecordParams) error
+	UpdateFileProcessedRecord(ctx context.Context, arg UpdateFileProcessedRecordParams) error
+	UpdateFileRecordsForReplay(ctx context.Context, arg UpdateFileRecordsForReplayParams) error
 	UpdateFileStatus(ctx context.Context, arg UpdateFileStatusParams) error
 	UpdateFileSuccessRecord(ctx context.Context, arg UpdateFileSuccessRecordParams) error
 	UpdateFileTotalRecord(ctx context.Context, arg UpdateFileTotalRecordParams) error
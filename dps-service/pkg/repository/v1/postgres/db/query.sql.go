@@ -11,11 +11,275 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/lib/pq"
 )
 
+const addFileJobs = `-- name: AddFileJobs :exec
+WITH added AS (
+    INSERT INTO upload_file_jobs (upload_id,file_name,job_id)
+    SELECT $1::INTEGER, $2::VARCHAR, j.job_id FROM unnest($3::INTEGER[]) AS j(job_id)
+    WHERE j.job_id > 0
+    ON CONFLICT DO NOTHING
+    RETURNING job_id
+)
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs + (SELECT COUNT(*) FROM added)
+WHERE upload_id = $1::INTEGER AND file_name = $2::VARCHAR
+`
+
+type AddFileJobsParams struct {
+	UploadID int32   `json:"upload_id"`
+	FileName string  `json:"file_name"`
+	JobIds   []int32 `json:"job_ids"`
+}
+
+func (q *Queries) AddFileJobs(ctx context.Context, arg AddFileJobsParams) error {
+	_, err := q.db.ExecContext(ctx, addFileJobs, arg.UploadID, arg.FileName, pq.Array(arg.JobIds))
+	return err
+}
+
+const addFilePendingJobs = `-- name: AddFilePendingJobs :exec
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs + $3 where upload_id = $1 AND file_name = $2
+`
+
+type AddFilePendingJobsParams struct {
+	UploadID    int32  `json:"upload_id"`
+	FileName    string `json:"file_name"`
+	PendingJobs int32  `json:"pending_jobs"`
+}
+
+func (q *Queries) AddFilePendingJobs(ctx context.Context, arg AddFilePendingJobsParams) error {
+	_, err := q.db.ExecContext(ctx, addFilePendingJobs, arg.UploadID, arg.FileName, arg.PendingJobs)
+	return err
+}
+
+const cancelUpload = `-- name: CancelUpload :exec
+UPDATE uploads SET cancelled_by = $2, cancelled_on = NOW() WHERE upload_id = $1
+`
+
+type CancelUploadParams struct {
+	UploadID    int32          `json:"upload_id"`
+	CancelledBy sql.NullString `json:"cancelled_by"`
+}
+
+func (q *Queries) CancelUpload(ctx context.Context, arg CancelUploadParams) error {
+	_, err := q.db.ExecContext(ctx, cancelUpload, arg.UploadID, arg.CancelledBy)
+	return err
+}
+
+const cancelUploadFiles = `-- name: CancelUploadFiles :exec
+UPDATE uploaded_data_files SET status = 'CANCELLED' WHERE upload_id = $1 AND status IN ('PENDING','INPROGRESS')
+`
+
+func (q *Queries) CancelUploadFiles(ctx context.Context, uploadID int32) error {
+	_, err := q.db.ExecContext(ctx, cancelUploadFiles, uploadID)
+	return err
+}
+
+const cancelUploadJobs = `-- name: CancelUploadJobs :many
+UPDATE jobs SET status = 'CANCELLED', end_time = NOW()
+WHERE
+    status IN ('PENDING','RETRY')
+    AND ((type = $1 AND (data->>'UploadID')::INTEGER = $2::INTEGER)
+    OR (type = $3 AND (data->>'upload_id')::INTEGER = $2::INTEGER))
+returning job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key
+`
+
+type CancelUploadJobsParams struct {
+	ApiType  string `json:"api_type"`
+	UploadID int32  `json:"upload_id"`
+	FileType string `json:"file_type"`
+}
+
+func (q *Queries) CancelUploadJobs(ctx context.Context, arg CancelUploadJobsParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, cancelUploadJobs, arg.ApiType, arg.UploadID, arg.FileType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Type,
+			&i.Status,
+			&i.Data,
+			&i.Comments,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.RetryCount,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.RunAfter,
+			&i.Priority,
+			&i.TraceContext,
+			&i.IdempotencyKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteFailedUploadRecords = `-- name: DeleteFailedUploadRecords :exec
+DELETE FROM upload_record_failures WHERE upload_id = $1 AND failure_type = 'FAILED'
+`
+
+func (q *Queries) DeleteFailedUploadRecords(ctx context.Context, uploadID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteFailedUploadRecords, uploadID)
+	return err
+}
+
+const deleteStaleFingerprints = `-- name: DeleteStaleFingerprints :exec
+DELETE FROM file_fingerprints f USING applied_files a
+WHERE f.scope = a.scope AND f.file_type = a.file_type AND a.scope = $1 AND a.file_type = $2 AND f.upload_id < a.upload_id
+`
+
+type DeleteStaleFingerprintsParams struct {
+	Scope    string `json:"scope"`
+	FileType string `json:"file_type"`
+}
+
+func (q *Queries) DeleteStaleFingerprints(ctx context.Context, arg DeleteStaleFingerprintsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteStaleFingerprints, arg.Scope, arg.FileType)
+	return err
+}
+
+const doneFilePendingJob = `-- name: DoneFilePendingJob :one
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs - 1 where upload_id = $1 AND file_name = $2 returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta
+`
+
+type DoneFilePendingJobParams struct {
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) DoneFilePendingJob(ctx context.Context, arg DoneFilePendingJobParams) (UploadedDataFile, error) {
+	row := q.db.QueryRowContext(ctx, doneFilePendingJob, arg.UploadID, arg.FileName)
+	var i UploadedDataFile
+	err := row.Scan(
+		&i.UploadID,
+		&i.Scope,
+		&i.DataType,
+		&i.FileName,
+		&i.Status,
+		&i.UploadedBy,
+		&i.UploadedOn,
+		&i.TotalRecords,
+		&i.SuccessRecords,
+		&i.FailedRecords,
+		&i.InvalidRecords,
+		&i.ProcessedRecords,
+		&i.FileOrder,
+		&i.PendingJobs,
+		&i.Delta,
+	)
+	return i, err
+}
+
+const exportUploadRecordFailures = `-- name: ExportUploadRecordFailures :many
+SELECT f.failure_id, f.upload_id, f.file_name, f.line, f.failure_type, f.payload, f.grpc_code, f.reason, f.failed_on from
+upload_record_failures f JOIN uploaded_data_files u
+ON f.upload_id = u.upload_id AND f.file_name = u.file_name
+WHERE
+    f.upload_id = $1
+    AND (u.scope = ANY($2::TEXT[]) OR u.data_type = 'METADATA')
+ORDER BY f.file_name,f.line,f.failure_id
+`
+
+type ExportUploadRecordFailuresParams struct {
+	UploadID int32    `json:"upload_id"`
+	Scope    []string `json:"scope"`
+}
+
+func (q *Queries) ExportUploadRecordFailures(ctx context.Context, arg ExportUploadRecordFailuresParams) ([]UploadRecordFailure, error) {
+	rows, err := q.db.QueryContext(ctx, exportUploadRecordFailures, arg.UploadID, pq.Array(arg.Scope))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UploadRecordFailure
+	for rows.Next() {
+		var i UploadRecordFailure
+		if err := rows.Scan(
+			&i.FailureID,
+			&i.UploadID,
+			&i.FileName,
+			&i.Line,
+			&i.FailureType,
+			&i.Payload,
+			&i.GrpcCode,
+			&i.Reason,
+			&i.FailedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const failDependentFiles = `-- name: FailDependentFiles :exec
+WITH RECURSIVE dependents AS (
+    SELECT d.file_name FROM upload_file_dependencies d WHERE d.upload_id = $1 AND d.depends_on = $2
+    UNION
+    SELECT d.file_name FROM upload_file_dependencies d JOIN dependents ON d.depends_on = dependents.file_name
+    WHERE d.upload_id = $1
+)
+UPDATE uploaded_data_files u SET status = 'FAILED'
+WHERE u.upload_id = $1 AND u.status = 'PENDING' AND u.file_name IN (SELECT dependents.file_name FROM dependents)
+`
+
+type FailDependentFilesParams struct {
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) FailDependentFiles(ctx context.Context, arg FailDependentFilesParams) error {
+	_, err := q.db.ExecContext(ctx, failDependentFiles, arg.UploadID, arg.FileName)
+	return err
+}
+
+const getAppliedFile = `-- name: GetAppliedFile :one
+SELECT scope, file_type, upload_id, file_name, applied_on FROM applied_files WHERE scope = $1 AND file_type = $2
+`
+
+type GetAppliedFileParams struct {
+	Scope    string `json:"scope"`
+	FileType string `json:"file_type"`
+}
+
+func (q *Queries) GetAppliedFile(ctx context.Context, arg GetAppliedFileParams) (AppliedFile, error) {
+	row := q.db.QueryRowContext(ctx, getAppliedFile, arg.Scope, arg.FileType)
+	var i AppliedFile
+	err := row.Scan(
+		&i.Scope,
+		&i.FileType,
+		&i.UploadID,
+		&i.FileName,
+		&i.AppliedOn,
+	)
+	return i, err
+}
+
 const getFileStatus = `-- name: GetFileStatus :one
 SELECT status FROM uploaded_data_files WHERE upload_id = $1 AND file_name = $2
 `
@@ -32,9 +296,245 @@ func (q *Queries) GetFileStatus(ctx context.Context, arg GetFileStatusParams) (U
 	return status, err
 }
 
+const getUpload = `-- name: GetUpload :one
+SELECT upload_id, scope, data_type, uploaded_by, uploaded_on, cancelled_by, cancelled_on FROM uploads WHERE upload_id = $1
+`
+
+func (q *Queries) GetUpload(ctx context.Context, uploadID int32) (Upload, error) {
+	row := q.db.QueryRowContext(ctx, getUpload, uploadID)
+	var i Upload
+	err := row.Scan(
+		&i.UploadID,
+		&i.Scope,
+		&i.DataType,
+		&i.UploadedBy,
+		&i.UploadedOn,
+		&i.CancelledBy,
+		&i.CancelledOn,
+	)
+	return i, err
+}
+
+const getUploadProgress = `-- name: GetUploadProgress :one
+SELECT
+    COUNT(*)::INTEGER AS total_files,
+    COUNT(*) FILTER (WHERE status = 'PENDING')::INTEGER AS pending_files,
+    COUNT(*) FILTER (WHERE status = 'INPROGRESS')::INTEGER AS inprogress_files,
+    COUNT(*) FILTER (WHERE status = 'FAILED')::INTEGER AS failed_files,
+    COUNT(*) FILTER (WHERE status IN ('COMPLETED','FAILED','CANCELLED') AND pending_jobs <= 0)::INTEGER AS done_files,
+    COALESCE(SUM(GREATEST(pending_jobs,0)),0)::INTEGER AS pending_jobs,
+    COALESCE(SUM(total_records),0)::INTEGER AS total_records,
+    COALESCE(SUM(processed_records),0)::INTEGER AS processed_records,
+    COALESCE(SUM(success_records),0)::INTEGER AS success_records,
+    COALESCE(SUM(failed_records),0)::INTEGER AS failed_records,
+    COALESCE(SUM(invalid_records),0)::INTEGER AS invalid_records
+FROM uploaded_data_files WHERE upload_id = $1
+`
+
+type GetUploadProgressRow struct {
+	TotalFiles       int32 `json:"total_files"`
+	PendingFiles     int32 `json:"pending_files"`
+	InprogressFiles  int32 `json:"inprogress_files"`
+	FailedFiles      int32 `json:"failed_files"`
+	DoneFiles        int32 `json:"done_files"`
+	PendingJobs      int32 `json:"pending_jobs"`
+	TotalRecords     int32 `json:"total_records"`
+	ProcessedRecords int32 `json:"processed_records"`
+	SuccessRecords   int32 `json:"success_records"`
+	FailedRecords    int32 `json:"failed_records"`
+	InvalidRecords   int32 `json:"invalid_records"`
+}
+
+func (q *Queries) GetUploadProgress(ctx context.Context, uploadID int32) (GetUploadProgressRow, error) {
+	row := q.db.QueryRowContext(ctx, getUploadProgress, uploadID)
+	var i GetUploadProgressRow
+	err := row.Scan(
+		&i.TotalFiles,
+		&i.PendingFiles,
+		&i.InprogressFiles,
+		&i.FailedFiles,
+		&i.DoneFiles,
+		&i.PendingJobs,
+		&i.TotalRecords,
+		&i.ProcessedRecords,
+		&i.SuccessRecords,
+		&i.FailedRecords,
+		&i.InvalidRecords,
+	)
+	return i, err
+}
+
+const getUploadedDataFiles = `-- name: GetUploadedDataFiles :many
+SELECT upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta FROM uploaded_data_files WHERE upload_id = $1
+`
+
+func (q *Queries) GetUploadedDataFiles(ctx context.Context, uploadID int32) ([]UploadedDataFile, error) {
+	rows, err := q.db.QueryContext(ctx, getUploadedDataFiles, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UploadedDataFile
+	for rows.Next() {
+		var i UploadedDataFile
+		if err := rows.Scan(
+			&i.UploadID,
+			&i.Scope,
+			&i.DataType,
+			&i.FileName,
+			&i.Status,
+			&i.UploadedBy,
+			&i.UploadedOn,
+			&i.TotalRecords,
+			&i.SuccessRecords,
+			&i.FailedRecords,
+			&i.InvalidRecords,
+			&i.ProcessedRecords,
+			&i.FileOrder,
+			&i.PendingJobs,
+			&i.Delta,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertFileFingerprints = `-- name: InsertFileFingerprints :exec
+INSERT INTO file_fingerprints (scope,file_type,upload_id,row_key,row_hash,row_data)
+SELECT $1::VARCHAR, $2::VARCHAR, $3::INTEGER, unnest($4::VARCHAR[]), unnest($5::VARCHAR[]), unnest($6::VARCHAR[])::JSONB
+ON CONFLICT (scope,file_type,upload_id,row_key) DO UPDATE SET row_hash = EXCLUDED.row_hash, row_data = EXCLUDED.row_data
+`
+
+type InsertFileFingerprintsParams struct {
+	Scope     string   `json:"scope"`
+	FileType  string   `json:"file_type"`
+	UploadID  int32    `json:"upload_id"`
+	RowKeys   []string `json:"row_keys"`
+	RowHashes []string `json:"row_hashes"`
+	RowData   []string `json:"row_data"`
+}
+
+func (q *Queries) InsertFileFingerprints(ctx context.Context, arg InsertFileFingerprintsParams) error {
+	_, err := q.db.ExecContext(ctx, insertFileFingerprints,
+		arg.Scope,
+		arg.FileType,
+		arg.UploadID,
+		pq.Array(arg.RowKeys),
+		pq.Array(arg.RowHashes),
+		pq.Array(arg.RowData),
+	)
+	return err
+}
+
+const insertUpload = `-- name: InsertUpload :exec
+INSERT INTO uploads (upload_id,scope,data_type,uploaded_by)
+VALUES($1,$2,$3,$4)
+`
+
+type InsertUploadParams struct {
+	UploadID   int32    `json:"upload_id"`
+	Scope      string   `json:"scope"`
+	DataType   DataType `json:"data_type"`
+	UploadedBy string   `json:"uploaded_by"`
+}
+
+func (q *Queries) InsertUpload(ctx context.Context, arg InsertUploadParams) error {
+	_, err := q.db.ExecContext(ctx, insertUpload,
+		arg.UploadID,
+		arg.Scope,
+		arg.DataType,
+		arg.UploadedBy,
+	)
+	return err
+}
+
+const insertUploadFileDependency = `-- name: InsertUploadFileDependency :exec
+INSERT INTO upload_file_dependencies (upload_id,file_name,depends_on)
+VALUES($1,$2,$3) ON CONFLICT DO NOTHING
+`
+
+type InsertUploadFileDependencyParams struct {
+	UploadID  int32  `json:"upload_id"`
+	FileName  string `json:"file_name"`
+	DependsOn string `json:"depends_on"`
+}
+
+func (q *Queries) InsertUploadFileDependency(ctx context.Context, arg InsertUploadFileDependencyParams) error {
+	_, err := q.db.ExecContext(ctx, insertUploadFileDependency, arg.UploadID, arg.FileName, arg.DependsOn)
+	return err
+}
+
+const insertUploadRecordFailure = `-- name: InsertUploadRecordFailure :exec
+INSERT INTO upload_record_failures (upload_id,file_name,line,failure_type,payload,grpc_code,reason)
+VALUES($1,$2,$3,$4,$5,$6,$7)
+ON CONFLICT (upload_id,file_name,line) WHERE failure_type = 'INVALID' DO NOTHING
+`
+
+type InsertUploadRecordFailureParams struct {
+	UploadID    int32           `json:"upload_id"`
+	FileName    string          `json:"file_name"`
+	Line        int32           `json:"line"`
+	FailureType FailureType     `json:"failure_type"`
+	Payload     json.RawMessage `json:"payload"`
+	GrpcCode    string          `json:"grpc_code"`
+	Reason      string          `json:"reason"`
+}
+
+func (q *Queries) InsertUploadRecordFailure(ctx context.Context, arg InsertUploadRecordFailureParams) error {
+	_, err := q.db.ExecContext(ctx, insertUploadRecordFailure,
+		arg.UploadID,
+		arg.FileName,
+		arg.Line,
+		arg.FailureType,
+		arg.Payload,
+		arg.GrpcCode,
+		arg.Reason,
+	)
+	return err
+}
+
+const insertUploadReplay = `-- name: InsertUploadReplay :one
+INSERT INTO upload_replays (upload_id,only_failed,jobs_count,replayed_by)
+VALUES($1,$2,$3,$4) returning replay_id, upload_id, only_failed, jobs_count, replayed_by, replayed_on
+`
+
+type InsertUploadReplayParams struct {
+	UploadID   int32  `json:"upload_id"`
+	OnlyFailed bool   `json:"only_failed"`
+	JobsCount  int32  `json:"jobs_count"`
+	ReplayedBy string `json:"replayed_by"`
+}
+
+func (q *Queries) InsertUploadReplay(ctx context.Context, arg InsertUploadReplayParams) (UploadReplay, error) {
+	row := q.db.QueryRowContext(ctx, insertUploadReplay,
+		arg.UploadID,
+		arg.OnlyFailed,
+		arg.JobsCount,
+		arg.ReplayedBy,
+	)
+	var i UploadReplay
+	err := row.Scan(
+		&i.ReplayID,
+		&i.UploadID,
+		&i.OnlyFailed,
+		&i.JobsCount,
+		&i.ReplayedBy,
+		&i.ReplayedOn,
+	)
+	return i, err
+}
+
 const insertUploadedData = `-- name: InsertUploadedData :one
 INSERT INTO uploaded_data_files (scope,data_type,file_name,uploaded_by)
-VALUES($1,$2,$3,$4) returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records
+VALUES($1,$2,$3,$4) returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta
 `
 
 type InsertUploadedDataParams struct {
@@ -64,13 +564,63 @@ func (q *Queries) InsertUploadedData(ctx context.Context, arg InsertUploadedData
 		&i.SuccessRecords,
 		&i.FailedRecords,
 		&i.InvalidRecords,
+		&i.ProcessedRecords,
+		&i.FileOrder,
+		&i.PendingJobs,
+		&i.Delta,
+	)
+	return i, err
+}
+
+const insertUploadedDataInSet = `-- name: InsertUploadedDataInSet :one
+INSERT INTO uploaded_data_files (upload_id,scope,data_type,file_name,uploaded_by,file_order,delta)
+VALUES($1,$2,$3,$4,$5,$6,$7) returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta
+`
+
+type InsertUploadedDataInSetParams struct {
+	UploadID   int32    `json:"upload_id"`
+	Scope      string   `json:"scope"`
+	DataType   DataType `json:"data_type"`
+	FileName   string   `json:"file_name"`
+	UploadedBy string   `json:"uploaded_by"`
+	FileOrder  int32    `json:"file_order"`
+	Delta      bool     `json:"delta"`
+}
+
+func (q *Queries) InsertUploadedDataInSet(ctx context.Context, arg InsertUploadedDataInSetParams) (UploadedDataFile, error) {
+	row := q.db.QueryRowContext(ctx, insertUploadedDataInSet,
+		arg.UploadID,
+		arg.Scope,
+		arg.DataType,
+		arg.FileName,
+		arg.UploadedBy,
+		arg.FileOrder,
+		arg.Delta,
+	)
+	var i UploadedDataFile
+	err := row.Scan(
+		&i.UploadID,
+		&i.Scope,
+		&i.DataType,
+		&i.FileName,
+		&i.Status,
+		&i.UploadedBy,
+		&i.UploadedOn,
+		&i.TotalRecords,
+		&i.SuccessRecords,
+		&i.FailedRecords,
+		&i.InvalidRecords,
+		&i.ProcessedRecords,
+		&i.FileOrder,
+		&i.PendingJobs,
+		&i.Delta,
 	)
 	return i, err
 }
 
 const insertUploadedMetaData = `-- name: InsertUploadedMetaData :one
 INSERT INTO uploaded_data_files (file_name,uploaded_by)
-VALUES($1,$2) returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records
+VALUES($1,$2) returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta
 `
 
 type InsertUploadedMetaDataParams struct {
@@ -93,12 +643,288 @@ func (q *Queries) InsertUploadedMetaData(ctx context.Context, arg InsertUploaded
 		&i.SuccessRecords,
 		&i.FailedRecords,
 		&i.InvalidRecords,
+		&i.ProcessedRecords,
+		&i.FileOrder,
+		&i.PendingJobs,
+		&i.Delta,
 	)
 	return i, err
 }
 
+const listFileFingerprints = `-- name: ListFileFingerprints :many
+SELECT row_key,row_hash FROM file_fingerprints
+WHERE scope = $1 AND file_type = $2 AND upload_id = $3 AND row_key = ANY($4::VARCHAR[])
+`
+
+type ListFileFingerprintsParams struct {
+	Scope    string   `json:"scope"`
+	FileType string   `json:"file_type"`
+	UploadID int32    `json:"upload_id"`
+	RowKeys  []string `json:"row_keys"`
+}
+
+type ListFileFingerprintsRow struct {
+	RowKey  string `json:"row_key"`
+	RowHash string `json:"row_hash"`
+}
+
+func (q *Queries) ListFileFingerprints(ctx context.Context, arg ListFileFingerprintsParams) ([]ListFileFingerprintsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFileFingerprints,
+		arg.Scope,
+		arg.FileType,
+		arg.UploadID,
+		pq.Array(arg.RowKeys),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFileFingerprintsRow
+	for rows.Next() {
+		var i ListFileFingerprintsRow
+		if err := rows.Scan(&i.RowKey, &i.RowHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRemovedFingerprints = `-- name: ListRemovedFingerprints :many
+SELECT row_key,row_data FROM file_fingerprints
+WHERE scope = $1 AND file_type = $2 AND upload_id = $3 AND NOT (row_key = ANY($4::VARCHAR[]))
+ORDER BY row_key
+`
+
+type ListRemovedFingerprintsParams struct {
+	Scope    string   `json:"scope"`
+	FileType string   `json:"file_type"`
+	UploadID int32    `json:"upload_id"`
+	RowKeys  []string `json:"row_keys"`
+}
+
+type ListRemovedFingerprintsRow struct {
+	RowKey  string          `json:"row_key"`
+	RowData json.RawMessage `json:"row_data"`
+}
+
+func (q *Queries) ListRemovedFingerprints(ctx context.Context, arg ListRemovedFingerprintsParams) ([]ListRemovedFingerprintsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRemovedFingerprints,
+		arg.Scope,
+		arg.FileType,
+		arg.UploadID,
+		pq.Array(arg.RowKeys),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRemovedFingerprintsRow
+	for rows.Next() {
+		var i ListRemovedFingerprintsRow
+		if err := rows.Scan(&i.RowKey, &i.RowData); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReplayableUploadJobs = `-- name: ListReplayableUploadJobs :many
+SELECT job_id, type, status, data, comments, start_time, end_time, created_at, retry_count, lease_owner, lease_expires_at, run_after, priority, trace_context, idempotency_key FROM jobs
+WHERE
+    type = $1
+    AND (data->>'UploadID')::INTEGER = $2::INTEGER
+    AND (status IN ('FAILED','DEAD') OR (status = 'RETRY' AND retry_count >= $3::INTEGER) OR (status = 'COMPLETED' AND NOT $4::bool))
+ORDER BY job_id
+`
+
+type ListReplayableUploadJobsParams struct {
+	Type       string `json:"type"`
+	UploadID   int32  `json:"upload_id"`
+	Retries    int32  `json:"retries"`
+	OnlyFailed bool   `json:"only_failed"`
+}
+
+func (q *Queries) ListReplayableUploadJobs(ctx context.Context, arg ListReplayableUploadJobsParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, listReplayableUploadJobs,
+		arg.Type,
+		arg.UploadID,
+		arg.Retries,
+		arg.OnlyFailed,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Type,
+			&i.Status,
+			&i.Data,
+			&i.Comments,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.RetryCount,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.RunAfter,
+			&i.Priority,
+			&i.TraceContext,
+			&i.IdempotencyKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStartableFiles = `-- name: ListStartableFiles :many
+SELECT upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta FROM uploaded_data_files f
+WHERE f.upload_id = $1 AND f.status = 'PENDING'
+AND NOT EXISTS (
+    SELECT 1 FROM upload_file_dependencies d
+    JOIN uploaded_data_files p ON p.upload_id = d.upload_id AND p.file_name = d.depends_on
+    WHERE d.upload_id = f.upload_id AND d.file_name = f.file_name
+    AND (p.status IN ('PENDING','INPROGRESS') OR p.pending_jobs > 0)
+)
+ORDER BY f.file_order
+`
+
+func (q *Queries) ListStartableFiles(ctx context.Context, uploadID int32) ([]UploadedDataFile, error) {
+	rows, err := q.db.QueryContext(ctx, listStartableFiles, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UploadedDataFile
+	for rows.Next() {
+		var i UploadedDataFile
+		if err := rows.Scan(
+			&i.UploadID,
+			&i.Scope,
+			&i.DataType,
+			&i.FileName,
+			&i.Status,
+			&i.UploadedBy,
+			&i.UploadedOn,
+			&i.TotalRecords,
+			&i.SuccessRecords,
+			&i.FailedRecords,
+			&i.InvalidRecords,
+			&i.ProcessedRecords,
+			&i.FileOrder,
+			&i.PendingJobs,
+			&i.Delta,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUploadRecordFailures = `-- name: ListUploadRecordFailures :many
+SELECT count(*) OVER() AS totalRecords,f.failure_id, f.upload_id, f.file_name, f.line, f.failure_type, f.payload, f.grpc_code, f.reason, f.failed_on from
+upload_record_failures f JOIN uploaded_data_files u
+ON f.upload_id = u.upload_id AND f.file_name = u.file_name
+WHERE
+    f.upload_id = $1
+    AND (u.scope = ANY($2::TEXT[]) OR u.data_type = 'METADATA')
+ORDER BY f.file_name,f.line,f.failure_id
+LIMIT $4 OFFSET $3
+`
+
+type ListUploadRecordFailuresParams struct {
+	UploadID int32    `json:"upload_id"`
+	Scope    []string `json:"scope"`
+	PageNum  int32    `json:"page_num"`
+	PageSize int32    `json:"page_size"`
+}
+
+type ListUploadRecordFailuresRow struct {
+	Totalrecords int64           `json:"totalrecords"`
+	FailureID    int32           `json:"failure_id"`
+	UploadID     int32           `json:"upload_id"`
+	FileName     string          `json:"file_name"`
+	Line         int32           `json:"line"`
+	FailureType  FailureType     `json:"failure_type"`
+	Payload      json.RawMessage `json:"payload"`
+	GrpcCode     string          `json:"grpc_code"`
+	Reason       string          `json:"reason"`
+	FailedOn     time.Time       `json:"failed_on"`
+}
+
+func (q *Queries) ListUploadRecordFailures(ctx context.Context, arg ListUploadRecordFailuresParams) ([]ListUploadRecordFailuresRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUploadRecordFailures,
+		arg.UploadID,
+		pq.Array(arg.Scope),
+		arg.PageNum,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUploadRecordFailuresRow
+	for rows.Next() {
+		var i ListUploadRecordFailuresRow
+		if err := rows.Scan(
+			&i.Totalrecords,
+			&i.FailureID,
+			&i.UploadID,
+			&i.FileName,
+			&i.Line,
+			&i.FailureType,
+			&i.Payload,
+			&i.GrpcCode,
+			&i.Reason,
+			&i.FailedOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUploadedDataFiles = `-- name: ListUploadedDataFiles :many
-SELECT count(*) OVER() AS totalRecords,upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records from 
+SELECT count(*) OVER() AS totalRecords,upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta from 
 uploaded_data_files
 WHERE 
     scope = ANY($1::TEXT[])
@@ -138,18 +964,22 @@ type ListUploadedDataFilesParams struct {
 }
 
 type ListUploadedDataFilesRow struct {
-	Totalrecords   int64        `json:"totalrecords"`
-	UploadID       int32        `json:"upload_id"`
-	Scope          string       `json:"scope"`
-	DataType       DataType     `json:"data_type"`
-	FileName       string       `json:"file_name"`
-	Status         UploadStatus `json:"status"`
-	UploadedBy     string       `json:"uploaded_by"`
-	UploadedOn     time.Time    `json:"uploaded_on"`
-	TotalRecords   int32        `json:"total_records"`
-	SuccessRecords int32        `json:"success_records"`
-	FailedRecords  int32        `json:"failed_records"`
-	InvalidRecords int32        `json:"invalid_records"`
+	Totalrecords     int64        `json:"totalrecords"`
+	UploadID         int32        `json:"upload_id"`
+	Scope            string       `json:"scope"`
+	DataType         DataType     `json:"data_type"`
+	FileName         string       `json:"file_name"`
+	Status           UploadStatus `json:"status"`
+	UploadedBy       string       `json:"uploaded_by"`
+	UploadedOn       time.Time    `json:"uploaded_on"`
+	TotalRecords     int32        `json:"total_records"`
+	SuccessRecords   int32        `json:"success_records"`
+	FailedRecords    int32        `json:"failed_records"`
+	InvalidRecords   int32        `json:"invalid_records"`
+	ProcessedRecords int32        `json:"processed_records"`
+	FileOrder        int32        `json:"file_order"`
+	PendingJobs      int32        `json:"pending_jobs"`
+	Delta            bool         `json:"delta"`
 }
 
 func (q *Queries) ListUploadedDataFiles(ctx context.Context, arg ListUploadedDataFilesParams) ([]ListUploadedDataFilesRow, error) {
@@ -190,6 +1020,10 @@ func (q *Queries) ListUploadedDataFiles(ctx context.Context, arg ListUploadedDat
 			&i.SuccessRecords,
 			&i.FailedRecords,
 			&i.InvalidRecords,
+			&i.ProcessedRecords,
+			&i.FileOrder,
+			&i.PendingJobs,
+			&i.Delta,
 		); err != nil {
 			return nil, err
 		}
@@ -205,7 +1039,7 @@ func (q *Queries) ListUploadedDataFiles(ctx context.Context, arg ListUploadedDat
 }
 
 const listUploadedMetaDataFiles = `-- name: ListUploadedMetaDataFiles :many
-SELECT count(*) OVER() AS totalRecords,upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records from 
+SELECT count(*) OVER() AS totalRecords,upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta from 
 uploaded_data_files
 WHERE data_type = 'METADATA'
 ORDER BY
@@ -242,18 +1076,22 @@ type ListUploadedMetaDataFilesParams struct {
 }
 
 type ListUploadedMetaDataFilesRow struct {
-	Totalrecords   int64        `json:"totalrecords"`
-	UploadID       int32        `json:"upload_id"`
-	Scope          string       `json:"scope"`
-	DataType       DataType     `json:"data_type"`
-	FileName       string       `json:"file_name"`
-	Status         UploadStatus `json:"status"`
-	UploadedBy     string       `json:"uploaded_by"`
-	UploadedOn     time.Time    `json:"uploaded_on"`
-	TotalRecords   int32        `json:"total_records"`
-	SuccessRecords int32        `json:"success_records"`
-	FailedRecords  int32        `json:"failed_records"`
-	InvalidRecords int32        `json:"invalid_records"`
+	Totalrecords     int64        `json:"totalrecords"`
+	UploadID         int32        `json:"upload_id"`
+	Scope            string       `json:"scope"`
+	DataType         DataType     `json:"data_type"`
+	FileName         string       `json:"file_name"`
+	Status           UploadStatus `json:"status"`
+	UploadedBy       string       `json:"uploaded_by"`
+	UploadedOn       time.Time    `json:"uploaded_on"`
+	TotalRecords     int32        `json:"total_records"`
+	SuccessRecords   int32        `json:"success_records"`
+	FailedRecords    int32        `json:"failed_records"`
+	InvalidRecords   int32        `json:"invalid_records"`
+	ProcessedRecords int32        `json:"processed_records"`
+	FileOrder        int32        `json:"file_order"`
+	PendingJobs      int32        `json:"pending_jobs"`
+	Delta            bool         `json:"delta"`
 }
 
 func (q *Queries) ListUploadedMetaDataFiles(ctx context.Context, arg ListUploadedMetaDataFilesParams) ([]ListUploadedMetaDataFilesRow, error) {
@@ -293,6 +1131,10 @@ func (q *Queries) ListUploadedMetaDataFiles(ctx context.Context, arg ListUploade
 			&i.SuccessRecords,
 			&i.FailedRecords,
 			&i.InvalidRecords,
+			&i.ProcessedRecords,
+			&i.FileOrder,
+			&i.PendingJobs,
+			&i.Delta,
 		); err != nil {
 			return nil, err
 		}
@@ -307,6 +1149,89 @@ func (q *Queries) ListUploadedMetaDataFiles(ctx context.Context, arg ListUploade
 	return items, nil
 }
 
+const nextUploadID = `-- name: NextUploadID :one
+SELECT nextval(pg_get_serial_sequence('uploaded_data_files', 'upload_id'))::INTEGER AS upload_id
+`
+
+func (q *Queries) NextUploadID(ctx context.Context) (int32, error) {
+	row := q.db.QueryRowContext(ctx, nextUploadID)
+	var upload_id int32
+	err := row.Scan(&upload_id)
+	return upload_id, err
+}
+
+const resetFileRecords = `-- name: ResetFileRecords :exec
+UPDATE uploaded_data_files SET total_records = $1, processed_records = 0 where upload_id = $2 AND file_name = $3
+`
+
+type ResetFileRecordsParams struct {
+	TotalRecords int32  `json:"total_records"`
+	UploadID     int32  `json:"upload_id"`
+	FileName     string `json:"file_name"`
+}
+
+func (q *Queries) ResetFileRecords(ctx context.Context, arg ResetFileRecordsParams) error {
+	_, err := q.db.ExecContext(ctx, resetFileRecords, arg.TotalRecords, arg.UploadID, arg.FileName)
+	return err
+}
+
+const setAppliedFile = `-- name: SetAppliedFile :exec
+INSERT INTO applied_files (scope,file_type,upload_id,file_name)
+SELECT f.scope, $1::VARCHAR, f.upload_id, f.file_name FROM uploaded_data_files f
+WHERE
+    f.upload_id = $2
+    AND f.file_name = $3
+    AND f.status = 'COMPLETED'
+    AND f.pending_jobs <= 0
+    AND NOT EXISTS (SELECT 1 FROM upload_record_failures r WHERE r.upload_id = f.upload_id AND r.file_name = f.file_name AND r.failure_type = 'FAILED')
+ON CONFLICT (scope,file_type) DO UPDATE SET upload_id = EXCLUDED.upload_id, file_name = EXCLUDED.file_name, applied_on = NOW()
+WHERE applied_files.upload_id <= EXCLUDED.upload_id
+`
+
+type SetAppliedFileParams struct {
+	FileType string `json:"file_type"`
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) SetAppliedFile(ctx context.Context, arg SetAppliedFileParams) error {
+	_, err := q.db.ExecContext(ctx, setAppliedFile, arg.FileType, arg.UploadID, arg.FileName)
+	return err
+}
+
+const startFile = `-- name: StartFile :one
+UPDATE uploaded_data_files SET status = 'INPROGRESS'
+WHERE upload_id = $1 AND file_name = $2 AND status = 'PENDING' returning upload_id, scope, data_type, file_name, status, uploaded_by, uploaded_on, total_records, success_records, failed_records, invalid_records, processed_records, file_order, pending_jobs, delta
+`
+
+type StartFileParams struct {
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) StartFile(ctx context.Context, arg StartFileParams) (UploadedDataFile, error) {
+	row := q.db.QueryRowContext(ctx, startFile, arg.UploadID, arg.FileName)
+	var i UploadedDataFile
+	err := row.Scan(
+		&i.UploadID,
+		&i.Scope,
+		&i.DataType,
+		&i.FileName,
+		&i.Status,
+		&i.UploadedBy,
+		&i.UploadedOn,
+		&i.TotalRecords,
+		&i.SuccessRecords,
+		&i.FailedRecords,
+		&i.InvalidRecords,
+		&i.ProcessedRecords,
+		&i.FileOrder,
+		&i.PendingJobs,
+		&i.Delta,
+	)
+	return i, err
+}
+
 const updateFileFailedRecord = `-- name: UpdateFileFailedRecord :exec
 UPDATE uploaded_data_files SET failed_records = failed_records + $3 where upload_id = $1 AND file_name = $2
 `
@@ -322,6 +1247,65 @@ func (q *Queries) UpdateFileFailedRecord(ctx context.Context, arg UpdateFileFail
 	return err
 }
 
+const updateFileInvalidRecord = `-- name: UpdateFileInvalidRecord :exec
+UPDATE uploaded_data_files SET invalid_records = (
+    SELECT COUNT(*) FROM upload_record_failures f
+    WHERE f.upload_id = $1 AND f.file_name = $2 AND f.failure_type = 'INVALID'
+) where upload_id = $1 AND file_name = $2
+`
+
+type UpdateFileInvalidRecordParams struct {
+	UploadID int32  `json:"upload_id"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) UpdateFileInvalidRecord(ctx context.Context, arg UpdateFileInvalidRecordParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileInvalidRecord, arg.UploadID, arg.FileName)
+	return err
+}
+
+const updateFileProcessedRecord = `-- name: UpdateFileProcessedRecord :exec
+UPDATE uploaded_data_files SET processed_records = processed_records + $3 where upload_id = $1 AND file_name = $2
+`
+
+type UpdateFileProcessedRecordParams struct {
+	UploadID         int32  `json:"upload_id"`
+	FileName         string `json:"file_name"`
+	ProcessedRecords int32  `json:"processed_records"`
+}
+
+func (q *Queries) UpdateFileProcessedRecord(ctx context.Context, arg UpdateFileProcessedRecordParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileProcessedRecord, arg.UploadID, arg.FileName, arg.ProcessedRecords)
+	return err
+}
+
+const updateFileRecordsForReplay = `-- name: UpdateFileRecordsForReplay :exec
+UPDATE uploaded_data_files SET
+    success_records = GREATEST(success_records - $1, 0),
+    failed_records = GREATEST(failed_records - $2, 0),
+    pending_jobs = pending_jobs + $3
+WHERE upload_id = $4 AND file_name = $5
+`
+
+type UpdateFileRecordsForReplayParams struct {
+	SuccessRecords int32  `json:"success_records"`
+	FailedRecords  int32  `json:"failed_records"`
+	PendingJobs    int32  `json:"pending_jobs"`
+	UploadID       int32  `json:"upload_id"`
+	FileName       string `json:"file_name"`
+}
+
+func (q *Queries) UpdateFileRecordsForReplay(ctx context.Context, arg UpdateFileRecordsForReplayParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileRecordsForReplay,
+		arg.SuccessRecords,
+		arg.FailedRecords,
+		arg.PendingJobs,
+		arg.UploadID,
+		arg.FileName,
+	)
+	return err
+}
+
 const updateFileStatus = `-- name: UpdateFileStatus :exec
 UPDATE uploaded_data_files SET status = $1 where upload_id = $2 AND file_name = $3
 `
@@ -7,6 +7,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	//gendb "optisam-backend/common/optisam/workerqueue/repository/postgres/db"
 	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
@@ -16,8 +17,8 @@ var repoObj *DpsRepository
 
 //DpsRepository is struct for service to repo
 type DpsRepository struct {
-	db      *sql.DB
-	Queries *gendb.Queries
+	*gendb.Queries
+	db *sql.DB
 }
 
 //GetDpsRepository give repo object
@@ -37,3 +38,42 @@ func SetDpsRepository(db *sql.DB) {
 			Queries: gendb.New(db)}
 	}
 }
+
+//InsertUploadTX inserts an upload with its files and their dependencies in one transaction, and gives its id
+func (r *DpsRepository) InsertUploadTX(ctx context.Context, upload gendb.InsertUploadParams, files []gendb.InsertUploadedDataInSetParams, deps []gendb.InsertUploadFileDependencyParams) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	q := r.Queries.WithTx(tx)
+	uploadID, err := insertUpload(ctx, q, upload, files, deps)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	return uploadID, tx.Commit()
+}
+
+func insertUpload(ctx context.Context, q *gendb.Queries, upload gendb.InsertUploadParams, files []gendb.InsertUploadedDataInSetParams, deps []gendb.InsertUploadFileDependencyParams) (int32, error) {
+	uploadID, err := q.NextUploadID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	upload.UploadID = uploadID
+	if err := q.InsertUpload(ctx, upload); err != nil {
+		return 0, err
+	}
+	for _, file := range files {
+		file.UploadID = uploadID
+		if _, err := q.InsertUploadedDataInSet(ctx, file); err != nil {
+			return 0, err
+		}
+	}
+	for _, dep := range deps {
+		dep.UploadID = uploadID
+		if err := q.InsertUploadFileDependency(ctx, dep); err != nil {
+			return 0, err
+		}
+	}
+	return uploadID, nil
+}
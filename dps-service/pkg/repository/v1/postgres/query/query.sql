@@ -2,6 +2,95 @@
 INSERT INTO uploaded_data_files (scope,data_type,file_name,uploaded_by)
 VALUES($1,$2,$3,$4) returning *;
 
+-- name: NextUploadID :one
+SELECT nextval(pg_get_serial_sequence('uploaded_data_files', 'upload_id'))::INTEGER AS upload_id;
+
+-- name: InsertUploadedDataInSet :one
+INSERT INTO uploaded_data_files (upload_id,scope,data_type,file_name,uploaded_by,file_order,delta)
+VALUES($1,$2,$3,$4,$5,$6,$7) returning *;
+
+-- name: InsertUpload :exec
+INSERT INTO uploads (upload_id,scope,data_type,uploaded_by)
+VALUES($1,$2,$3,$4);
+
+-- name: GetUpload :one
+SELECT * FROM uploads WHERE upload_id = $1;
+
+-- name: GetUploadProgress :one
+SELECT
+    COUNT(*)::INTEGER AS total_files,
+    COUNT(*) FILTER (WHERE status = 'PENDING')::INTEGER AS pending_files,
+    COUNT(*) FILTER (WHERE status = 'INPROGRESS')::INTEGER AS inprogress_files,
+    COUNT(*) FILTER (WHERE status = 'FAILED')::INTEGER AS failed_files,
+    COUNT(*) FILTER (WHERE status IN ('COMPLETED','FAILED','CANCELLED') AND pending_jobs <= 0)::INTEGER AS done_files,
+    COALESCE(SUM(GREATEST(pending_jobs,0)),0)::INTEGER AS pending_jobs,
+    COALESCE(SUM(total_records),0)::INTEGER AS total_records,
+    COALESCE(SUM(processed_records),0)::INTEGER AS processed_records,
+    COALESCE(SUM(success_records),0)::INTEGER AS success_records,
+    COALESCE(SUM(failed_records),0)::INTEGER AS failed_records,
+    COALESCE(SUM(invalid_records),0)::INTEGER AS invalid_records
+FROM uploaded_data_files WHERE upload_id = $1;
+
+-- name: CancelUpload :exec
+UPDATE uploads SET cancelled_by = $2, cancelled_on = NOW() WHERE upload_id = $1;
+
+-- name: CancelUploadFiles :exec
+UPDATE uploaded_data_files SET status = 'CANCELLED' WHERE upload_id = $1 AND status IN ('PENDING','INPROGRESS');
+
+-- name: CancelUploadJobs :many
+UPDATE jobs SET status = 'CANCELLED', end_time = NOW()
+WHERE
+    status IN ('PENDING','RETRY')
+    AND ((type = @api_type AND (data->>'UploadID')::INTEGER = @upload_id::INTEGER)
+    OR (type = @file_type AND (data->>'upload_id')::INTEGER = @upload_id::INTEGER))
+returning *;
+
+-- name: InsertUploadFileDependency :exec
+INSERT INTO upload_file_dependencies (upload_id,file_name,depends_on)
+VALUES($1,$2,$3) ON CONFLICT DO NOTHING;
+
+-- name: ListStartableFiles :many
+SELECT * FROM uploaded_data_files f
+WHERE f.upload_id = $1 AND f.status = 'PENDING'
+AND NOT EXISTS (
+    SELECT 1 FROM upload_file_dependencies d
+    JOIN uploaded_data_files p ON p.upload_id = d.upload_id AND p.file_name = d.depends_on
+    WHERE d.upload_id = f.upload_id AND d.file_name = f.file_name
+    AND (p.status IN ('PENDING','INPROGRESS') OR p.pending_jobs > 0)
+)
+ORDER BY f.file_order;
+
+-- name: StartFile :one
+UPDATE uploaded_data_files SET status = 'INPROGRESS'
+WHERE upload_id = $1 AND file_name = $2 AND status = 'PENDING' returning *;
+
+-- name: FailDependentFiles :exec
+WITH RECURSIVE dependents AS (
+    SELECT d.file_name FROM upload_file_dependencies d WHERE d.upload_id = @upload_id AND d.depends_on = @file_name
+    UNION
+    SELECT d.file_name FROM upload_file_dependencies d JOIN dependents ON d.depends_on = dependents.file_name
+    WHERE d.upload_id = @upload_id
+)
+UPDATE uploaded_data_files u SET status = 'FAILED'
+WHERE u.upload_id = @upload_id AND u.status = 'PENDING' AND u.file_name IN (SELECT dependents.file_name FROM dependents);
+
+-- name: AddFilePendingJobs :exec
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs + $3 where upload_id = $1 AND file_name = $2;
+
+-- name: AddFileJobs :exec
+WITH added AS (
+    INSERT INTO upload_file_jobs (upload_id,file_name,job_id)
+    SELECT @upload_id::INTEGER, @file_name::VARCHAR, j.job_id FROM unnest(@job_ids::INTEGER[]) AS j(job_id)
+    WHERE j.job_id > 0
+    ON CONFLICT DO NOTHING
+    RETURNING job_id
+)
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs + (SELECT COUNT(*) FROM added)
+WHERE upload_id = @upload_id::INTEGER AND file_name = @file_name::VARCHAR;
+
+-- name: DoneFilePendingJob :one
+UPDATE uploaded_data_files SET pending_jobs = pending_jobs - 1 where upload_id = $1 AND file_name = $2 returning *;
+
 -- name: InsertUploadedMetaData :one
 INSERT INTO uploaded_data_files (file_name,uploaded_by)
 VALUES($1,$2) returning *;
@@ -13,9 +102,15 @@ UPDATE uploaded_data_files SET status = $1 where upload_id = $2 AND file_name =
 -- name: GetFileStatus :one
 SELECT status FROM uploaded_data_files WHERE upload_id = $1 AND file_name = $2;
 
+-- name: ResetFileRecords :exec
+UPDATE uploaded_data_files SET total_records = $1, processed_records = 0 where upload_id = $2 AND file_name = $3;
+
 -- name: UpdateFileTotalRecord :exec
 UPDATE uploaded_data_files SET total_records = $1 where upload_id = $2 AND file_name = $3;
 
+-- name: UpdateFileProcessedRecord :exec
+UPDATE uploaded_data_files SET processed_records = processed_records + $3 where upload_id = $1 AND file_name = $2;
+
 -- name: UpdateFileSuccessRecord :exec
 UPDATE uploaded_data_files SET success_records = success_records + $3 where upload_id = $1 AND file_name = $2;
 
@@ -60,4 +155,92 @@ ORDER BY
   CASE WHEN @uploaded_by_desc::bool THEN uploaded_by END desc,
   CASE WHEN @uploaded_on_asc::bool THEN uploaded_on END asc,
   CASE WHEN @uploaded_on_desc::bool THEN uploaded_on END desc
-  LIMIT @page_size OFFSET @page_num;
\ No newline at end of file
+  LIMIT @page_size OFFSET @page_num;
+
+-- name: UpdateFileInvalidRecord :exec
+UPDATE uploaded_data_files SET invalid_records = (
+    SELECT COUNT(*) FROM upload_record_failures f
+    WHERE f.upload_id = $1 AND f.file_name = $2 AND f.failure_type = 'INVALID'
+) where upload_id = $1 AND file_name = $2;
+
+-- name: InsertUploadRecordFailure :exec
+INSERT INTO upload_record_failures (upload_id,file_name,line,failure_type,payload,grpc_code,reason)
+VALUES($1,$2,$3,$4,$5,$6,$7)
+ON CONFLICT (upload_id,file_name,line) WHERE failure_type = 'INVALID' DO NOTHING;
+
+-- name: ListUploadRecordFailures :many
+SELECT count(*) OVER() AS totalRecords,f.* from
+upload_record_failures f JOIN uploaded_data_files u
+ON f.upload_id = u.upload_id AND f.file_name = u.file_name
+WHERE
+    f.upload_id = @upload_id
+    AND (u.scope = ANY(@scope::TEXT[]) OR u.data_type = 'METADATA')
+ORDER BY f.file_name,f.line,f.failure_id
+LIMIT @page_size OFFSET @page_num;
+
+-- name: ExportUploadRecordFailures :many
+SELECT f.* from
+upload_record_failures f JOIN uploaded_data_files u
+ON f.upload_id = u.upload_id AND f.file_name = u.file_name
+WHERE
+    f.upload_id = @upload_id
+    AND (u.scope = ANY(@scope::TEXT[]) OR u.data_type = 'METADATA')
+ORDER BY f.file_name,f.line,f.failure_id;
+
+-- name: GetUploadedDataFiles :many
+SELECT * FROM uploaded_data_files WHERE upload_id = $1;
+
+-- name: ListReplayableUploadJobs :many
+SELECT * FROM jobs
+WHERE
+    type = @type
+    AND (data->>'UploadID')::INTEGER = @upload_id::INTEGER
+    AND (status IN ('FAILED','DEAD') OR (status = 'RETRY' AND retry_count >= @retries::INTEGER) OR (status = 'COMPLETED' AND NOT @only_failed::bool))
+ORDER BY job_id;
+
+-- name: UpdateFileRecordsForReplay :exec
+UPDATE uploaded_data_files SET
+    success_records = GREATEST(success_records - @success_records, 0),
+    failed_records = GREATEST(failed_records - @failed_records, 0),
+    pending_jobs = pending_jobs + @pending_jobs
+WHERE upload_id = @upload_id AND file_name = @file_name;
+
+-- name: DeleteFailedUploadRecords :exec
+DELETE FROM upload_record_failures WHERE upload_id = $1 AND failure_type = 'FAILED';
+
+-- name: InsertUploadReplay :one
+INSERT INTO upload_replays (upload_id,only_failed,jobs_count,replayed_by)
+VALUES($1,$2,$3,$4) returning *;
+
+-- name: GetAppliedFile :one
+SELECT * FROM applied_files WHERE scope = $1 AND file_type = $2;
+
+-- name: ListFileFingerprints :many
+SELECT row_key,row_hash FROM file_fingerprints
+WHERE scope = @scope AND file_type = @file_type AND upload_id = @upload_id AND row_key = ANY(@row_keys::VARCHAR[]);
+
+-- name: ListRemovedFingerprints :many
+SELECT row_key,row_data FROM file_fingerprints
+WHERE scope = @scope AND file_type = @file_type AND upload_id = @upload_id AND NOT (row_key = ANY(@row_keys::VARCHAR[]))
+ORDER BY row_key;
+
+-- name: InsertFileFingerprints :exec
+INSERT INTO file_fingerprints (scope,file_type,upload_id,row_key,row_hash,row_data)
+SELECT @scope::VARCHAR, @file_type::VARCHAR, @upload_id::INTEGER, unnest(@row_keys::VARCHAR[]), unnest(@row_hashes::VARCHAR[]), unnest(@row_data::VARCHAR[])::JSONB
+ON CONFLICT (scope,file_type,upload_id,row_key) DO UPDATE SET row_hash = EXCLUDED.row_hash, row_data = EXCLUDED.row_data;
+
+-- name: SetAppliedFile :exec
+INSERT INTO applied_files (scope,file_type,upload_id,file_name)
+SELECT f.scope, @file_type::VARCHAR, f.upload_id, f.file_name FROM uploaded_data_files f
+WHERE
+    f.upload_id = @upload_id
+    AND f.file_name = @file_name
+    AND f.status = 'COMPLETED'
+    AND f.pending_jobs <= 0
+    AND NOT EXISTS (SELECT 1 FROM upload_record_failures r WHERE r.upload_id = f.upload_id AND r.file_name = f.file_name AND r.failure_type = 'FAILED')
+ON CONFLICT (scope,file_type) DO UPDATE SET upload_id = EXCLUDED.upload_id, file_name = EXCLUDED.file_name, applied_on = NOW()
+WHERE applied_files.upload_id <= EXCLUDED.upload_id;
+
+-- name: DeleteStaleFingerprints :exec
+DELETE FROM file_fingerprints f USING applied_files a
+WHERE f.scope = a.scope AND f.file_type = a.file_type AND a.scope = @scope AND a.file_type = @file_type AND f.upload_id < a.upload_id;
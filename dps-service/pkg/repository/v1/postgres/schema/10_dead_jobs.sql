@@ -0,0 +1,9 @@
+-- +migrate Up notransaction
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- jobs failing after all their retries are dead, their last error is kept in comments
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'DEAD';
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+-- values of an enum can not be removed
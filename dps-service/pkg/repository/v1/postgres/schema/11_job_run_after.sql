@@ -0,0 +1,12 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- jobs are not run before run_after, eg: retries waiting for their backoff and scheduled jobs
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS run_after TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS jobs_run_after_idx ON jobs (run_after) WHERE run_after IS NOT NULL;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS jobs_run_after_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS run_after;
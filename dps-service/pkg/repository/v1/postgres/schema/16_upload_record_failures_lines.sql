@@ -0,0 +1,17 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- a line rejected while parsing is kept once, even when its file job is retried
+DELETE FROM upload_record_failures a USING upload_record_failures b
+WHERE a.failure_type = 'INVALID' AND b.failure_type = 'INVALID' AND a.upload_id = b.upload_id AND a.file_name = b.file_name AND a.line = b.line AND a.failure_id > b.failure_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS upload_record_failures_invalid_line_idx ON upload_record_failures (upload_id,file_name,line) WHERE failure_type = 'INVALID';
+
+UPDATE uploaded_data_files u SET invalid_records = (
+    SELECT COUNT(*) FROM upload_record_failures f
+    WHERE f.upload_id = u.upload_id AND f.file_name = u.file_name AND f.failure_type = 'INVALID'
+) WHERE u.invalid_records > 0;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX IF EXISTS upload_record_failures_invalid_line_idx;
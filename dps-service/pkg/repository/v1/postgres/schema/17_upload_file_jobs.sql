@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- api jobs pushed for a file, a job pushed again when file job is retried is a pending job of file once
+CREATE TABLE IF NOT EXISTS upload_file_jobs (
+    upload_id INTEGER NOT NULL,
+    file_name VARCHAR NOT NULL,
+    job_id INTEGER NOT NULL,
+    PRIMARY KEY(upload_id,file_name,job_id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE upload_file_jobs;
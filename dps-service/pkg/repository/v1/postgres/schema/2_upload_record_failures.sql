@@ -0,0 +1,24 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TYPE failure_type AS ENUM ('INVALID', 'FAILED');
+
+CREATE TABLE IF NOT EXISTS upload_record_failures (
+    failure_id SERIAL NOT NULL PRIMARY KEY,
+    upload_id INTEGER NOT NULL,
+    file_name VARCHAR NOT NULL,
+    line INTEGER NOT NULL DEFAULT 0,
+    failure_type failure_type NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    grpc_code VARCHAR NOT NULL DEFAULT '',
+    reason VARCHAR NOT NULL DEFAULT '',
+    failed_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (upload_id, file_name) REFERENCES uploaded_data_files (upload_id, file_name) ON DELETE CASCADE
+);
+
+CREATE INDEX upload_record_failures_upload_id_idx ON upload_record_failures (upload_id);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE upload_record_failures;
+DROP TYPE failure_type;
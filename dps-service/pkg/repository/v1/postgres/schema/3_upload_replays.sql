@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS upload_replays (
+    replay_id SERIAL NOT NULL PRIMARY KEY,
+    upload_id INTEGER NOT NULL,
+    only_failed BOOLEAN NOT NULL DEFAULT TRUE,
+    jobs_count INTEGER NOT NULL DEFAULT 0,
+    replayed_by VARCHAR NOT NULL,
+    replayed_on TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE upload_replays;
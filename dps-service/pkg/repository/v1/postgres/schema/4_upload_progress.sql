@@ -0,0 +1,8 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE uploaded_data_files ADD COLUMN IF NOT EXISTS processed_records INTEGER NOT NULL DEFAULT 0;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE uploaded_data_files DROP COLUMN IF EXISTS processed_records;
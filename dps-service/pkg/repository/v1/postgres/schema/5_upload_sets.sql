@@ -0,0 +1,9 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- files of an upload set share the upload_id and are processed in file_order
+ALTER TABLE uploaded_data_files ADD COLUMN IF NOT EXISTS file_order INTEGER NOT NULL DEFAULT 0;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE uploaded_data_files DROP COLUMN IF EXISTS file_order;
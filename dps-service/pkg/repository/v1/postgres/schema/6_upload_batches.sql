@@ -0,0 +1,18 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- api jobs of file which are not yet completed or failed after all retries
+ALTER TABLE uploaded_data_files ADD COLUMN IF NOT EXISTS pending_jobs INTEGER NOT NULL DEFAULT 0;
+
+-- a file of an upload is processed once the files it depends on are processed
+CREATE TABLE IF NOT EXISTS upload_file_dependencies (
+    upload_id INTEGER NOT NULL,
+    file_name VARCHAR NOT NULL,
+    depends_on VARCHAR NOT NULL,
+    PRIMARY KEY(upload_id,file_name,depends_on)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE upload_file_dependencies;
+ALTER TABLE uploaded_data_files DROP COLUMN IF EXISTS pending_jobs;
@@ -0,0 +1,25 @@
+-- +migrate Up notransaction
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'CANCELLED';
+ALTER TYPE upload_status ADD VALUE IF NOT EXISTS 'CANCELLED';
+
+-- one row per notification of files, status of upload is derived from its files
+CREATE TABLE IF NOT EXISTS uploads (
+    upload_id INTEGER NOT NULL PRIMARY KEY,
+    scope VARCHAR NOT NULL DEFAULT '',
+    data_type data_type,
+    uploaded_by VARCHAR NOT NULL,
+    uploaded_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    cancelled_by VARCHAR,
+    cancelled_on TIMESTAMP
+);
+
+INSERT INTO uploads (upload_id,scope,data_type,uploaded_by,uploaded_on)
+SELECT upload_id, MIN(scope), COALESCE(MIN(data_type::VARCHAR),'METADATA')::data_type, MIN(uploaded_by), MIN(uploaded_on)
+FROM uploaded_data_files GROUP BY upload_id
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE uploads;
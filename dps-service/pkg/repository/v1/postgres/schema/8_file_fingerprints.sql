@@ -0,0 +1,32 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- only the rows changed since the last applied file of same type are processed
+ALTER TABLE uploaded_data_files ADD COLUMN IF NOT EXISTS delta BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- valid rows of every processed file by the key of row, row_data keeps the values of row by header
+CREATE TABLE IF NOT EXISTS file_fingerprints (
+    scope VARCHAR NOT NULL,
+    file_type VARCHAR NOT NULL,
+    upload_id INTEGER NOT NULL,
+    row_key VARCHAR NOT NULL,
+    row_hash VARCHAR NOT NULL,
+    row_data JSONB NOT NULL,
+    PRIMARY KEY(scope,file_type,upload_id,row_key)
+);
+
+-- last file of a scope and type whose every row is applied, delta of next file is computed against it
+CREATE TABLE IF NOT EXISTS applied_files (
+    scope VARCHAR NOT NULL,
+    file_type VARCHAR NOT NULL,
+    upload_id INTEGER NOT NULL,
+    file_name VARCHAR NOT NULL,
+    applied_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(scope,file_type)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE applied_files;
+DROP TABLE file_fingerprints;
+ALTER TABLE uploaded_data_files DROP COLUMN IF EXISTS delta;
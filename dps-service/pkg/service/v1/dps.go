@@ -7,39 +7,72 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"optisam-backend/common/optisam/ctxmanage"
+	"optisam-backend/common/optisam/helper"
 	"optisam-backend/common/optisam/logger"
 	worker "optisam-backend/common/optisam/workerqueue"
-	job "optisam-backend/common/optisam/workerqueue/job"
 	v1 "optisam-backend/dps-service/pkg/api/v1"
 	repo "optisam-backend/dps-service/pkg/repository/v1"
 	"optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	apiworker "optisam-backend/dps-service/pkg/worker/api_worker"
 	"optisam-backend/dps-service/pkg/worker/constants"
+	fileworker "optisam-backend/dps-service/pkg/worker/file_worker"
+	"optisam-backend/dps-service/pkg/worker/models"
+	equipment "optisam-backend/equipment-service/pkg/api/v1"
 
 	"github.com/golang/protobuf/ptypes"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type dpsServiceServer struct {
-	dpsRepo repo.Dps
-	queue   worker.Queue
+	dpsRepo     repo.Dps
+	queue       worker.Queue
+	equipClient equipment.EquipmentServiceClient
 }
 
 // NewDpsServiceServer creates Application service
-func NewDpsServiceServer(dpsRepo repo.Dps, queue worker.Queue) v1.DpsServiceServer {
-	return &dpsServiceServer{dpsRepo: dpsRepo, queue: queue}
+func NewDpsServiceServer(dpsRepo repo.Dps, queue worker.Queue, grpcServers map[string]*grpc.ClientConn) v1.DpsServiceServer {
+	d := &dpsServiceServer{dpsRepo: dpsRepo, queue: queue}
+	if conn, ok := grpcServers[constants.EQUIP_SERVICE]; ok {
+		d.equipClient = equipment.NewEquipmentServiceClient(conn)
+	}
+	return d
 }
 
 //NotifyUpload tells dps to process a batch of files of a scope
 func (d *dpsServiceServer) NotifyUpload(ctx context.Context, req *v1.NotifyUploadRequest) (*v1.NotifyUploadResponse, error) {
+	//Dry run only validates the files, nothing is recorded and files are not archived
+	if req.GetDryRun() {
+		//files of a scope are validated for its users only, as ValidateUpload does
+		userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+		if !ok {
+			return nil, status.Error(codes.Internal, "cannot find claims in context")
+		}
+		if req.GetScope() != "" && !helper.Contains(userClaims.Socpes, req.GetScope()) {
+			logger.Log.Error("service/v1 - NotifyUpload", zap.String("reason", "ScopeError"))
+			return nil, status.Error(codes.PermissionDenied, "ScopeValidationError")
+		}
+		valid, reports := validateFiles(req.GetScope(), req.GetFiles())
+		resp := &v1.NotifyUploadResponse{Success: valid, Reports: reports}
+		if req.GetDelta() {
+			resp.Deltas = d.diffFiles(ctx, req.GetScope(), req.GetFiles())
+		}
+		return resp, nil
+	}
 
 	var datatype db.DataType
 	if req.GetType() == strings.ToLower(constants.METADATA) {
@@ -47,43 +80,79 @@ func (d *dpsServiceServer) NotifyUpload(ctx context.Context, req *v1.NotifyUploa
 	} else {
 		datatype = db.DataTypeDATA
 	}
+	var files []string
 	for _, file := range req.GetFiles() {
 		if strings.TrimSpace(file) == "" {
 			continue
 		}
-
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "NoFiles")
+	}
+	//files of a notification are one upload, a file is processed once the files it depends on are processed
+	fileParams := make([]db.InsertUploadedDataInSetParams, 0, len(files))
+	var depParams []db.InsertUploadFileDependencyParams
+	deps := fileworker.FileDependencies(req.GetScope(), files, d.equipmentParents(ctx, req.GetScope(), files), req.GetOrdered())
+	for i, file := range files {
 		//TODO will go in import service in future
-		dbresp, err := d.dpsRepo.InsertUploadedData(ctx, db.InsertUploadedDataParams{
-			FileName:   file,
-			DataType:   datatype,
+		fileParams = append(fileParams, db.InsertUploadedDataInSetParams{
 			Scope:      req.GetScope(),
+			DataType:   datatype,
+			FileName:   file,
 			UploadedBy: req.GetUploadedBy(),
+			FileOrder:  int32(i),
+			Delta:      req.GetDelta(),
 		})
-		if err != nil {
-			log.Println("Failed to insert file record in dps, err :", err)
-			continue
+		for _, prerequisite := range deps[file] {
+			depParams = append(depParams, db.InsertUploadFileDependencyParams{
+				FileName:  file,
+				DependsOn: prerequisite,
+			})
 		}
-		logger.Log.Info("service", zap.Int32("uploadID", dbresp.UploadID))
+	}
+	uploadID, err := d.dpsRepo.InsertUploadTX(ctx, db.InsertUploadParams{
+		Scope:      req.GetScope(),
+		DataType:   datatype,
+		UploadedBy: req.GetUploadedBy(),
+	}, fileParams, depParams)
+	if err != nil {
+		logger.Log.Error("service/v1 - NotifyUpload - InsertUploadTX", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	logger.Log.Info("service", zap.Int32("uploadID", uploadID), zap.Strings("files", files))
+	//Async Job Submission
+	if err := fileworker.QueueReadyFiles(ctx, d.dpsRepo, &d.queue, uploadID); err != nil {
+		log.Println("Failed to push jobs for upload ", uploadID, " for scope ", req.GetScope(), " err : ", err)
+		return nil, status.Error(codes.Internal, "JobError")
+	}
+	return &v1.NotifyUploadResponse{Success: true, UploadId: uploadID}, nil
+}
 
-		//Async Job Submission
-		dataForJob, err := json.Marshal(dbresp)
-		if err != nil {
-			log.Println("Failed to marshal notifyPayload data for file type job, err:", err)
-			continue
+//equipmentParents gives the parent of equipment types having a file in upload, files of parent types are processed first.
+//Equipment files are processed without any order when types are not known.
+func (d *dpsServiceServer) equipmentParents(ctx context.Context, scope string, files []string) map[string]string {
+	eqFiles := 0
+	for _, file := range files {
+		if strings.Contains(strings.ToUpper(file), strings.ToUpper(scope)+"_EQUIPMENT_") {
+			eqFiles++
 		}
-		job := job.Job{
-			Type:   constants.FILETYPE,
-			Data:   dataForJob,
-			Status: job.JobStatusPENDING,
-		}
-		_, err = d.queue.PushJob(ctx, job, constants.FILEWORKER)
-		if err != nil {
-			log.Println("Failed to push job for file :", file, " for scope ", req.GetScope(), " err : ", err)
-			continue
+	}
+	if eqFiles < 2 || d.equipClient == nil {
+		return nil
+	}
+	resp, err := d.equipClient.EquipmentsTypes(ctx, &equipment.EquipmentTypesRequest{})
+	if err != nil {
+		logger.Log.Error("service/v1 - NotifyUpload - EquipmentsTypes", zap.Error(err))
+		return nil
+	}
+	parents := make(map[string]string)
+	for _, eqType := range resp.GetEquipmentTypes() {
+		if eqType.GetParentType() != "" {
+			parents[strings.ToUpper(eqType.GetType())] = strings.ToUpper(eqType.GetParentType())
 		}
-
 	}
-	return &v1.NotifyUploadResponse{Success: true}, nil
+	return parents
 }
 
 func (d *dpsServiceServer) ListUploadData(ctx context.Context, req *v1.ListUploadRequest) (*v1.ListUploadResponse, error) {
@@ -135,6 +204,8 @@ func (d *dpsServiceServer) ListUploadData(ctx context.Context, req *v1.ListUploa
 		apiresp.Uploads[i].FailedRecords = dbresp[i].FailedRecords
 		apiresp.Uploads[i].SuccessRecords = dbresp[i].SuccessRecords
 		apiresp.Uploads[i].TotalRecords = dbresp[i].TotalRecords
+		apiresp.Uploads[i].InvalidRecords = dbresp[i].InvalidRecords
+		apiresp.Uploads[i].ProcessedRecords = dbresp[i].ProcessedRecords
 	}
 	return apiresp, nil
 }
@@ -183,6 +254,446 @@ func (d *dpsServiceServer) ListUploadMetaData(ctx context.Context, req *v1.ListU
 		apiresp.Uploads[i].FailedRecords = dbresp[i].FailedRecords
 		apiresp.Uploads[i].SuccessRecords = dbresp[i].SuccessRecords
 		apiresp.Uploads[i].TotalRecords = dbresp[i].TotalRecords
+		apiresp.Uploads[i].InvalidRecords = dbresp[i].InvalidRecords
+		apiresp.Uploads[i].ProcessedRecords = dbresp[i].ProcessedRecords
+	}
+	return apiresp, nil
+}
+
+//ValidateUpload parses the files of a scope like NotifyUpload does, without creating any job,
+//and gives the rejected lines of every file
+func (d *dpsServiceServer) ValidateUpload(ctx context.Context, req *v1.ValidateUploadRequest) (*v1.ValidateUploadResponse, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	if req.GetScope() != "" && !helper.Contains(userClaims.Socpes, req.GetScope()) {
+		logger.Log.Error("service/v1 - ValidateUpload", zap.String("reason", "ScopeError"))
+		return nil, status.Error(codes.PermissionDenied, "ScopeValidationError")
+	}
+	valid, reports := validateFiles(req.GetScope(), req.GetFiles())
+	return &v1.ValidateUploadResponse{Valid: valid, Reports: reports}, nil
+}
+
+//ListUploadFailures gives the records of an upload which were rejected while parsing or failed in target service
+func (d *dpsServiceServer) ListUploadFailures(ctx context.Context, req *v1.ListUploadFailuresRequest) (*v1.ListUploadFailuresResponse, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	dbresp, err := d.dpsRepo.ListUploadRecordFailures(ctx, db.ListUploadRecordFailuresParams{
+		UploadID: req.GetUploadId(),
+		Scope:    userClaims.Socpes,
+		//API expect pagenum from 1 but the offset in DB starts with 0
+		PageNum:  req.GetPageSize() * (req.GetPageNum() - 1),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &v1.ListUploadFailuresResponse{}, nil
+		}
+		logger.Log.Error("service/v1 - ListUploadFailures - ListUploadRecordFailures", zap.Error(err))
+		return &v1.ListUploadFailuresResponse{}, status.Error(codes.Unknown, "DBError")
+	}
+
+	apiresp := &v1.ListUploadFailuresResponse{}
+	apiresp.Failures = make([]*v1.UploadFailure, len(dbresp))
+	if len(dbresp) > 0 {
+		apiresp.TotalRecords = int32(dbresp[0].Totalrecords)
+	}
+	for i := range dbresp {
+		apiresp.Failures[i] = &v1.UploadFailure{
+			UploadId:    dbresp[i].UploadID,
+			FileName:    dbresp[i].FileName,
+			Line:        dbresp[i].Line,
+			FailureType: string(dbresp[i].FailureType),
+			Payload:     string(dbresp[i].Payload),
+			GrpcCode:    dbresp[i].GrpcCode,
+			Reason:      dbresp[i].Reason,
+		}
+		apiresp.Failures[i].FailedOn, _ = ptypes.TimestampProto(dbresp[i].FailedOn)
+	}
+	return apiresp, nil
+}
+
+//ExportUploadFailures gives all the failed records of an upload as a csv file, so that they can be corrected and uploaded again
+func (d *dpsServiceServer) ExportUploadFailures(ctx context.Context, req *v1.ExportUploadFailuresRequest) (*httpbody.HttpBody, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	dbresp, err := d.dpsRepo.ExportUploadRecordFailures(ctx, db.ExportUploadRecordFailuresParams{
+		UploadID: req.GetUploadId(),
+		Scope:    userClaims.Socpes,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		logger.Log.Error("service/v1 - ExportUploadFailures - ExportUploadRecordFailures", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Comma = []rune(constants.DELIMETER)[0]
+	records := [][]string{{"upload_id", "file_name", "line", "failure_type", "grpc_code", "reason", "payload", "failed_on"}}
+	for _, f := range dbresp {
+		records = append(records, []string{
+			fmt.Sprint(f.UploadID),
+			f.FileName,
+			fmt.Sprint(f.Line),
+			string(f.FailureType),
+			f.GrpcCode,
+			f.Reason,
+			string(f.Payload),
+			f.FailedOn.Format(time.RFC3339),
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		logger.Log.Error("service/v1 - ExportUploadFailures - WriteAll", zap.Error(err))
+		return nil, status.Error(codes.Internal, "ExportError")
+	}
+	return &httpbody.HttpBody{ContentType: "text/csv", Data: buf.Bytes()}, nil
+}
+
+//ReprocessUpload puts back in queue the api jobs of an upload which failed after all retries,
+//or all its api jobs when only_failed is false
+func (d *dpsServiceServer) ReprocessUpload(ctx context.Context, req *v1.ReprocessUploadRequest) (*v1.ReprocessUploadResponse, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	onlyFailed := req.GetOnlyFailed() == nil || req.GetOnlyFailed().GetValue()
+	files, err := d.dpsRepo.GetUploadedDataFiles(ctx, req.GetUploadId())
+	if err != nil {
+		logger.Log.Error("service/v1 - ReprocessUpload - GetUploadedDataFiles", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	if len(files) == 0 {
+		return nil, status.Error(codes.NotFound, "UploadNotFound")
+	}
+	for _, file := range files {
+		if file.DataType == db.DataTypeDATA && !helper.Contains(userClaims.Socpes, file.Scope) {
+			logger.Log.Error("service/v1 - ReprocessUpload", zap.String("reason", "ScopeError"))
+			return nil, status.Error(codes.PermissionDenied, "ScopeValidationError")
+		}
+		if file.Status == db.UploadStatusPENDING || file.Status == db.UploadStatusINPROGRESS || file.PendingJobs > 0 {
+			return nil, status.Error(codes.FailedPrecondition, "UploadInProgress")
+		}
+	}
+	jobs, err := d.dpsRepo.ListReplayableUploadJobs(ctx, db.ListReplayableUploadJobsParams{
+		Type:       constants.APIWORKER,
+		UploadID:   req.GetUploadId(),
+		Retries:    d.queue.GetRetries(),
+		OnlyFailed: onlyFailed,
+	})
+	if err != nil {
+		logger.Log.Error("service/v1 - ReprocessUpload - ListReplayableUploadJobs", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	if len(jobs) == 0 {
+		return &v1.ReprocessUploadResponse{Success: true}, nil
+	}
+
+	//requeued jobs are due now, workers are notified of them as they have room
+	requeued := make([]db.Job, 0, len(jobs))
+	for _, j := range jobs {
+		if err := d.queue.RequeueJob(ctx, j.JobID, constants.APIWORKER); err != nil {
+			logger.Log.Error("service/v1 - ReprocessUpload - RequeueJob", zap.Int32("jobID", j.JobID), zap.Error(err))
+			continue
+		}
+		requeued = append(requeued, j)
+	}
+	if len(requeued) == 0 {
+		return nil, status.Error(codes.Internal, "JobError")
+	}
+
+	//records of requeued jobs are taken out of counters, api worker counts them again.
+	//Their files are in progress until api worker has done their pending jobs, failed ones are completed by them.
+	success := make(map[string]int32)
+	failed := make(map[string]int32)
+	pending := make(map[string]int32)
+	for _, j := range requeued {
+		var data models.Envlope
+		if err := json.Unmarshal(j.Data, &data); err != nil {
+			log.Println("Failed to get data from job ", j.JobID, " err : ", err)
+			continue
+		}
+		pending[data.FileName]++
+		if j.Status == db.JobStatusCOMPLETED {
+			success[data.FileName] += apiworker.GetDataCount(data)
+		} else {
+			failed[data.FileName] += apiworker.GetDataCount(data)
+		}
+	}
+	for _, file := range files {
+		if pending[file.FileName] == 0 {
+			continue
+		}
+		err = d.dpsRepo.UpdateFileRecordsForReplay(ctx, db.UpdateFileRecordsForReplayParams{
+			SuccessRecords: success[file.FileName],
+			FailedRecords:  failed[file.FileName],
+			PendingJobs:    pending[file.FileName],
+			UploadID:       file.UploadID,
+			FileName:       file.FileName,
+		})
+		if err != nil {
+			logger.Log.Error("service/v1 - ReprocessUpload - UpdateFileRecordsForReplay", zap.Error(err))
+			return nil, status.Error(codes.Unknown, "DBError")
+		}
+		if file.Status != db.UploadStatusFAILED {
+			continue
+		}
+		err = d.dpsRepo.UpdateFileStatus(ctx, db.UpdateFileStatusParams{
+			Status:   db.UploadStatusCOMPLETED,
+			UploadID: file.UploadID,
+			FileName: file.FileName,
+		})
+		if err != nil {
+			logger.Log.Error("service/v1 - ReprocessUpload - UpdateFileStatus", zap.Error(err))
+			return nil, status.Error(codes.Unknown, "DBError")
+		}
+	}
+	if err := d.dpsRepo.DeleteFailedUploadRecords(ctx, req.GetUploadId()); err != nil {
+		logger.Log.Error("service/v1 - ReprocessUpload - DeleteFailedUploadRecords", zap.Error(err))
+	}
+	if _, err := d.dpsRepo.InsertUploadReplay(ctx, db.InsertUploadReplayParams{
+		UploadID:   req.GetUploadId(),
+		OnlyFailed: onlyFailed,
+		JobsCount:  int32(len(requeued)),
+		ReplayedBy: userClaims.UserID,
+	}); err != nil {
+		logger.Log.Error("service/v1 - ReprocessUpload - InsertUploadReplay", zap.Error(err))
+	}
+	return &v1.ReprocessUploadResponse{Success: len(requeued) == len(jobs), JobsRequeued: int32(len(requeued))}, nil
+}
+
+//GetUpload gives the status and the progress of an upload with the details of its files
+func (d *dpsServiceServer) GetUpload(ctx context.Context, req *v1.GetUploadRequest) (*v1.GetUploadResponse, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	upload, err := d.getUpload(ctx, userClaims.Socpes, req.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+	progress, err := d.dpsRepo.GetUploadProgress(ctx, req.GetUploadId())
+	if err != nil {
+		logger.Log.Error("service/v1 - GetUpload - GetUploadProgress", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	files, err := d.dpsRepo.GetUploadedDataFiles(ctx, req.GetUploadId())
+	if err != nil {
+		logger.Log.Error("service/v1 - GetUpload - GetUploadedDataFiles", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	apiresp := &v1.GetUploadResponse{
+		UploadId:         upload.UploadID,
+		Scope:            upload.Scope,
+		DataType:         string(upload.DataType),
+		Status:           string(uploadStatus(upload, progress)),
+		UploadedBy:       upload.UploadedBy,
+		TotalFiles:       progress.TotalFiles,
+		DoneFiles:        progress.DoneFiles,
+		PendingJobs:      progress.PendingJobs,
+		TotalRecords:     progress.TotalRecords,
+		ProcessedRecords: progress.ProcessedRecords,
+		SuccessRecords:   progress.SuccessRecords,
+		FailedRecords:    progress.FailedRecords,
+		InvalidRecords:   progress.InvalidRecords,
+		CancelledBy:      upload.CancelledBy.String,
+		Files:            make([]*v1.Upload, len(files)),
+	}
+	if progress.TotalFiles > 0 {
+		apiresp.Progress = float32(progress.DoneFiles) * 100 / float32(progress.TotalFiles)
+	}
+	apiresp.UploadedOn, _ = ptypes.TimestampProto(upload.UploadedOn)
+	if upload.CancelledOn.Valid {
+		apiresp.CancelledOn, _ = ptypes.TimestampProto(upload.CancelledOn.Time)
+	}
+	for i, file := range files {
+		apiresp.Files[i] = &v1.Upload{
+			UploadId:         file.UploadID,
+			Scope:            file.Scope,
+			FileName:         file.FileName,
+			Status:           string(file.Status),
+			UploadedBy:       file.UploadedBy,
+			TotalRecords:     file.TotalRecords,
+			SuccessRecords:   file.SuccessRecords,
+			FailedRecords:    file.FailedRecords,
+			InvalidRecords:   file.InvalidRecords,
+			ProcessedRecords: file.ProcessedRecords,
+		}
+		apiresp.Files[i].UploadedOn, _ = ptypes.TimestampProto(file.UploadedOn)
 	}
 	return apiresp, nil
 }
+
+//CancelUpload stops an upload, its jobs waiting in queue are cancelled and its files not yet processed are not processed
+func (d *dpsServiceServer) CancelUpload(ctx context.Context, req *v1.CancelUploadRequest) (*v1.CancelUploadResponse, error) {
+	userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "cannot find claims in context")
+	}
+	upload, err := d.getUpload(ctx, userClaims.Socpes, req.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+	progress, err := d.dpsRepo.GetUploadProgress(ctx, req.GetUploadId())
+	if err != nil {
+		logger.Log.Error("service/v1 - CancelUpload - GetUploadProgress", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	if st := uploadStatus(upload, progress); st != db.UploadStatusPENDING && st != db.UploadStatusINPROGRESS {
+		return nil, status.Error(codes.FailedPrecondition, "UploadNotInProgress")
+	}
+	//file worker stops reading a file once upload is cancelled
+	err = d.dpsRepo.CancelUpload(ctx, db.CancelUploadParams{
+		UploadID:    req.GetUploadId(),
+		CancelledBy: sql.NullString{String: userClaims.UserID, Valid: true},
+	})
+	if err != nil {
+		logger.Log.Error("service/v1 - CancelUpload - CancelUpload", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	jobs, err := d.dpsRepo.CancelUploadJobs(ctx, db.CancelUploadJobsParams{
+		ApiType:  constants.APIWORKER,
+		FileType: constants.FILEWORKER,
+		UploadID: req.GetUploadId(),
+	})
+	if err != nil {
+		logger.Log.Error("service/v1 - CancelUpload - CancelUploadJobs", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	//cancelled api jobs are not waited for anymore
+	cancelled := make(map[string]int32)
+	for _, j := range jobs {
+		if j.Type != constants.APIWORKER {
+			continue
+		}
+		var data models.Envlope
+		if err := json.Unmarshal(j.Data, &data); err != nil {
+			log.Println("Failed to get data from job ", j.JobID, " err : ", err)
+			continue
+		}
+		cancelled[data.FileName]++
+	}
+	for file, count := range cancelled {
+		err := d.dpsRepo.AddFilePendingJobs(ctx, db.AddFilePendingJobsParams{
+			UploadID:    req.GetUploadId(),
+			FileName:    file,
+			PendingJobs: -count,
+		})
+		if err != nil {
+			logger.Log.Error("service/v1 - CancelUpload - AddFilePendingJobs", zap.Error(err))
+		}
+	}
+	if err := d.dpsRepo.CancelUploadFiles(ctx, req.GetUploadId()); err != nil {
+		logger.Log.Error("service/v1 - CancelUpload - CancelUploadFiles", zap.Error(err))
+		return nil, status.Error(codes.Unknown, "DBError")
+	}
+	return &v1.CancelUploadResponse{Success: true, JobsCancelled: int32(len(jobs))}, nil
+}
+
+//getUpload gives an upload if user has access to its scope
+func (d *dpsServiceServer) getUpload(ctx context.Context, scopes []string, uploadID int32) (db.Upload, error) {
+	upload, err := d.dpsRepo.GetUpload(ctx, uploadID)
+	if err == sql.ErrNoRows {
+		return upload, status.Error(codes.NotFound, "UploadNotFound")
+	}
+	if err != nil {
+		logger.Log.Error("service/v1 - GetUpload", zap.Error(err))
+		return upload, status.Error(codes.Unknown, "DBError")
+	}
+	if upload.DataType == db.DataTypeDATA && !helper.Contains(scopes, upload.Scope) {
+		logger.Log.Error("service/v1 - GetUpload", zap.String("reason", "ScopeError"))
+		return upload, status.Error(codes.PermissionDenied, "ScopeValidationError")
+	}
+	return upload, nil
+}
+
+//uploadStatus derives the status of an upload from its files, files are processed once all their jobs are done
+func uploadStatus(upload db.Upload, progress db.GetUploadProgressRow) db.UploadStatus {
+	switch {
+	case upload.CancelledOn.Valid:
+		return db.UploadStatusCANCELLED
+	case progress.PendingFiles == progress.TotalFiles:
+		return db.UploadStatusPENDING
+	case progress.DoneFiles < progress.TotalFiles:
+		return db.UploadStatusINPROGRESS
+	case progress.FailedFiles > 0:
+		return db.UploadStatusFAILED
+	default:
+		return db.UploadStatusCOMPLETED
+	}
+}
+
+func validateFiles(scope string, files []string) (valid bool, reports []*v1.FileValidationReport) {
+	valid = true
+	for _, file := range files {
+		if strings.TrimSpace(file) == "" {
+			continue
+		}
+		report := fileworker.ValidateFile(scope, file)
+		if len(report.Errors) > 0 {
+			valid = false
+		}
+		reports = append(reports, toAPIValidationReport(report))
+	}
+	return
+}
+
+//diffFiles computes the delta of files without applying it, a file which can not be read is reported
+//by its validation report and has no delta
+func (d *dpsServiceServer) diffFiles(ctx context.Context, scope string, files []string) (deltas []*v1.FileDelta) {
+	for _, file := range files {
+		if strings.TrimSpace(file) == "" {
+			continue
+		}
+		report, err := fileworker.DiffFile(ctx, d.dpsRepo, scope, file)
+		if err != nil {
+			logger.Log.Error("service/v1 - NotifyUpload - DiffFile", zap.String("file", file), zap.Error(err))
+			continue
+		}
+		deltas = append(deltas, toAPIFileDelta(report))
+	}
+	return
+}
+
+func toAPIFileDelta(report models.DeltaReport) *v1.FileDelta {
+	apiDelta := &v1.FileDelta{
+		FileName:         report.FileName,
+		FileType:         report.FileType,
+		PreviousUploadId: report.PreviousUploadID,
+		Full:             report.Full,
+		Added:            report.Added,
+		Changed:          report.Changed,
+		Removed:          report.Removed,
+		Unchanged:        report.Unchanged,
+		Rows:             make([]*v1.DeltaRow, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		apiDelta.Rows[i] = &v1.DeltaRow{
+			Line:   row.Line,
+			Key:    row.Key,
+			Change: row.Change,
+		}
+	}
+	return apiDelta
+}
+
+func toAPIValidationReport(report models.ValidationReport) *v1.FileValidationReport {
+	apiReport := &v1.FileValidationReport{
+		FileName:       report.FileName,
+		FileType:       report.FileType,
+		TotalRecords:   report.TotalCount,
+		InvalidRecords: report.InvalidCount,
+		Errors:         make([]*v1.RowError, len(report.Errors)),
+	}
+	for i, e := range report.Errors {
+		apiReport.Errors[i] = &v1.RowError{
+			Line:   e.Line,
+			Column: e.Column,
+			Reason: e.Reason,
+		}
+	}
+	return apiReport
+}
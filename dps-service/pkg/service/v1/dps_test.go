@@ -0,0 +1,214 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"optisam-backend/common/optisam/ctxmanage"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/token/claims"
+	worker "optisam-backend/common/optisam/workerqueue"
+	v1 "optisam-backend/dps-service/pkg/api/v1"
+	"optisam-backend/dps-service/pkg/config"
+	"optisam-backend/dps-service/pkg/repository/v1/mock"
+	"optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx = ctxmanage.AddClaims(context.Background(), &claims.Claims{
+		UserID: "admin@superuser.com",
+		Role:   "SuperAdmin",
+		Socpes: []string{"s1", "s2"},
+	})
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(-1, "")
+	dir, err := ioutil.TempDir("", "dps-files")
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.SetConfig(config.Config{FilesLocation: dir, ArchiveLocation: dir})
+	if err := ioutil.WriteFile(filepath.Join(dir, "s1_products.csv"), []byte("swidtag;version;category;editor;isoptionof;name;flag\np1;1.0;db;oracle;;Oracle DB;1\np2;1.0\n"), 0600); err != nil {
+		log.Fatal(err)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestNotifyUpload_dryRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *v1.NotifyUploadRequest
+		want    *v1.NotifyUploadResponse
+		wantErr codes.Code
+	}{
+		{name: "claims not found",
+			ctx:     context.Background(),
+			req:     &v1.NotifyUploadRequest{Scope: "s1", Files: []string{"s1_products.csv"}, DryRun: true},
+			wantErr: codes.Internal,
+		},
+		{name: "scope of user not found",
+			ctx:     ctx,
+			req:     &v1.NotifyUploadRequest{Scope: "s3", Files: []string{"s3_products.csv"}, DryRun: true},
+			wantErr: codes.PermissionDenied,
+		},
+		{name: "invalid rows",
+			ctx: ctx,
+			req: &v1.NotifyUploadRequest{Scope: "s1", Files: []string{"s1_products.csv", " "}, DryRun: true},
+			want: &v1.NotifyUploadResponse{
+				Reports: []*v1.FileValidationReport{
+					{
+						FileName:       "s1_products.csv",
+						FileType:       "PRODUCTS",
+						TotalRecords:   2,
+						InvalidRecords: 1,
+						Errors:         []*v1.RowError{{Line: 3, Reason: "expected at least 7 columns, found 2"}},
+					},
+				},
+			},
+		},
+		{name: "file not found",
+			ctx: ctx,
+			req: &v1.NotifyUploadRequest{Scope: "s1", Files: []string{"s1_applications.csv"}, DryRun: true},
+			want: &v1.NotifyUploadResponse{
+				Reports: []*v1.FileValidationReport{
+					{
+						FileName: "s1_applications.csv",
+						FileType: "APPLICATIONS",
+						Errors:   []*v1.RowError{{Reason: "File name not found"}},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//nothing is recorded by a dry run without delta, so repository and queue are not needed
+			s := &dpsServiceServer{}
+			got, err := s.NotifyUpload(test.ctx, test.req)
+			if test.wantErr != codes.OK {
+				assert.Equal(t, test.wantErr, status.Code(err))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNotifyUpload(t *testing.T) {
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockDps
+	tests := []struct {
+		name    string
+		req     *v1.NotifyUploadRequest
+		setup   func()
+		wantErr codes.Code
+	}{
+		{name: "no files",
+			req:     &v1.NotifyUploadRequest{Scope: "s1", Files: []string{" ", ""}},
+			setup:   func() {},
+			wantErr: codes.InvalidArgument,
+		},
+		{name: "upload not inserted",
+			req: &v1.NotifyUploadRequest{Scope: "s1", Files: []string{"s1_products.csv", " ", "s1_products_acquiredRights.csv"}, UploadedBy: "admin@superuser.com", Ordered: true},
+			setup: func() {
+				rep.EXPECT().InsertUploadTX(ctx, db.InsertUploadParams{
+					Scope:      "s1",
+					DataType:   db.DataTypeDATA,
+					UploadedBy: "admin@superuser.com",
+				}, []db.InsertUploadedDataInSetParams{
+					{Scope: "s1", DataType: db.DataTypeDATA, FileName: "s1_products.csv", UploadedBy: "admin@superuser.com", FileOrder: 0},
+					{Scope: "s1", DataType: db.DataTypeDATA, FileName: "s1_products_acquiredRights.csv", UploadedBy: "admin@superuser.com", FileOrder: 1},
+				}, []db.InsertUploadFileDependencyParams{
+					{FileName: "s1_products_acquiredRights.csv", DependsOn: "s1_products.csv"},
+				}).Return(int32(0), errors.New("test error")).Times(1)
+			},
+			wantErr: codes.Unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockDps(mockCtrl)
+			test.setup()
+			s := &dpsServiceServer{dpsRepo: rep}
+			_, err := s.NotifyUpload(ctx, test.req)
+			assert.Equal(t, test.wantErr, status.Code(err))
+		})
+	}
+}
+
+func TestReprocessUpload(t *testing.T) {
+	var mockCtrl *gomock.Controller
+	var rep *mock.MockDps
+	files := []db.UploadedDataFile{{UploadID: 1, Scope: "s1", DataType: db.DataTypeDATA, FileName: "s1_products.csv", Status: db.UploadStatusFAILED}}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *v1.ReprocessUploadResponse
+		wantErr codes.Code
+	}{
+		{name: "upload in progress",
+			setup: func() {
+				rep.EXPECT().GetUploadedDataFiles(ctx, int32(1)).Return([]db.UploadedDataFile{{UploadID: 1, Scope: "s1", DataType: db.DataTypeDATA, FileName: "s1_products.csv", Status: db.UploadStatusCOMPLETED, PendingJobs: 2}}, nil).Times(1)
+			},
+			wantErr: codes.FailedPrecondition,
+		},
+		{name: "no jobs to replay",
+			setup: func() {
+				rep.EXPECT().GetUploadedDataFiles(ctx, int32(1)).Return(files, nil).Times(1)
+				rep.EXPECT().ListReplayableUploadJobs(ctx, gomock.Any()).Return(nil, nil).Times(1)
+			},
+			want: &v1.ReprocessUploadResponse{Success: true},
+		},
+		{name: "no job requeued",
+			setup: func() {
+				rep.EXPECT().GetUploadedDataFiles(ctx, int32(1)).Return(files, nil).Times(1)
+				rep.EXPECT().ListReplayableUploadJobs(ctx, gomock.Any()).Return([]db.Job{{JobID: 7, Status: db.JobStatusFAILED, Data: []byte(`{"FileName":"s1_products.csv"}`)}}, nil).Times(1)
+				//counters and status of files are left as they are
+			},
+			wantErr: codes.Internal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl = gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rep = mock.NewMockDps(mockCtrl)
+			test.setup()
+			//jobs kept in memory cannot be requeued
+			s := &dpsServiceServer{dpsRepo: rep, queue: *worker.NewQueueWithBackend("test", worker.NewMemoryBackend(), worker.QueueConfig{})}
+			got, err := s.ReprocessUpload(ctx, &v1.ReprocessUploadRequest{UploadId: 1})
+			if test.wantErr != codes.OK {
+				assert.Equal(t, test.wantErr, status.Code(err))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
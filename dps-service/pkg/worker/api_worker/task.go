@@ -52,6 +52,11 @@ func init() {
 	dataToRPCMappings[constants.PRODUCTS_ACQUIREDRIGHTS][constants.UPSERT] = sendUpsertAcqRightsReq
 }
 
+//GetDataCount tells how many records of file are carried by the envlope
+func GetDataCount(data models.Envlope) int32 {
+	return getDataCountInPayload(data.Data, data.TargetRPC)
+}
+
 func getDataCountInPayload(data []byte, fileType string) int32 {
 	var count int32
 	switch fileType {
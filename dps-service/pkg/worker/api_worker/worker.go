@@ -14,9 +14,11 @@ import (
 	"optisam-backend/common/optisam/workerqueue"
 	"optisam-backend/common/optisam/workerqueue/job"
 	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	fileworker "optisam-backend/dps-service/pkg/worker/file_worker"
 	"optisam-backend/dps-service/pkg/worker/models"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 type worker struct {
@@ -35,6 +37,15 @@ func (w *worker) ID() string {
 	return w.id
 }
 
+//ConcurrencyKey limits api jobs per target service so that a big upload does not overwhelm a service
+func (w *worker) ConcurrencyKey(j *job.Job) string {
+	var data models.Envlope
+	if err := json.Unmarshal(j.Data, &data); err != nil {
+		return ""
+	}
+	return data.TargetService
+}
+
 func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 	var data models.Envlope
 	err := json.Unmarshal(j.Data, &data)
@@ -43,6 +54,8 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 		return err
 	}
 	dataCount := getDataCountInPayload(data.Data, data.TargetRPC)
+	//jobs pushed by target service for this job are not duplicated when it is retried
+	ctx = workerqueue.WithIdempotencyKey(ctx, j.IdempotencyKey)
 	err = dataToRPCMappings[data.TargetRPC][data.TargetAction](ctx, data, w.grpcServers[data.TargetService])
 	if err != nil {
 		log.Println("Failed RPC request , err : ", err)
@@ -56,6 +69,8 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 				log.Println("Failed to update failedrecord in db , err :", err)
 				return dbErr
 			}
+			w.saveFailedRecords(ctx, data, err)
+			w.jobDone(ctx, data)
 		}
 		return err
 	}
@@ -68,5 +83,54 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 		log.Println("Failed to update success record in db , err :", err)
 		return err
 	}
+	w.jobDone(ctx, data)
 	return nil
 }
+
+//jobDone counts a job of file as processed, files of upload waiting for this file are queued after its last job
+func (w *worker) jobDone(ctx context.Context, data models.Envlope) {
+	file, err := w.Queries.DoneFilePendingJob(ctx, gendb.DoneFilePendingJobParams{
+		UploadID: data.UploadID,
+		FileName: data.FileName,
+	})
+	if err != nil {
+		log.Println("Failed to update pending jobs of file ", data.FileName, " err :", err)
+		return
+	}
+	if file.PendingJobs > 0 || (file.Status != gendb.UploadStatusCOMPLETED && file.Status != gendb.UploadStatusFAILED) {
+		return
+	}
+	if file.Status == gendb.UploadStatusCOMPLETED {
+		fileworker.SetFileApplied(ctx, w.Queries, file.UploadID, file.Scope, file.FileName)
+	}
+	if err := fileworker.QueueReadyFiles(ctx, w.Queries, w.Queue, data.UploadID); err != nil {
+		log.Println("Failed to queue files of upload ", data.UploadID, " err :", err)
+	}
+}
+
+//saveFailedRecords keeps the lines of file whose data is rejected by target service after all retries
+func (w *worker) saveFailedRecords(ctx context.Context, data models.Envlope, rpcErr error) {
+	lines := data.Lines
+	if len(lines) == 0 {
+		lines = []int32{0}
+	}
+	payload := data.Data
+	if !json.Valid(payload) {
+		payload, _ = json.Marshal(string(data.Data))
+	}
+	st := status.Convert(rpcErr)
+	for _, line := range lines {
+		err := w.Queries.InsertUploadRecordFailure(ctx, gendb.InsertUploadRecordFailureParams{
+			UploadID:    data.UploadID,
+			FileName:    data.FileName,
+			Line:        line,
+			FailureType: gendb.FailureTypeFAILED,
+			Payload:     payload,
+			GrpcCode:    st.Code().String(),
+			Reason:      st.Message(),
+		})
+		if err != nil {
+			log.Println("Failed to save failed record of file ", data.FileName, " line ", line, " err :", err)
+		}
+	}
+}
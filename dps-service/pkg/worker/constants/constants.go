@@ -63,27 +63,75 @@ const (
 const (
 	DELIMETER       string = ";"
 	FILE_EXTENSION  string = ".CSV"
+	XLSX_EXTENSION  string = ".xlsx"
+	JSONL_EXTENSION string = ".jsonl"
 	SCOPE_DELIMETER string = "_"
 	FILEWORKER      string = "FILE_WORKER"
 	APIWORKER       string = "API_WORKER"
 	DPSQUEUE        string = "DPS_QUEUE"
 	UPSERT          string = "UPSERT"
 	DELETE          string = "DELETE"
+	MAX_ROW_ERRORS  int    = 1000 // row errors kept in a validation report
+	MAX_DELTA_ROWS  int    = 1000 // changed rows kept in a delta report
+	BATCH_SIZE      int32  = 1000 // records of file read in memory at once, when not configured
+	SMALL_FILE_SIZE int32  = 100  // records of a file whose jobs are run before the jobs of bigger files
+)
+
+//Changes of a row in a delta
+const (
+	ROW_ADDED   string = "ADDED"
+	ROW_CHANGED string = "CHANGED"
+	ROW_REMOVED string = "REMOVED"
 )
 
 //Services
 var (
 	SERVICES = map[string][]string{
-		PRODUCTS:                []string{PROD_SERVICE},
-		EQUIPMENTS:              []string{EQUIP_SERVICE},
-		APPLICATIONS:            []string{APP_SERVICE},
-		PRODUCTS_EQUIPMENTS:     []string{PROD_SERVICE},
-		APPLICATIONS_INSTANCES:  []string{APP_SERVICE},
-		APPLICATIONS_PRODUCTS:   []string{PROD_SERVICE},
-		INSTANCES_EQUIPMENTS:    []string{APP_SERVICE},
-		INSTANCES_PRODUCTS:      []string{APP_SERVICE},
-		PRODUCTS_ACQUIREDRIGHTS: []string{ACQ_SERVICE},
-		METADATA:                []string{EQUIP_SERVICE},
+		PRODUCTS:                {PROD_SERVICE},
+		EQUIPMENTS:              {EQUIP_SERVICE},
+		APPLICATIONS:            {APP_SERVICE},
+		PRODUCTS_EQUIPMENTS:     {PROD_SERVICE},
+		APPLICATIONS_INSTANCES:  {APP_SERVICE},
+		APPLICATIONS_PRODUCTS:   {PROD_SERVICE},
+		INSTANCES_EQUIPMENTS:    {APP_SERVICE},
+		INSTANCES_PRODUCTS:      {APP_SERVICE},
+		PRODUCTS_ACQUIREDRIGHTS: {ACQ_SERVICE},
+		METADATA:                {EQUIP_SERVICE},
+	}
+)
+
+//Processing order of files of an upload, data of a file refers to the data of the files it depends on.
+//Equipment files depend on the file of their parent type.
+var (
+	FILE_DEPENDENCIES = map[string][]string{
+		APPLICATIONS:            {PRODUCTS},
+		PRODUCTS_ACQUIREDRIGHTS: {PRODUCTS},
+		APPLICATIONS_PRODUCTS:   {APPLICATIONS, PRODUCTS},
+		APPLICATIONS_INSTANCES:  {APPLICATIONS},
+		INSTANCES_PRODUCTS:      {APPLICATIONS_INSTANCES, PRODUCTS},
+		INSTANCES_EQUIPMENTS:    {APPLICATIONS_INSTANCES, EQUIPMENTS},
+		PRODUCTS_EQUIPMENTS:     {PRODUCTS, EQUIPMENTS},
+	}
+)
+
+//Validation rules
+var (
+	MANDATORY_FIELDS = map[string][]string{
+		PRODUCTS:                {SWIDTAG},
+		APPLICATIONS:            {APP_ID},
+		APPLICATIONS_INSTANCES:  {APP_ID, INST_ID},
+		APPLICATIONS_PRODUCTS:   {APP_ID, SWIDTAG},
+		PRODUCTS_EQUIPMENTS:     {EQUIP_ID, SWIDTAG},
+		INSTANCES_PRODUCTS:      {INST_ID, SWIDTAG},
+		INSTANCES_EQUIPMENTS:    {INST_ID, EQUIP_ID},
+		PRODUCTS_ACQUIREDRIGHTS: {SWIDTAG, SKU},
+	}
+	INTEGER_FIELDS = map[string][]string{
+		PRODUCTS_EQUIPMENTS:     {NBUSERS},
+		PRODUCTS_ACQUIREDRIGHTS: {ACQ_LIC_NO, LIC_UNDER_MAINTENANCE_NO},
+	}
+	FLOAT_FIELDS = map[string][]string{
+		PRODUCTS_ACQUIREDRIGHTS: {AVG_UNIT_PRICE, AVG_MAINENANCE_UNIT_PRICE, TOTAL_PURCHASE_COST, TOTAL_MAINENANCE_COST, TOTAL_COST},
 	}
 )
 
@@ -93,4 +141,7 @@ var (
 	APITYPE     = sql.NullString{String: APIWORKER, Valid: true}
 	ACTION_TYPE = map[string]string{"1": UPSERT, "0": DELETE}
 	API_ACTION  = map[string]string{UPSERT: "add", DELETE: "delete"}
+	//Services of these file types have no delete request, their rows are upserted whatever is their flag
+	//and rows removed from a file since the last applied one are not given by a delta
+	NO_DELETE = map[string]bool{PRODUCTS: true, PRODUCTS_ACQUIREDRIGHTS: true}
 )
@@ -0,0 +1,38 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"io/ioutil"
+	"log"
+	"optisam-backend/dps-service/pkg/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//filesDir is the FilesLocation of tests, files of a test are written in it
+var filesDir string
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "dps-files")
+	if err != nil {
+		log.Fatal(err)
+	}
+	filesDir = dir
+	config.SetConfig(config.Config{FilesLocation: dir, ArchiveLocation: dir})
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+//writeFile writes a file in FilesLocation, files are removed once all tests have run
+func writeFile(t *testing.T, name string, data []byte) {
+	if err := ioutil.WriteFile(filepath.Join(filesDir, name), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"optisam-backend/common/optisam/workerqueue"
+	"optisam-backend/common/optisam/workerqueue/job"
+	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"strings"
+)
+
+//FileDependencies gives for every file of an upload the files of same upload which are to be processed before it.
+//Files of an ordered upload are processed one after another, otherwise the order comes from the type of files,
+//eqParents gives the parent of every equipment type.
+func FileDependencies(scope string, files []string, eqParents map[string]string, ordered bool) map[string][]string {
+	deps := make(map[string][]string)
+	if ordered {
+		for i := 1; i < len(files); i++ {
+			deps[files[i]] = []string{files[i-1]}
+		}
+		return deps
+	}
+	byType := make(map[string][]string)
+	fileTypes := make(map[string]string)
+	for _, file := range files {
+		fileType, err := getFileTypeFromFileName(file, scope)
+		if err != nil || isFileIsMetadataType(file) {
+			continue
+		}
+		fileTypes[file] = fileType
+		if equipmentType(fileType) != "" {
+			byType[constants.EQUIPMENTS] = append(byType[constants.EQUIPMENTS], file)
+		}
+		byType[fileType] = append(byType[fileType], file)
+	}
+	for _, file := range files {
+		fileType, ok := fileTypes[file]
+		if !ok {
+			continue
+		}
+		prerequisites := constants.FILE_DEPENDENCIES[fileType]
+		if eqType := equipmentType(fileType); eqType != "" {
+			if parent := eqParents[eqType]; parent != "" {
+				prerequisites = []string{"EQUIPMENT_" + parent}
+			}
+		}
+		for _, prerequisite := range prerequisites {
+			//a file depends only on the files uploaded with it
+			if len(byType[prerequisite]) > 0 {
+				deps[file] = append(deps[file], byType[prerequisite]...)
+			}
+		}
+	}
+	return deps
+}
+
+//equipmentType gives the equipment type of an equipment file type in uppercase, eg: SERVER for EQUIPMENT_SERVER
+func equipmentType(fileType string) string {
+	if !strings.HasPrefix(fileType, "EQUIPMENT_") {
+		return ""
+	}
+	return strings.TrimPrefix(fileType, "EQUIPMENT_")
+}
+
+//QueueReadyFiles queues the pending files of an upload whose prerequisites are processed,
+//a file is queued only once even if many workers ask for it at same time
+func QueueReadyFiles(ctx context.Context, q gendb.Querier, queue *workerqueue.Queue, uploadID int32) error {
+	files, err := q.ListStartableFiles(ctx, uploadID)
+	if err != nil {
+		log.Println("Failed to get startable files of upload ", uploadID, " err :", err)
+		return err
+	}
+	for _, file := range files {
+		started, err := q.StartFile(ctx, gendb.StartFileParams{UploadID: uploadID, FileName: file.FileName})
+		if err == sql.ErrNoRows {
+			// already queued by another worker
+			continue
+		}
+		if err != nil {
+			log.Println("Failed to start file ", file.FileName, " of upload ", uploadID, " err :", err)
+			return err
+		}
+		data, err := json.Marshal(started)
+		if err != nil {
+			log.Println("Failed to marshal file ", file.FileName, " of upload ", uploadID, " err :", err)
+			return err
+		}
+		_, err = queue.PushJob(ctx, job.Job{
+			Type:   constants.FILETYPE,
+			Data:   data,
+			Status: job.JobStatusPENDING,
+		}, constants.FILEWORKER)
+		if err != nil {
+			log.Println("Failed to push job for file :", file.FileName, " of upload ", uploadID, " err : ", err)
+			return err
+		}
+	}
+	return nil
+}
+
+//failFile marks a file of an upload as failed with the files depending on it, which would refer to missing data.
+//Only a failure to update the file itself is returned.
+func failFile(ctx context.Context, q gendb.Querier, uploadID int32, fileName string) error {
+	err := q.UpdateFileStatus(ctx, gendb.UpdateFileStatusParams{
+		UploadID: uploadID,
+		FileName: fileName,
+		Status:   gendb.UploadStatusFAILED,
+	})
+	if err != nil {
+		log.Println("Failed to update the status of file ", fileName, " , err :", err)
+		return err
+	}
+	err = q.FailDependentFiles(ctx, gendb.FailDependentFilesParams{
+		UploadID: uploadID,
+		FileName: fileName,
+	})
+	if err != nil {
+		log.Println("Failed to fail the files depending on ", fileName, " , err :", err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"optisam-backend/common/optisam/workerqueue"
+	"optisam-backend/common/optisam/workerqueue/job"
+	"optisam-backend/dps-service/pkg/repository/v1/mock"
+	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string
+		eqParents map[string]string
+		ordered   bool
+		want      map[string][]string
+	}{
+		{name: "ordered upload",
+			files:   []string{"s1_applications.csv", "s1_products.csv", "s1_instances_products.csv"},
+			ordered: true,
+			want: map[string][]string{
+				"s1_products.csv":           {"s1_applications.csv"},
+				"s1_instances_products.csv": {"s1_products.csv"},
+			},
+		},
+		{name: "order of file types",
+			files: []string{"s1_instances_products.csv", "s1_applications_instances.csv", "s1_applications.csv", "s1_products.csv", "s1_products_acquiredrights.csv"},
+			want: map[string][]string{
+				"s1_instances_products.csv":      {"s1_applications_instances.csv", "s1_products.csv"},
+				"s1_applications_instances.csv":  {"s1_applications.csv"},
+				"s1_applications.csv":            {"s1_products.csv"},
+				"s1_products_acquiredrights.csv": {"s1_products.csv"},
+			},
+		},
+		{name: "equipments after their parents",
+			files:     []string{"s1_equipment_vm.csv", "s1_equipment_server.csv", "s1_equipment_cluster.csv", "s1_products.csv", "s1_products_equipments.csv"},
+			eqParents: map[string]string{"VM": "SERVER", "SERVER": "CLUSTER"},
+			want: map[string][]string{
+				"s1_equipment_vm.csv":        {"s1_equipment_server.csv"},
+				"s1_equipment_server.csv":    {"s1_equipment_cluster.csv"},
+				"s1_products_equipments.csv": {"s1_products.csv", "s1_equipment_vm.csv", "s1_equipment_server.csv", "s1_equipment_cluster.csv"},
+			},
+		},
+		{name: "prerequisite not uploaded",
+			files: []string{"s1_applications_products.csv", "s1_applications.csv"},
+			want: map[string][]string{
+				"s1_applications_products.csv": {"s1_applications.csv"},
+			},
+		},
+		{name: "metadata and files of another scope",
+			files: []string{"metadata_s1_server.csv", "s2_products.csv", "s1_applications.csv"},
+			want:  map[string][]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, FileDependencies("s1", test.files, test.eqParents, test.ordered))
+		})
+	}
+}
+
+func TestQueueReadyFiles(t *testing.T) {
+	ctx := context.Background()
+	started := gendb.UploadedDataFile{UploadID: 1, Scope: "s1", FileName: "s1_products.csv", Status: gendb.UploadStatusINPROGRESS}
+	tests := []struct {
+		name    string
+		setup   func(*mock.MockDps)
+		want    []gendb.UploadedDataFile
+		wantErr bool
+	}{
+		{name: "files started by this worker only are queued",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().ListStartableFiles(ctx, int32(1)).Return([]gendb.UploadedDataFile{
+					{UploadID: 1, FileName: "s1_products.csv"},
+					{UploadID: 1, FileName: "s1_equipment_server.csv"},
+				}, nil)
+				q.EXPECT().StartFile(ctx, gendb.StartFileParams{UploadID: 1, FileName: "s1_products.csv"}).Return(started, nil)
+				q.EXPECT().StartFile(ctx, gendb.StartFileParams{UploadID: 1, FileName: "s1_equipment_server.csv"}).Return(gendb.UploadedDataFile{}, sql.ErrNoRows)
+			},
+			want: []gendb.UploadedDataFile{started},
+		},
+		{name: "no startable files",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().ListStartableFiles(ctx, int32(1)).Return(nil, nil)
+			},
+		},
+		{name: "startable files not listed",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().ListStartableFiles(ctx, int32(1)).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+		{name: "file not started",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().ListStartableFiles(ctx, int32(1)).Return([]gendb.UploadedDataFile{{UploadID: 1, FileName: "s1_products.csv"}}, nil)
+				q.EXPECT().StartFile(ctx, gendb.StartFileParams{UploadID: 1, FileName: "s1_products.csv"}).Return(gendb.UploadedDataFile{}, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			test.setup(q)
+			backend := workerqueue.NewMemoryBackend()
+			queue := workerqueue.NewQueueWithBackend("dps", backend, workerqueue.QueueConfig{})
+			err := QueueReadyFiles(ctx, q, queue, 1)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			jobs, err := backend.List(ctx, workerqueue.ListFilter{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			var got []gendb.UploadedDataFile
+			for _, j := range jobs {
+				assert.Equal(t, constants.FILETYPE, j.Type)
+				assert.Equal(t, job.JobStatusPENDING, j.Status)
+				var file gendb.UploadedDataFile
+				if assert.NoError(t, json.Unmarshal(j.Data, &file)) {
+					got = append(got, file)
+				}
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func Test_failFile(t *testing.T) {
+	ctx := context.Background()
+	failed := gendb.UpdateFileStatusParams{UploadID: 1, FileName: "s1_products.csv", Status: gendb.UploadStatusFAILED}
+	dependents := gendb.FailDependentFilesParams{UploadID: 1, FileName: "s1_products.csv"}
+	tests := []struct {
+		name    string
+		setup   func(*mock.MockDps)
+		wantErr bool
+	}{
+		{name: "dependent files are failed",
+			setup: func(q *mock.MockDps) {
+				gomock.InOrder(
+					q.EXPECT().UpdateFileStatus(ctx, failed).Return(nil),
+					q.EXPECT().FailDependentFiles(ctx, dependents).Return(nil),
+				)
+			},
+		},
+		{name: "dependent files not failed",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().UpdateFileStatus(ctx, failed).Return(nil)
+				q.EXPECT().FailDependentFiles(ctx, dependents).Return(errors.New("connection refused"))
+			},
+		},
+		{name: "file not failed",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().UpdateFileStatus(ctx, failed).Return(errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			test.setup(q)
+			err := failFile(ctx, q, 1, "s1_products.csv")
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"optisam-backend/dps-service/pkg/worker/models"
+	"strings"
+)
+
+//fingerprinter compares the rows of a file with the last applied file of same scope and type.
+//Rows are keyed by the mandatory fields of their type, files of other types are always applied in full.
+type fingerprinter struct {
+	ctx      context.Context
+	q        gendb.Querier
+	scope    string
+	uploadID int32 // fingerprints of rows are saved for this upload, nothing is saved when 0
+	delta    bool  // only the rows changed since the last applied file are given
+	report   models.DeltaReport
+	skipped  int32 // unchanged rows not yet counted in a batch
+}
+
+//takeUnchanged gives the unchanged rows left out since last call
+func (fp *fingerprinter) takeUnchanged() (n int32) {
+	if fp == nil {
+		return 0
+	}
+	n, fp.skipped = fp.skipped, 0
+	return
+}
+
+type deltaRow struct {
+	line int32
+	row  []string
+	key  string
+	hash string
+}
+
+//deltaReader gives the rows of a file through a fingerprinter.
+//Unchanged rows are left out of a delta and the rows removed since the last applied file are given at the end
+//with flag 0, so that they are processed like the rows deleted in a full file, see constants.NO_DELETE.
+//Lines of rows are the lines of file, line is set before each row is given.
+type deltaReader struct {
+	recordReader
+	fp       *fingerprinter
+	fileType string
+	headers  models.HeadersInfo
+	fields   []string
+	previous int32 // upload of last applied file, 0 for none
+	line     *int32
+	next     int32 // line of next row of file
+	rows     []deltaRow
+	pos      int
+	row      []string
+	seen     map[string]struct{}
+	eof      bool
+	done     bool
+	err      error
+}
+
+func (fp *fingerprinter) reader(r recordReader, fileType string, headers models.HeadersInfo, expectedHeaders []string, line *int32) (*deltaReader, error) {
+	d := &deltaReader{
+		recordReader: r,
+		fp:           fp,
+		fileType:     fileType,
+		headers:      headers,
+		line:         line,
+		next:         *line,
+		seen:         make(map[string]struct{}),
+	}
+	for _, field := range expectedHeaders {
+		d.fields = append(d.fields, strings.ToLower(field))
+	}
+	applied, err := fp.q.GetAppliedFile(fp.ctx, gendb.GetAppliedFileParams{Scope: fp.scope, FileType: fileType})
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("Failed to get last applied file of type ", fileType, " err :", err)
+		return nil, err
+	}
+	d.previous = applied.UploadID
+	fp.report.FileType = fileType
+	fp.report.PreviousUploadID = applied.UploadID
+	return d, nil
+}
+
+func (d *deltaReader) Scan() bool {
+	for d.pos >= len(d.rows) {
+		if d.err != nil || d.done {
+			return false
+		}
+		d.fill()
+		//rows of a batch which failed are not given, unchanged rows would be applied again
+		if d.err != nil {
+			return false
+		}
+	}
+	next := d.rows[d.pos]
+	d.pos++
+	d.row = next.row
+	//reader of file type counts the row
+	*d.line = next.line - 1
+	return true
+}
+
+func (d *deltaReader) Row() []string {
+	return d.row
+}
+
+func (d *deltaReader) Err() error {
+	if d.err != nil {
+		return d.err
+	}
+	return d.recordReader.Err()
+}
+
+//fill reads a batch of rows of file, or the removed rows once file is read
+func (d *deltaReader) fill() {
+	d.rows, d.pos = d.rows[:0], 0
+	if d.eof {
+		d.fillRemoved()
+		d.done = true
+		return
+	}
+	var fresh []deltaRow
+	for n := int32(0); n < batchSize(); n++ {
+		if !d.recordReader.Scan() {
+			d.eof = true
+			break
+		}
+		d.next++
+		row := deltaRow{line: d.next, row: d.recordReader.Row()}
+		if len(row.row) > d.headers.MaxIndexVal {
+			d.seen[d.key(row.row)] = struct{}{}
+		}
+		//invalid rows are reported as usual and deleted rows are always applied
+		if len(columnsError(row.row, d.headers)) == 0 && row.row[d.headers.IndexesOfHeaders[constants.FLAG]] == "1" {
+			row.key = d.key(row.row)
+			row.hash = d.hash(row.row)
+			fresh = append(fresh, row)
+		}
+		d.rows = append(d.rows, row)
+	}
+	if err := d.recordReader.Err(); err != nil {
+		d.err = err
+		return
+	}
+	if err := d.save(fresh); err != nil {
+		d.err = err
+		return
+	}
+	previous, err := d.previousHashes(fresh)
+	if err != nil {
+		d.err = err
+		return
+	}
+	rows := d.rows[:0]
+	for _, row := range d.rows {
+		if row.key == "" {
+			rows = append(rows, row)
+			continue
+		}
+		hash, ok := previous[row.key]
+		switch {
+		case !ok:
+			d.fp.report.Added++
+			d.note(row.line, row.key, constants.ROW_ADDED)
+		case hash != row.hash:
+			d.fp.report.Changed++
+			d.note(row.line, row.key, constants.ROW_CHANGED)
+		default:
+			d.fp.report.Unchanged++
+			if d.fp.delta {
+				d.fp.skipped++
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+	d.rows = rows
+}
+
+//fillRemoved gives the rows of last applied file whose key is not in file,
+//none for the file types whose rows can not be deleted
+func (d *deltaReader) fillRemoved() {
+	if !d.fp.delta || d.previous == 0 || constants.NO_DELETE[d.fileType] {
+		return
+	}
+	keys := make([]string, 0, len(d.seen))
+	for key := range d.seen {
+		keys = append(keys, key)
+	}
+	removed, err := d.fp.q.ListRemovedFingerprints(d.fp.ctx, gendb.ListRemovedFingerprintsParams{
+		Scope:    d.fp.scope,
+		FileType: d.fileType,
+		UploadID: d.previous,
+		RowKeys:  keys,
+	})
+	if err != nil {
+		log.Println("Failed to get removed rows of type ", d.fileType, " err :", err)
+		d.err = err
+		return
+	}
+	for _, rem := range removed {
+		values := make(map[string]string)
+		if err := json.Unmarshal(rem.RowData, &values); err != nil {
+			log.Println("Failed to read removed row ", rem.RowKey, " of type ", d.fileType, " err :", err)
+			d.err = err
+			return
+		}
+		row := make([]string, d.headers.MaxIndexVal+1)
+		for field, index := range d.headers.IndexesOfHeaders {
+			row[index] = values[field]
+		}
+		row[d.headers.IndexesOfHeaders[constants.FLAG]] = "0"
+		d.rows = append(d.rows, deltaRow{row: row})
+		d.fp.report.Removed++
+		d.note(0, rem.RowKey, constants.ROW_REMOVED)
+	}
+}
+
+//previousHashes gives the hashes of rows of last applied file having the keys of rows
+func (d *deltaReader) previousHashes(rows []deltaRow) (map[string]string, error) {
+	hashes := make(map[string]string)
+	if !d.fp.delta || d.previous == 0 || len(rows) == 0 {
+		return hashes, nil
+	}
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.key
+	}
+	previous, err := d.fp.q.ListFileFingerprints(d.fp.ctx, gendb.ListFileFingerprintsParams{
+		Scope:    d.fp.scope,
+		FileType: d.fileType,
+		UploadID: d.previous,
+		RowKeys:  keys,
+	})
+	if err != nil {
+		log.Println("Failed to get fingerprints of type ", d.fileType, " err :", err)
+		return nil, err
+	}
+	for _, fp := range previous {
+		hashes[fp.RowKey] = fp.RowHash
+	}
+	return hashes, nil
+}
+
+//save keeps the fingerprints of rows for the delta of next file, last row of a key wins
+func (d *deltaReader) save(rows []deltaRow) error {
+	if d.fp.uploadID == 0 || len(rows) == 0 {
+		return nil
+	}
+	index := make(map[string]int)
+	var keys, hashes, data []string
+	for _, row := range rows {
+		values := make(map[string]string, len(d.fields))
+		for _, field := range d.fields {
+			values[field] = row.row[d.headers.IndexesOfHeaders[field]]
+		}
+		b, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		if i, ok := index[row.key]; ok {
+			hashes[i], data[i] = row.hash, string(b)
+			continue
+		}
+		index[row.key] = len(keys)
+		keys = append(keys, row.key)
+		hashes = append(hashes, row.hash)
+		data = append(data, string(b))
+	}
+	err := d.fp.q.InsertFileFingerprints(d.fp.ctx, gendb.InsertFileFingerprintsParams{
+		Scope:     d.fp.scope,
+		FileType:  d.fileType,
+		UploadID:  d.fp.uploadID,
+		RowKeys:   keys,
+		RowHashes: hashes,
+		RowData:   data,
+	})
+	if err != nil {
+		log.Println("Failed to save fingerprints of type ", d.fileType, " err :", err)
+	}
+	return err
+}
+
+//key gives the values of mandatory fields of row, eg: idapplication;idinstance
+func (d *deltaReader) key(row []string) string {
+	fields := constants.MANDATORY_FIELDS[d.fileType]
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = strings.TrimSpace(row[d.headers.IndexesOfHeaders[field]])
+	}
+	return strings.Join(values, constants.DELIMETER)
+}
+
+//hash gives the fingerprint of values of row, order of columns in file doesn't matter
+func (d *deltaReader) hash(row []string) string {
+	values := make([]string, len(d.fields))
+	for i, field := range d.fields {
+		values[i] = row[d.headers.IndexesOfHeaders[field]]
+	}
+	b, _ := json.Marshal(values)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *deltaReader) note(line int32, key, change string) {
+	if len(d.fp.report.Rows) < constants.MAX_DELTA_ROWS {
+		d.fp.report.Rows = append(d.fp.report.Rows, models.DeltaRow{Line: line, Key: key, Change: change})
+	}
+}
+
+//DiffFile computes the delta of a file against the last applied file of same scope and type without
+//applying or recording anything. The file is left in place.
+func DiffFile(ctx context.Context, q gendb.Querier, scope, fileName string) (models.DeltaReport, error) {
+	fp := &fingerprinter{ctx: ctx, q: q, scope: scope, delta: true}
+	fp.report.FileName = fileName
+	if isFileIsMetadataType(fileName) {
+		fp.report.FileType = constants.METADATA
+		fp.report.Full = true
+		return fp.report, nil
+	}
+	fileType, err := getFileTypeFromFileName(fileName, scope)
+	if err != nil {
+		return fp.report, err
+	}
+	fp.report.FileType = fileType
+	if _, ok := constants.MANDATORY_FIELDS[fileType]; !ok {
+		fp.report.Full = true
+		return fp.report, nil
+	}
+	expectedHeaders, err := getHeadersForFileType(fileType)
+	if err != nil {
+		return fp.report, err
+	}
+	err = csvToFileData(fileType, fileName, expectedHeaders, fp, func(models.FileData) error { return nil })
+	return fp.report, err
+}
+
+//SetFileApplied makes a file whose every row is applied the base of the next delta of its type,
+//nothing is done while the file has pending or failed jobs
+func SetFileApplied(ctx context.Context, q gendb.Querier, uploadID int32, scope, fileName string) {
+	if isFileIsMetadataType(fileName) {
+		return
+	}
+	fileType, err := getFileTypeFromFileName(fileName, scope)
+	if err != nil {
+		return
+	}
+	if _, ok := constants.MANDATORY_FIELDS[fileType]; !ok {
+		return
+	}
+	err = q.SetAppliedFile(ctx, gendb.SetAppliedFileParams{
+		FileType: fileType,
+		UploadID: uploadID,
+		FileName: fileName,
+	})
+	if err != nil {
+		log.Println("Failed to set applied file ", fileName, " of upload ", uploadID, " err :", err)
+		return
+	}
+	//fingerprints of files older than the applied one are never compared again
+	err = q.DeleteStaleFingerprints(ctx, gendb.DeleteStaleFingerprintsParams{Scope: scope, FileType: fileType})
+	if err != nil {
+		log.Println("Failed to delete old fingerprints of type ", fileType, " err :", err)
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	acq "optisam-backend/acqrights-service/pkg/api/v1"
+	application "optisam-backend/application-service/pkg/api/v1"
+	"optisam-backend/dps-service/pkg/repository/v1/mock"
+	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"optisam-backend/dps-service/pkg/worker/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const productsFile = "swidtag;version;category;editor;isoptionof;name;flag\n" +
+	"p1;1.0;db;oracle;;Oracle DB;1\n" +
+	"p2;2.0;db;oracle;;Oracle DB;1\n" +
+	"p3;1.0;db;oracle;;MySQL;1\n" +
+	"p4;1.0;db;oracle;;Java;0\n" +
+	"p5;1.0\n" +
+	"p3;1.1;db;oracle;;MySQL;1\n"
+
+//rowHash gives the fingerprint of a row of fileType
+func rowHash(t *testing.T, fileType, row string) string {
+	d := &deltaReader{fileType: fileType, headers: headersOf(t, fileType)}
+	expected, _ := getHeadersForFileType(fileType)
+	for _, field := range expected {
+		d.fields = append(d.fields, strings.ToLower(field))
+	}
+	return d.hash(strings.Split(row, constants.DELIMETER))
+}
+
+//productHash gives the fingerprint of a row of products
+func productHash(t *testing.T, row string) string {
+	return rowHash(t, constants.PRODUCTS, row)
+}
+
+//lineRow is a row given by a delta reader with its line in file, 0 for a removed row
+type lineRow struct {
+	line int32
+	row  string
+}
+
+func Test_fingerprinter_reader(t *testing.T) {
+	ctx := context.Background()
+	applied := gendb.GetAppliedFileParams{Scope: "s1", FileType: constants.PRODUCTS}
+	previous := gendb.ListFileFingerprintsParams{Scope: "s1", FileType: constants.PRODUCTS, UploadID: 5, RowKeys: []string{"p1", "p2", "p3", "p3"}}
+	fingerprints := []gendb.ListFileFingerprintsRow{
+		{RowKey: "p1", RowHash: productHash(t, "p1;1.0;db;oracle;;Oracle DB;1")},
+		{RowKey: "p2", RowHash: productHash(t, "p2;1.0;db;oracle;;Oracle DB;1")},
+	}
+	//fingerprints are saved for upload 2, last row of a key wins
+	saved := func(q *mock.MockDps) {
+		q.EXPECT().InsertFileFingerprints(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, arg gendb.InsertFileFingerprintsParams) error {
+			assert.Equal(t, int32(2), arg.UploadID)
+			assert.Equal(t, []string{"p1", "p2", "p3"}, arg.RowKeys)
+			assert.Equal(t, productHash(t, "p3;1.1;db;oracle;;MySQL;1"), arg.RowHashes[2])
+			assert.Contains(t, arg.RowData[2], `"version":"1.1"`)
+			return nil
+		})
+	}
+	allRows := []lineRow{
+		{2, "p1;1.0;db;oracle;;Oracle DB;1"},
+		{3, "p2;2.0;db;oracle;;Oracle DB;1"},
+		{4, "p3;1.0;db;oracle;;MySQL;1"},
+		{5, "p4;1.0;db;oracle;;Java;0"},
+		{6, "p5;1.0"},
+		{7, "p3;1.1;db;oracle;;MySQL;1"},
+	}
+	tests := []struct {
+		name       string
+		delta      bool
+		setup      func(*mock.MockDps)
+		want       []lineRow
+		wantReport models.DeltaReport
+		wantErr    bool
+	}{
+		{name: "delta gives changed rows, products are not removed",
+			delta: true,
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{UploadID: 5}, nil)
+				saved(q)
+				q.EXPECT().ListFileFingerprints(ctx, previous).Return(fingerprints, nil)
+			},
+			want: []lineRow{
+				{3, "p2;2.0;db;oracle;;Oracle DB;1"},
+				{4, "p3;1.0;db;oracle;;MySQL;1"},
+				{5, "p4;1.0;db;oracle;;Java;0"},
+				{6, "p5;1.0"},
+				{7, "p3;1.1;db;oracle;;MySQL;1"},
+			},
+			wantReport: models.DeltaReport{
+				FileType:         constants.PRODUCTS,
+				PreviousUploadID: 5,
+				Added:            2,
+				Changed:          1,
+				Unchanged:        1,
+				Rows: []models.DeltaRow{
+					{Line: 3, Key: "p2", Change: constants.ROW_CHANGED},
+					{Line: 4, Key: "p3", Change: constants.ROW_ADDED},
+					{Line: 7, Key: "p3", Change: constants.ROW_ADDED},
+				},
+			},
+		},
+		{name: "full file gives every row",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{UploadID: 5}, nil)
+				saved(q)
+			},
+			want: allRows,
+			wantReport: models.DeltaReport{
+				FileType:         constants.PRODUCTS,
+				PreviousUploadID: 5,
+				Added:            4,
+				Rows: []models.DeltaRow{
+					{Line: 2, Key: "p1", Change: constants.ROW_ADDED},
+					{Line: 3, Key: "p2", Change: constants.ROW_ADDED},
+					{Line: 4, Key: "p3", Change: constants.ROW_ADDED},
+					{Line: 7, Key: "p3", Change: constants.ROW_ADDED},
+				},
+			},
+		},
+		{name: "no file applied yet",
+			delta: true,
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{}, sql.ErrNoRows)
+				saved(q)
+			},
+			want: allRows,
+			wantReport: models.DeltaReport{
+				FileType: constants.PRODUCTS,
+				Added:    4,
+				Rows: []models.DeltaRow{
+					{Line: 2, Key: "p1", Change: constants.ROW_ADDED},
+					{Line: 3, Key: "p2", Change: constants.ROW_ADDED},
+					{Line: 4, Key: "p3", Change: constants.ROW_ADDED},
+					{Line: 7, Key: "p3", Change: constants.ROW_ADDED},
+				},
+			},
+		},
+		{name: "applied file not found",
+			delta: true,
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{}, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+		{name: "fingerprints not saved",
+			delta: true,
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{UploadID: 5}, nil)
+				q.EXPECT().InsertFileFingerprints(ctx, gomock.Any()).Return(errors.New("connection refused"))
+			},
+			wantReport: models.DeltaReport{FileType: constants.PRODUCTS, PreviousUploadID: 5},
+			wantErr:    true,
+		},
+		{name: "previous fingerprints not found",
+			delta: true,
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, applied).Return(gendb.AppliedFile{UploadID: 5}, nil)
+				saved(q)
+				q.EXPECT().ListFileFingerprints(ctx, previous).Return(nil, errors.New("connection refused"))
+			},
+			wantReport: models.DeltaReport{FileType: constants.PRODUCTS, PreviousUploadID: 5},
+			wantErr:    true,
+		},
+	}
+	writeFile(t, "s1_products.csv", []byte(productsFile))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			test.setup(q)
+			r, err := newRecordReader("s1_products.csv")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			r.Scan()
+			expected, _ := getHeadersForFileType(constants.PRODUCTS)
+			fp := &fingerprinter{ctx: ctx, q: q, scope: "s1", uploadID: 2, delta: test.delta}
+			line := int32(1)
+			d, err := fp.reader(r, constants.PRODUCTS, headersOf(t, constants.PRODUCTS), expected, &line)
+			if err != nil {
+				assert.True(t, test.wantErr, "unexpected error: %v", err)
+				return
+			}
+			var got []lineRow
+			for d.Scan() {
+				//reader of file type counts the row
+				line++
+				got = append(got, lineRow{line, strings.Join(d.Row(), constants.DELIMETER)})
+			}
+			if test.wantErr {
+				assert.Error(t, d.Err())
+			} else {
+				assert.NoError(t, d.Err())
+			}
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantReport, fp.report)
+			assert.Equal(t, test.wantReport.Unchanged, fp.takeUnchanged())
+		})
+	}
+}
+
+func Test_deltaJobs(t *testing.T) {
+	ctx := context.Background()
+	app := func(action string, req interface{}, lines ...int32) models.Envlope {
+		envlope := getEnvlope(constants.APP_SERVICE, constants.APPLICATIONS, "s1_applications.csv", 2)
+		envlope.TargetAction = action
+		envlope.Lines = lines
+		envlope.Data, _ = json.Marshal(req)
+		return envlope
+	}
+	acqRights := func(req interface{}, lines ...int32) models.Envlope {
+		envlope := getEnvlope(constants.ACQ_SERVICE, constants.PRODUCTS_ACQUIREDRIGHTS, "s1_products_acquiredrights.csv", 2)
+		envlope.TargetAction = constants.UPSERT
+		envlope.Lines = lines
+		envlope.Data, _ = json.Marshal(req)
+		return envlope
+	}
+	acqHeaders := "entity;sku;swidtag;product name;editor;metric;Acquired licenses number;Licenses under maintenance number;Total purchase cost;Total maintenance cost;AVG Unit Price;AVG Maintenant Unit Price;Total cost;flag\n"
+	tests := []struct {
+		name     string
+		fileType string
+		fileName string
+		data     string
+		previous []gendb.ListFileFingerprintsRow
+		removed  []gendb.ListRemovedFingerprintsRow
+		want     []models.Envlope
+	}{
+		{name: "removed application is deleted",
+			fileType: constants.APPLICATIONS,
+			fileName: "s1_applications.csv",
+			data:     "idapplication;version;owner;name;flag\na1;1;o1;app1;1\na3;1;o3;app3;1\n",
+			previous: []gendb.ListFileFingerprintsRow{{RowKey: "a1", RowHash: rowHash(t, constants.APPLICATIONS, "a1;1;o1;app1;1")}},
+			removed: []gendb.ListRemovedFingerprintsRow{
+				{RowKey: "a2", RowData: []byte(`{"idapplication":"a2","version":"1","owner":"o2","name":"app2","flag":"1"}`)},
+			},
+			want: []models.Envlope{
+				app(constants.UPSERT, application.UpsertApplicationRequest{ApplicationId: "a3", Name: "app3", Version: "1", Owner: "o3", Scope: "s1"}, 3),
+				app(constants.DELETE, application.DeleteApplicationRequest{ApplicationId: "a2"}, 0),
+			},
+		},
+		{name: "removed acquired rights are not upserted",
+			fileType: constants.PRODUCTS_ACQUIREDRIGHTS,
+			fileName: "s1_products_acquiredrights.csv",
+			data:     acqHeaders + "e;sku1;p1;Oracle DB;oracle;ops;10;5;1000;100;100;10;1100;1\n",
+			want: []models.Envlope{
+				acqRights(acq.UpsertAcqRightsRequest{
+					Sku: "sku1", Swidtag: "p1", ProductName: "Oracle DB", ProductEditor: "oracle", MetricType: "ops", Entity: "e", Scope: "s1",
+					NumLicensesAcquired: 10, NumLicencesMaintainance: 5, TotalPurchaseCost: 1000, TotalMaintenanceCost: 100,
+					AvgUnitPrice: 100, AvgMaintenanceUnitPrice: 10, TotalCost: 1100,
+				}, 2),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			q.EXPECT().GetAppliedFile(ctx, gendb.GetAppliedFileParams{Scope: "s1", FileType: test.fileType}).Return(gendb.AppliedFile{UploadID: 1}, nil)
+			q.EXPECT().InsertFileFingerprints(ctx, gomock.Any()).Return(nil)
+			q.EXPECT().ListFileFingerprints(ctx, gomock.Any()).Return(test.previous, nil)
+			if !constants.NO_DELETE[test.fileType] {
+				q.EXPECT().ListRemovedFingerprints(ctx, gomock.Any()).Return(test.removed, nil)
+			}
+			writeFile(t, test.fileName, []byte(test.data))
+			defer os.Remove(filepath.Join(filesDir, test.fileName))
+			expected, _ := getHeadersForFileType(test.fileType)
+			fp := &fingerprinter{ctx: ctx, q: q, scope: "s1", uploadID: 2, delta: true}
+			var got []models.Envlope
+			err := csvToFileData(test.fileType, test.fileName, expected, fp, func(data models.FileData) error {
+				data.Scope, data.UploadID = "s1", 2
+				jobs, err := createAPITypeJobs(data)
+				if err != nil {
+					return err
+				}
+				for _, j := range jobs {
+					var envlope models.Envlope
+					if err := json.Unmarshal(j.Data, &envlope); err != nil {
+						return err
+					}
+					got = append(got, envlope)
+				}
+				return nil
+			})
+			if assert.NoError(t, err) {
+				assert.ElementsMatch(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffFile(t *testing.T) {
+	ctx := context.Background()
+	writeFile(t, "s1_products.csv", []byte(productsFile))
+	tests := []struct {
+		name     string
+		scope    string
+		fileName string
+		setup    func(*mock.MockDps)
+		want     models.DeltaReport
+		wantErr  bool
+	}{
+		{name: "nothing is saved",
+			scope:    "s1",
+			fileName: "s1_products.csv",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().GetAppliedFile(ctx, gendb.GetAppliedFileParams{Scope: "s1", FileType: constants.PRODUCTS}).Return(gendb.AppliedFile{UploadID: 5}, nil)
+				q.EXPECT().ListFileFingerprints(ctx, gomock.Any()).Return([]gendb.ListFileFingerprintsRow{
+					{RowKey: "p1", RowHash: productHash(t, "p1;1.0;db;oracle;;Oracle DB;1")},
+					{RowKey: "p2", RowHash: productHash(t, "p2;2.0;db;oracle;;Oracle DB;1")},
+					{RowKey: "p3", RowHash: productHash(t, "p3;1.1;db;oracle;;MySQL;1")},
+				}, nil)
+			},
+			want: models.DeltaReport{
+				FileName:         "s1_products.csv",
+				FileType:         constants.PRODUCTS,
+				PreviousUploadID: 5,
+				Changed:          1,
+				Unchanged:        3,
+				Rows:             []models.DeltaRow{{Line: 4, Key: "p3", Change: constants.ROW_CHANGED}},
+			},
+		},
+		{name: "metadata file is applied in full",
+			scope:    "s1",
+			fileName: "metadata_s1_server.csv",
+			want:     models.DeltaReport{FileName: "metadata_s1_server.csv", FileType: constants.METADATA, Full: true},
+		},
+		{name: "equipment file is applied in full",
+			scope:    "s1",
+			fileName: "s1_equipment_server.csv",
+			want:     models.DeltaReport{FileName: "s1_equipment_server.csv", FileType: "EQUIPMENT_SERVER", Full: true},
+		},
+		{name: "file of another scope",
+			scope:    "s2",
+			fileName: "s1_products.csv",
+			want:     models.DeltaReport{FileName: "s1_products.csv"},
+			wantErr:  true,
+		},
+		{name: "file not found",
+			scope:    "s1",
+			fileName: "s1_applications.csv",
+			want:     models.DeltaReport{FileName: "s1_applications.csv", FileType: constants.APPLICATIONS},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			if test.setup != nil {
+				test.setup(q)
+			}
+			got, err := DiffFile(ctx, q, test.scope, test.fileName)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSetFileApplied(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		fileName string
+		setup    func(*mock.MockDps)
+	}{
+		{name: "file is base of next delta",
+			fileName: "s1_products.csv",
+			setup: func(q *mock.MockDps) {
+				gomock.InOrder(
+					q.EXPECT().SetAppliedFile(ctx, gendb.SetAppliedFileParams{FileType: constants.PRODUCTS, UploadID: 2, FileName: "s1_products.csv"}).Return(nil),
+					q.EXPECT().DeleteStaleFingerprints(ctx, gendb.DeleteStaleFingerprintsParams{Scope: "s1", FileType: constants.PRODUCTS}).Return(nil),
+				)
+			},
+		},
+		{name: "fingerprints kept when file is not applied",
+			fileName: "s1_products.csv",
+			setup: func(q *mock.MockDps) {
+				q.EXPECT().SetAppliedFile(ctx, gomock.Any()).Return(errors.New("connection refused"))
+			},
+		},
+		{name: "equipment file has no delta",
+			fileName: "s1_equipment_server.csv",
+		},
+		{name: "metadata file has no delta",
+			fileName: "metadata_s1_server.csv",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			q := mock.NewMockDps(mockCtrl)
+			if test.setup != nil {
+				test.setup(q)
+			}
+			SetFileApplied(ctx, q, 2, "s1", test.fileName)
+		})
+	}
+}
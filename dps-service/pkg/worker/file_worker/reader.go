@@ -0,0 +1,398 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"optisam-backend/dps-service/pkg/config"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//recordReader gives the records of a file one by one whatever is the format of file,
+//first record of file is its headers
+type recordReader interface {
+	Scan() bool
+	Row() []string
+	Err() error
+	Close() error
+}
+
+//newRecordReader opens a file of FilesLocation with the reader of its format
+func newRecordReader(fileName string) (recordReader, error) {
+	file := fmt.Sprintf("%s/%s", config.GetConfig().FilesLocation, fileName)
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case constants.XLSX_EXTENSION:
+		return newXlsxReader(file)
+	case constants.JSONL_EXTENSION:
+		return newJSONLReader(file)
+	default:
+		return newCsvReader(file)
+	}
+}
+
+type csvReader struct {
+	file *os.File
+	*bufio.Scanner
+}
+
+func newCsvReader(file string) (*csvReader, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	return &csvReader{file: f, Scanner: bufio.NewScanner(f)}, nil
+}
+
+func (r *csvReader) Row() []string {
+	return strings.Split(r.Text(), constants.DELIMETER)
+}
+
+func (r *csvReader) Close() error {
+	return r.file.Close()
+}
+
+//jsonlReader reads JSON Lines files, keys of first object are the headers of file
+//and every line gives the values of these keys
+type jsonlReader struct {
+	file    *os.File
+	scanner *bufio.Scanner
+	headers []string
+	first   []byte
+	row     []string
+	err     error
+}
+
+func newJSONLReader(file string) (*jsonlReader, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonlReader{file: f, scanner: bufio.NewScanner(f)}, nil
+}
+
+func (r *jsonlReader) Scan() bool {
+	if r.err != nil {
+		return false
+	}
+	var line []byte
+	if r.first != nil {
+		line, r.first = r.first, nil
+	} else {
+		if !r.scanner.Scan() {
+			return false
+		}
+		line = r.scanner.Bytes()
+		if r.headers == nil {
+			r.headers, r.err = objectKeys(line)
+			if r.err != nil {
+				return false
+			}
+			//first line is a record too, it is given again after the headers
+			r.first = append([]byte{}, line...)
+			r.row = r.headers
+			return true
+		}
+	}
+	obj := make(map[string]interface{})
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		//malformed line is kept as it is, so that it is reported as invalid
+		r.row = []string{string(line)}
+		return true
+	}
+	r.row = make([]string, len(r.headers))
+	for i, key := range r.headers {
+		r.row[i] = jsonValue(obj[key])
+	}
+	return true
+}
+
+func (r *jsonlReader) Row() []string {
+	return r.row
+}
+
+func (r *jsonlReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.scanner.Err()
+}
+
+func (r *jsonlReader) Close() error {
+	return r.file.Close()
+}
+
+//objectKeys gives the keys of a json object in the order of line
+func objectKeys(line []byte) (keys []string, err error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("first line of file is not a json object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func jsonValue(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+//xlsxReader reads first sheet of a workbook row by row, missing rows are given as empty records
+//like empty lines of a csv file
+type xlsxReader struct {
+	zip           *zip.ReadCloser
+	sheet         io.ReadCloser
+	dec           *xml.Decoder
+	sharedStrings []string
+	width         int
+	next          int // number of next row in sheet
+	pending       *xlsxRow
+	row           []string
+	err           error
+}
+
+type xlsxText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	s := t.T
+	for _, r := range t.R {
+		s += r.T
+	}
+	return s
+}
+
+type xlsxRow struct {
+	R     int `xml:"r,attr"`
+	Cells []struct {
+		R  string   `xml:"r,attr"`
+		T  string   `xml:"t,attr"`
+		V  string   `xml:"v"`
+		Is xlsxText `xml:"is"`
+	} `xml:"c"`
+}
+
+func newXlsxReader(file string) (*xlsxReader, error) {
+	z, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+	r := &xlsxReader{zip: z, next: 1}
+	if err := r.readSharedStrings(); err != nil {
+		z.Close()
+		return nil, err
+	}
+	sheet, err := r.open(r.firstSheet())
+	if err != nil {
+		z.Close()
+		return nil, err
+	}
+	r.sheet = sheet
+	r.dec = xml.NewDecoder(sheet)
+	return r, nil
+}
+
+func (r *xlsxReader) open(name string) (io.ReadCloser, error) {
+	for _, f := range r.zip.File {
+		if f.Name == name {
+			return f.Open()
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+func (r *xlsxReader) decode(name string, v interface{}) error {
+	f, err := r.open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return xml.NewDecoder(f).Decode(v)
+}
+
+func (r *xlsxReader) readSharedStrings() error {
+	var sst struct {
+		Si []xlsxText `xml:"si"`
+	}
+	err := r.decode("xl/sharedStrings.xml", &sst)
+	if err == os.ErrNotExist {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r.sharedStrings = make([]string, len(sst.Si))
+	for i, si := range sst.Si {
+		r.sharedStrings[i] = si.String()
+	}
+	return nil
+}
+
+//firstSheet finds the part of first sheet of workbook
+func (r *xlsxReader) firstSheet() string {
+	const defaultSheet = "xl/worksheets/sheet1.xml"
+	var wb struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if r.decode("xl/workbook.xml", &wb) != nil || len(wb.Sheets) == 0 || r.decode("xl/_rels/workbook.xml.rels", &rels) != nil {
+		return defaultSheet
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return defaultSheet
+}
+
+func (r *xlsxReader) Scan() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.pending == nil {
+		r.pending, r.err = r.nextRow()
+		if r.pending == nil {
+			return false
+		}
+	}
+	if r.pending.R > r.next {
+		r.next++
+		r.row = []string{""}
+		return true
+	}
+	r.row = r.cells(r.pending)
+	if r.width == 0 {
+		r.width = len(r.row)
+	}
+	//trailing empty cells are not written in sheet
+	for len(r.row) < r.width {
+		r.row = append(r.row, "")
+	}
+	r.pending = nil
+	r.next++
+	return true
+}
+
+func (r *xlsxReader) nextRow() (*xlsxRow, error) {
+	for {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "row" {
+			row := &xlsxRow{}
+			if err := r.dec.DecodeElement(row, &start); err != nil {
+				return nil, err
+			}
+			if row.R == 0 {
+				row.R = r.next
+			}
+			return row, nil
+		}
+	}
+}
+
+func (r *xlsxReader) cells(row *xlsxRow) (list []string) {
+	for i, c := range row.Cells {
+		col := i
+		if c.R != "" {
+			col = columnIndex(c.R)
+		}
+		for len(list) <= col {
+			list = append(list, "")
+		}
+		switch c.T {
+		case "s":
+			if idx, err := strconv.Atoi(c.V); err == nil && idx < len(r.sharedStrings) {
+				list[col] = r.sharedStrings[idx]
+			}
+		case "inlineStr":
+			list[col] = c.Is.String()
+		case "b":
+			list[col] = strconv.FormatBool(c.V == "1")
+		default:
+			list[col] = c.V
+		}
+	}
+	return
+}
+
+//columnIndex gives index of column of a cell reference, eg: 0 for A1, 27 for AB3
+func columnIndex(ref string) (col int) {
+	for _, ch := range strings.ToUpper(ref) {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+	}
+	return col - 1
+}
+
+func (r *xlsxReader) Row() []string {
+	return r.row
+}
+
+func (r *xlsxReader) Err() error {
+	return r.err
+}
+
+func (r *xlsxReader) Close() error {
+	r.sheet.Close()
+	return r.zip.Close()
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//writeXlsx writes a workbook made of the given parts in FilesLocation
+func writeXlsx(t *testing.T, name string, parts map[string]string) {
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	for part, data := range parts {
+		w, err := z.Create(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, name, buf.Bytes())
+}
+
+//readRecords gives all the records of a file of FilesLocation
+func readRecords(t *testing.T, fileName string) ([][]string, error) {
+	r, err := newRecordReader(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var records [][]string
+	for r.Scan() {
+		records = append(records, r.Row())
+	}
+	return records, r.Err()
+}
+
+func TestRecordReader_xlsx(t *testing.T) {
+	const workbook = `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="products" sheetId="2" r:id="rId2"/><sheet name="old" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	const rels = `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="worksheets/sheet2.xml"/></Relationships>`
+	const sharedStrings = `<sst><si><t>swidtag</t></si><si><t>version</t></si><si><r><t>Oracle </t></r><r><t>DB</t></r></si></sst>`
+	tests := []struct {
+		name    string
+		parts   map[string]string
+		want    [][]string
+		wantErr bool
+	}{
+		{name: "first sheet of workbook",
+			parts: map[string]string{
+				"xl/workbook.xml":            workbook,
+				"xl/_rels/workbook.xml.rels": rels,
+				"xl/sharedStrings.xml":       sharedStrings,
+				"xl/worksheets/sheet1.xml":   `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>old</t></is></c></row></sheetData></worksheet>`,
+				"xl/worksheets/sheet2.xml": `<worksheet><sheetData>` +
+					`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>name</t></is></c><c r="D1" t="inlineStr"><is><t>flag</t></is></c></row>` +
+					`<row r="2"><c r="A2" t="inlineStr"><is><t>p1</t></is></c><c r="B2"><v>12.5</v></c><c r="C2" t="s"><v>2</v></c><c r="D2" t="b"><v>1</v></c></row>` +
+					`<row r="4"><c r="A4" t="inlineStr"><is><t>p2</t></is></c><c r="C4" t="inlineStr"><is><t>MySQL</t></is></c></row>` +
+					`</sheetData></worksheet>`,
+			},
+			want: [][]string{
+				{"swidtag", "version", "name", "flag"},
+				{"p1", "12.5", "Oracle DB", "true"},
+				//missing row is an empty record, missing cells are empty values
+				{""},
+				{"p2", "", "MySQL", ""},
+			},
+		},
+		{name: "default sheet without workbook",
+			parts: map[string]string{
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c t="inlineStr"><is><t>id</t></is></c><c t="inlineStr"><is><t>name</t></is></c></row><row><c><v>1</v></c></row></sheetData></worksheet>`,
+			},
+			want: [][]string{{"id", "name"}, {"1", ""}},
+		},
+		{name: "sheet not found",
+			parts:   map[string]string{"xl/workbook.xml": workbook},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writeXlsx(t, "s1_products.xlsx", test.parts)
+			got, err := readRecords(t, "s1_products.xlsx")
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordReader_jsonl(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    [][]string
+		wantErr bool
+	}{
+		{name: "keys of first object are headers",
+			data: `{"swidtag":"p1","version":1.10,"name":"Oracle DB","flag":true}` + "\n" +
+				`{"name":"MySQL","swidtag":"p2","flag":false,"extra":"x"}` + "\n" +
+				`{"swidtag":"p3","version":null,"name":{"en":"Java"},"flag":1}` + "\n",
+			want: [][]string{
+				{"swidtag", "version", "name", "flag"},
+				{"p1", "1.10", "Oracle DB", "true"},
+				{"p2", "", "MySQL", "false"},
+				{"p3", "", `{"en":"Java"}`, "1"},
+			},
+		},
+		{name: "malformed line is kept as it is",
+			data: `{"id":"a1","name":"app"}` + "\n" + `{"id":"a2",` + "\n",
+			want: [][]string{{"id", "name"}, {"a1", "app"}, {`{"id":"a2",`}},
+		},
+		{name: "first line is not an object",
+			data:    `["id","name"]` + "\n",
+			wantErr: true,
+		},
+		{name: "empty file",
+			data: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writeFile(t, "s1_applications.jsonl", []byte(test.data))
+			got, err := readRecords(t, "s1_applications.jsonl")
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func Test_columnIndex(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{ref: "A1", want: 0},
+		{ref: "d12", want: 3},
+		{ref: "Z3", want: 25},
+		{ref: "AB3", want: 27},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			assert.Equal(t, test.want, columnIndex(test.ref))
+		})
+	}
+}
@@ -7,7 +7,6 @@
 package fileworker
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -23,9 +22,9 @@ import (
 	equipment "optisam-backend/equipment-service/pkg/api/v1"
 	product "optisam-backend/product-service/pkg/api/v1"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"go.uber.org/zap"
 )
@@ -39,7 +38,8 @@ func getFileTypeFromFileName(fileName, scope string) (fileType string, err error
 		err = errObj.GetError("InvalidFileName")
 		return
 	}
-	fileType = strings.Split(strings.Split(fileName, sep)[1], constants.FILE_EXTENSION)[0]
+	fileType = strings.Split(fileName, sep)[1]
+	fileType = strings.TrimSuffix(fileType, filepath.Ext(fileType))
 	return
 }
 
@@ -50,51 +50,87 @@ func isFileIsMetadataType(fileName string) bool {
 	return false
 }
 
-func fileProcessing(jobData gendb.UploadedDataFile) (data models.FileData, err error) {
+//fileProcessing reads the file batch by batch, each batch is given to process before next one is read.
+//Rows of file are compared with the last applied file through fp when it is given.
+func fileProcessing(jobData gendb.UploadedDataFile, fp *fingerprinter, process func(models.FileData) error) (err error) {
 	var fileType string
 	var expectedHeaders []string
 	if jobData.FileName == "" {
 		err = errObj.GetError("MissingFileName")
 		return
 	}
+	//every batch carries the details of file
+	processBatch := func(data models.FileData) error {
+		data.Scope = jobData.Scope
+		data.FileName = jobData.FileName
+		data.UploadID = jobData.UploadID
+		return process(data)
+	}
 	if isFileIsMetadataType(jobData.FileName) {
+		var data models.FileData
 		data, err = csvFileToSchemaData(jobData.FileName, data.Scope)
-		data.FileType = constants.METADATA
-		data.TargetServices = constants.SERVICES[data.FileType]
-	} else {
-		fileType, err = getFileTypeFromFileName(jobData.FileName, jobData.Scope)
-		log.Printf("File Type %s", fileType)
 		if err != nil {
-			log.Println("File name doesn't has scope, err ", err)
 			return
 		}
-		//For equipment, dynamic processing is required
-		if strings.Contains(fileType, "EQUIPMENT_") {
-			data, err = getEquipment(fileType, jobData.FileName)
-			if err != nil {
-				log.Println("This file is not supported, err ", err)
-				return
-			}
-			data.TargetServices = constants.SERVICES[constants.EQUIPMENTS]
-		} else {
-			expectedHeaders, err = getHeadersForFileType(fileType)
-			if err != nil {
-				log.Println("This file is not supported, err ", err)
-				return
-			}
-			data, err = csvToFileData(fileType, jobData.FileName, expectedHeaders)
-			if err != nil {
-				log.Println("Failed to read data from  file ", jobData.FileName, " with err ", err)
-				return
-			}
+		data.FileType = constants.METADATA
+		data.TargetServices = constants.SERVICES[data.FileType]
+		return processBatch(data)
+	}
+	fileType, err = getFileTypeFromFileName(jobData.FileName, jobData.Scope)
+	log.Printf("File Type %s", fileType)
+	if err != nil {
+		log.Println("File name doesn't has scope, err ", err)
+		return
+	}
+	//For equipment, dynamic processing is required
+	if strings.Contains(fileType, "EQUIPMENT_") {
+		err = getEquipment(fileType, jobData.FileName, processBatch)
+		if err != nil {
+			log.Println("Failed to read equipments from file ", jobData.FileName, " with err ", err)
 		}
+		return
+	}
+	expectedHeaders, err = getHeadersForFileType(fileType)
+	if err != nil {
+		log.Println("This file is not supported, err ", err)
+		return
+	}
+	err = csvToFileData(fileType, jobData.FileName, expectedHeaders, fp, processBatch)
+	if err != nil {
+		log.Println("Failed to read data from  file ", jobData.FileName, " with err ", err)
 	}
-	data.Scope = jobData.Scope
-	data.FileName = jobData.FileName
-	data.UploadID = jobData.UploadID
 	return
 }
 
+//countFileRecords tells the number of records in file without keeping them in memory
+func countFileRecords(fileName string) (count int32, err error) {
+	if isFileIsMetadataType(fileName) {
+		return 1, nil
+	}
+	r, err := newRecordReader(fileName)
+	if err != nil {
+		return
+	}
+	defer r.Close()
+	//first record of file is headers
+	if !r.Scan() {
+		return 0, r.Err()
+	}
+	for r.Scan() {
+		count++
+	}
+	err = r.Err()
+	return
+}
+
+//batchSize tells the max records of file kept in memory at once
+func batchSize() int32 {
+	if size := config.GetConfig().BatchSize; size > 0 {
+		return int32(size)
+	}
+	return constants.BATCH_SIZE
+}
+
 func getHeadersForFileType(fileType string) (headers []string, err error) {
 	headers = []string{}
 	switch fileType {
@@ -131,21 +167,19 @@ func getHeadersForFileType(fileType string) (headers []string, err error) {
 func csvFileToSchemaData(fileName, scope string) (data models.FileData, err error) {
 	file := fmt.Sprintf("%s/%s", config.GetConfig().FilesLocation, fileName)
 	log.Println("Looking for schema file >>>>>>>>>> : ", file)
-	csvFile, err := os.Open(file)
+	r, err := newRecordReader(fileName)
 	if err != nil {
 		log.Println("Failed to open the schema file , err :", err)
 		return
 	}
-	defer csvFile.Close()
-	scanner := bufio.NewScanner(csvFile)
-	if !scanner.Scan() {
-		err = scanner.Err()
+	defer r.Close()
+	if !r.Scan() {
+		err = r.Err()
 		return
 	}
-	row := scanner.Text()
 	//schemaType := strings.Split(strings.Split(fileName, constants.SCOPE_DELIMETER)[2], constants.FILE_EXTENSION)[0]
 
-	for _, val := range strings.Split(row, constants.DELIMETER) {
+	for _, val := range r.Row() {
 		data.Schema = append(data.Schema, val)
 	}
 	data.TotalCount++
@@ -183,12 +217,14 @@ func getIndexOfHeaders(firstRow string, expectedHeaders []string) (headers model
 	return
 }
 
-func getProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getProducts(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.Products = make(map[string]models.ProductInfo)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.PRODUCTS, list, headers); len(errs) == 0 {
 			data := models.ProductInfo{}
 			data.Name = list[headers.IndexesOfHeaders[constants.NAME]]
 			data.Version = list[headers.IndexesOfHeaders[constants.VERSION]]
@@ -198,22 +234,26 @@ func getProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.File
 			data.SwidTag = list[headers.IndexesOfHeaders[constants.SWIDTAG]]
 			data.Action = constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.Products[data.SwidTag] = data
+			resp.Lines[data.SwidTag] = append(resp.Lines[data.SwidTag], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 
 }
 
-func getApplications(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getApplications(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.Applications = make(map[string]models.ApplicationInfo)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.APPLICATIONS, list, headers); len(errs) == 0 {
 			data := models.ApplicationInfo{}
 			data.ID = list[headers.IndexesOfHeaders[constants.APP_ID]]
 			data.Name = list[headers.IndexesOfHeaders[constants.NAME]]
@@ -221,129 +261,153 @@ func getApplications(s *bufio.Scanner, headers models.HeadersInfo) (resp models.
 			data.Version = list[headers.IndexesOfHeaders[constants.VERSION]]
 			data.Action = constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.Applications[data.ID] = data
+			resp.Lines[data.ID] = append(resp.Lines[data.ID], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getApplicationsAndProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getApplicationsAndProducts(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.AppProducts = make(map[string]map[string][]string)
 	resp.AppProducts[constants.UPSERT] = make(map[string][]string)
 	resp.AppProducts[constants.DELETE] = make(map[string][]string)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.APPLICATIONS_PRODUCTS, list, headers); len(errs) == 0 {
 			prodID := list[headers.IndexesOfHeaders[constants.SWIDTAG]]
 			appID := list[headers.IndexesOfHeaders[constants.APP_ID]]
 			action := constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.AppProducts[action][prodID] = append(resp.AppProducts[action][prodID], appID)
+			resp.Lines[lineKey(action, prodID)] = append(resp.Lines[lineKey(action, prodID)], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getInstancesOfProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getInstancesOfProducts(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.ProdInstances = make(map[string]map[string][]string)
 	resp.ProdInstances[constants.UPSERT] = make(map[string][]string)
 	resp.ProdInstances[constants.DELETE] = make(map[string][]string)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.INSTANCES_PRODUCTS, list, headers); len(errs) == 0 {
 			instanceID := list[headers.IndexesOfHeaders[constants.INST_ID]]
 			prodId := list[headers.IndexesOfHeaders[constants.SWIDTAG]]
 			action := constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.ProdInstances[action][instanceID] = append(resp.ProdInstances[action][instanceID], prodId)
+			resp.Lines[lineKey(action, instanceID)] = append(resp.Lines[lineKey(action, instanceID)], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getInstanceOfApplications(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getInstanceOfApplications(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.AppInstances = make(map[string][]models.AppInstance)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.APPLICATIONS_INSTANCES, list, headers); len(errs) == 0 {
 			data := models.AppInstance{}
 			data.ID = list[headers.IndexesOfHeaders["idinstance"]]
 			appID := list[headers.IndexesOfHeaders["idapplication"]]
 			data.Env = list[headers.IndexesOfHeaders["environment"]]
 			data.Action = constants.ACTION_TYPE[list[headers.IndexesOfHeaders["flag"]]]
 			resp.AppInstances[appID] = append(resp.AppInstances[appID], data)
+			resp.Lines[lineKey(appID, data.ID)] = append(resp.Lines[lineKey(appID, data.ID)], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getEquipmentsOfProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getEquipmentsOfProducts(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.ProdEquipments = make(map[string]map[string][]models.ProdEquipemtInfo)
 	resp.ProdEquipments[constants.UPSERT] = make(map[string][]models.ProdEquipemtInfo)
 	resp.ProdEquipments[constants.DELETE] = make(map[string][]models.ProdEquipemtInfo)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.PRODUCTS_EQUIPMENTS, list, headers); len(errs) == 0 {
 			temp := models.ProdEquipemtInfo{}
 			prodID := list[headers.IndexesOfHeaders[constants.SWIDTAG]]
 			temp.EquipID = list[headers.IndexesOfHeaders[constants.EQUIP_ID]]
 			temp.NbUsers = list[headers.IndexesOfHeaders[constants.NBUSERS]]
 			action := constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.ProdEquipments[action][prodID] = append(resp.ProdEquipments[action][prodID], temp)
+			resp.Lines[lineKey(action, prodID)] = append(resp.Lines[lineKey(action, prodID)], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getEquipmentsOnInstances(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getEquipmentsOnInstances(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.EquipInstances = make(map[string]map[string][]string)
 	resp.EquipInstances[constants.UPSERT] = make(map[string][]string)
 	resp.EquipInstances[constants.DELETE] = make(map[string][]string)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.INSTANCES_EQUIPMENTS, list, headers); len(errs) == 0 {
 			instanceID := list[headers.IndexesOfHeaders[constants.INST_ID]]
 			equipID := list[headers.IndexesOfHeaders[constants.EQUIP_ID]]
 			action := constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.EquipInstances[action][instanceID] = append(resp.EquipInstances[action][instanceID], equipID)
+			resp.Lines[lineKey(action, instanceID)] = append(resp.Lines[lineKey(action, instanceID)], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func getAcqRightsOfProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp models.FileData, err error) {
+func getAcqRightsOfProducts(r recordReader, headers models.HeadersInfo, line *int32) (resp models.FileData, err error) {
 	resp.AcqRights = make(map[string]models.AcqRightsInfo)
-	for s.Scan() {
-		row := s.Text()
-		list := strings.Split(row, constants.DELIMETER)
-		if len(list) >= headers.MaxIndexVal+1 {
+	resp.Lines = make(map[string][]int32)
+	for resp.TotalCount < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		row := strings.Join(list, constants.DELIMETER)
+		if errs := validateRow(constants.PRODUCTS_ACQUIREDRIGHTS, list, headers); len(errs) == 0 {
 			temp := models.AcqRightsInfo{}
 			temp.SwidTag = list[headers.IndexesOfHeaders[constants.SWIDTAG]]
 			temp.Sku = list[headers.IndexesOfHeaders[constants.SKU]]
@@ -360,68 +424,87 @@ func getAcqRightsOfProducts(s *bufio.Scanner, headers models.HeadersInfo) (resp
 			temp.TotalCost, _ = strconv.ParseFloat(list[headers.IndexesOfHeaders[constants.TOTAL_COST]], 64)
 			temp.Action = constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]
 			resp.AcqRights[temp.SwidTag] = temp
+			resp.Lines[temp.SwidTag] = append(resp.Lines[temp.SwidTag], *line)
 		} else {
 			resp.InvalidCount++
+			resp.InvalidRecords = append(resp.InvalidRecords, invalidRecord(*line, row, errs))
 		}
 		resp.TotalCount++
 	}
-	err = s.Err()
+	err = r.Err()
 	return
 }
 
-func csvToFileData(fileType, fileName string, expectedHeaders []string) (resp models.FileData, err error) {
+func csvToFileData(fileType, fileName string, expectedHeaders []string, fp *fingerprinter, process func(models.FileData) error) (err error) {
 	var headers models.HeadersInfo
+	var resp models.FileData
 	file := fmt.Sprintf("%s/%s", config.GetConfig().FilesLocation, fileName)
 	log.Println("Looking for file   >>>>>>>>>>>>>>>>> : ", file)
-	csvFile, err := os.Open(file)
+	r, err := newRecordReader(fileName)
 	if err != nil {
 		log.Println("Failed to open the file , err :", err)
 		return
 	}
-	defer csvFile.Close()
-	scanner := bufio.NewScanner(csvFile)
-	if !scanner.Scan() {
-		err = scanner.Err()
+	defer r.Close()
+	if !r.Scan() {
+		err = r.Err()
 		return
 	}
-	headers, err = getIndexOfHeaders(scanner.Text(), expectedHeaders)
+	headers, err = getIndexOfHeaders(strings.Join(r.Row(), constants.DELIMETER), expectedHeaders)
 	if err != nil {
 		log.Println("Headers error ", err)
 		return
 	}
-	switch fileType {
-	case constants.PRODUCTS:
-		resp, err = getProducts(scanner, headers)
+	line := int32(1)
+	if fp != nil {
+		if r, err = fp.reader(r, fileType, headers, expectedHeaders, &line); err != nil {
+			return
+		}
+	}
+	for {
+		switch fileType {
+		case constants.PRODUCTS:
+			resp, err = getProducts(r, headers, &line)
 
-	case constants.APPLICATIONS:
-		resp, err = getApplications(scanner, headers)
+		case constants.APPLICATIONS:
+			resp, err = getApplications(r, headers, &line)
 
-	case constants.PRODUCTS_EQUIPMENTS:
-		resp, err = getEquipmentsOfProducts(scanner, headers)
+		case constants.PRODUCTS_EQUIPMENTS:
+			resp, err = getEquipmentsOfProducts(r, headers, &line)
 
-	case constants.PRODUCTS_ACQUIREDRIGHTS:
-		resp, err = getAcqRightsOfProducts(scanner, headers)
+		case constants.PRODUCTS_ACQUIREDRIGHTS:
+			resp, err = getAcqRightsOfProducts(r, headers, &line)
 
-	case constants.INSTANCES_PRODUCTS:
-		resp, err = getInstancesOfProducts(scanner, headers)
+		case constants.INSTANCES_PRODUCTS:
+			resp, err = getInstancesOfProducts(r, headers, &line)
 
-	case constants.INSTANCES_EQUIPMENTS:
-		resp, err = getEquipmentsOnInstances(scanner, headers)
+		case constants.INSTANCES_EQUIPMENTS:
+			resp, err = getEquipmentsOnInstances(r, headers, &line)
 
-	case constants.APPLICATIONS_INSTANCES:
-		resp, err = getInstanceOfApplications(scanner, headers)
+		case constants.APPLICATIONS_INSTANCES:
+			resp, err = getInstanceOfApplications(r, headers, &line)
 
-	case constants.APPLICATIONS_PRODUCTS:
-		resp, err = getApplicationsAndProducts(scanner, headers)
+		case constants.APPLICATIONS_PRODUCTS:
+			resp, err = getApplicationsAndProducts(r, headers, &line)
 
-	default:
-		err = errObj.GetError("UnknownFileType")
-		return
+		default:
+			err = errObj.GetError("UnknownFileType")
+		}
+		resp.UnchangedCount = fp.takeUnchanged()
+		if err != nil || resp.TotalCount+resp.UnchangedCount == 0 {
+			return
+		}
+		resp.FileType = fileType
+		resp.FileName = fileName
+		resp.TargetServices = constants.SERVICES[fileType]
+		if err = process(resp); err != nil {
+			return
+		}
+		//a batch not full means end of file
+		if resp.TotalCount < batchSize() {
+			return
+		}
 	}
-	resp.FileType = fileType
-	resp.FileName = fileName
-	resp.TargetServices = constants.SERVICES[fileType]
-	return
 }
 
 func createAPITypeJobs(data models.FileData) (jobs []job.Job, err error) {
@@ -459,6 +542,7 @@ func createEquipServiceJobs(data models.FileData, targetService string) (jobs []
 		}
 
 		envlope.TargetAction = constants.UPSERT
+		envlope.Lines = []int32{1}
 		envlope.Data, err = json.Marshal(appData)
 		if err != nil {
 			log.Println("Failed to marshal jobdata, err:", err)
@@ -474,7 +558,7 @@ func createEquipServiceJobs(data models.FileData, targetService string) (jobs []
 	} else {
 		envlope := getEnvlope(targetService, "EQUIPMENTS", data.FileName, data.UploadID)
 		for k, v := range data.Equipments {
-			for _, rec := range v {
+			for i, rec := range v {
 				//Marshal Map
 				b, _ := json.Marshal(rec)
 				//fmt.Printf("json %s", string(b))
@@ -487,6 +571,7 @@ func createEquipServiceJobs(data models.FileData, targetService string) (jobs []
 				// }
 				eqData := models.EquipmentRequest{Scope: data.Scope, EqType: strings.ToLower(k), EqData: b}
 				envlope.TargetAction = constants.UPSERT
+				envlope.Lines = data.Lines[lineKey(k, strconv.Itoa(i))]
 				//marshal to specific job
 				envlope.Data, err = json.Marshal(eqData)
 				if err != nil {
@@ -530,6 +615,7 @@ func createAcqServiceJob(data models.FileData, targetService string) (jobs []job
 			Scope:                   data.Scope,
 		}
 		envlope.TargetAction = constants.UPSERT
+		envlope.Lines = data.Lines[val.SwidTag]
 		envlope.Data, err = json.Marshal(appData)
 		if err != nil {
 			log.Println("Failed to marshal jobdata, err:", err)
@@ -592,6 +678,7 @@ func createProdEquipJobs(data models.FileData, targetService string) (jobs []job
 				},
 			}
 			envlope.TargetAction = constants.UPSERT
+			envlope.Lines = data.Lines[lineKey(action, prodID)]
 			envlope.Data, err = json.Marshal(appData)
 			if err != nil {
 				log.Println("Failed to marshal jobdata, err:", err)
@@ -635,6 +722,7 @@ func createAppProductsJobs(data models.FileData, targetService string) (jobs []j
 				},
 			}
 			envlope.TargetAction = constants.UPSERT
+			envlope.Lines = data.Lines[lineKey(action, prodID)]
 			envlope.Data, err = json.Marshal(appData)
 			if err != nil {
 				log.Println("Failed to marshal jobdata, err:", err)
@@ -667,6 +755,7 @@ func createProductJobs(data models.FileData, targetService string) (jobs []job.J
 			Scope:    data.Scope,
 		}
 		envlope.TargetAction = constants.UPSERT
+		envlope.Lines = data.Lines[val.SwidTag]
 		envlope.Data, err = json.Marshal(appData)
 		if err != nil {
 			log.Println("Failed to marshal jobdata, err:", err)
@@ -698,6 +787,7 @@ func createInstanceEquipJobs(data models.FileData, targetService string) (jobs [
 				},
 			}
 			envlope.TargetAction = constants.UPSERT
+			envlope.Lines = data.Lines[lineKey(action, instanceID)]
 			envlope.Data, err = json.Marshal(appData)
 			if err != nil {
 				log.Println("Failed to marshal jobdata, err:", err)
@@ -730,6 +820,7 @@ func createInstanceProdJobs(data models.FileData, targetService string) (jobs []
 				},
 			}
 			envlope.TargetAction = constants.UPSERT
+			envlope.Lines = data.Lines[lineKey(action, instanceID)]
 			envlope.Data, err = json.Marshal(appData)
 			if err != nil {
 				log.Println("Failed to marshal jobdata, err:", err)
@@ -769,6 +860,7 @@ func createAppInstanceJobs(data models.FileData, targetService string) (jobs []j
 				}
 				envlope.TargetAction = constants.DELETE
 			}
+			envlope.Lines = data.Lines[lineKey(appId, val.ID)]
 			envlope.Data, err = json.Marshal(appData)
 			if err != nil {
 				log.Println("Failed to marshal jobdata, err:", err)
@@ -807,6 +899,7 @@ func createApplicationJobs(data models.FileData, targetService string) (jobs []j
 			}
 			envlope.TargetAction = constants.DELETE
 		}
+		envlope.Lines = data.Lines[val.ID]
 		envlope.Data, err = json.Marshal(appData)
 		if err != nil {
 			log.Println("Failed to marshal jobdata, err:", err)
@@ -830,6 +923,11 @@ func archiveFile(fileName string, uploadId int32) error {
 	return os.Rename(oldFile, newfile)
 }
 
+//lineKey gives the key of Lines for records grouped under an action or a parent
+func lineKey(group, id string) string {
+	return group + "\x00" + id
+}
+
 func getEnvlope(service, fileType, fileName string, id int32) models.Envlope {
 	return models.Envlope{
 		TargetService: service,
@@ -839,48 +937,68 @@ func getEnvlope(service, fileType, fileName string, id int32) models.Envlope {
 	}
 }
 
-func getEquipment(fileType, fileName string) (models.FileData, error) {
+//getEquipment reads an equipment file batch by batch. Equipment files have no delta, their columns are
+//given by the metadata of their type, so they are always applied in full.
+func getEquipment(fileType, fileName string, process func(models.FileData) error) error {
 	file := fmt.Sprintf("%s/%s", config.GetConfig().FilesLocation, fileName)
 	eqType := strings.Split(fileType, "_")[1]
 	log.Println("Looking for file   >>>>>>>>>>>>>>>>> : ", file, fileType)
-	time.Sleep(5)
-	data, err := getDynamicEquipmentFromCsv(file)
-	if err != nil {
-		logger.Log.Error("Error reading equipment csv", zap.Error(err))
-		return models.FileData{}, err
-	}
-
-	resp := models.FileData{}
-	resp.Equipments = make(map[string][]map[string]interface{})
-	resp.Equipments[eqType] = data
-	resp.TotalCount = int32(len(data))
-	resp.FileType = fileType
-	resp.FileName = fileName
-	resp.TargetServices = constants.SERVICES[fileType]
-	return resp, nil
-}
-
-func getDynamicEquipmentFromCsv(file string) (resp []map[string]interface{}, err error) {
-
-	csvFile, err := os.Open(file)
+	r, err := newRecordReader(fileName)
 	if err != nil {
 		logger.Log.Error("The file is not found", zap.Error(err))
-		return nil, err
+		return err
 	}
-	defer csvFile.Close()
-	s := bufio.NewScanner(csvFile)
-	if !s.Scan() {
-		err = s.Err()
-		return
+	defer r.Close()
+	if !r.Scan() {
+		return r.Err()
 	}
 	headers := make(map[int]string)
-	for key, val := range strings.Split(s.Text(), constants.DELIMETER) {
+	for key, val := range r.Row() {
 		headers[key] = val
 	}
+	line := int32(1)
+	for {
+		data, lines, invalid, err := getDynamicEquipmentFromCsv(r, headers, &line)
+		if err != nil {
+			logger.Log.Error("Error reading equipment csv", zap.Error(err))
+			return err
+		}
+		resp := models.FileData{}
+		resp.Equipments = make(map[string][]map[string]interface{})
+		resp.Equipments[eqType] = data
+		resp.Lines = make(map[string][]int32)
+		for i := range data {
+			resp.Lines[lineKey(eqType, strconv.Itoa(i))] = []int32{lines[i]}
+		}
+		resp.InvalidRecords = invalid
+		resp.InvalidCount = int32(len(invalid))
+		resp.TotalCount = int32(len(data) + len(invalid))
+		if resp.TotalCount == 0 {
+			break
+		}
+		resp.FileType = fileType
+		resp.FileName = fileName
+		resp.TargetServices = constants.SERVICES[fileType]
+		if err = process(resp); err != nil {
+			return err
+		}
+		//a batch not full means end of file
+		if resp.TotalCount < batchSize() {
+			break
+		}
+	}
+	log.Println("<<<<<<<<<<<>>>>>>>>>>>> Equipment File Processed in DPS service ")
+	return nil
+}
+
+func getDynamicEquipmentFromCsv(r recordReader, headers map[int]string, line *int32) (resp []map[string]interface{}, lines []int32, invalid []models.InvalidRecord, err error) {
 	hlen := len(headers)
-	for s.Scan() {
-		list := strings.Split(s.Text(), constants.DELIMETER)
-		if len(list) >= hlen {
+	for int32(len(resp)+len(invalid)) < batchSize() && r.Scan() {
+		*line++
+		list := r.Row()
+		if len(list) < hlen {
+			invalid = append(invalid, invalidRecord(*line, strings.Join(list, constants.DELIMETER), []models.RowError{{Reason: fmt.Sprintf("expected %d columns, found %d", hlen, len(list))}}))
+		} else {
 			temp := make(map[string]interface{})
 			for index, val := range list {
 				var out interface{}
@@ -898,10 +1016,9 @@ func getDynamicEquipmentFromCsv(file string) (resp []map[string]interface{}, err
 				temp[headers[index]] = out
 			}
 			resp = append(resp, temp)
+			lines = append(lines, *line)
 		}
 	}
-	err = s.Err()
-	log.Println("<<<<<<<<<<<>>>>>>>>>>>> Equipment File Processed in DPS service ")
-
+	err = r.Err()
 	return
 }
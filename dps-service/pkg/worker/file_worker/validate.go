@@ -0,0 +1,210 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"fmt"
+	"log"
+	errObj "optisam-backend/dps-service/pkg/error"
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"optisam-backend/dps-service/pkg/worker/models"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//ValidateFile runs the header detection and row parsing of a file without creating any job,
+//and reports every rejected line. The file is left in place.
+func ValidateFile(scope, fileName string) (report models.ValidationReport) {
+	report.FileName = fileName
+	if fileName == "" || filepath.Base(fileName) != fileName {
+		addFileError(&report, 0, "", errObj.GetError("InvalidFileName"))
+		return
+	}
+	if isFileIsMetadataType(fileName) {
+		report.FileType = constants.METADATA
+		validateSchemaFile(&report)
+		return
+	}
+	fileType, err := getFileTypeFromFileName(fileName, scope)
+	if err != nil {
+		addFileError(&report, 0, "", err)
+		return
+	}
+	report.FileType = fileType
+	if strings.Contains(fileType, "EQUIPMENT_") {
+		validateEquipmentFile(&report)
+		return
+	}
+	expectedHeaders, err := getHeadersForFileType(fileType)
+	if err != nil {
+		addFileError(&report, 0, "", err)
+		return
+	}
+	validateCsvFile(&report, expectedHeaders)
+	return
+}
+
+//columnsError rejects a line missing columns of headers, the values of the other lines are read without panic
+func columnsError(list []string, headers models.HeadersInfo) []models.RowError {
+	if len(list) < headers.MaxIndexVal+1 {
+		return []models.RowError{{Reason: fmt.Sprintf("expected at least %d columns, found %d", headers.MaxIndexVal+1, len(list))}}
+	}
+	return nil
+}
+
+//validateRow checks a line against the rules of its file type, it returns nothing for a valid line.
+//Lines are checked the same way by a dry run and when their file is processed.
+func validateRow(fileType string, list []string, headers models.HeadersInfo) (errs []models.RowError) {
+	if errs = columnsError(list, headers); len(errs) > 0 {
+		return
+	}
+	if _, ok := constants.ACTION_TYPE[list[headers.IndexesOfHeaders[constants.FLAG]]]; !ok {
+		errs = append(errs, models.RowError{Column: constants.FLAG, Reason: "flag must be 1 (upsert) or 0 (delete)"})
+	}
+	for _, field := range constants.MANDATORY_FIELDS[fileType] {
+		if strings.TrimSpace(list[headers.IndexesOfHeaders[field]]) == "" {
+			errs = append(errs, models.RowError{Column: field, Reason: "mandatory value is missing"})
+		}
+	}
+	for _, field := range constants.INTEGER_FIELDS[fileType] {
+		val := list[headers.IndexesOfHeaders[field]]
+		if _, err := strconv.Atoi(val); val != "" && err != nil {
+			errs = append(errs, models.RowError{Column: field, Reason: fmt.Sprintf("%q is not an integer", val)})
+		}
+	}
+	for _, field := range constants.FLOAT_FIELDS[fileType] {
+		val := list[headers.IndexesOfHeaders[field]]
+		if _, err := strconv.ParseFloat(val, 64); val != "" && err != nil {
+			errs = append(errs, models.RowError{Column: field, Reason: fmt.Sprintf("%q is not a number", val)})
+		}
+	}
+	return
+}
+
+func validateCsvFile(report *models.ValidationReport, expectedHeaders []string) {
+	r, err := newRecordReader(report.FileName)
+	if err != nil {
+		log.Println("Failed to open the file , err :", err)
+		addFileError(report, 0, "", errObj.GetError("MissingFileName"))
+		return
+	}
+	defer r.Close()
+	if !r.Scan() {
+		addFileError(report, 1, "", errObj.GetError("InvalidCsvFile"))
+		return
+	}
+	firstRow := strings.Join(r.Row(), constants.DELIMETER)
+	headers, err := getIndexOfHeaders(firstRow, expectedHeaders)
+	if err != nil {
+		missing := missingHeaders(firstRow, expectedHeaders)
+		if len(missing) == 0 {
+			addFileError(report, 1, "", err)
+		}
+		for _, header := range missing {
+			addFileError(report, 1, header, err)
+		}
+		return
+	}
+	line := int32(1)
+	for r.Scan() {
+		line++
+		report.TotalCount++
+		errs := validateRow(report.FileType, r.Row(), headers)
+		if len(errs) == 0 {
+			continue
+		}
+		report.InvalidCount++
+		for _, e := range errs {
+			e.Line = line
+			addRowError(report, e)
+		}
+	}
+	if err := r.Err(); err != nil {
+		addFileError(report, line+1, "", errObj.GetError("InvalidCsvFile"))
+	}
+}
+
+func validateEquipmentFile(report *models.ValidationReport) {
+	r, err := newRecordReader(report.FileName)
+	if err != nil {
+		log.Println("Failed to open the file , err :", err)
+		addFileError(report, 0, "", errObj.GetError("MissingFileName"))
+		return
+	}
+	defer r.Close()
+	if !r.Scan() || strings.TrimSpace(strings.Join(r.Row(), "")) == "" {
+		addFileError(report, 1, "", errObj.GetError("HeadersMissing"))
+		return
+	}
+	hlen := len(r.Row())
+	line := int32(1)
+	for r.Scan() {
+		line++
+		report.TotalCount++
+		if list := r.Row(); len(list) < hlen {
+			report.InvalidCount++
+			addRowError(report, models.RowError{Line: line, Reason: fmt.Sprintf("expected %d columns, found %d", hlen, len(list))})
+		}
+	}
+	if err := r.Err(); err != nil {
+		addFileError(report, line+1, "", errObj.GetError("InvalidCsvFile"))
+	}
+}
+
+func validateSchemaFile(report *models.ValidationReport) {
+	data, err := csvFileToSchemaData(report.FileName, "")
+	if err != nil {
+		addFileError(report, 0, "", errObj.GetError("MissingFileName"))
+		return
+	}
+	report.TotalCount = data.TotalCount
+	if len(data.Schema) == 0 || strings.TrimSpace(strings.Join(data.Schema, "")) == "" {
+		addFileError(report, 1, "", errObj.GetError("HeadersMissing"))
+	}
+}
+
+//missingHeaders gives the expected headers which are not found in first line of file
+func missingHeaders(firstRow string, expectedHeaders []string) (missing []string) {
+	actual := make(map[string]bool)
+	for _, val := range strings.Split(strings.ToLower(firstRow), constants.DELIMETER) {
+		actual[val] = true
+	}
+	for _, val := range expectedHeaders {
+		if !actual[strings.ToLower(val)] {
+			missing = append(missing, strings.ToLower(val))
+		}
+	}
+	return
+}
+
+//invalidRecord keeps a rejected line with all the reasons of its rejection
+func invalidRecord(line int32, row string, errs []models.RowError) models.InvalidRecord {
+	reasons := make([]string, len(errs))
+	for i, e := range errs {
+		reasons[i] = e.Reason
+		if e.Column != "" {
+			reasons[i] = fmt.Sprintf("%s: %s", e.Column, e.Reason)
+		}
+	}
+	return models.InvalidRecord{Line: line, Row: row, Reason: strings.Join(reasons, "; ")}
+}
+
+func addFileError(report *models.ValidationReport, line int32, column string, err error) {
+	reason := err.Error()
+	if cErr, ok := err.(*errObj.CustomError); ok {
+		reason = cErr.Message
+	}
+	report.Errors = append(report.Errors, models.RowError{Line: line, Column: column, Reason: reason})
+}
+
+func addRowError(report *models.ValidationReport, rowErr models.RowError) {
+	if len(report.Errors) >= constants.MAX_ROW_ERRORS {
+		return
+	}
+	report.Errors = append(report.Errors, rowErr)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package fileworker
+
+import (
+	"optisam-backend/dps-service/pkg/worker/constants"
+	"optisam-backend/dps-service/pkg/worker/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//headersOf gives the headers of a file of fileType having its expected headers in order
+func headersOf(t *testing.T, fileType string) models.HeadersInfo {
+	expected, err := getHeadersForFileType(fileType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers, err := getIndexOfHeaders(strings.Join(expected, constants.DELIMETER), expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return headers
+}
+
+func Test_validateRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType string
+		row      string
+		want     []models.RowError
+	}{
+		{name: "valid product",
+			fileType: constants.PRODUCTS,
+			row:      "p1;1.0;db;oracle;;Oracle DB;1",
+		},
+		{name: "missing columns",
+			fileType: constants.PRODUCTS,
+			row:      "p1;1.0",
+			want:     []models.RowError{{Reason: "expected at least 7 columns, found 2"}},
+		},
+		{name: "unknown flag",
+			fileType: constants.PRODUCTS,
+			row:      "p1;1.0;db;oracle;;Oracle DB;2",
+			want:     []models.RowError{{Column: constants.FLAG, Reason: "flag must be 1 (upsert) or 0 (delete)"}},
+		},
+		{name: "missing mandatory values",
+			fileType: constants.APPLICATIONS_PRODUCTS,
+			row:      " ;;0",
+			want: []models.RowError{
+				{Column: constants.APP_ID, Reason: "mandatory value is missing"},
+				{Column: constants.SWIDTAG, Reason: "mandatory value is missing"},
+			},
+		},
+		{name: "integer is not a number",
+			fileType: constants.PRODUCTS_EQUIPMENTS,
+			row:      "e1;p1;ten;1",
+			want:     []models.RowError{{Column: constants.NBUSERS, Reason: `"ten" is not an integer`}},
+		},
+		{name: "empty integer",
+			fileType: constants.PRODUCTS_EQUIPMENTS,
+			row:      "e1;p1;;1",
+		},
+		{name: "float is not a number",
+			fileType: constants.PRODUCTS_ACQUIREDRIGHTS,
+			row:      "e;sku1;p1;Oracle DB;oracle;ops;10;5;1000;100;free;10;1100;1",
+			want:     []models.RowError{{Column: constants.AVG_UNIT_PRICE, Reason: `"free" is not a number`}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := validateRow(test.fileType, strings.Split(test.row, constants.DELIMETER), headersOf(t, test.fileType))
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func Test_columnsError(t *testing.T) {
+	headers := headersOf(t, constants.PRODUCTS)
+	//values of a line are checked by validateRow once it has all the columns
+	assert.Empty(t, columnsError(strings.Split("p1;1.0;db;oracle;;Oracle DB;2", constants.DELIMETER), headers))
+	assert.Equal(t, []models.RowError{{Reason: "expected at least 7 columns, found 1"}}, columnsError([]string{"p1"}, headers))
+}
+
+func Test_csvToFileData_invalidRows(t *testing.T) {
+	//lines rejected by a dry run are rejected when their file is processed
+	writeFile(t, "s1_products_equipments.csv", []byte("IdEquipment;swidtag;nbusers;flag\ne1;p1;10;1\ne2;p1;ten;1\ne3;;1;1\ne4;p2;1;2\n"))
+	defer os.Remove(filepath.Join(filesDir, "s1_products_equipments.csv"))
+	expected, _ := getHeadersForFileType(constants.PRODUCTS_EQUIPMENTS)
+	var got models.FileData
+	err := csvToFileData(constants.PRODUCTS_EQUIPMENTS, "s1_products_equipments.csv", expected, nil, func(data models.FileData) error {
+		got = data
+		return nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int32(4), got.TotalCount)
+	assert.Equal(t, int32(3), got.InvalidCount)
+	assert.Equal(t, []models.InvalidRecord{
+		{Line: 3, Row: "e2;p1;ten;1", Reason: `nbusers: "ten" is not an integer`},
+		{Line: 4, Row: "e3;;1;1", Reason: "swidtag: mandatory value is missing"},
+		{Line: 5, Row: "e4;p2;1;2", Reason: "flag: flag must be 1 (upsert) or 0 (delete)"},
+	}, got.InvalidRecords)
+	assert.Equal(t, map[string][]models.ProdEquipemtInfo{"p1": {{EquipID: "e1", NbUsers: "10"}}}, got.ProdEquipments[constants.UPSERT])
+}
+
+func TestValidateFile(t *testing.T) {
+	writeFile(t, "s1_products.csv", []byte("swidtag;version;category;editor;isoptionof;name;flag\np1;1.0;db;oracle;;Oracle DB;1\np2;1.0\n;1.0;db;oracle;;Oracle DB;3\n"))
+	writeFile(t, "s1_applications.csv", []byte("idapplication;name;flag\n"))
+	writeFile(t, "s1_equipment_server.csv", []byte("id;name;cpu\ns1;server 1;4\ns2\n"))
+	tests := []struct {
+		name     string
+		scope    string
+		fileName string
+		want     models.ValidationReport
+	}{
+		{name: "rows of csv file",
+			scope:    "s1",
+			fileName: "s1_products.csv",
+			want: models.ValidationReport{
+				FileName:     "s1_products.csv",
+				FileType:     constants.PRODUCTS,
+				TotalCount:   3,
+				InvalidCount: 2,
+				Errors: []models.RowError{
+					{Line: 3, Reason: "expected at least 7 columns, found 2"},
+					{Line: 4, Column: constants.FLAG, Reason: "flag must be 1 (upsert) or 0 (delete)"},
+					{Line: 4, Column: constants.SWIDTAG, Reason: "mandatory value is missing"},
+				},
+			},
+		},
+		{name: "missing headers",
+			scope:    "s1",
+			fileName: "s1_applications.csv",
+			want: models.ValidationReport{
+				FileName: "s1_applications.csv",
+				FileType: constants.APPLICATIONS,
+				Errors: []models.RowError{
+					{Line: 1, Column: "version", Reason: "defined headers are not found in csv, wrong file "},
+					{Line: 1, Column: "owner", Reason: "defined headers are not found in csv, wrong file "},
+				},
+			},
+		},
+		{name: "equipment file",
+			scope:    "s1",
+			fileName: "s1_equipment_server.csv",
+			want: models.ValidationReport{
+				FileName:     "s1_equipment_server.csv",
+				FileType:     "EQUIPMENT_SERVER",
+				TotalCount:   2,
+				InvalidCount: 1,
+				Errors:       []models.RowError{{Line: 3, Reason: "expected 3 columns, found 1"}},
+			},
+		},
+		{name: "file of another scope",
+			scope:    "s2",
+			fileName: "s1_products.csv",
+			want: models.ValidationReport{
+				FileName: "s1_products.csv",
+				Errors:   []models.RowError{{Reason: "File name is not as exxpected, required scope_filename.csv"}},
+			},
+		},
+		{name: "file out of files location",
+			scope:    "s1",
+			fileName: "../s1_products.csv",
+			want: models.ValidationReport{
+				FileName: "../s1_products.csv",
+				Errors:   []models.RowError{{Reason: "File name is not as exxpected, required scope_filename.csv"}},
+			},
+		},
+		{name: "missing file",
+			scope:    "s1",
+			fileName: "s1_instances_products.csv",
+			want: models.ValidationReport{
+				FileName: "s1_instances_products.csv",
+				FileType: constants.INSTANCES_PRODUCTS,
+				Errors:   []models.RowError{{Reason: "File name not found"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ValidateFile(test.scope, test.fileName))
+		})
+	}
+}
@@ -8,11 +8,14 @@ package fileworker
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log"
 	"optisam-backend/common/optisam/workerqueue"
 	"optisam-backend/common/optisam/workerqueue/job"
+	errObj "optisam-backend/dps-service/pkg/error"
 	gendb "optisam-backend/dps-service/pkg/repository/v1/postgres/db"
 	"optisam-backend/dps-service/pkg/worker/constants"
 	"optisam-backend/dps-service/pkg/worker/models"
@@ -36,17 +39,22 @@ func (w *worker) ID() string {
 }
 
 //DoWork tell the functionality of worker
-func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
+func (w *worker) DoWork(ctx context.Context, j *job.Job) (err error) {
 	//defer profile.Start(profile.MemProfile, profile.ProfilePath(".")).Stop()
 	dataFromJob := gendb.UploadedDataFile{}
-	var data models.FileData
 
-	err := json.Unmarshal(j.Data, &dataFromJob)
+	err = json.Unmarshal(j.Data, &dataFromJob)
 	if err != nil {
 		log.Println("Failed to unmarshal the file type job data , err :", err)
 		return err
 	}
-	defer archiveFile(dataFromJob.FileName, dataFromJob.UploadID)
+	defer func() {
+		//file is kept for the retries of its job, it is archived once processed or after the last attempt.
+		//File of a job interrupted by shutdown is processed again on next start.
+		if err == nil || (ctx.Err() == nil && j.RetryCount.Int32 >= w.Queue.GetRetries()) {
+			archiveFile(dataFromJob.FileName, dataFromJob.UploadID)
+		}
+	}()
 
 	dataToUpdate := gendb.UpdateFileStatusParams{
 		UploadID: dataFromJob.UploadID,
@@ -60,45 +68,142 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 		return err
 	}
 
-	data, err = fileProcessing(dataFromJob)
+	total, err := countFileRecords(dataFromJob.FileName)
 	if err != nil {
-		log.Println("Failed to process the file ", dataFromJob.FileName, " err : ", err)
-		dataToUpdate.Status = gendb.UploadStatusFAILED
-		dbErr := w.Queries.UpdateFileStatus(ctx, dataToUpdate)
-		if dbErr != nil {
-			log.Println("Failed to update the status of file ", dataFromJob.FileName, " , err :", err)
-			return dbErr
-		}
-		return err
+		log.Println("Failed to count records of file ", dataFromJob.FileName, " err : ", err)
 	}
-	log.Println(" <<<<>>>>>>>>>>>> File processed ", dataFromJob.FileName)
-
-	err = w.Queries.UpdateFileTotalRecord(ctx, gendb.UpdateFileTotalRecordParams{
+	//counters of a retried file job start over, jobs pushed by previous attempts are not pushed again
+	//and still count in pending jobs of file until api worker has done them
+	err = w.Queries.ResetFileRecords(ctx, gendb.ResetFileRecordsParams{
 		FileName:     dataFromJob.FileName,
 		UploadID:     dataFromJob.UploadID,
-		TotalRecords: data.TotalCount})
+		TotalRecords: total})
 	if err != nil {
 		log.Println("Failed to update total Records in DB for file ", dataFromJob.FileName, " err :", err)
 		return err
 	}
 
-	//log.Printf(" %s  file's complete data  from file: %+v", dataFromJob.FileName, data)
-	jobs, err := createAPITypeJobs(data)
-	lenJ := len(jobs)
-	log.Println(" <<<<>>>>>>>>>>>> Jobs created in memory ", dataFromJob.FileName, lenJ)
-	for _, job := range jobs {
-		_, err = w.Queue.PushJob(ctx, job, constants.APIWORKER)
+	//rows are fingerprinted for the delta of next file, a delta file only gives its changed rows
+	fp := &fingerprinter{ctx: ctx, q: w.Queries, scope: dataFromJob.Scope, uploadID: dataFromJob.UploadID, delta: dataFromJob.Delta}
+	//jobs are pushed batch by batch so that file is never completely in memory
+	err = fileProcessing(dataFromJob, fp, func(data models.FileData) error {
+		if w.uploadCancelled(ctx, dataFromJob.UploadID) {
+			return errObj.GetError("UploadCancelled")
+		}
+		w.saveInvalidRecords(ctx, data)
+		jobs, err := createAPITypeJobs(data)
 		if err != nil {
-			log.Println("Failed to push api type jobs  , err :", err)
-			return err
+			log.Println("Failed to create api type jobs , err :", err)
+		}
+		for i := range jobs {
+			//jobs of small files, eg: a single product update, are not left behind the jobs of a big upload
+			if total > 0 && total <= constants.SMALL_FILE_SIZE {
+				jobs[i].Priority = 1
+			}
+			//jobs already pushed for upload are not pushed again when file job is retried
+			jobs[i].IdempotencyKey = fmt.Sprintf("%d:%x", dataFromJob.UploadID, sha256.Sum256(jobs[i].Data))
+		}
+		ids, pushErr := w.Queue.PushJobs(ctx, jobs, constants.APIWORKER)
+		if pushErr != nil {
+			log.Println("Failed to push api type jobs  , err :", pushErr)
+		}
+		//files depending on this file wait for these jobs, jobs pushed by a previous attempt are waited for once
+		if dbErr := w.Queries.AddFileJobs(ctx, gendb.AddFileJobsParams{
+			UploadID: dataFromJob.UploadID,
+			FileName: dataFromJob.FileName,
+			JobIds:   ids,
+		}); dbErr != nil {
+			log.Println("Failed to update pending jobs of file ", dataFromJob.FileName, " err :", dbErr)
+		}
+		if pushErr != nil {
+			return pushErr
+		}
+		log.Println(" <<<<>>>>>>>>>>>> Jobs Pushed ", dataFromJob.FileName, len(jobs))
+		return w.Queries.UpdateFileProcessedRecord(ctx, gendb.UpdateFileProcessedRecordParams{
+			UploadID:         dataFromJob.UploadID,
+			FileName:         dataFromJob.FileName,
+			ProcessedRecords: data.TotalCount + data.UnchangedCount,
+		})
+	})
+	if fp.report.Removed > 0 {
+		//removed rows are records of file too
+		if dbErr := w.Queries.UpdateFileTotalRecord(ctx, gendb.UpdateFileTotalRecordParams{
+			FileName:     dataFromJob.FileName,
+			UploadID:     dataFromJob.UploadID,
+			TotalRecords: total + fp.report.Removed,
+		}); dbErr != nil {
+			log.Println("Failed to update total Records in DB for file ", dataFromJob.FileName, " err :", dbErr)
 		}
 	}
-	log.Println(" <<<<>>>>>>>>>>>> Jobs Pushed ", dataFromJob.FileName)
+	if err == errObj.GetError("UploadCancelled") {
+		log.Println("Upload of file ", dataFromJob.FileName, " is cancelled")
+		dataToUpdate.Status = gendb.UploadStatusCANCELLED
+		return w.Queries.UpdateFileStatus(ctx, dataToUpdate)
+	}
+	if err != nil && ctx.Err() != nil {
+		log.Println("Processing of file ", dataFromJob.FileName, " is interrupted, err : ", err)
+		return err
+	}
+	if err != nil {
+		log.Println("Failed to process the file ", dataFromJob.FileName, " err : ", err)
+		if dbErr := failFile(ctx, w.Queries, dataFromJob.UploadID, dataFromJob.FileName); dbErr != nil {
+			return dbErr
+		}
+		return err
+	}
+	if dataFromJob.Delta {
+		log.Println(" <<<<>>>>>>>>>>>> Delta of file ", dataFromJob.FileName, " added ", fp.report.Added, " changed ", fp.report.Changed, " removed ", fp.report.Removed, " unchanged ", fp.report.Unchanged)
+	}
+	log.Println(" <<<<>>>>>>>>>>>> File processed ", dataFromJob.FileName)
 	dataToUpdate.Status = gendb.UploadStatusCOMPLETED
 	err = w.Queries.UpdateFileStatus(ctx, dataToUpdate)
 	if err != nil {
 		log.Println("Failed to update status , err ", err)
 		return err
 	}
-	return nil
+	//file without any job lets its dependent files start now, otherwise its last job does it
+	SetFileApplied(ctx, w.Queries, dataFromJob.UploadID, dataFromJob.Scope, dataFromJob.FileName)
+	return QueueReadyFiles(ctx, w.Queries, w.Queue, dataFromJob.UploadID)
+}
+
+//uploadCancelled tells if upload of file is cancelled, in doubt file is processed
+func (w *worker) uploadCancelled(ctx context.Context, uploadID int32) bool {
+	upload, err := w.Queries.GetUpload(ctx, uploadID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Failed to get upload ", uploadID, " err :", err)
+		}
+		return false
+	}
+	return upload.CancelledOn.Valid
+}
+
+//saveInvalidRecords keeps the lines rejected while parsing so that they can be listed and exported,
+//a failure here doesn't stop the processing of valid lines.
+//A line is kept once when file job is retried, invalid records of file are counted from the lines kept.
+func (w *worker) saveInvalidRecords(ctx context.Context, data models.FileData) {
+	if data.InvalidCount == 0 {
+		return
+	}
+	for _, rec := range data.InvalidRecords {
+		payload, _ := json.Marshal(rec.Row)
+		err := w.Queries.InsertUploadRecordFailure(ctx, gendb.InsertUploadRecordFailureParams{
+			UploadID:    data.UploadID,
+			FileName:    data.FileName,
+			Line:        rec.Line,
+			FailureType: gendb.FailureTypeINVALID,
+			Payload:     payload,
+			Reason:      rec.Reason,
+		})
+		if err != nil {
+			log.Println("Failed to save invalid record of file ", data.FileName, " line ", rec.Line, " err :", err)
+		}
+	}
+	err := w.Queries.UpdateFileInvalidRecord(ctx, gendb.UpdateFileInvalidRecordParams{
+		UploadID: data.UploadID,
+		FileName: data.FileName,
+	})
+	if err != nil {
+		log.Println("Failed to update invalid records in DB for file ", data.FileName, " err :", err)
+	}
 }
@@ -71,9 +71,12 @@ type FileData struct {
 	AppProducts    map[string]map[string][]string
 	ProdEquipments map[string]map[string][]ProdEquipemtInfo
 	AcqRights      map[string]AcqRightsInfo
-	Schema         []string // map[type]{schema names}, eg: [cluster]{name, parent}
+	Schema         []string           // map[type]{schema names}, eg: [cluster]{name, parent}
+	Lines          map[string][]int32 // lines of file behind each record, keyed by record
+	InvalidRecords []InvalidRecord
 	TotalCount     int32
 	InvalidCount   int32
+	UnchangedCount int32    // rows of file left out of batch by delta as they are already applied
 	TargetServices []string //tells send data to how many services
 	FileType       string
 	Scope          string
@@ -93,6 +96,7 @@ type Envlope struct {
 	TargetRPC     string          //tell this action to do on which rpc
 	UploadID      int32
 	FileName      string
+	Lines         []int32 //tells lines of file carried by this data
 }
 
 type EquipmentRequest struct {
@@ -100,3 +104,46 @@ type EquipmentRequest struct {
 	EqType string          `json:"eq_type,omitempty"`
 	EqData json.RawMessage `json:"eq_data,omitempty"`
 }
+
+//RowError tells why a line of a file is rejected
+type RowError struct {
+	Line   int32
+	Column string
+	Reason string
+}
+
+//InvalidRecord is a line of file rejected while parsing
+type InvalidRecord struct {
+	Line   int32
+	Row    string
+	Reason string
+}
+
+//ValidationReport carries the dry-run result of a file
+type ValidationReport struct {
+	FileName     string
+	FileType     string
+	TotalCount   int32
+	InvalidCount int32
+	Errors       []RowError
+}
+
+//DeltaRow is a row of file which differs from the last applied file of its type
+type DeltaRow struct {
+	Line   int32 // 0 for a removed row
+	Key    string
+	Change string
+}
+
+//DeltaReport carries the difference of a file with the last applied file of same scope and type
+type DeltaReport struct {
+	FileName         string
+	FileType         string
+	PreviousUploadID int32 // 0 when no file of the type is applied yet
+	Full             bool  // delta is not supported for the type, whole file is applied
+	Added            int32
+	Changed          int32
+	Removed          int32
+	Unchanged        int32
+	Rows             []DeltaRow
+}
@@ -12,9 +12,9 @@ import (
 	"log"
 	"optisam-backend/common/optisam/config"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/common/optisam/docker"
 	"optisam-backend/common/optisam/logger"
-	"optisam-backend/equipment-service/pkg/repository/v1/dgraph/loader"
 	"os"
 	"strings"
 	"testing"
@@ -204,7 +204,7 @@ func loadEquipments(badgerDir, masterDir string, scopes []string, filenames ...s
 		log.Println("Failed to get dgclient err", err)
 		return err
 	}
-	config.Repository = NewEquipmentRepository(dg)
+	config.Repository = NewLoaderRepository(NewEquipmentRepository(dg))
 
 	return loader.Load(config)
 }
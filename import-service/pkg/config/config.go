@@ -14,6 +14,7 @@ import (
 	"optisam-backend/common/optisam/postgres"
 
 	"os"
+	"regexp"
 	"time"
 
 	"optisam-backend/common/optisam/prometheus"
@@ -59,6 +60,30 @@ type UploadConfig struct {
 	UploadDir                string `toml:"upload_dir"`
 	DataFileAllowedRegex     []string
 	MetaDatafileAllowedRegex []string
+	// MaxArchiveSize is the maximum size in bytes of the content of a zip archive once unpacked
+	MaxArchiveSize int64
+	// MaxArchiveFiles is the maximum number of data files in a zip archive
+	MaxArchiveFiles int
+	// Watch are the directories whose new files are uploaded on schedule
+	Watch []WatchConfig
+}
+
+// WatchConfig is a directory where inventory files are dropped by other tools
+type WatchConfig struct {
+	// Path is the watched directory
+	Path string
+	// Scope of the data of files
+	Scope string
+	// FilePattern is the regex the name of a file must match to be picked, every file is picked when empty
+	FilePattern string
+	// Schedule is either the interval between two pickups, eg: 30m, or a daily time of pickup, eg: 02:30
+	Schedule string
+	// ArchiveDir is where files are moved once uploaded, default is archive directory in Path
+	ArchiveDir string
+	// SettleTime is the time a file must be left unmodified before it is picked, default is one minute
+	SettleTime time.Duration
+	// ServiceAccount is the user recorded as uploader of files
+	ServiceAccount string
 }
 
 // InstrumentationConfig represents the instrumentation related configuration.
@@ -93,9 +118,51 @@ func (c Config) Validate() error {
 		return err
 	}
 
+	for _, w := range c.Upload.Watch {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Validate validates the configuration.
+func (w WatchConfig) Validate() error {
+	if w.Path == "" {
+		return errors.New("path of watched directory is required")
+	}
+	if w.Scope == "" || w.ServiceAccount == "" {
+		return fmt.Errorf("scope and service account are required for watched directory %s", w.Path)
+	}
+	if _, err := regexp.Compile(w.FilePattern); err != nil {
+		return fmt.Errorf("invalid file pattern for watched directory %s: %v", w.Path, err)
+	}
+	if _, err := w.NextPickup(time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NextPickup gives the time of the first pickup of files after now
+func (w WatchConfig) NextPickup(now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(w.Schedule); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("schedule of watched directory %s must be positive", w.Path)
+		}
+		return now.Add(d), nil
+	}
+	at, err := time.Parse("15:04", w.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q of watched directory %s, expected an interval like 1h or a daily time like 02:30", w.Schedule, w.Path)
+	}
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
+
 // Validate validates the configuration.
 func (c InstrumentationConfig) Validate() error {
 	if c.Jaeger.Enabled {
@@ -46,6 +46,8 @@ func RunServer(ctx context.Context, config *config.Config) error {
 	// TODO add a import handler here
 	router.POST("/api/v1/import/data", h.UploadDataHandler)
 	router.POST("/api/v1/import/metadata", h.UploadMetaDataHandler)
+	// files dropped in watched directories are uploaded on schedule
+	h.WatchDirectories(ctx)
 
 	srv := &http.Server{
 		Addr: ":" + config.HTTPPort,
@@ -0,0 +1,213 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package v1
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"optisam-backend/common/optisam/helper"
+	"optisam-backend/import-service/pkg/config"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	archiveExtension = ".zip"
+	manifestFileName = "manifest.json"
+
+	defaultMaxArchiveSize  int64 = 1 << 30
+	defaultMaxArchiveFiles       = 100
+)
+
+//dataFilesOrder is the order in which files of an archive are processed when manifest does not give it,
+//files referring to other data come after the files they refer to
+var dataFilesOrder = []string{
+	"products.",
+	"applications.",
+	"products_acquiredRights.",
+	"applications_products.",
+	"applications_instances.",
+	"equipment_",
+	"instances_products.",
+	"instances_equipments.",
+	"products_equipments.",
+}
+
+//manifest describes the content of an archive, all fields are optional
+type manifest struct {
+	Scope string         `json:"scope"`
+	Files []manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	Name   string `json:"name"`
+	Sha256 string `json:"sha256"`
+}
+
+//unpackArchive extracts the data files of a zip archive in dir with the scope prefix
+//and gives their names in the order they are to be processed.
+//Nothing is left in dir if the archive is rejected.
+func unpackArchive(r io.ReaderAt, size int64, scope, dir string, cfg config.UploadConfig) (filenames []string, retErr error) {
+	maxSize, maxFiles := cfg.MaxArchiveSize, cfg.MaxArchiveFiles
+	if maxSize <= 0 {
+		maxSize = defaultMaxArchiveSize
+	}
+	if maxFiles <= 0 {
+		maxFiles = defaultMaxArchiveFiles
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %v", err)
+	}
+	var man *manifest
+	var entries []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		if strings.ContainsAny(f.Name, `/\`) || strings.Contains(f.Name, "..") {
+			return nil, fmt.Errorf("archive entry %q is not allowed, files must be at root of archive", f.Name)
+		}
+		if f.Name == manifestFileName {
+			if man, err = readManifest(f); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !helper.RegexContains(cfg.DataFileAllowedRegex, f.Name) {
+			return nil, fmt.Errorf("archive entry %q is not allowed", f.Name)
+		}
+		entries = append(entries, f)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("archive has no data file")
+	}
+	if len(entries) > maxFiles {
+		return nil, fmt.Errorf("archive has %d files, maximum is %d", len(entries), maxFiles)
+	}
+	checksums := make(map[string]string)
+	if man != nil {
+		if man.Scope != "" && man.Scope != scope {
+			return nil, fmt.Errorf("archive is for scope %s, not %s", man.Scope, scope)
+		}
+		if entries, err = manifestOrder(man, entries); err != nil {
+			return nil, err
+		}
+		for _, mf := range man.Files {
+			checksums[mf.Name] = strings.ToLower(mf.Sha256)
+		}
+	}
+	if len(checksums) == 0 {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return dataFileRank(entries[i].Name) < dataFileRank(entries[j].Name)
+		})
+	}
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, f := range filenames {
+			os.Remove(filepath.Join(dir, f))
+		}
+		filenames = nil
+	}()
+	remaining := maxSize
+	for _, f := range entries {
+		name := scope + "_" + f.Name
+		filenames = append(filenames, name)
+		written, sum, err := extractFile(f, filepath.Join(dir, name), remaining)
+		if err != nil {
+			return filenames, err
+		}
+		remaining -= written
+		if want := checksums[f.Name]; want != "" && want != sum {
+			return filenames, fmt.Errorf("checksum of %s does not match manifest", f.Name)
+		}
+	}
+	return filenames, nil
+}
+
+func readManifest(f *zip.File) (*manifest, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	man := &manifest{}
+	if err := json.NewDecoder(io.LimitReader(rc, 1<<20)).Decode(man); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %v", err)
+	}
+	return man, nil
+}
+
+//manifestOrder orders entries as listed in manifest, every data file of archive must be listed
+func manifestOrder(man *manifest, entries []*zip.File) ([]*zip.File, error) {
+	if len(man.Files) == 0 {
+		return entries, nil
+	}
+	byName := make(map[string]*zip.File, len(entries))
+	for _, f := range entries {
+		byName[f.Name] = f
+	}
+	ordered := make([]*zip.File, 0, len(entries))
+	for _, mf := range man.Files {
+		f, ok := byName[mf.Name]
+		if !ok {
+			return nil, fmt.Errorf("file %s of manifest is missing in archive", mf.Name)
+		}
+		delete(byName, mf.Name)
+		ordered = append(ordered, f)
+	}
+	if len(ordered) != len(entries) {
+		return nil, fmt.Errorf("archive has files which are not listed in manifest")
+	}
+	return ordered, nil
+}
+
+func dataFileRank(name string) int {
+	for i, prefix := range dataFilesOrder {
+		if strings.HasPrefix(name, prefix) {
+			return i
+		}
+	}
+	return len(dataFilesOrder)
+}
+
+//extractFile copies an archive entry to fn, it fails if the entry is bigger than limit
+func extractFile(f *zip.File, fn string, limit int64) (int64, string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, "", err
+	}
+	defer rc.Close()
+	out, err := os.Create(fn)
+	if err != nil {
+		return 0, "", err
+	}
+	defer out.Close()
+	h := sha256.New()
+	// size in header of entry can not be trusted, content is limited while copying
+	written, err := io.Copy(io.MultiWriter(out, h), io.LimitReader(rc, limit+1))
+	if err != nil {
+		return written, "", err
+	}
+	if written > limit {
+		return written, "", fmt.Errorf("archive content is bigger than the allowed size")
+	}
+	return written, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+//isArchive tells if an uploaded file is a zip archive
+func isArchive(filename string) bool {
+	return strings.ToLower(filepath.Ext(filename)) == archiveExtension
+}
@@ -7,9 +7,11 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/helper"
@@ -49,6 +51,9 @@ func (i *importServiceServer) UploadDataHandler(res http.ResponseWriter, req *ht
 		logger.Log.Error("No Scope for Data")
 		return
 	}
+	dryRun := req.FormValue("dry_run") == "true"
+	// only rows changed since the last applied files are processed
+	delta := req.FormValue("delta") == "true"
 	userClaims, ok := ctxmanage.RetrieveClaims(req.Context())
 	if !ok {
 		logger.Log.Error("cannot find claims in context")
@@ -70,9 +75,23 @@ func (i *importServiceServer) UploadDataHandler(res http.ResponseWriter, req *ht
 		return
 	}
 	var filenames []string
+	// files of an archive are processed one after another as one upload
+	ordered := false
 	for _, fheaders := range req.MultipartForm.File {
 		for _, hdr := range fheaders {
 			logger.Log.Info("Import File Handler", zap.String("File", hdr.Filename), zap.String("uploadedBy", uploadedBy))
+			if isArchive(hdr.Filename) {
+				files, err := i.unpackArchive(hdr, dataScope)
+				if err != nil {
+					logger.Log.Error("Validation Error-Archive Not allowed", zap.String("File", hdr.Filename), zap.Error(err))
+					http.Error(res, err.Error(), http.StatusBadRequest)
+					removeFiles(dataScope, i.config.Upload.UploadDir, dataload)
+					return
+				}
+				filenames = append(filenames, files...)
+				ordered = true
+				continue
+			}
 			if !helper.RegexContains(i.config.Upload.DataFileAllowedRegex, hdr.Filename) {
 				logger.Log.Error("Validation Error-File Not allowed", zap.String("File", hdr.Filename))
 				http.Error(res, "cannot upload Error", http.StatusInternalServerError)
@@ -120,15 +139,44 @@ func (i *importServiceServer) UploadDataHandler(res http.ResponseWriter, req *ht
 			Type:       "data",
 			Files:      filenames,
 			UploadedBy: uploadedBy,
+			DryRun:     dryRun,
+			Ordered:    ordered,
+			Delta:      delta,
 		})
 		if err != nil {
 			logger.Log.Error("DPS call failed", zap.Error(err))
 		}
 		logger.Log.Info("Incoming response", zap.Any("response", resp))
+		if dryRun {
+			// files were only validated or compared, dps will not pick them
+			for _, f := range filenames {
+				if rErr := os.Remove(filepath.Join(i.config.Upload.UploadDir, f)); rErr != nil {
+					logger.Log.Error("cannot remove", zap.Error(rErr))
+				}
+			}
+			if err != nil {
+				http.Error(res, "cannot validate files", http.StatusInternalServerError)
+				return
+			}
+			res.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(res).Encode(resp); err != nil {
+				logger.Log.Error("cannot encode validation report", zap.Error(err))
+			}
+			return
+		}
 		res.Write([]byte("Files Uploaded"))
 	}
 }
 
+func (i *importServiceServer) unpackArchive(hdr *multipart.FileHeader, scope string) ([]string, error) {
+	infile, err := hdr.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer infile.Close()
+	return unpackArchive(infile, hdr.Size, scope, i.config.Upload.UploadDir, i.config.Upload)
+}
+
 func (i *importServiceServer) UploadMetaDataHandler(res http.ResponseWriter, req *http.Request, param httprouter.Params) {
 	userClaims, ok := ctxmanage.RetrieveClaims(req.Context())
 	if !ok {
@@ -125,6 +125,92 @@ func Test_importServiceServer_UploadDataHandler(t *testing.T) {
 			},
 			code: 200,
 		},
+		{
+			name: "SUCCESS - Data JSON Lines file with correct naming",
+			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.(csv|xlsx|jsonl)$`, `^applications\.(csv|xlsx|jsonl)$`}}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data", "France", "files", []string{"testdata/applications.jsonl"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(request.Context(), &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_applications.jsonl"}, Type: "data", UploadedBy: "TestUser",
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: true}, nil)
+			},
+			cleanup: func() {
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 200,
+		},
+		{
+			name: "SUCCESS - Data dry run gives validation report",
+			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `products_equipments\.csv`, `product_application\.csv`}}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data?dry_run=true", "France", "files", []string{"testdata/products.csv"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(request.Context(), &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_products.csv"}, Type: "data", UploadedBy: "TestUser", DryRun: true,
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: false, Reports: []*v1.FileValidationReport{
+					&v1.FileValidationReport{FileName: "France_products.csv", TotalRecords: 2, InvalidRecords: 1,
+						Errors: []*v1.RowError{&v1.RowError{Line: 3, Column: "flag", Reason: "flag must be 1 (upsert) or 0 (delete)"}}},
+				}}, nil)
+			},
+			cleanup: func() {
+				if _, err := os.Stat(filepath.Join("data", "France_products.csv")); !os.IsNotExist(err) {
+					t.Errorf("Failed = validated file is not removed")
+				}
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 200,
+		},
+		{
+			name: "SUCCESS - Data delta dry run gives changes of rows",
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `products_equipments\.csv`, `product_application\.csv`}}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data?dry_run=true&delta=true", "France", "files", []string{"testdata/products.csv"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(request.Context(), &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_products.csv"}, Type: "data", UploadedBy: "TestUser", DryRun: true, Delta: true,
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: true, Deltas: []*v1.FileDelta{
+					&v1.FileDelta{FileName: "France_products.csv", FileType: "PRODUCTS", PreviousUploadId: 4, Changed: 1, Unchanged: 1,
+						Rows: []*v1.DeltaRow{&v1.DeltaRow{Line: 2, Key: "P1", Change: "CHANGED"}}},
+				}}, nil)
+			},
+			cleanup: func() {
+				if _, err := os.Stat(filepath.Join("data", "France_products.csv")); !os.IsNotExist(err) {
+					t.Errorf("Failed = compared file is not removed")
+				}
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 200,
+		},
 		{
 			name: "FAILURE - Data Multiple Files with some having incorrect correct naming",
 			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
@@ -151,6 +237,81 @@ func Test_importServiceServer_UploadDataHandler(t *testing.T) {
 			},
 			code: 500,
 		},
+		{
+			name: "SUCCESS - Data zip archive with manifest",
+			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `^applications\.csv$`}}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data", "France", "files", []string{"testdata/inventory.zip"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(request.Context(), &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_products.csv", "France_applications.csv"}, Type: "data", UploadedBy: "TestUser", Ordered: true,
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: true, UploadId: 1}, nil)
+			},
+			cleanup: func() {
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 200,
+		},
+		{
+			name: "FAILURE - Data zip archive with path traversal",
+			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `^applications\.csv$`}}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data", "France", "files", []string{"testdata/traversal.zip"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+			},
+			cleanup: func() {
+				if _, err := os.Stat("products.csv"); !os.IsNotExist(err) {
+					t.Errorf("Failed = archive entry is written outside upload dir")
+				}
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 400,
+		},
+		{
+			name: "FAILURE - Data zip archive with more files than allowed",
+			// args:   args{res: httptest.NewRecorder(), req: request, param: httprouter.Params{}},
+			fields: fields{&config.Config{Upload: config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `^applications\.csv$`}, MaxArchiveFiles: 1}}},
+			setup: func() {
+				request, err = newfileUploadRequest("/api/v1/import/data", "France", "files", []string{"testdata/inventory.zip"})
+				if err != nil {
+					logger.Log.Error("Failed creating request", zap.Error(err))
+					t.Fatal(err)
+				}
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+			},
+			cleanup: func() {
+				if files, _ := filepath.Glob(filepath.Join("data", "France_*")); len(files) != 0 {
+					t.Errorf("Failed = files of rejected archive are not removed: %v", files)
+				}
+				err = os.RemoveAll("data")
+				if err != nil {
+					fmt.Println(err)
+					t.Fatal(err)
+				}
+			},
+			code: 400,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -7,6 +7,7 @@
 package v1
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -15,4 +16,5 @@ import (
 type ImportServiceServer interface {
 	UploadDataHandler(res http.ResponseWriter, req *http.Request, param httprouter.Params)
 	UploadMetaDataHandler(res http.ResponseWriter, req *http.Request, param httprouter.Params)
+	WatchDirectories(ctx context.Context)
 }
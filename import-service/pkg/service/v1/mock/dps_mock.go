@@ -13,6 +13,7 @@ package mock
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	v1 "optisam-backend/dps-service/pkg/api/v1"
 	reflect "reflect"
@@ -41,24 +42,124 @@ func (m *MockDpsServiceClient) EXPECT() *MockDpsServiceClientMockRecorder {
 	return m.recorder
 }
 
-// ListUpload mocks base method
-func (m *MockDpsServiceClient) ListUpload(arg0 context.Context, arg1 *v1.ListUploadRequest, arg2 ...grpc.CallOption) (*v1.ListUploadResponse, error) {
+// CancelUpload mocks base method
+func (m *MockDpsServiceClient) CancelUpload(arg0 context.Context, arg1 *v1.CancelUploadRequest, arg2 ...grpc.CallOption) (*v1.CancelUploadResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "ListUpload", varargs...)
+	ret := m.ctrl.Call(m, "CancelUpload", varargs...)
+	ret0, _ := ret[0].(*v1.CancelUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUpload indicates an expected call of CancelUpload
+func (mr *MockDpsServiceClientMockRecorder) CancelUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).CancelUpload), varargs...)
+}
+
+// ExportUploadFailures mocks base method
+func (m *MockDpsServiceClient) ExportUploadFailures(arg0 context.Context, arg1 *v1.ExportUploadFailuresRequest, arg2 ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportUploadFailures", varargs...)
+	ret0, _ := ret[0].(*httpbody.HttpBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUploadFailures indicates an expected call of ExportUploadFailures
+func (mr *MockDpsServiceClientMockRecorder) ExportUploadFailures(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUploadFailures", reflect.TypeOf((*MockDpsServiceClient)(nil).ExportUploadFailures), varargs...)
+}
+
+// GetUpload mocks base method
+func (m *MockDpsServiceClient) GetUpload(arg0 context.Context, arg1 *v1.GetUploadRequest, arg2 ...grpc.CallOption) (*v1.GetUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUpload", varargs...)
+	ret0, _ := ret[0].(*v1.GetUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload
+func (mr *MockDpsServiceClientMockRecorder) GetUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).GetUpload), varargs...)
+}
+
+// ListUploadData mocks base method
+func (m *MockDpsServiceClient) ListUploadData(arg0 context.Context, arg1 *v1.ListUploadRequest, arg2 ...grpc.CallOption) (*v1.ListUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUploadData", varargs...)
+	ret0, _ := ret[0].(*v1.ListUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadData indicates an expected call of ListUploadData
+func (mr *MockDpsServiceClientMockRecorder) ListUploadData(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadData", reflect.TypeOf((*MockDpsServiceClient)(nil).ListUploadData), varargs...)
+}
+
+// ListUploadFailures mocks base method
+func (m *MockDpsServiceClient) ListUploadFailures(arg0 context.Context, arg1 *v1.ListUploadFailuresRequest, arg2 ...grpc.CallOption) (*v1.ListUploadFailuresResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUploadFailures", varargs...)
+	ret0, _ := ret[0].(*v1.ListUploadFailuresResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadFailures indicates an expected call of ListUploadFailures
+func (mr *MockDpsServiceClientMockRecorder) ListUploadFailures(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadFailures", reflect.TypeOf((*MockDpsServiceClient)(nil).ListUploadFailures), varargs...)
+}
+
+// ListUploadMetaData mocks base method
+func (m *MockDpsServiceClient) ListUploadMetaData(arg0 context.Context, arg1 *v1.ListUploadRequest, arg2 ...grpc.CallOption) (*v1.ListUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUploadMetaData", varargs...)
 	ret0, _ := ret[0].(*v1.ListUploadResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUpload indicates an expected call of ListUpload
-func (mr *MockDpsServiceClientMockRecorder) ListUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+// ListUploadMetaData indicates an expected call of ListUploadMetaData
+func (mr *MockDpsServiceClientMockRecorder) ListUploadMetaData(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).ListUpload), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadMetaData", reflect.TypeOf((*MockDpsServiceClient)(nil).ListUploadMetaData), varargs...)
 }
 
 // NotifyUpload mocks base method
@@ -80,3 +181,43 @@ func (mr *MockDpsServiceClientMockRecorder) NotifyUpload(arg0, arg1 interface{},
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).NotifyUpload), varargs...)
 }
+
+// ReprocessUpload mocks base method
+func (m *MockDpsServiceClient) ReprocessUpload(arg0 context.Context, arg1 *v1.ReprocessUploadRequest, arg2 ...grpc.CallOption) (*v1.ReprocessUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReprocessUpload", varargs...)
+	ret0, _ := ret[0].(*v1.ReprocessUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReprocessUpload indicates an expected call of ReprocessUpload
+func (mr *MockDpsServiceClientMockRecorder) ReprocessUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReprocessUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).ReprocessUpload), varargs...)
+}
+
+// ValidateUpload mocks base method
+func (m *MockDpsServiceClient) ValidateUpload(arg0 context.Context, arg1 *v1.ValidateUploadRequest, arg2 ...grpc.CallOption) (*v1.ValidateUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateUpload", varargs...)
+	ret0, _ := ret[0].(*v1.ValidateUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateUpload indicates an expected call of ValidateUpload
+func (mr *MockDpsServiceClientMockRecorder) ValidateUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpload", reflect.TypeOf((*MockDpsServiceClient)(nil).ValidateUpload), varargs...)
+}
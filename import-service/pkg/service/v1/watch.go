@@ -0,0 +1,197 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"optisam-backend/common/optisam/helper"
+	"optisam-backend/common/optisam/logger"
+	v1 "optisam-backend/dps-service/pkg/api/v1"
+	"optisam-backend/import-service/pkg/config"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSettleTime = time.Minute
+	watchArchiveDir   = "archive"
+)
+
+//WatchDirectories starts the scheduled pickup of the watched directories of config, it does not wait for them
+func (i *importServiceServer) WatchDirectories(ctx context.Context) {
+	for _, wc := range i.config.Upload.Watch {
+		go i.watch(ctx, wc)
+	}
+}
+
+func (i *importServiceServer) watch(ctx context.Context, wc config.WatchConfig) {
+	logger.Log.Info("Watching directory", zap.String("path", wc.Path), zap.String("scope", wc.Scope), zap.String("schedule", wc.Schedule))
+	for {
+		next, err := wc.NextPickup(time.Now())
+		if err != nil {
+			logger.Log.Error("Cannot schedule pickup of directory", zap.String("path", wc.Path), zap.Error(err))
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if err := i.pickup(ctx, wc); err != nil {
+			logger.Log.Error("Pickup of directory failed", zap.String("path", wc.Path), zap.Error(err))
+		}
+	}
+}
+
+//pickup uploads the files of a watched directory as one upload of the service account.
+//Files are moved to the archive directory once dps has them, they are left in place to be picked again otherwise.
+func (i *importServiceServer) pickup(ctx context.Context, wc config.WatchConfig) (retErr error) {
+	pattern, err := regexp.Compile(wc.FilePattern)
+	if err != nil {
+		return err
+	}
+	settle := wc.SettleTime
+	if settle <= 0 {
+		settle = defaultSettleTime
+	}
+	entries, err := ioutil.ReadDir(wc.Path)
+	if err != nil {
+		return err
+	}
+	uploadDir := i.config.Upload.UploadDir
+	if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
+		return err
+	}
+	var picked, filenames []string
+	// files of an archive are processed one after another as one upload
+	ordered := false
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, f := range filenames {
+			if err := os.Remove(filepath.Join(uploadDir, f)); err != nil {
+				logger.Log.Error("cannot remove", zap.String("file", f), zap.Error(err))
+			}
+		}
+	}()
+	for _, fi := range entries {
+		if fi.IsDir() || !pattern.MatchString(fi.Name()) {
+			continue
+		}
+		// file may still be written by the tool dropping it
+		if time.Since(fi.ModTime()) < settle {
+			logger.Log.Info("File is not settled, it is left for next pickup", zap.String("file", fi.Name()))
+			continue
+		}
+		src := filepath.Join(wc.Path, fi.Name())
+		if isArchive(fi.Name()) {
+			files, err := i.unpackArchiveFile(src, wc.Scope)
+			if err != nil {
+				logger.Log.Error("Archive is not allowed, it is left in place", zap.String("file", src), zap.Error(err))
+				continue
+			}
+			filenames = append(filenames, files...)
+			picked = append(picked, fi.Name())
+			ordered = true
+			continue
+		}
+		if !helper.RegexContains(i.config.Upload.DataFileAllowedRegex, fi.Name()) {
+			logger.Log.Error("File is not allowed, it is left in place", zap.String("file", src))
+			continue
+		}
+		name := wc.Scope + "_" + fi.Name()
+		if err := copyFile(src, filepath.Join(uploadDir, name)); err != nil {
+			return err
+		}
+		filenames = append(filenames, name)
+		picked = append(picked, fi.Name())
+	}
+	if len(filenames) == 0 {
+		return nil
+	}
+	// files are archived once uploaded so that they are not picked again, they are not uploaded when they can not be
+	archiveDir := wc.ArchiveDir
+	if archiveDir == "" {
+		archiveDir = filepath.Join(wc.Path, watchArchiveDir)
+	}
+	if err := checkWritable(archiveDir); err != nil {
+		return fmt.Errorf("archive directory %s is not writable, files are left in place: %v", archiveDir, err)
+	}
+	logger.Log.Info("Sending picked files to dps", zap.String("scope", wc.Scope), zap.Strings("files", filenames), zap.String("uploadedBy", wc.ServiceAccount))
+	resp, err := i.dpsClient.NotifyUpload(ctx, &v1.NotifyUploadRequest{
+		Scope:      wc.Scope,
+		Type:       string(dataload),
+		Files:      filenames,
+		UploadedBy: wc.ServiceAccount,
+		Ordered:    ordered,
+	})
+	if err != nil {
+		return err
+	}
+	for _, f := range picked {
+		dst := filepath.Join(archiveDir, fmt.Sprintf("%d_%s", resp.GetUploadId(), f))
+		if err := os.Rename(filepath.Join(wc.Path, f), dst); err != nil {
+			logger.Log.Error("Cannot archive uploaded file, it is left in place", zap.String("file", f), zap.Int32("uploadID", resp.GetUploadId()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+//checkWritable creates dir when it does not exist and checks a file can be created in it
+func checkWritable(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".pickup")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+func (i *importServiceServer) unpackArchiveFile(file, scope string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return unpackArchive(f, fi.Size(), scope, i.config.Upload.UploadDir, i.config.Upload)
+}
+
+//copyFile copies src to dst, watched directory may be on another volume than upload directory
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
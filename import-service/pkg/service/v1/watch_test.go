@@ -0,0 +1,177 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	v1 "optisam-backend/dps-service/pkg/api/v1"
+	"optisam-backend/import-service/pkg/config"
+	"optisam-backend/import-service/pkg/service/v1/mock"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_importServiceServer_pickup(t *testing.T) {
+	var dpsClient v1.DpsServiceClient
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := context.Background()
+	upload := config.UploadConfig{UploadDir: "data", DataFileAllowedRegex: []string{`^products\.csv$`, `^applications\.csv$`}}
+	watch := config.WatchConfig{Path: "watched", Scope: "France", FilePattern: `\.(csv|zip)$`, Schedule: "1h", ArchiveDir: "archived", SettleTime: time.Millisecond, ServiceAccount: "discovery@test.com"}
+	dropFile := func(name string) {
+		if err := os.MkdirAll("watched", os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join("watched", name), []byte("swidtag;flag\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removeDirs := func() {
+		for _, dir := range []string{"watched", "archived", "data"} {
+			if err := os.RemoveAll(dir); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	tests := []struct {
+		name    string
+		watch   config.WatchConfig
+		setup   func()
+		check   func()
+		wantErr bool
+	}{
+		{
+			name:  "SUCCESS - picked files are uploaded and archived",
+			watch: watch,
+			setup: func() {
+				dropFile("products.csv")
+				dropFile("notes.txt")
+				time.Sleep(5 * time.Millisecond)
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(ctx, &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_products.csv"}, Type: "data", UploadedBy: "discovery@test.com",
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: true, UploadId: 7}, nil)
+			},
+			check: func() {
+				if _, err := os.Stat(filepath.Join("data", "France_products.csv")); err != nil {
+					t.Errorf("Failed = file is not in upload directory: %v", err)
+				}
+				if _, err := os.Stat(filepath.Join("archived", "7_products.csv")); err != nil {
+					t.Errorf("Failed = file is not archived: %v", err)
+				}
+				if _, err := os.Stat(filepath.Join("watched", "notes.txt")); err != nil {
+					t.Errorf("Failed = file not matching pattern is not left in place: %v", err)
+				}
+			},
+		},
+		{
+			name:  "SUCCESS - archive is uploaded as ordered files",
+			watch: watch,
+			setup: func() {
+				if err := os.MkdirAll("watched", os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+				b, err := ioutil.ReadFile(filepath.Join("testdata", "inventory.zip"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(filepath.Join("watched", "inventory.zip"), b, 0644); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(5 * time.Millisecond)
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(ctx, &v1.NotifyUploadRequest{
+					Scope: "France", Files: []string{"France_products.csv", "France_applications.csv"}, Type: "data", UploadedBy: "discovery@test.com", Ordered: true,
+				}).Times(1).Return(&v1.NotifyUploadResponse{Success: true, UploadId: 8}, nil)
+			},
+			check: func() {
+				if _, err := os.Stat(filepath.Join("archived", "8_inventory.zip")); err != nil {
+					t.Errorf("Failed = archive is not archived: %v", err)
+				}
+			},
+		},
+		{
+			name:  "SUCCESS - file not settled is left for next pickup",
+			watch: config.WatchConfig{Path: "watched", Scope: "France", Schedule: "1h", SettleTime: time.Hour, ServiceAccount: "discovery@test.com"},
+			setup: func() {
+				dropFile("products.csv")
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+			},
+			check: func() {
+				if _, err := os.Stat(filepath.Join("watched", "products.csv")); err != nil {
+					t.Errorf("Failed = file is not left in place: %v", err)
+				}
+			},
+		},
+		{
+			name:  "FAILURE - files are not uploaded when archive directory is not writable",
+			watch: config.WatchConfig{Path: "watched", Scope: "France", FilePattern: `\.csv$`, Schedule: "1h", ArchiveDir: filepath.Join("watched", "notes.txt"), SettleTime: time.Millisecond, ServiceAccount: "discovery@test.com"},
+			setup: func() {
+				dropFile("products.csv")
+				dropFile("notes.txt")
+				time.Sleep(5 * time.Millisecond)
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+			},
+			check: func() {
+				if files, _ := filepath.Glob(filepath.Join("data", "France_*")); len(files) != 0 {
+					t.Errorf("Failed = files of failed pickup are not removed from upload directory: %v", files)
+				}
+				if _, err := os.Stat(filepath.Join("watched", "products.csv")); err != nil {
+					t.Errorf("Failed = file is not left in place: %v", err)
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name:  "FAILURE - files are kept when dps can not be notified",
+			watch: watch,
+			setup: func() {
+				dropFile("products.csv")
+				dropFile("applications.csv")
+				time.Sleep(5 * time.Millisecond)
+				mockDPSClient := mock.NewMockDpsServiceClient(mockCtrl)
+				dpsClient = mockDPSClient
+				mockDPSClient.EXPECT().NotifyUpload(ctx, gomock.Any()).Times(1).Return(nil, errors.New("dps unavailable"))
+			},
+			check: func() {
+				if files, _ := filepath.Glob(filepath.Join("data", "France_*")); len(files) != 0 {
+					t.Errorf("Failed = files of failed pickup are not removed from upload directory: %v", files)
+				}
+				for _, f := range []string{"products.csv", "applications.csv"} {
+					if _, err := os.Stat(filepath.Join("watched", f)); err != nil {
+						t.Errorf("Failed = file is not left in place: %v", err)
+					}
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer removeDirs()
+			tt.setup()
+			i := &importServiceServer{
+				config:    &config.Config{Upload: upload},
+				dpsClient: dpsClient,
+			}
+			if err := i.pickup(ctx, tt.watch); (err != nil) != tt.wantErr {
+				t.Errorf("importServiceServer.pickup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.check()
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"optisam-backend/common/optisam/buildinfo"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/migrate"
 	"optisam-backend/common/optisam/healthcheck"
 	"optisam-backend/common/optisam/iam"
 	"optisam-backend/common/optisam/jaeger"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/InVisionApp/go-health"
 	"github.com/InVisionApp/go-health/checkers"
+	"github.com/gobuffalo/packr/v2"
 	"go.uber.org/zap"
 
 	//postgres library
@@ -111,6 +113,19 @@ func RunServer() error {
 	}
 	fmt.Println("Dgraph connection verified to", cfg.Dgraph.Hosts)
 
+	// Run Dgraph Migration
+	if cfg.MigrateDgraph {
+		migrations, err := migrate.Load(packr.New("dgraph migrations", "./../../pkg/repository/v1/dgraph/schema/migrations"))
+		if err != nil {
+			return fmt.Errorf("failed to load dgraph migrations: %v", err)
+		}
+		n, err := migrate.Up(ctx, dg, migrations)
+		log.Printf("Applied %d dgraph migrations!\n", n)
+		if err != nil {
+			return fmt.Errorf("failed to apply dgraph migrations: %v", err)
+		}
+	}
+
 	// Register http health check
 	{
 		check, err := checkers.NewHTTP(&checkers.HTTPConfig{URL: &url.URL{Scheme: "http", Host: "localhost:8080"}})
@@ -43,6 +43,10 @@ type Config struct {
 	// Dgraph connection information
 	Dgraph *dgraph.Config
 
+	// Applies the dgraph schema migrations at startup, to be enabled on a single replica
+	// as migrations can not be applied concurrently, otherwise they are applied by dataloader migrate
+	MigrateDgraph bool
+
 	// Log configuration
 	Log logger.Config
 
@@ -160,6 +164,7 @@ func Configure(v *viper.Viper, p *pflag.FlagSet) {
 
 	// Dgraph configuration
 	_ = v.BindEnv("dgraph.host")
+	v.SetDefault("migrateDgraph", false)
 
 	// App Params Configuration
 
@@ -12,9 +12,9 @@ import (
 	"log"
 	"optisam-backend/common/optisam/config"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/common/optisam/docker"
 	"optisam-backend/common/optisam/logger"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"os"
 	"strings"
 	"testing"
@@ -205,7 +205,7 @@ func loadEquipments(badgerDir, masterDir string, scopes []string, filenames ...s
 	config.Scopes = scopes
 	config.LoadEquipments = true
 	config.IgnoreNew = true
-	config.Repository = NewLicenseRepository(dgClient)
+	config.Repository = NewLoaderRepository(NewLicenseRepository(dgClient))
 	return loader.Load(config)
 }
 
@@ -10,10 +10,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	optisam_dg "optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/common/optisam/files"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"path/filepath"
 	"strings"
 
@@ -80,7 +80,7 @@ func loadEquipemnts() error {
 		return err
 	}
 
-	config.Repository = dgraph.NewLicenseRepository(dgClient)
+	config.Repository = dgraph.NewLoaderRepository(dgraph.NewLicenseRepository(dgClient))
 	return loader.Load(config)
 }
 
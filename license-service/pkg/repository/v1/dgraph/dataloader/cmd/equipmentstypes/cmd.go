@@ -9,9 +9,9 @@ package equipmentstypes
 import (
 	"fmt"
 	optisam_dg "optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -50,5 +50,5 @@ func loadEquipemntsTypes() error {
 		return err
 	}
 
-	return loader.LoadDefaultEquipmentTypes(dgraph.NewLicenseRepository(dgClient))
+	return loader.LoadDefaultEquipmentTypes(dgraph.NewLoaderRepository(dgraph.NewLicenseRepository(dgClient)))
 }
@@ -9,8 +9,8 @@ package metadata
 import (
 	"fmt"
 	"io/ioutil"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"path/filepath"
 	"strings"
 
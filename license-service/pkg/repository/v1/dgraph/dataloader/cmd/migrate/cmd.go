@@ -0,0 +1,72 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/migrate"
+	"optisam-backend/common/optisam/logger"
+	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// CmdMigrate informs about the command
+	CmdMigrate *config.Command
+)
+
+func init() {
+	CmdMigrate = &config.Command{
+		Cmd: &cobra.Command{
+			Use:   "migrate",
+			Short: "apply schema migrations in the dgraph",
+			Long:  `apply the schema migrations newer than the schema version of dgraph, in order`,
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				fmt.Println("loading config " + CmdMigrate.Conf.GetString("config"))
+				fmt.Println("loading migrations from " + CmdMigrate.Conf.GetString("migrations_dir"))
+				fmt.Println("connecting alpha to " + strings.Join(CmdMigrate.Conf.GetStringSlice("alpha"), ","))
+				if err := applyMigrations(); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		EnvPrefix: "MIGRATE",
+	}
+	CmdMigrate.Cmd.Flags().StringP("migrations_dir", "m", "schema/migrations", "directory where migration files are present")
+}
+
+func applyMigrations() error {
+	if err := logger.Init(-1, ""); err != nil {
+		return err
+	}
+	migrations, err := migrate.Load(migrate.Dir(CmdMigrate.Conf.GetString("migrations_dir")))
+	if err != nil {
+		return err
+	}
+	dg, err := dgraph.NewDgraphConnection(&dgraph.Config{Hosts: CmdMigrate.Conf.GetStringSlice("alpha")})
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	n, err := migrate.Up(ctx, dg, migrations)
+	fmt.Printf("applied %d migrations\n", n)
+	if err != nil {
+		return err
+	}
+	version, err := migrate.Version(ctx, dg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version is %d\n", version)
+	return nil
+}
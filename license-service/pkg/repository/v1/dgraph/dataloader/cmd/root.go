@@ -11,6 +11,7 @@ import (
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/equipments"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/equipmentstypes"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/metadata"
+	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/migrate"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/schema"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/cmd/staticdata"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
@@ -37,6 +38,7 @@ var (
 var (
 	subCommands = []*config.Command{
 		schema.CmdSchema,
+		migrate.CmdMigrate,
 		metadata.CmdMetadata,
 		staticdata.CmdStaticdata,
 		equipments.CmdEquipments,
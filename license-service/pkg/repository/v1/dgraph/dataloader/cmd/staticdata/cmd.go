@@ -8,9 +8,9 @@ package staticdata
 
 import (
 	"fmt"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/common/optisam/files"
 	"optisam-backend/license-service/pkg/repository/v1/dgraph/dataloader/config"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"strings"
 
 	"github.com/spf13/cobra"
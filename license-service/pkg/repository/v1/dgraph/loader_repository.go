@@ -0,0 +1,100 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package dgraph
+
+import (
+	"context"
+	"optisam-backend/common/optisam/dgraph/loader"
+	v1 "optisam-backend/license-service/pkg/repository/v1"
+)
+
+type loaderRepository struct {
+	r v1.License
+}
+
+// NewLoaderRepository gives the repository of equipment types used by the dgraph loader
+func NewLoaderRepository(r v1.License) loader.Repository {
+	return &loaderRepository{r: r}
+}
+
+// EquipmentTypes implements loader.Repository EquipmentTypes function
+func (l *loaderRepository) EquipmentTypes(ctx context.Context) ([]*loader.EquipmentType, error) {
+	eqTypes, err := l.r.EquipmentTypes(ctx, []string{})
+	if err != nil {
+		return nil, err
+	}
+	types := make([]*loader.EquipmentType, len(eqTypes))
+	for i, eqType := range eqTypes {
+		types[i] = &loader.EquipmentType{
+			ID:         eqType.ID,
+			Type:       eqType.Type,
+			SourceID:   eqType.SourceID,
+			SourceName: eqType.SourceName,
+			ParentID:   eqType.ParentID,
+			ParentType: eqType.ParentType,
+			Attributes: make([]*loader.Attribute, len(eqType.Attributes)),
+		}
+		for j, attr := range eqType.Attributes {
+			types[i].Attributes[j] = &loader.Attribute{
+				ID:                 attr.ID,
+				Name:               attr.Name,
+				Type:               loader.DataType(attr.Type),
+				IsIdentifier:       attr.IsIdentifier,
+				IsDisplayed:        attr.IsDisplayed,
+				IsSearchable:       attr.IsSearchable,
+				IsParentIdentifier: attr.IsParentIdentifier,
+				MappedTo:           attr.MappedTo,
+			}
+		}
+	}
+	return types, nil
+}
+
+// EquipmentMetadata implements loader.Repository EquipmentMetadata function
+func (l *loaderRepository) EquipmentMetadata(ctx context.Context) ([]*loader.Metadata, error) {
+	metas, err := l.r.MetadataAllWithType(ctx, v1.MetadataTypeEquipment, []string{})
+	if err != nil {
+		return nil, err
+	}
+	metadata := make([]*loader.Metadata, len(metas))
+	for i, m := range metas {
+		metadata[i] = &loader.Metadata{ID: m.ID, Source: m.Source}
+	}
+	return metadata, nil
+}
+
+// CreateEquipmentType implements loader.Repository CreateEquipmentType function
+func (l *loaderRepository) CreateEquipmentType(ctx context.Context, eqType *loader.EquipmentType) error {
+	repoType := &v1.EquipmentType{
+		Type:       eqType.Type,
+		SourceID:   eqType.SourceID,
+		SourceName: eqType.SourceName,
+		ParentID:   eqType.ParentID,
+		ParentType: eqType.ParentType,
+		Attributes: make([]*v1.Attribute, len(eqType.Attributes)),
+	}
+	for i, attr := range eqType.Attributes {
+		repoType.Attributes[i] = &v1.Attribute{
+			Name:               attr.Name,
+			Type:               v1.DataType(attr.Type),
+			IsIdentifier:       attr.IsIdentifier,
+			IsDisplayed:        attr.IsDisplayed,
+			IsSearchable:       attr.IsSearchable,
+			IsParentIdentifier: attr.IsParentIdentifier,
+			MappedTo:           attr.MappedTo,
+		}
+	}
+	created, err := l.r.CreateEquipmentType(ctx, repoType, []string{})
+	if err != nil {
+		return err
+	}
+	eqType.ID = created.ID
+	for i, attr := range created.Attributes {
+		eqType.Attributes[i].ID = attr.ID
+	}
+	return nil
+}
@@ -9,8 +9,8 @@ package dgraph
 import (
 	"context"
 	"encoding/json"
+	"optisam-backend/common/optisam/dgraph/loader"
 	v1 "optisam-backend/license-service/pkg/repository/v1"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -715,7 +715,7 @@ func setup() (func() error, error) {
 	config.LoadMetadata = true
 	config.MasterDir = "testdata"
 	config.ScopeSkeleten = "skeletonscope"
-	repo := NewLicenseRepository(dgClient)
+	repo := NewLoaderRepository(NewLicenseRepository(dgClient))
 	config.Repository = repo
 	config.IgnoreNew = true
 	equipFiles := []string{
@@ -11,9 +11,9 @@ import (
 	"log"
 	"optisam-backend/common/optisam/config"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/loader"
 	"optisam-backend/common/optisam/docker"
 	"optisam-backend/common/optisam/logger"
-	"optisam-backend/license-service/pkg/repository/v1/dgraph/loader"
 	"os"
 	"strings"
 	"testing"
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"optisam-backend/common/optisam/buildinfo"
 	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	"optisam-backend/common/optisam/healthcheck"
 	"optisam-backend/common/optisam/iam"
 	"optisam-backend/common/optisam/jaeger"
@@ -211,6 +212,9 @@ func RunServer() error {
 	if err != nil {
 		logger.Log.Error("Failed to register server stats view")
 	}
+	if err := view.Register(workerqueue.DefaultViews...); err != nil {
+		logger.Log.Error("Failed to register worker queue stats view")
+	}
 
 	// Run Instumentation Server
 	instrumentationServer := &http.Server{
@@ -251,5 +255,6 @@ func RunServer() error {
 	go func() {
 		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, verifyKey)
 	}()
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
+	adminAPI := reconcile.NewAdminServer("products", worker.NewReconciler(rep, dg, q))
+	return grpc.RunServer(ctx, v1API, adminAPI, cfg.GRPCPort, verifyKey, authZPolicies, cfg.IAM.APIKey)
 }
@@ -11,11 +11,13 @@ import (
 	"crypto/rsa"
 	"log"
 	"net"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	mw "optisam-backend/common/optisam/middleware/grpc"
 	v1 "optisam-backend/product-service/pkg/api/v1"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/open-policy-agent/opa/rego"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -23,7 +25,7 @@ import (
 )
 
 // RunServer runs gRPC service to publish Auth service
-func RunServer(ctx context.Context, v1API v1.ProductServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
+func RunServer(ctx context.Context, v1API v1.ProductServiceServer, adminAPI rcv1.ReconcileServiceServer, port string, verifyKey *rsa.PublicKey, p *rego.PreparedEvalQuery, apiKey string) error {
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
@@ -37,10 +39,11 @@ func RunServer(ctx context.Context, v1API v1.ProductServiceServer, port string,
 	// register service
 	server := grpc.NewServer(opts...)
 	v1.RegisterProductServiceServer(server, v1API)
+	rcv1.RegisterReconcileServiceServer(server, adminAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			// sig is a ^C, handle it
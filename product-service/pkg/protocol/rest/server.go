@@ -11,6 +11,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 	"net/http/pprof"
+	rcv1 "optisam-backend/common/optisam/dgraph/reconcile/api/v1"
 	"optisam-backend/common/optisam/logger"
 	rest_middleware "optisam-backend/common/optisam/middleware/rest"
 	v1 "optisam-backend/product-service/pkg/api/v1"
@@ -74,5 +75,8 @@ func newGateway(ctx context.Context, grpcPort string) (http.Handler, error) {
 	if err := v1.RegisterProductServiceHandler(ctx, mux_gateway, conn); err != nil {
 		return nil, err
 	}
+	if err := rcv1.RegisterReconcileServiceHandler(ctx, mux_gateway, conn); err != nil {
+		return nil, err
+	}
 	return mux_gateway, err
 }
@@ -203,6 +203,21 @@ func (mr *MockProductMockRecorder) ListEditors(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEditors", reflect.TypeOf((*MockProduct)(nil).ListEditors), arg0, arg1)
 }
 
+// ListProductsOfScope mocks base method
+func (m *MockProduct) ListProductsOfScope(arg0 context.Context, arg1 string) ([]db.ListProductsOfScopeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsOfScope", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListProductsOfScopeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsOfScope indicates an expected call of ListProductsOfScope
+func (mr *MockProductMockRecorder) ListProductsOfScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsOfScope", reflect.TypeOf((*MockProduct)(nil).ListProductsOfScope), arg0, arg1)
+}
+
 // ListProductsView mocks base method
 func (m *MockProduct) ListProductsView(arg0 context.Context, arg1 db.ListProductsViewParams) ([]db.ListProductsViewRow, error) {
 	m.ctrl.T.Helper()
@@ -14,7 +14,6 @@ import (
 )
 
 //go:generate mockgen -destination=dbmock/mock.go -package=mock optisam-backend/product-service/pkg/repository/v1 Product
-//go:generate mockgen -destination=queuemock/mock.go -package=mock optisam-backend/common/optisam/workerqueue  Workerqueue
 
 
 //Interface to satisfy SQL DB and TX interface
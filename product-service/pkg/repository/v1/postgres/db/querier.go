@@ -24,6 +24,7 @@ type Querier interface {
 	ListAggregationProductsView(ctx context.Context, arg ListAggregationProductsViewParams) ([]ListAggregationProductsViewRow, error)
 	ListAggregationsView(ctx context.Context, arg ListAggregationsViewParams) ([]ListAggregationsViewRow, error)
 	ListEditors(ctx context.Context, scope []string) ([]string, error)
+	ListProductsOfScope(ctx context.Context, scope string) ([]ListProductsOfScopeRow, error)
 	ListProductsView(ctx context.Context, arg ListProductsViewParams) ([]ListProductsViewRow, error)
 	ListProductsViewRedirectedApplication(ctx context.Context, arg ListProductsViewRedirectedApplicationParams) ([]ListProductsViewRedirectedApplicationRow, error)
 	ListProductsViewRedirectedEquipment(ctx context.Context, arg ListProductsViewRedirectedEquipmentParams) ([]ListProductsViewRedirectedEquipmentRow, error)
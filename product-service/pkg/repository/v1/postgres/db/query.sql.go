@@ -461,6 +461,64 @@ func (q *Queries) ListEditors(ctx context.Context, scope []string) ([]string, er
 	return items, nil
 }
 
+const listProductsOfScope = `-- name: ListProductsOfScope :many
+SELECT p.swidtag,p.product_name,p.product_version,p.product_edition,p.product_category,p.product_editor,p.scope,p.option_of,
+ARRAY(SELECT pa.application_id FROM products_applications pa WHERE pa.swidtag = p.swidtag ORDER BY pa.application_id)::TEXT[] as applications,
+ARRAY(SELECT pe.equipment_id FROM products_equipments pe WHERE pe.swidtag = p.swidtag ORDER BY pe.equipment_id)::TEXT[] as equipments,
+ARRAY(SELECT COALESCE(pe.num_of_users,0) FROM products_equipments pe WHERE pe.swidtag = p.swidtag ORDER BY pe.equipment_id)::BIGINT[] as num_of_users
+FROM products p
+WHERE p.scope = $1
+`
+
+type ListProductsOfScopeRow struct {
+	Swidtag         string   `json:"swidtag"`
+	ProductName     string   `json:"product_name"`
+	ProductVersion  string   `json:"product_version"`
+	ProductEdition  string   `json:"product_edition"`
+	ProductCategory string   `json:"product_category"`
+	ProductEditor   string   `json:"product_editor"`
+	Scope           string   `json:"scope"`
+	OptionOf        string   `json:"option_of"`
+	Applications    []string `json:"applications"`
+	Equipments      []string `json:"equipments"`
+	NumOfUsers      []int64  `json:"num_of_users"`
+}
+
+func (q *Queries) ListProductsOfScope(ctx context.Context, scope string) ([]ListProductsOfScopeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsOfScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductsOfScopeRow
+	for rows.Next() {
+		var i ListProductsOfScopeRow
+		if err := rows.Scan(
+			&i.Swidtag,
+			&i.ProductName,
+			&i.ProductVersion,
+			&i.ProductEdition,
+			&i.ProductCategory,
+			&i.ProductEditor,
+			&i.Scope,
+			&i.OptionOf,
+			pq.Array(&i.Applications),
+			pq.Array(&i.Equipments),
+			pq.Array(&i.NumOfUsers),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductsView = `-- name: ListProductsView :many
 SELECT count(*) OVER() AS totalRecords,p.swidtag,p.product_name,p.product_version,p.product_category,p.product_editor,p.product_edition, COALESCE(pa.num_of_applications,0)::INTEGER as num_of_applications , COALESCE(pe.num_of_equipments,0)::INTEGER as num_of_equipments , sum(cost)::FLOAT as cost 
 FROM products p 
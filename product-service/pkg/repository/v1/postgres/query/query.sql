@@ -238,3 +238,11 @@ WHERE aggregation_id = $1 and aggregation_name = $2;
 -- name: DeleteProductAggregation :exec
 Update products set aggregation_id = $1, aggregation_name = $2 WHERE
 aggregation_id = $3;
+
+-- name: ListProductsOfScope :many
+SELECT p.swidtag,p.product_name,p.product_version,p.product_edition,p.product_category,p.product_editor,p.scope,p.option_of,
+ARRAY(SELECT pa.application_id FROM products_applications pa WHERE pa.swidtag = p.swidtag ORDER BY pa.application_id)::TEXT[] as applications,
+ARRAY(SELECT pe.equipment_id FROM products_equipments pe WHERE pe.swidtag = p.swidtag ORDER BY pe.equipment_id)::TEXT[] as equipments,
+ARRAY(SELECT COALESCE(pe.num_of_users,0) FROM products_equipments pe WHERE pe.swidtag = p.swidtag ORDER BY pe.equipment_id)::BIGINT[] as num_of_users
+FROM products p
+WHERE p.scope = $1;
@@ -11,10 +11,10 @@ import (
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/token/claims"
+	"optisam-backend/common/optisam/workerqueue"
 	v1 "optisam-backend/product-service/pkg/api/v1"
 	dbmock "optisam-backend/product-service/pkg/repository/v1/dbmock"
 	"optisam-backend/product-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/product-service/pkg/repository/v1/queuemock"
 	"os"
 	"testing"
 
@@ -39,7 +39,7 @@ func TestMain(m *testing.M) {
 func TestListEditors(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListEditorsRequest
@@ -99,7 +99,7 @@ func TestListEditors(t *testing.T) {
 func TestListEditorProducts(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListEditorProductsRequest
@@ -10,10 +10,10 @@ import (
 	"context"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue"
 	v1 "optisam-backend/product-service/pkg/api/v1"
 	dbmock "optisam-backend/product-service/pkg/repository/v1/dbmock"
 	"optisam-backend/product-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/product-service/pkg/repository/v1/queuemock"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -25,7 +25,7 @@ import (
 func TestListProductAggregationView(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListProductAggregationViewRequest
@@ -153,7 +153,7 @@ func TestListProductAggregationView(t *testing.T) {
 func TestProductAggregationProductViewOptions(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ProductAggregationProductViewOptionsRequest
@@ -253,7 +253,7 @@ func TestProductAggregationProductViewOptions(t *testing.T) {
 func TestListProductAggregationProductView(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListProductAggregationProductViewRequest
@@ -369,7 +369,7 @@ func TestListProductAggregationProductView(t *testing.T) {
 func TestProductAggregationProductViewDetails(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ProductAggregationProductViewDetailsRequest
@@ -64,6 +64,8 @@ func (s *productServiceServer) UpsertProduct(ctx context.Context, req *v1.Upsert
 		Type:   sql.NullString{String: "aw"},
 		Status: job.JobStatusPENDING,
 		Data:   envolveData,
+		//retried upsert of product does not push its job twice
+		IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, envolveData),
 	}, "aw")
 	if err != nil {
 		logger.Log.Error("Failed to push job to the queue", zap.Error(err))
@@ -12,11 +12,11 @@ import (
 	"encoding/json"
 	"optisam-backend/common/optisam/ctxmanage"
 	"optisam-backend/common/optisam/logger"
+	"optisam-backend/common/optisam/workerqueue"
 	"optisam-backend/common/optisam/workerqueue/job"
 	v1 "optisam-backend/product-service/pkg/api/v1"
 	dbmock "optisam-backend/product-service/pkg/repository/v1/dbmock"
 	"optisam-backend/product-service/pkg/repository/v1/postgres/db"
-	queuemock "optisam-backend/product-service/pkg/repository/v1/queuemock"
 	"optisam-backend/product-service/pkg/worker"
 	"testing"
 
@@ -24,12 +24,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestGetProductDetail(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ProductRequest
@@ -93,7 +94,7 @@ func TestGetProductDetail(t *testing.T) {
 func TestGetProductOptions(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ProductRequest
@@ -179,7 +180,7 @@ func TestGetProductOptions(t *testing.T) {
 func TestListProducts(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.ListProductsRequest
@@ -376,7 +377,7 @@ func TestListProducts(t *testing.T) {
 func TestUpsertProduct(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	var jobData []byte
 	testSet := []struct {
 		name   string
 		input  *v1.UpsertProductRequest
@@ -384,6 +385,8 @@ func TestUpsertProduct(t *testing.T) {
 		mock   func(*v1.UpsertProductRequest)
 		ctx    context.Context
 		outErr bool
+		//jobs are the jobs pushed to queue
+		jobs func() []job.Job
 	}{
 		{
 			name: "UpsertProductWithCorrectData",
@@ -418,7 +421,7 @@ func TestUpsertProduct(t *testing.T) {
 				if !ok {
 					t.Errorf("cannot find claims in context")
 				}
-				fcall := dbObj.EXPECT().UpsertProductTx(ctx, input, userClaims.UserID).Return(nil).Times(1)
+				dbObj.EXPECT().UpsertProductTx(ctx, input, userClaims.UserID).Return(nil).Times(1)
 				jsonData, err := json.Marshal(input)
 				if err != nil {
 					t.Errorf("Failed to do json marshalling")
@@ -429,10 +432,46 @@ func TestUpsertProduct(t *testing.T) {
 				if err != nil {
 					t.Error("Failed to do json marshalling")
 				}
-				qObj.EXPECT().PushJob(ctx, job.Job{
-					Type:   sql.NullString{String: "aw"},
-					Status: job.JobStatusPENDING,
-					Data:   envolveData}, "aw").Return(int32(1), nil).After(fcall)
+				jobData = envolveData
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: workerqueue.JobIdempotencyKey(ctx, jobData)}}
+			},
+		},
+		{
+			name:   "UpsertProductWithKeyOfCaller",
+			input:  &v1.UpsertProductRequest{SwidTag: "p", Name: "n", Scope: "s1"},
+			output: &v1.UpsertProductResponse{Success: true},
+			outErr: false,
+			ctx:    metadata.NewIncomingContext(ctx, metadata.Pairs(workerqueue.IdempotencyKeyHeader, "1:abc")),
+			mock: func(input *v1.UpsertProductRequest) {
+				userClaims, ok := ctxmanage.RetrieveClaims(ctx)
+				if !ok {
+					t.Errorf("cannot find claims in context")
+				}
+				dbObj.EXPECT().UpsertProductTx(gomock.Any(), input, userClaims.UserID).Return(nil).Times(1)
+				jsonData, err := json.Marshal(input)
+				if err != nil {
+					t.Errorf("Failed to do json marshalling")
+				}
+				e := worker.Envelope{Type: worker.UpsertProductRequest, Json: jsonData}
+
+				envolveData, err := json.Marshal(e)
+				if err != nil {
+					t.Error("Failed to do json marshalling")
+				}
+				jobData = envolveData
+			},
+			jobs: func() []job.Job {
+				return []job.Job{{
+					Type:           sql.NullString{String: "aw"},
+					Status:         job.JobStatusPENDING,
+					Data:           jobData,
+					IdempotencyKey: "1:abc"}}
 			},
 		},
 		{
@@ -441,12 +480,14 @@ func TestUpsertProduct(t *testing.T) {
 			outErr: true,
 			ctx:    context.Background(),
 			mock:   func(input *v1.UpsertProductRequest) {},
+			jobs:   func() []job.Job { return nil },
 		},
 	}
 	for _, test := range testSet {
 		t.Run("", func(t *testing.T) {
 			test.mock(test.input)
-			s := NewProductServiceServer(dbObj, qObj)
+			b := workerqueue.NewMemoryBackend()
+			s := NewProductServiceServer(dbObj, workerqueue.NewQueueWithBackend("test", b, workerqueue.QueueConfig{}))
 			got, err := s.UpsertProduct(test.ctx, test.input)
 			if (err != nil) != test.outErr {
 				t.Errorf("Failed case [%s]  because expected err [%v] is mismatched with actual err [%v]", test.name, test.outErr, err)
@@ -454,6 +495,9 @@ func TestUpsertProduct(t *testing.T) {
 			} else if (got != nil && test.output != nil) && !assert.Equal(t, *got, *(test.output)) {
 				t.Errorf("Failed case [%s]  because expected and actual output is mismatched, act [%v], ex[ [%v]", test.name, test.output, got)
 
+			} else if jobs := pushedJobs(t, b); !assert.Equal(t, test.jobs(), jobs) {
+				t.Errorf("Failed case [%s]  because expected and actual jobs are mismatched, act [%v], ex[ [%v]", test.name, jobs, test.jobs())
+
 			} else {
 				logger.Log.Info(" passed : ", zap.String(" test : ", test.name))
 			}
@@ -464,7 +508,7 @@ func TestUpsertProduct(t *testing.T) {
 func TestUpsertProductAggregation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	dbObj := dbmock.NewMockProduct(mockCtrl)
-	qObj := queuemock.NewMockWorkerqueue(mockCtrl)
+	qObj := workerqueue.NewQueueWithBackend("test", workerqueue.NewMemoryBackend(), workerqueue.QueueConfig{})
 	testSet := []struct {
 		name   string
 		input  *v1.UpsertAggregationRequest
@@ -559,3 +603,16 @@ func TestUpsertProductAggregation(t *testing.T) {
 		})
 	}
 }
+
+//pushedJobs gives the jobs pushed to queue, without the fields set by queue
+func pushedJobs(t *testing.T, b workerqueue.Backend) []job.Job {
+	jobs, err := b.List(context.Background(), workerqueue.ListFilter{})
+	if err != nil {
+		t.Fatalf("cannot list jobs: %v", err)
+	}
+	var pushed []job.Job
+	for _, j := range jobs {
+		pushed = append(pushed, job.Job{Type: j.Type, Status: j.Status, Data: j.Data, IdempotencyKey: j.IdempotencyKey})
+	}
+	return pushed
+}
@@ -11,13 +11,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
 	"optisam-backend/common/optisam/logger"
 	"optisam-backend/common/optisam/workerqueue/job"
 	v1 "optisam-backend/product-service/pkg/api/v1"
 	"strconv"
 
 	dgo "github.com/dgraph-io/dgo/v2"
-	"github.com/dgraph-io/dgo/v2/protos/api"
 	"go.uber.org/zap"
 )
 
@@ -30,6 +31,8 @@ type MessageType string
 
 const (
 	UpsertProductRequest MessageType = "UpsertProduct"
+	ReconcileProduct     MessageType = "ReconcileProduct"
+	DeleteNode           MessageType = "DeleteNode"
 )
 
 type Envelope struct {
@@ -52,134 +55,97 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 	_ = json.Unmarshal(j.Data, &e)
 	logger.Log.Info("Operation Type", zap.String("message_type", string(e.Type)))
 	switch e.Type {
-	case UpsertProductRequest:
+	case UpsertProductRequest, ReconcileProduct:
 		logger.Log.Info("Processing UpsertProductRequest")
 		var upr v1.UpsertProductRequest
 		_ = json.Unmarshal(e.Json, &upr)
-		var addProductApplication, addProductEquipment string
-		var muUpsertProduct, muAddProductEquipment, muAddProductApplication *api.Mutation
-		query := `query {
-			var(func: eq(product.swidtag,"` + upr.GetSwidTag() + `")) @filter(eq(type_name,"product")){
-				product as uid
+		if e.Type == ReconcileProduct {
+			//equipments of a reconciled product are the equipments of its message
+			deletion := dgraph.NewUpsert()
+			product := deletion.Find("product", "product.swidtag", upr.GetSwidTag(), "product")
+			deletion.Mutation().Delete.
+				Add(product, "product.equipment", dgraph.Star).
+				Add(product, "product.users", dgraph.Star)
+			if err := w.do(ctx, deletion); err != nil {
+				return err
 			}
-			`
-
-		addProduct := `
-			uid(product) <product.swidtag> "` + upr.GetSwidTag() + `" .
-			uid(product) <type_name> "product" .
-			uid(product) <dgraph.type> "Product" .
-			uid(product) <scopes> "` + upr.GetScope() + `" .
-			`
+		}
+		upsert := dgraph.NewUpsert()
+		product := upsert.Find("product", "product.swidtag", upr.GetSwidTag(), "product")
+		addProduct := &upsert.Mutation().Set
+		addProductApplication := &upsert.Mutation().Set
+		addProductEquipment := &upsert.Mutation().Set
+		addProduct.Add(product, "product.swidtag", dgraph.String(upr.GetSwidTag())).
+			Add(product, "type_name", dgraph.String("product")).
+			Add(product, "dgraph.type", dgraph.String("Product")).
+			Add(product, "scopes", dgraph.String(upr.GetScope()))
 		if upr.GetOptionOf() != "" {
-			query += `
-				var(func: eq(product.swidtag,"` + upr.GetOptionOf() + `")) @filter(eq(type_name,"product")){
-					child as uid
-				}`
-			addProduct += `
-				uid(child) <product.child> uid(product) .
-				`
+			child := upsert.Find("child", "product.swidtag", upr.GetOptionOf(), "product")
+			addProduct.Add(child, "product.child", product)
 		}
 		// Application Upsert
 		if len(upr.GetApplications().GetApplicationId()) > 0 {
 			updatePartialFlag = true
-			addProductApplication = `
-			uid(product) <product.swidtag> "` + upr.GetSwidTag() + `" .
-			uid(product) <type_name> "product" .
-			uid(product) <dgraph.type> "Product" .
-			`
+			addProductApplication.Add(product, "product.swidtag", dgraph.String(upr.GetSwidTag())).
+				Add(product, "type_name", dgraph.String("product")).
+				Add(product, "dgraph.type", dgraph.String("Product"))
 			if upr.GetApplications().GetOperation() == "add" {
-
 				for i, app := range upr.GetApplications().GetApplicationId() {
-					query += `
-					var(func: eq(application.id,"` + app + `")) @filter(eq(type_name,"application")){
-						application` + strconv.Itoa(i) + ` as uid
-					}
-					`
-					addProductApplication += `
-					uid(application` + strconv.Itoa(i) + `) <application.id> "` + app + `" .
-					uid(application` + strconv.Itoa(i) + `) <type_name> "application" .
-					uid(application` + strconv.Itoa(i) + `) <dgraph.type> "Application" .
-					uid(application` + strconv.Itoa(i) + `) <application.product> uid(product) .
-					`
+					application := upsert.Find("application"+strconv.Itoa(i), "application.id", app, "application")
+					addProductApplication.Add(application, "application.id", dgraph.String(app)).
+						Add(application, "type_name", dgraph.String("application")).
+						Add(application, "dgraph.type", dgraph.String("Application")).
+						Add(application, "application.product", product)
 				}
-
 			}
 		}
 		// Equipments Upsert
 		if len(upr.GetEquipments().GetEquipmentusers()) > 0 {
 			updatePartialFlag = true
-			addProductEquipment = `
-			uid(product) <product.swidtag> "` + upr.GetSwidTag() + `" .
-			uid(product) <type_name> "product" .
-			uid(product) <dgraph.type> "Product" .
-			`
+			addProductEquipment.Add(product, "product.swidtag", dgraph.String(upr.GetSwidTag())).
+				Add(product, "type_name", dgraph.String("product")).
+				Add(product, "dgraph.type", dgraph.String("Product"))
 			if upr.GetEquipments().GetOperation() == "add" {
 				for i, equipUser := range upr.GetEquipments().GetEquipmentusers() {
-					query += `
-					var(func: eq(equipment.id,"` + equipUser.GetEquipmentId() + `")) @filter(eq(type_name,"equipment")){
-						equipment` + strconv.Itoa(i) + ` as uid
-					}
-					`
-					addProductEquipment += `
-					uid(equipment` + strconv.Itoa(i) + `) <equipment.id> "` + equipUser.GetEquipmentId() + `" .
-					uid(equipment` + strconv.Itoa(i) + `) <type_name> "equipment" .
-					uid(equipment` + strconv.Itoa(i) + `) <dgraph.type> "Equipment" .
-					uid(product) <product.equipment> uid(equipment` + strconv.Itoa(i) + `) .
-					`
+					equipment := upsert.Find("equipment"+strconv.Itoa(i), "equipment.id", equipUser.GetEquipmentId(), "equipment")
+					addProductEquipment.Add(equipment, "equipment.id", dgraph.String(equipUser.GetEquipmentId())).
+						Add(equipment, "type_name", dgraph.String("equipment")).
+						Add(equipment, "dgraph.type", dgraph.String("Equipment")).
+						Add(product, "product.equipment", equipment)
 					if equipUser.GetNumUser() > 0 {
-						query += `
-						var(func: eq(users.id,user_` + upr.GetSwidTag() + equipUser.GetEquipmentId() + `)) @filter(eq(type_name,"users")){
-							users` + strconv.Itoa(i) + ` as uid
-						}
-						`
-						addProductEquipment += `
-						uid(users` + strconv.Itoa(i) + `) <users.id> "user_` + upr.GetSwidTag() + equipUser.GetEquipmentId() + `" .
-						uid(users` + strconv.Itoa(i) + `) <type_name> "instance_users" .
-						uid(users` + strconv.Itoa(i) + `) <dgraph.type> "User" .
-						uid(product) <product.users> uid(users` + strconv.Itoa(i) + `) .
-						uid(equipment` + strconv.Itoa(i) + `) <equipment.users>  uid(users` + strconv.Itoa(i) + `) .
-						uid(users` + strconv.Itoa(i) + `) <users.count> "` + strconv.Itoa(int(equipUser.GetNumUser())) + `" .
-						`
+						usersID := "user_" + upr.GetSwidTag() + equipUser.GetEquipmentId()
+						users := upsert.Find("users"+strconv.Itoa(i), "users.id", usersID, "instance_users")
+						addProductEquipment.Add(users, "users.id", dgraph.String(usersID)).
+							Add(users, "type_name", dgraph.String("instance_users")).
+							Add(users, "dgraph.type", dgraph.String("User")).
+							Add(product, "product.users", users).
+							Add(equipment, "equipment.users", users).
+							Add(users, "users.count", dgraph.Int(int64(equipUser.GetNumUser())))
 					}
 				}
-
 			}
 		}
 
-		if !updatePartialFlag {
-			query += `
-			var(func: eq(editor.name,"` + upr.GetEditor() + `")) @filter(eq(type_name,"editor")){
-				editor as uid
-			}
-			`
-			addProduct += `
-			uid(product) <product.name> "` + upr.GetName() + `" .
-			uid(product) <product.version> "` + upr.GetVersion() + `" .
-			uid(product) <product.category> "` + upr.GetCategory() + `" .
-			uid(product) <product.editor> "` + upr.GetEditor() + `" .
-			uid(editor) <editor.product> uid(product) .
-			uid(editor) <type_name> "editor" .
-			uid(editor) <dgraph.type> "Editor" .
-			uid(editor) <editor.name> "` + upr.GetEditor() + `" .
-			`
+		//a reconciled product is written whole, with its applications and equipments
+		if !updatePartialFlag || e.Type == ReconcileProduct {
+			editor := upsert.Find("editor", "editor.name", upr.GetEditor(), "editor")
+			addProduct.Add(product, "product.name", dgraph.String(upr.GetName())).
+				Add(product, "product.version", dgraph.String(upr.GetVersion())).
+				Add(product, "product.category", dgraph.String(upr.GetCategory())).
+				Add(product, "product.editor", dgraph.String(upr.GetEditor())).
+				Add(editor, "editor.product", product).
+				Add(editor, "type_name", dgraph.String("editor")).
+				Add(editor, "dgraph.type", dgraph.String("Editor")).
+				Add(editor, "editor.name", dgraph.String(upr.GetEditor()))
 		}
-		query += `
-		}`
-		logger.Log.Info("", zap.String("query", query))
-		logger.Log.Info("", zap.String("muUpsertProduct", addProduct))
-		logger.Log.Info("", zap.String("muAddProductApplication", addProductApplication))
-		logger.Log.Info("", zap.String("muAddProductEquipment", addProductEquipment))
-		muUpsertProduct = &api.Mutation{SetNquads: []byte(addProduct)}
-		muAddProductApplication = &api.Mutation{SetNquads: []byte(addProductApplication)}
-		muAddProductEquipment = &api.Mutation{SetNquads: []byte(addProductEquipment)}
-		req := &api.Request{
-			Query:     query,
-			Mutations: []*api.Mutation{muUpsertProduct, muAddProductApplication, muAddProductEquipment},
-			CommitNow: true,
+		if err := w.do(ctx, upsert); err != nil {
+			return err
 		}
-		if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
-			logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
-			return errors.New("RETRY")
+	case DeleteNode:
+		var orphan reconcile.Orphan
+		_ = json.Unmarshal(e.Json, &orphan)
+		if err := w.do(ctx, orphan.Upsert()); err != nil {
+			return err
 		}
 	default:
 		fmt.Println(e.Json)
@@ -188,3 +154,22 @@ func (w *worker) DoWork(ctx context.Context, j *job.Job) error {
 	//Everything's fine, we're done here
 	return nil
 }
+
+//do runs an upsert in Dgraph, a failed upsert is retried
+func (w *worker) do(ctx context.Context, upsert *dgraph.Upsert) error {
+	req, err := upsert.Request()
+	if err != nil {
+		//an invalid upsert fails again when retried
+		logger.Log.Error("Invalid upsert to Dgraph", zap.Error(err))
+		return job.Permanent(err)
+	}
+	logger.Log.Info("", zap.String("query", req.Query))
+	for _, mu := range req.Mutations {
+		logger.Log.Info("", zap.ByteString("mutation", mu.SetNquads))
+	}
+	if _, err := w.dg.NewTxn().Do(ctx, req); err != nil {
+		logger.Log.Error("Failed to upsert to Dgraph", zap.Error(err))
+		return errors.New("RETRY")
+	}
+	return nil
+}
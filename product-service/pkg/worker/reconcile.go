@@ -0,0 +1,264 @@
+// Copyright (C) 2019 Orange
+// 
+// This software is distributed under the terms and conditions of the 'Apache License 2.0'
+// license which can be found in the file 'License.txt' in this package distribution 
+// or at 'http://www.apache.org/licenses/LICENSE-2.0'. 
+
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"optisam-backend/common/optisam/dgraph"
+	"optisam-backend/common/optisam/dgraph/reconcile"
+	"optisam-backend/common/optisam/workerqueue"
+	"optisam-backend/common/optisam/workerqueue/job"
+	v1 "optisam-backend/product-service/pkg/api/v1"
+	repo "optisam-backend/product-service/pkg/repository/v1"
+	"optisam-backend/product-service/pkg/repository/v1/postgres/db"
+	"strconv"
+
+	dgo "github.com/dgraph-io/dgo/v2"
+)
+
+const kindProduct = "product"
+
+//predicates of product nodes written by product service, other services share product nodes
+var productPredicates = []string{
+	"product.name",
+	"product.version",
+	"product.category",
+	"product.editor",
+	"product.equipment",
+	"product.users",
+}
+
+//predicates of other nodes linking to product nodes
+var productLinks = []string{
+	"application.product",
+	"instance.product",
+	"editor.product",
+	"product_aggregation.products",
+}
+
+//Reconciler reconciles the products of dgraph with postgres.
+//Applications and parents of a product which are linked in dgraph only are reported, they are not unlinked by repairs.
+type Reconciler struct {
+	repo  repo.Product
+	dg    *dgo.Dgraph
+	queue workerqueue.Workerqueue
+}
+
+//NewReconciler gives the reconciler of products, its repairs are pushed to the aw worker of queue
+func NewReconciler(r repo.Product, dg *dgo.Dgraph, queue workerqueue.Workerqueue) *Reconciler {
+	return &Reconciler{repo: r, dg: dg, queue: queue}
+}
+
+//Postgres gives the products of scope
+func (r *Reconciler) Postgres(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	products, err := r.repo.ListProductsOfScope(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list products of scope %s: %v", scope, err)
+	}
+	records := make([]reconcile.Record, len(products))
+	for i, p := range products {
+		var users []string
+		for j, e := range p.Equipments {
+			if j < len(p.NumOfUsers) && p.NumOfUsers[j] > 0 {
+				users = append(users, usersID(p.Swidtag, e)+"="+strconv.FormatInt(p.NumOfUsers[j], 10))
+			}
+		}
+		records[i] = reconcile.Record{Kind: kindProduct, Key: p.Swidtag, Fields: map[string]string{
+			"product.name":        p.ProductName,
+			"product.version":     p.ProductVersion,
+			"product.category":    p.ProductCategory,
+			"product.editor":      p.ProductEditor,
+			"~product.child":      reconcile.Links([]string{p.OptionOf}),
+			"application.product": reconcile.Links(p.Applications),
+			"product.equipment":   reconcile.Links(p.Equipments),
+			"product.users":       reconcile.Links(users),
+		}, Data: p}
+	}
+	return records, nil
+}
+
+//Dgraph gives the products of scope in dgraph, which are the product nodes having predicates of product service
+func (r *Reconciler) Dgraph(ctx context.Context, scope string) ([]reconcile.Record, error) {
+	q := `query Reconcile($scope: string) {
+		Products(func: eq(type_name, "product")) @filter(eq(scopes, $scope) AND (has(product.name) OR has(product.equipment))) {
+			product.swidtag
+			product.name
+			product.version
+			product.category
+			product.editor
+			~product.child {
+				product.swidtag
+			}
+			~application.product {
+				application.id
+			}
+			product.equipment {
+				equipment.id
+			}
+			product.users {
+				users.id
+				users.count
+			}
+		}
+	}`
+	resp, err := r.dg.NewReadOnlyTxn().QueryWithVars(ctx, q, map[string]string{"$scope": scope})
+	if err != nil {
+		return nil, fmt.Errorf("cannot query products of scope %s: %v", scope, err)
+	}
+	var data struct {
+		Products []struct {
+			Swidtag  string `json:"product.swidtag"`
+			Name     string `json:"product.name"`
+			Version  string `json:"product.version"`
+			Category string `json:"product.category"`
+			Editor   string `json:"product.editor"`
+			Parents  []struct {
+				Swidtag string `json:"product.swidtag"`
+			} `json:"~product.child"`
+			Applications []struct {
+				ID string `json:"application.id"`
+			} `json:"~application.product"`
+			Equipments []struct {
+				ID string `json:"equipment.id"`
+			} `json:"product.equipment"`
+			Users []struct {
+				ID    string `json:"users.id"`
+				Count int64  `json:"users.count"`
+			} `json:"product.users"`
+		}
+	}
+	if err := json.Unmarshal(resp.GetJson(), &data); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal products of scope %s: %v", scope, err)
+	}
+	records := make([]reconcile.Record, len(data.Products))
+	for i, p := range data.Products {
+		var parents, applications, equipments, users []string
+		for _, parent := range p.Parents {
+			parents = append(parents, parent.Swidtag)
+		}
+		for _, a := range p.Applications {
+			applications = append(applications, a.ID)
+		}
+		for _, e := range p.Equipments {
+			equipments = append(equipments, e.ID)
+		}
+		for _, u := range p.Users {
+			users = append(users, u.ID+"="+strconv.FormatInt(u.Count, 10))
+		}
+		records[i] = reconcile.Record{Kind: kindProduct, Key: p.Swidtag, Fields: map[string]string{
+			"product.name":        p.Name,
+			"product.version":     p.Version,
+			"product.category":    p.Category,
+			"product.editor":      p.Editor,
+			"~product.child":      reconcile.Links(parents),
+			"application.product": reconcile.Links(applications),
+			"product.equipment":   reconcile.Links(equipments),
+			"product.users":       reconcile.Links(users),
+		}}
+	}
+	return records, nil
+}
+
+//Repair pushes the upserts of drifted products from their rows, and the deletions of orphan products
+func (r *Reconciler) Repair(ctx context.Context, scope string, drifts []reconcile.Drift) (int32, error) {
+	jobs := make([]job.Job, 0, len(drifts))
+	for _, d := range drifts {
+		var data []byte
+		var err error
+		if d.Status == reconcile.StatusOrphan {
+			data, err = envelope(DeleteNode, reconcile.Orphan{Predicate: "product.swidtag", Key: d.Key, TypeName: "product", Predicates: productPredicates})
+		} else {
+			p, ok := d.Data.(db.ListProductsOfScopeRow)
+			if !ok {
+				return 0, fmt.Errorf("product %s of scope %s has no row to repair from", d.Key, scope)
+			}
+			data, err = envelope(ReconcileProduct, upsertRequest(p))
+		}
+		if err != nil {
+			return 0, err
+		}
+		jobs = append(jobs, job.Job{
+			Type:   sql.NullString{String: "aw"},
+			Status: job.JobStatusPENDING,
+			Data:   data,
+		})
+	}
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	ids, err := r.queue.PushJobs(ctx, jobs, "aw")
+	return int32(len(ids)), err
+}
+
+//Drop deletes the products whose only scope is scope whole, with the links of other nodes to them which would be left to deleted nodes.
+//Products shared with other scopes, eg: by their acquired rights or applications, are left as they are, replays rewrite them.
+//Links of other services, eg: to instances, acquired rights and aggregations, are restored by reconciling their graph once products are replayed.
+func (r *Reconciler) Drop(ctx context.Context, scope string) error {
+	upsert := dgraph.NewUpsert()
+	products := upsert.Find("products", "scopes", scope, "product")
+	owned := upsert.Single("owned", products, "scopes")
+	deletion := &upsert.Mutation().Delete
+	deletion.Add(owned, "*", dgraph.Star)
+	for i, link := range productLinks {
+		linking := upsert.Linked("linking"+strconv.Itoa(i), owned, "~"+link)
+		deletion.Add(linking, link, owned)
+	}
+	req, err := upsert.Request()
+	if err != nil {
+		return err
+	}
+	if _, err := r.dg.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("cannot drop products of scope %s: %v", scope, err)
+	}
+	return nil
+}
+
+//upsertRequest gives the request writing product whole
+func upsertRequest(p db.ListProductsOfScopeRow) *v1.UpsertProductRequest {
+	req := &v1.UpsertProductRequest{
+		SwidTag:  p.Swidtag,
+		Name:     p.ProductName,
+		Category: p.ProductCategory,
+		Edition:  p.ProductEdition,
+		Editor:   p.ProductEditor,
+		Version:  p.ProductVersion,
+		OptionOf: p.OptionOf,
+		Scope:    p.Scope,
+		Applications: &v1.UpsertProductRequestApplication{
+			Operation:     "add",
+			ApplicationId: p.Applications,
+		},
+		Equipments: &v1.UpsertProductRequestEquipment{
+			Operation: "add",
+		},
+	}
+	for i, e := range p.Equipments {
+		user := &v1.UpsertProductRequestEquipmentEquipmentuser{EquipmentId: e}
+		if i < len(p.NumOfUsers) {
+			user.NumUser = int32(p.NumOfUsers[i])
+		}
+		req.Equipments.Equipmentusers = append(req.Equipments.Equipmentusers, user)
+	}
+	return req
+}
+
+//envelope gives the data of a job of worker
+func envelope(t MessageType, message interface{}) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{Type: t, Json: data})
+}
+
+//usersID gives the id of the users node of a product on an equipment
+func usersID(swidtag, equipmentID string) string {
+	return "user_" + swidtag + equipmentID
+}
@@ -214,6 +214,9 @@ func RunServer() error {
 	if err != nil {
 		logger.Log.Error("Failed to register server stats view")
 	}
+	if err := view.Register(workerqueue.DefaultViews...); err != nil {
+		logger.Log.Error("Failed to register worker queue stats view")
+	}
 
 	// Run Instumentation Server
 	instrumentationServer := &http.Server{
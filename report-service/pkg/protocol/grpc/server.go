@@ -16,6 +16,7 @@ import (
 	v1 "optisam-backend/report-service/pkg/api/v1"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/open-policy-agent/opa/rego"
 	"go.opencensus.io/plugin/ocgrpc"
@@ -40,7 +41,7 @@ func RunServer(ctx context.Context, v1API v1.ReportServiceServer, port string, v
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			// sig is a ^C, handle it